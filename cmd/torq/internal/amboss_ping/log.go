@@ -0,0 +1,6 @@
+package amboss_ping
+
+import "github.com/lncapital/torq/internal/logging"
+
+//nolint:gochecknoglobals
+var log = logging.GetLogger(logging.Ping)
@@ -10,8 +10,6 @@ import (
 	//"net/http"
 	"time"
 
-	"github.com/rs/zerolog/log"
-
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc"
 
@@ -0,0 +1,6 @@
+package notifications
+
+import "github.com/lncapital/torq/internal/logging"
+
+//nolint:gochecknoglobals
+var log = logging.GetLogger(logging.Notifications)
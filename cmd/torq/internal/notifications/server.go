@@ -5,7 +5,6 @@ import (
 	"runtime/debug"
 
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/communications"
@@ -0,0 +1,6 @@
+package services
+
+import "github.com/lncapital/torq/internal/logging"
+
+//nolint:gochecknoglobals
+var log = logging.GetLogger(logging.Workflows)
@@ -5,11 +5,11 @@ import (
 	"runtime/debug"
 
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 
 	"github.com/lncapital/torq/internal/automation"
 	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/internal/workflows"
 )
@@ -17,6 +17,7 @@ import (
 func StartIntervalService(ctx context.Context, db *sqlx.DB) {
 
 	serviceType := services_helpers.AutomationIntervalTriggerService
+	log := logging.WithService(log, serviceType.String())
 
 	defer log.Info().Msgf("%v terminated", serviceType.String())
 
@@ -38,6 +39,7 @@ func StartIntervalService(ctx context.Context, db *sqlx.DB) {
 func StartChannelBalanceEventService(ctx context.Context, db *sqlx.DB) {
 
 	serviceType := services_helpers.AutomationChannelBalanceEventTriggerService
+	log := logging.WithService(log, serviceType.String())
 
 	defer log.Info().Msgf("%v terminated", serviceType.String())
 
@@ -59,6 +61,7 @@ func StartChannelBalanceEventService(ctx context.Context, db *sqlx.DB) {
 func StartChannelEventService(ctx context.Context, db *sqlx.DB) {
 
 	serviceType := services_helpers.AutomationChannelEventTriggerService
+	log := logging.WithService(log, serviceType.String())
 
 	defer log.Info().Msgf("%v terminated", serviceType.String())
 
@@ -80,6 +83,7 @@ func StartChannelEventService(ctx context.Context, db *sqlx.DB) {
 func StartScheduledService(ctx context.Context, db *sqlx.DB) {
 
 	serviceType := services_helpers.AutomationScheduledTriggerService
+	log := logging.WithService(log, serviceType.String())
 
 	defer log.Info().Msgf("%v terminated", serviceType.String())
 
@@ -101,6 +105,7 @@ func StartScheduledService(ctx context.Context, db *sqlx.DB) {
 func StartRebalanceService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.LndServiceRebalanceService
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -122,6 +127,7 @@ func StartRebalanceService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.
 func StartMaintenanceService(ctx context.Context, db *sqlx.DB) {
 
 	serviceType := services_helpers.MaintenanceService
+	log := logging.WithService(log, serviceType.String())
 
 	defer log.Info().Msgf("%v terminated", serviceType.String())
 
@@ -143,6 +149,7 @@ func StartMaintenanceService(ctx context.Context, db *sqlx.DB) {
 func StartCronService(ctx context.Context, db *sqlx.DB) {
 
 	serviceType := services_helpers.CronService
+	log := logging.WithService(log, serviceType.String())
 
 	defer log.Info().Msgf("%v terminated", serviceType.String())
 
@@ -5,11 +5,11 @@ import (
 	"runtime/debug"
 
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 
 	"github.com/lncapital/torq/internal/cache"
 	cln2 "github.com/lncapital/torq/internal/cln"
 	"github.com/lncapital/torq/internal/lnd"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/cln"
 	"github.com/lncapital/torq/proto/lnrpc"
@@ -22,6 +22,7 @@ import (
 func StartChannelEventStream(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.LndServiceChannelEventStream
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -35,21 +36,21 @@ func StartChannelEventStream(ctx context.Context, conn *grpc.ClientConn, db *sql
 
 	cache.SetPendingNodeServiceState(serviceType, nodeId)
 
-	err := lnd.ImportAllChannels(db, false, nodeId)
+	err := lnd.ImportAllChannels(ctx, db, false, nodeId)
 	if err != nil {
 		log.Error().Err(err).Msgf("LND import Channels for nodeId: %v", nodeId)
 		cache.SetFailedNodeServiceState(serviceType, nodeId)
 		return
 	}
 
-	err = lnd.ImportChannelRoutingPolicies(db, false, nodeId)
+	err = lnd.ImportChannelRoutingPolicies(ctx, db, false, nodeId)
 	if err != nil {
 		log.Error().Err(err).Msgf("LND import Channel routing policies for nodeId: %v", nodeId)
 		cache.SetFailedNodeServiceState(serviceType, nodeId)
 		return
 	}
 
-	err = lnd.ImportNodeInformation(db, false, nodeId)
+	err = lnd.ImportNodeInformation(ctx, db, false, nodeId)
 	if err != nil {
 		log.Error().Err(err).Msgf("LND import Node Information for nodeId: %v", nodeId)
 		cache.SetFailedNodeServiceState(serviceType, nodeId)
@@ -62,6 +63,7 @@ func StartChannelEventStream(ctx context.Context, conn *grpc.ClientConn, db *sql
 func StartGraphEventStream(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.LndServiceGraphEventStream
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -75,21 +77,21 @@ func StartGraphEventStream(ctx context.Context, conn *grpc.ClientConn, db *sqlx.
 
 	cache.SetPendingNodeServiceState(serviceType, nodeId)
 
-	err := lnd.ImportAllChannels(db, false, nodeId)
+	err := lnd.ImportAllChannels(ctx, db, false, nodeId)
 	if err != nil {
 		log.Error().Err(err).Msgf("LND import Channels for nodeId: %v", nodeId)
 		cache.SetFailedNodeServiceState(serviceType, nodeId)
 		return
 	}
 
-	err = lnd.ImportChannelRoutingPolicies(db, false, nodeId)
+	err = lnd.ImportChannelRoutingPolicies(ctx, db, false, nodeId)
 	if err != nil {
 		log.Error().Err(err).Msgf("LND import Channel routing policies for nodeId: %v", nodeId)
 		cache.SetFailedNodeServiceState(serviceType, nodeId)
 		return
 	}
 
-	err = lnd.ImportNodeInformation(db, false, nodeId)
+	err = lnd.ImportNodeInformation(ctx, db, false, nodeId)
 	if err != nil {
 		log.Error().Err(err).Msgf("LND import Node Information for nodeId: %v", nodeId)
 		cache.SetFailedNodeServiceState(serviceType, nodeId)
@@ -102,6 +104,7 @@ func StartGraphEventStream(ctx context.Context, conn *grpc.ClientConn, db *sqlx.
 func StartHtlcEvents(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.LndServiceHtlcEventStream
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -121,6 +124,7 @@ func StartHtlcEvents(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, no
 func StartPeerEvents(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.LndServicePeerEventStream
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -134,7 +138,7 @@ func StartPeerEvents(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, no
 
 	cache.SetPendingNodeServiceState(serviceType, nodeId)
 
-	err := lnd.ImportPeerStatus(db, false, nodeId)
+	err := lnd.ImportPeerStatus(ctx, db, false, nodeId)
 	if err != nil {
 		log.Error().Err(err).Msgf("LND import peer status for nodeId: %v", nodeId)
 		cache.SetFailedNodeServiceState(serviceType, nodeId)
@@ -147,6 +151,7 @@ func StartPeerEvents(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, no
 func StartTransactionStream(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.LndServiceTransactionStream
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -170,6 +175,7 @@ func StartTransactionStream(ctx context.Context, conn *grpc.ClientConn, db *sqlx
 func StartForwardsService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.LndServiceForwardsService
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -189,6 +195,7 @@ func StartForwardsService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.D
 func StartPaymentsService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.LndServicePaymentsService
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -208,6 +215,7 @@ func StartPaymentsService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.D
 func StartInvoiceStream(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.LndServiceInvoiceStream
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -227,6 +235,7 @@ func StartInvoiceStream(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB,
 func StartInFlightPaymentsService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.LndServiceInFlightPaymentsService
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -246,6 +255,7 @@ func StartInFlightPaymentsService(ctx context.Context, conn *grpc.ClientConn, db
 func StartPeersService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.ClnServicePeersService
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -265,6 +275,7 @@ func StartPeersService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB,
 func StartChannelsService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.ClnServiceChannelsService
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -284,6 +295,7 @@ func StartChannelsService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.D
 func StartFundsService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.ClnServiceFundsService
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -303,6 +315,7 @@ func StartFundsService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB,
 func StartNodesService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.ClnServiceNodesService
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -325,6 +338,7 @@ func StartChannelBalanceCacheMaintenance(ctx context.Context,
 	nodeId int) {
 
 	serviceType := services_helpers.LndServiceChannelBalanceCacheService
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -344,6 +358,7 @@ func StartChannelBalanceCacheMaintenance(ctx context.Context,
 func StartTransactionsService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
 	serviceType := services_helpers.ClnServiceTransactionsService
+	log := logging.WithNodeService(log, serviceType.String(), nodeId)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
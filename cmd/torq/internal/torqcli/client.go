@@ -0,0 +1,214 @@
+package torqcli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+// Rebalancing and opening channels wait for the node
+const requestTimeout = 5 * time.Minute
+
+// client calls the API of the running Torq with the session of a cookie file login
+type client struct {
+	baseUrl    string
+	httpClient *http.Client
+}
+
+// getBaseUrl returns the URL of the HTTP API served on the network interface, all interfaces are reached locally
+func getBaseUrl(networkInterface string, port string) string {
+	host := networkInterface
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
+
+// newClient logs in with the access key in the cookie file (Torq replaces the cookie file after each login)
+func newClient(baseUrl string, cookiePath string) (*client, error) {
+	if cookiePath == "" {
+		return nil, errors.New("The cookie file of the running Torq is required (--torq.cookie-path)")
+	}
+	cookieFile, err := os.ReadFile(cookiePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "Reading cookie file")
+	}
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Creating cookie jar")
+	}
+	c := &client{
+		baseUrl:    strings.TrimSuffix(baseUrl, "/"),
+		httpClient: &http.Client{Jar: jar, Timeout: requestTimeout},
+	}
+	_, err = c.send(http.MethodPost, "/api/cookie-login",
+		map[string]string{"accessKey": strings.TrimSpace(string(cookieFile))})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Logging in to Torq at %v", c.baseUrl)
+	}
+	return c, nil
+}
+
+func (c *client) get(path string, query url.Values) ([]byte, error) {
+	if len(query) != 0 {
+		path += "?" + query.Encode()
+	}
+	request, err := http.NewRequest(http.MethodGet, c.baseUrl+path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Creating request")
+	}
+	return c.do(request)
+}
+
+// getAllPages returns the data of all pages of a paginated (/api/v1) endpoint
+func (c *client) getAllPages(path string, query url.Values) ([]json.RawMessage, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	data := make([]json.RawMessage, 0)
+	for {
+		query.Set("offset", strconv.Itoa(len(data)))
+		body, err := c.get(path, query)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Data       []json.RawMessage `json:"data"`
+			Pagination ah.Pagination     `json:"pagination"`
+		}
+		err = json.Unmarshal(body, &page)
+		if err != nil {
+			return nil, errors.Wrap(err, "Parsing response")
+		}
+		data = append(data, page.Data...)
+		if len(page.Data) == 0 || uint64(len(data)) >= page.Pagination.Total {
+			return data, nil
+		}
+	}
+}
+
+// send sends the request as JSON
+func (c *client) send(method string, path string, body interface{}) ([]byte, error) {
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Marshalling request")
+	}
+	request, err := http.NewRequest(method, c.baseUrl+path, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, errors.Wrap(err, "Creating request")
+	}
+	request.Header.Set("Content-Type", "application/json")
+	return c.do(request)
+}
+
+// sendForm sends the fields and the files (field name to path on disk) as a multipart form
+func (c *client) sendForm(method string, path string, fields map[string]string,
+	files map[string]string) ([]byte, error) {
+
+	var requestBody bytes.Buffer
+	writer := multipart.NewWriter(&requestBody)
+	for name, value := range fields {
+		err := writer.WriteField(name, value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Writing form field %v", name)
+		}
+	}
+	for name, filePath := range files {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Reading %v", filePath)
+		}
+		part, err := writer.CreateFormFile(name, filepath.Base(filePath))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Writing form file %v", name)
+		}
+		_, err = part.Write(content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Writing form file %v", name)
+		}
+	}
+	err := writer.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "Closing form")
+	}
+	request, err := http.NewRequest(method, c.baseUrl+path, &requestBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "Creating request")
+	}
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return c.do(request)
+}
+
+func (c *client) do(request *http.Request) ([]byte, error) {
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Calling %v %v", request.Method, request.URL.Path)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Reading response")
+	}
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return nil, getResponseError(response.StatusCode, body)
+	}
+	return body, nil
+}
+
+// getResponseError returns the error messages of the response, the API responds with a ServerError or {"error": ""}
+func getResponseError(statusCode int, body []byte) error {
+	var messages []string
+	var serverError server_errors.ServerError
+	if json.Unmarshal(body, &serverError) == nil {
+		for _, e := range serverError.Errors.Server {
+			messages = append(messages, getErrorDescription(e))
+		}
+		var fields []string
+		for field := range serverError.Errors.Fields {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			for _, e := range serverError.Errors.Fields[field] {
+				messages = append(messages, field+": "+getErrorDescription(e))
+			}
+		}
+	}
+	var simpleError struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &simpleError) == nil && simpleError.Error != "" {
+		messages = append(messages, simpleError.Error)
+	}
+	if len(messages) == 0 {
+		messages = append(messages, http.StatusText(statusCode))
+	}
+	return errors.Newf("Torq responded with %v: %v", statusCode, strings.Join(messages, ", "))
+}
+
+func getErrorDescription(e server_errors.ErrorCodeOrDescription) string {
+	if e.Description != "" {
+		return e.Description
+	}
+	if len(e.Attributes) != 0 {
+		return fmt.Sprintf("%v %v", e.Code, e.Attributes)
+	}
+	return e.Code
+}
@@ -0,0 +1,130 @@
+package torqcli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+)
+
+func TestGetBaseUrl(t *testing.T) {
+	tests := []struct {
+		networkInterface string
+		expected         string
+	}{
+		{"0.0.0.0", "http://127.0.0.1:8080"},
+		{"", "http://127.0.0.1:8080"},
+		{"::", "http://127.0.0.1:8080"},
+		{"192.168.1.2", "http://192.168.1.2:8080"},
+		{"::1", "http://[::1]:8080"},
+	}
+	for _, test := range tests {
+		if baseUrl := getBaseUrl(test.networkInterface, "8080"); baseUrl != test.expected {
+			t.Errorf("getBaseUrl(%q) = %v, expected %v", test.networkInterface, baseUrl, test.expected)
+		}
+	}
+}
+
+func TestGetResponseError(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"Server error",
+			`{"errors":{"server":[{"description":"Node not found"}],"fields":{"nodeId":[{"code":"required"}]}}}`,
+			"Torq responded with 400: Node not found, nodeId: required"},
+		{"Simple error", `{"error":"Invalid session"}`, "Torq responded with 400: Invalid session"},
+		{"No JSON", `Bad request`, "Torq responded with 400: Bad Request"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := getResponseError(http.StatusBadRequest, []byte(test.body))
+			if err.Error() != test.expected {
+				t.Errorf("getResponseError() = %v, expected %v", err, test.expected)
+			}
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	const accessKey = "secret"
+	items := []int{1, 2, 3, 4, 5}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/cookie-login", func(w http.ResponseWriter, r *http.Request) {
+		var login struct {
+			AccessKey string `json:"accessKey"`
+		}
+		if json.NewDecoder(r.Body).Decode(&login) != nil || login.AccessKey != accessKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "torq_session", Value: "session", Path: "/"})
+	})
+	mux.HandleFunc("/api/v1/items", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie("torq_session"); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + 2
+		if end > len(items) {
+			end = len(items)
+		}
+		_ = json.NewEncoder(w).Encode(ah.ApiResponse{
+			Data:       items[offset:end],
+			Pagination: ah.Pagination{Total: uint64(len(items)), Limit: 2, Offset: uint64(offset)},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cookiePath := filepath.Join(t.TempDir(), ".cookie")
+	if err := os.WriteFile(cookiePath, []byte("wrong\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newClient(server.URL, cookiePath); err == nil {
+		t.Fatal("newClient() with a wrong access key should fail")
+	}
+	if err := os.WriteFile(cookiePath, []byte(accessKey+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cl, err := newClient(server.URL, cookiePath)
+	if err != nil {
+		t.Fatalf("newClient() error = %v", err)
+	}
+	data, err := cl.getAllPages("/api/v1/items", nil)
+	if err != nil {
+		t.Fatalf("getAllPages() error = %v", err)
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "[1,2,3,4,5]" {
+		t.Errorf("getAllPages() = %s, expected all items", body)
+	}
+}
+
+func TestPrintObjects(t *testing.T) {
+	objects, err := getObjects([]byte(`[{"request":{"channelId":12},"status":"SUCCESS","amountMsat":18446744073709551615}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output bytes.Buffer
+	err = printObjects(&output, objects, []string{"request.channelId", "status", "amountMsat"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "request.channelId  status   amountMsat\n" +
+		"12                 SUCCESS  18446744073709551615\n"
+	if output.String() != expected {
+		t.Errorf("printObjects() = %q, expected %q", output.String(), expected)
+	}
+}
@@ -0,0 +1,559 @@
+package torqcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/services"
+	"github.com/lncapital/torq/internal/settings"
+	"github.com/lncapital/torq/internal/workflow_helpers"
+	"github.com/lncapital/torq/internal/workflows"
+)
+
+// GetCommands returns the commands that manage the running Torq through its API.
+// They log in with the cookie file (--torq.cookie-path) and reach the API on --torq.network-interface and --torq.port.
+func GetCommands() cli.Commands {
+	return cli.Commands{
+		getNodeCommand(),
+		getServicesCommand(),
+		getWorkflowCommand(),
+		getChannelCommand(),
+		getRebalanceCommand(),
+		getExportCommand(),
+	}
+}
+
+func getClient(c *cli.Context) (*client, error) {
+	return newClient(getBaseUrl(c.String("torq.network-interface"), c.String("torq.port")),
+		c.String("torq.cookie-path"))
+}
+
+func getNodeCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "node",
+		Usage: "Manage the nodes Torq connects to",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List the nodes",
+				Flags: []cli.Flag{outputFlag()},
+				Action: func(c *cli.Context) error {
+					cl, err := getClient(c)
+					if err != nil {
+						return err
+					}
+					body, err := cl.get("/api/settings/nodeConnectionDetails", nil)
+					if err != nil {
+						return err
+					}
+					if c.String("output") != tableOutput {
+						return printResult(c, body)
+					}
+					var nodes []settings.NodeConnectionDetails
+					err = json.Unmarshal(body, &nodes)
+					if err != nil {
+						return errors.Wrap(err, "Parsing response")
+					}
+					var rows [][]string
+					for _, node := range nodes {
+						grpcAddress := ""
+						if node.GRPCAddress != nil {
+							grpcAddress = *node.GRPCAddress
+						}
+						rows = append(rows, []string{strconv.Itoa(node.NodeId), node.Name,
+							node.Implementation.String(), grpcAddress, node.Status.String()})
+					}
+					return printTable(c.App.Writer, []string{"nodeId", "name", "implementation", "grpcAddress", "status"},
+						rows)
+				},
+			},
+			{
+				Name:  "add",
+				Usage: "Add an LND node",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "name", Usage: "Name of the node (Node_<nodeId> by default)"},
+					&cli.StringFlag{Name: "grpc-address", Usage: "Host:Port of the LND node", Required: true},
+					&cli.StringFlag{Name: "tls-file", Usage: "Path to the LND TLS certificate", Required: true},
+					&cli.StringFlag{Name: "macaroon-file", Usage: "Path to the LND macaroon", Required: true},
+					&cli.IntFlag{Name: "ping-system", Usage: "Ping systems (1 = Amboss, 2 = Vector, 3 = both)"},
+					&cli.IntFlag{Name: "custom-settings", Value: core.NodeConnectionDetailCustomSettingsMax,
+						Usage: "The data to import (bit flags, all data by default)"},
+					&cli.StringFlag{Name: "node-start-date", Usage: "Import history from this day (2006-01-02)"},
+					outputFlag(),
+				},
+				Action: func(c *cli.Context) error {
+					cl, err := getClient(c)
+					if err != nil {
+						return err
+					}
+					body, err := cl.sendForm(http.MethodPost, "/api/settings/nodeConnectionDetails",
+						map[string]string{
+							"name":           c.String("name"),
+							"implementation": strconv.Itoa(int(core.LND)),
+							"grpcAddress":    c.String("grpc-address"),
+							"status":         strconv.Itoa(int(core.Active)),
+							"pingSystem":     strconv.Itoa(c.Int("ping-system")),
+							"customSettings": strconv.Itoa(c.Int("custom-settings")),
+							"nodeStartDate":  c.String("node-start-date"),
+						},
+						map[string]string{
+							"tlsFile":      c.String("tls-file"),
+							"macaroonFile": c.String("macaroon-file"),
+						})
+					if err != nil {
+						return err
+					}
+					return printResult(c, body, "nodeId", "name", "grpcAddress", "status")
+				},
+			},
+			{
+				Name:  "rotate-macaroon",
+				Usage: "Replace the macaroon (and optionally the TLS certificate) of a node",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "node-id", Required: true},
+					&cli.StringFlag{Name: "macaroon-file", Usage: "Path to the new LND macaroon", Required: true},
+					&cli.StringFlag{Name: "tls-file", Usage: "Path to the new LND TLS certificate"},
+					outputFlag(),
+				},
+				Action: func(c *cli.Context) error {
+					cl, err := getClient(c)
+					if err != nil {
+						return err
+					}
+					body, err := cl.get(fmt.Sprintf("/api/settings/nodeConnectionDetails/%v", c.Int("node-id")), nil)
+					if err != nil {
+						return err
+					}
+					var node settings.NodeConnectionDetails
+					err = json.Unmarshal(body, &node)
+					if err != nil {
+						return errors.Wrap(err, "Parsing response")
+					}
+					fields := map[string]string{
+						"nodeId":         strconv.Itoa(node.NodeId),
+						"name":           node.Name,
+						"implementation": strconv.Itoa(int(node.Implementation)),
+						"status":         strconv.Itoa(int(node.Status)),
+						"pingSystem":     strconv.Itoa(int(node.PingSystem)),
+						"customSettings": strconv.Itoa(int(node.CustomSettings)),
+					}
+					if node.GRPCAddress != nil {
+						fields["grpcAddress"] = *node.GRPCAddress
+					}
+					if node.NodeStartDate != nil {
+						fields["nodeStartDate"] = node.NodeStartDate.Format("2006-01-02")
+					}
+					files := map[string]string{"macaroonFile": c.String("macaroon-file")}
+					if c.String("tls-file") != "" {
+						files["tlsFile"] = c.String("tls-file")
+					}
+					body, err = cl.sendForm(http.MethodPut, "/api/settings/nodeConnectionDetails", fields, files)
+					if err != nil {
+						return err
+					}
+					return printResult(c, body, "nodeId", "name", "grpcAddress", "status")
+				},
+			},
+			getNodeStatusCommand("disable", "Disable a node, Torq disconnects from it", core.Inactive),
+			getNodeStatusCommand("enable", "Enable a node, Torq connects to it", core.Active),
+		},
+	}
+}
+
+func getNodeStatusCommand(name string, usage string, status core.Status) *cli.Command {
+	return &cli.Command{
+		Name:  name,
+		Usage: usage,
+		Flags: []cli.Flag{&cli.IntFlag{Name: "node-id", Required: true}},
+		Action: func(c *cli.Context) error {
+			cl, err := getClient(c)
+			if err != nil {
+				return err
+			}
+			_, err = cl.send(http.MethodPut,
+				fmt.Sprintf("/api/settings/nodeConnectionDetails/%v/%v", c.Int("node-id"), int(status)), nil)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(c.App.Writer, "Node %v is %v\n", c.Int("node-id"), status.String())
+			return err
+		},
+	}
+}
+
+func getServicesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "services",
+		Usage: "Show the Torq services",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "status",
+				Usage: "Show the status of the services",
+				Flags: []cli.Flag{outputFlag()},
+				Action: func(c *cli.Context) error {
+					cl, err := getClient(c)
+					if err != nil {
+						return err
+					}
+					body, err := cl.get("/api/services/status", nil)
+					if err != nil {
+						return err
+					}
+					if c.String("output") != tableOutput {
+						return printResult(c, body)
+					}
+					var s services.Services
+					err = json.Unmarshal(body, &s)
+					if err != nil {
+						return errors.Wrap(err, "Parsing response")
+					}
+					return printTable(c.App.Writer, []string{"service", "nodeId", "status", "desiredStatus", "since"},
+						getServiceRows(s))
+				},
+			},
+		},
+	}
+}
+
+func getServiceRows(s services.Services) [][]string {
+	desiredStatuses := make(map[string]string)
+	for _, mismatch := range s.ServiceMismatches {
+		nodeId := ""
+		if mismatch.NodeId != nil {
+			nodeId = strconv.Itoa(*mismatch.NodeId)
+		}
+		desiredStatuses[mismatch.ServiceTypeString+"/"+nodeId] = mismatch.DesiredStatusString
+	}
+	var rows [][]string
+	addRow := func(service services.CommonService, nodeId string) {
+		since := ""
+		if service.BootTime != nil {
+			since = service.BootTime.Format(time.RFC3339)
+		}
+		rows = append(rows, []string{service.ServiceTypeString, nodeId, service.StatusString,
+			desiredStatuses[service.ServiceTypeString+"/"+nodeId], since})
+	}
+	addRow(s.MainService.CommonService, "")
+	for _, torqService := range s.TorqServices {
+		addRow(torqService.CommonService, "")
+	}
+	for _, lndService := range s.LndServices {
+		addRow(lndService.CommonService, strconv.Itoa(lndService.NodeId))
+	}
+	return rows
+}
+
+func getWorkflowCommand() *cli.Command {
+	workflowIdFlag := &cli.IntFlag{Name: "workflow-id", Required: true}
+	return &cli.Command{
+		Name:  "workflow",
+		Usage: "Manage automation workflows",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List the workflows",
+				Flags: []cli.Flag{outputFlag()},
+				Action: func(c *cli.Context) error {
+					cl, err := getClient(c)
+					if err != nil {
+						return err
+					}
+					data, err := cl.getAllPages("/api/v1/workflows", nil)
+					if err != nil {
+						return err
+					}
+					return printPages(c, data, "workflowId", "workflowName", "workflowStatus", "activeVersion",
+						"latestVersion")
+				},
+			},
+			{
+				Name:  "trigger",
+				Usage: "Trigger the active version of a workflow from its manual trigger",
+				Flags: []cli.Flag{
+					workflowIdFlag,
+					&cli.IntFlag{Name: "workflow-version-node-id",
+						Usage: "The trigger node (the manual trigger of the active version by default)"},
+					outputFlag(),
+				},
+				Action: func(c *cli.Context) error {
+					cl, err := getClient(c)
+					if err != nil {
+						return err
+					}
+					workflowToTrigger, err := getWorkflowToTrigger(cl, c.Int("workflow-id"),
+						c.Int("workflow-version-node-id"))
+					if err != nil {
+						return err
+					}
+					body, err := cl.send(http.MethodPost, "/api/v1/workflows/trigger", workflowToTrigger)
+					if err != nil {
+						return err
+					}
+					return printResult(c, body, "reference")
+				},
+			},
+			getWorkflowStatusCommand("activate", "Activate a workflow", workflowIdFlag, workflows.Active),
+			getWorkflowStatusCommand("deactivate", "Deactivate a workflow", workflowIdFlag, workflows.Inactive),
+		},
+	}
+}
+
+func getWorkflowStatusCommand(name string, usage string, workflowIdFlag cli.Flag,
+	status workflows.WorkflowStatus) *cli.Command {
+
+	return &cli.Command{
+		Name:  name,
+		Usage: usage,
+		Flags: []cli.Flag{workflowIdFlag},
+		Action: func(c *cli.Context) error {
+			cl, err := getClient(c)
+			if err != nil {
+				return err
+			}
+			_, err = cl.send(http.MethodPut, "/api/workflows",
+				workflows.UpdateWorkflow{WorkflowId: c.Int("workflow-id"), Status: &status})
+			if err != nil {
+				return err
+			}
+			coreStatus := core.Status(status)
+			_, err = fmt.Fprintf(c.App.Writer, "Workflow %v is %v\n", c.Int("workflow-id"), coreStatus.String())
+			return err
+		},
+	}
+}
+
+// getWorkflowToTrigger finds the active version of the workflow and its manual trigger when no node is given
+func getWorkflowToTrigger(cl *client, workflowId int, workflowVersionNodeId int) (workflows.WorkflowToTrigger, error) {
+	data, err := cl.getAllPages("/api/v1/workflows", nil)
+	if err != nil {
+		return workflows.WorkflowToTrigger{}, err
+	}
+	for _, item := range data {
+		var workflow workflows.WorkflowTableRow
+		err = json.Unmarshal(item, &workflow)
+		if err != nil {
+			return workflows.WorkflowToTrigger{}, errors.Wrap(err, "Parsing response")
+		}
+		if workflow.WorkflowId != workflowId {
+			continue
+		}
+		if workflow.ActiveWorkflowVersionId == nil || workflow.ActiveVersion == nil {
+			return workflows.WorkflowToTrigger{}, errors.Newf("Workflow %v has no active version", workflowId)
+		}
+		workflowToTrigger := workflows.WorkflowToTrigger{
+			WorkflowId:            workflowId,
+			WorkflowVersionId:     *workflow.ActiveWorkflowVersionId,
+			WorkflowVersionNodeId: workflowVersionNodeId,
+			Type:                  int(workflow_helpers.WorkflowNodeManualTrigger),
+		}
+		if workflowVersionNodeId != 0 {
+			return workflowToTrigger, nil
+		}
+		body, err := cl.get(fmt.Sprintf("/api/workflows/%v/versions/%v", workflowId, *workflow.ActiveVersion), nil)
+		if err != nil {
+			return workflows.WorkflowToTrigger{}, err
+		}
+		var workflowPage workflows.WorkflowPage
+		err = json.Unmarshal(body, &workflowPage)
+		if err != nil {
+			return workflows.WorkflowToTrigger{}, errors.Wrap(err, "Parsing response")
+		}
+		for _, node := range workflowPage.Nodes {
+			if node.Type == workflow_helpers.WorkflowNodeManualTrigger {
+				workflowToTrigger.WorkflowVersionNodeId = node.WorkflowVersionNodeId
+				return workflowToTrigger, nil
+			}
+		}
+		return workflows.WorkflowToTrigger{}, errors.Newf("The active version of workflow %v has no manual trigger",
+			workflowId)
+	}
+	return workflows.WorkflowToTrigger{}, errors.Newf("Workflow %v not found", workflowId)
+}
+
+func getChannelCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "channel",
+		Usage: "Manage channels",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "policy",
+				Usage: "Manage the routing policy of channels",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "set",
+						Usage: "Update the routing policy of a channel, only the given values are changed",
+						Flags: []cli.Flag{
+							&cli.IntFlag{Name: "node-id", Required: true},
+							&cli.IntFlag{Name: "channel-id", Required: true},
+							&cli.Int64Flag{Name: "fee-rate-milli-msat"},
+							&cli.Int64Flag{Name: "fee-base-msat"},
+							&cli.Uint64Flag{Name: "min-htlc-msat"},
+							&cli.Uint64Flag{Name: "max-htlc-msat"},
+							&cli.UintFlag{Name: "time-lock-delta"},
+							outputFlag(),
+						},
+						Action: func(c *cli.Context) error {
+							cl, err := getClient(c)
+							if err != nil {
+								return err
+							}
+							body, err := cl.send(http.MethodPut, "/api/v1/lightning/routing-policy",
+								getRoutingPolicyUpdateRequest(c))
+							if err != nil {
+								return err
+							}
+							return printResult(c, body, "request.channelId", "status", "message", "failedUpdates")
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getRoutingPolicyUpdateRequest(c *cli.Context) lightning_helpers.RoutingPolicyUpdateRequest {
+	request := lightning_helpers.RoutingPolicyUpdateRequest{
+		CommunicationRequest: lightning_helpers.CommunicationRequest{NodeId: c.Int("node-id")},
+		ChannelId:            c.Int("channel-id"),
+	}
+	if c.IsSet("fee-rate-milli-msat") {
+		feeRateMilliMsat := c.Int64("fee-rate-milli-msat")
+		request.FeeRateMilliMsat = &feeRateMilliMsat
+	}
+	if c.IsSet("fee-base-msat") {
+		feeBaseMsat := c.Int64("fee-base-msat")
+		request.FeeBaseMsat = &feeBaseMsat
+	}
+	if c.IsSet("min-htlc-msat") {
+		minHtlcMsat := c.Uint64("min-htlc-msat")
+		request.MinHtlcMsat = &minHtlcMsat
+	}
+	if c.IsSet("max-htlc-msat") {
+		maxHtlcMsat := c.Uint64("max-htlc-msat")
+		request.MaxHtlcMsat = &maxHtlcMsat
+	}
+	if c.IsSet("time-lock-delta") {
+		timeLockDelta := uint32(c.Uint("time-lock-delta"))
+		request.TimeLockDelta = &timeLockDelta
+	}
+	return request
+}
+
+func getRebalanceCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "rebalance",
+		Usage: "Rebalance channels",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "request",
+				Usage: "Rebalance a channel from or to all other channels of the node",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "node-id", Required: true},
+					&cli.IntFlag{Name: "outgoing-channel-id", Usage: "The channel to move liquidity out of"},
+					&cli.IntFlag{Name: "incoming-channel-id", Usage: "The channel to move liquidity into"},
+					&cli.Uint64Flag{Name: "amount-sat", Required: true},
+					&cli.Uint64Flag{Name: "maximum-cost-sat", Required: true},
+					&cli.IntFlag{Name: "maximum-concurrency", Value: 1},
+					outputFlag(),
+				},
+				Action: func(c *cli.Context) error {
+					if (c.Int("outgoing-channel-id") == 0) == (c.Int("incoming-channel-id") == 0) {
+						return errors.New("Either --outgoing-channel-id or --incoming-channel-id is required")
+					}
+					cl, err := getClient(c)
+					if err != nil {
+						return err
+					}
+					body, err := cl.send(http.MethodPost, "/api/automation/rebalance", lightning_helpers.RebalanceRequests{
+						CommunicationRequest: lightning_helpers.CommunicationRequest{NodeId: c.Int("node-id")},
+						Requests: []lightning_helpers.RebalanceRequest{{
+							Origin:             lightning_helpers.RebalanceManual,
+							OriginId:           int(time.Now().Unix()),
+							OriginReference:    "cli",
+							OutgoingChannelId:  c.Int("outgoing-channel-id"),
+							IncomingChannelId:  c.Int("incoming-channel-id"),
+							AmountMsat:         c.Uint64("amount-sat") * 1_000,
+							MaximumCostMsat:    c.Uint64("maximum-cost-sat") * 1_000,
+							MaximumConcurrency: c.Int("maximum-concurrency"),
+						}},
+					})
+					if err != nil {
+						return err
+					}
+					return printResult(c, body, "request.outgoingChannelIds", "request.incomingChannelId", "status",
+						"message", "error")
+				},
+			},
+		},
+	}
+}
+
+func getExportCommand() *cli.Command {
+	nodeIdFlag := &cli.IntSliceFlag{Name: "node-id", Usage: "Only for these nodes (all nodes by default)"}
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export data of all pages of the /api/v1 list endpoints",
+		Subcommands: []*cli.Command{
+			getExportSubcommand("channels", "/api/v1/channels", nil, nodeIdFlag),
+			getExportSubcommand("closed-channels", "/api/v1/channels/closed", nil, nodeIdFlag),
+			getExportSubcommand("peers", "/api/v1/peers", nil, nodeIdFlag),
+			getExportSubcommand("forwards", "/api/v1/forwards", []string{"from", "to"}, nodeIdFlag,
+				&cli.StringFlag{Name: "from", Usage: "First day (2006-01-02)", Required: true},
+				&cli.StringFlag{Name: "to", Usage: "Last day (2006-01-02)", Required: true}),
+			getExportSubcommand("payments", "/api/v1/payments", []string{"from", "to", "status"}, nodeIdFlag,
+				&cli.StringFlag{Name: "from", Usage: "Created on or after (RFC3339)"},
+				&cli.StringFlag{Name: "to", Usage: "Created before (RFC3339)"},
+				&cli.StringFlag{Name: "status", Usage: "Only payments with this status"}),
+			getExportSubcommand("invoices", "/api/v1/invoices", []string{"from", "to", "state"}, nodeIdFlag,
+				&cli.StringFlag{Name: "from", Usage: "Created on or after (RFC3339)"},
+				&cli.StringFlag{Name: "to", Usage: "Created before (RFC3339)"},
+				&cli.StringFlag{Name: "state", Usage: "Only invoices in this state"}),
+		},
+	}
+}
+
+// getExportSubcommand exports the endpoint, the parameters are flags that are passed as query parameters
+func getExportSubcommand(name string, path string, parameters []string, flags ...cli.Flag) *cli.Command {
+	return &cli.Command{
+		Name:  name,
+		Usage: "Export the " + name,
+		Flags: append(flags, outputFlag()),
+		Action: func(c *cli.Context) error {
+			cl, err := getClient(c)
+			if err != nil {
+				return err
+			}
+			query := url.Values{}
+			for _, nodeId := range c.IntSlice("node-id") {
+				query.Add("nodeId", strconv.Itoa(nodeId))
+			}
+			for _, parameter := range parameters {
+				if c.String(parameter) != "" {
+					query.Set(parameter, c.String(parameter))
+				}
+			}
+			data, err := cl.getAllPages(path, query)
+			if err != nil {
+				return err
+			}
+			return printPages(c, data)
+		},
+	}
+}
+
+func printPages(c *cli.Context, data []json.RawMessage, columns ...string) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "Marshalling pages")
+	}
+	return printResult(c, body, columns...)
+}
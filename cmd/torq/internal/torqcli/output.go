@@ -0,0 +1,158 @@
+package torqcli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cockroachdb/errors"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	tableOutput = "table"
+	jsonOutput  = "json"
+)
+
+func outputFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:    "output",
+		Aliases: []string{"o"},
+		Value:   tableOutput,
+		Usage:   "Output format (table|json)",
+	}
+}
+
+// printResult prints the JSON response as indented JSON or as a table with the columns
+// (all fields of the objects when no columns are given). The response can be an object or an array of objects.
+func printResult(c *cli.Context, body []byte, columns ...string) error {
+	switch c.String("output") {
+	case jsonOutput:
+		return printJson(c.App.Writer, body)
+	case tableOutput:
+		objects, err := getObjects(body)
+		if err != nil {
+			return err
+		}
+		return printObjects(c.App.Writer, objects, columns)
+	default:
+		return errors.Newf("Unknown output format %v (table|json)", c.String("output"))
+	}
+}
+
+func printJson(w io.Writer, body []byte) error {
+	var indented bytes.Buffer
+	err := json.Indent(&indented, body, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Formatting JSON")
+	}
+	_, err = fmt.Fprintln(w, indented.String())
+	return err
+}
+
+func getObjects(body []byte) ([]map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// Amounts in msat don't fit a float64
+	decoder.UseNumber()
+	var value interface{}
+	err := decoder.Decode(&value)
+	if err != nil {
+		return nil, errors.Wrap(err, "Parsing response")
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{v}, nil
+	case []interface{}:
+		objects := make([]map[string]interface{}, 0, len(v))
+		for _, item := range v {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				object = map[string]interface{}{"value": item}
+			}
+			objects = append(objects, object)
+		}
+		return objects, nil
+	default:
+		return []map[string]interface{}{{"value": v}}, nil
+	}
+}
+
+func printObjects(w io.Writer, objects []map[string]interface{}, columns []string) error {
+	if len(columns) == 0 {
+		columns = getColumns(objects)
+	}
+	var rows [][]string
+	for _, object := range objects {
+		var row []string
+		for _, column := range columns {
+			row = append(row, formatValue(getValue(object, column)))
+		}
+		rows = append(rows, row)
+	}
+	return printTable(w, columns, rows)
+}
+
+// getColumns returns the fields of all objects in alphabetical order
+func getColumns(objects []map[string]interface{}) []string {
+	fields := make(map[string]bool)
+	for _, object := range objects {
+		for field := range object {
+			fields[field] = true
+		}
+	}
+	var columns []string
+	for field := range fields {
+		columns = append(columns, field)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// getValue returns the value of the field, nested fields are separated with a dot (i.e. request.channelId)
+func getValue(object map[string]interface{}, field string) interface{} {
+	var value interface{} = object
+	for _, name := range strings.Split(field, ".") {
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = nested[name]
+	}
+	return value
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number, bool:
+		return fmt.Sprintf("%v", v)
+	default:
+		nested, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(nested)
+	}
+}
+
+func printTable(w io.Writer, headers []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, err := fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	if err != nil {
+		return errors.Wrap(err, "Writing table")
+	}
+	for _, row := range rows {
+		_, err = fmt.Fprintln(tw, strings.Join(row, "\t"))
+		if err != nil {
+			return errors.Wrap(err, "Writing table")
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,169 @@
+package torqgrpc
+
+import (
+	"net/http"
+
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lncapital/torq/internal/api_tokens"
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/proto/torqrpc"
+)
+
+// The API resource an API token needs to read to receive the events of a type
+func getEventTypeResources() map[torqrpc.EventType]string {
+	return map[torqrpc.EventType]string{
+		torqrpc.EventType_FORWARD_EVENTS:       "forwards",
+		torqrpc.EventType_PAYMENT_EVENTS:       "payments",
+		torqrpc.EventType_INVOICE_EVENTS:       "invoices",
+		torqrpc.EventType_CHANNEL_EVENTS:       "channels",
+		torqrpc.EventType_CHANNEL_GRAPH_EVENTS: "channels",
+		torqrpc.EventType_PEER_EVENTS:          "channels",
+	}
+}
+
+// getEventTypes returns the requested event types or all event types the API token can read when none are requested
+func getEventTypes(apiToken api_tokens.ApiToken, requestedEventTypes []torqrpc.EventType) ([]torqrpc.EventType, error) {
+	var eventTypes []torqrpc.EventType
+	for eventType, resource := range getEventTypeResources() {
+		requested := len(requestedEventTypes) == 0 || slices.Contains(requestedEventTypes, eventType) ||
+			slices.Contains(requestedEventTypes, torqrpc.EventType_ALL_EVENTS)
+		if !requested {
+			continue
+		}
+		if !apiToken.IsAllowed(resource, http.MethodGet, torqrpc.Torq_SubscribeEvents_FullMethodName) {
+			if slices.Contains(requestedEventTypes, eventType) {
+				return nil, status.Errorf(codes.PermissionDenied, "forbidden: missing scope for %v", eventType)
+			}
+			continue
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+	if len(eventTypes) == 0 {
+		return nil, status.Error(codes.PermissionDenied, "forbidden: missing scope")
+	}
+	return eventTypes, nil
+}
+
+func (s *Server) SubscribeEvents(request *torqrpc.SubscribeEventsRequest,
+	stream torqrpc.Torq_SubscribeEventsServer) error {
+
+	nodeIds, err := getNodeIds(stream.Context(), request.NodeIds)
+	if err != nil {
+		return err
+	}
+	eventTypes, err := getEventTypes(getApiToken(stream.Context()), request.Types)
+	if err != nil {
+		return err
+	}
+	subscription := cache.SubscribeEvents(0)
+	defer cache.UnsubscribeEvents(subscription.SubscriptionId)
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "The subscription is not keeping up with the events")
+			}
+			torqEvent, eventType := toEvent(event.Event)
+			if torqEvent == nil || !slices.Contains(eventTypes, eventType) ||
+				!slices.Contains(nodeIds, int(torqEvent.NodeId)) {
+				continue
+			}
+			if err := stream.Send(torqEvent); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toEvent converts the events the UI receives, other events (i.e. core.HtlcEvent) are not exposed (nil)
+func toEvent(event any) (*torqrpc.Event, torqrpc.EventType) {
+	switch e := event.(type) {
+	case core.ForwardEvent:
+		torqEvent := newEvent(e.EventData)
+		torqEvent.Event = &torqrpc.Event_Forward{Forward: &torqrpc.ForwardEvent{
+			Timestamp:         formatTime(&e.Timestamp),
+			FeeMsat:           e.FeeMsat,
+			AmountInMsat:      e.AmountInMsat,
+			AmountOutMsat:     e.AmountOutMsat,
+			IncomingChannelId: toInt64(e.IncomingChannelId),
+			OutgoingChannelId: toInt64(e.OutgoingChannelId),
+		}}
+		return torqEvent, torqrpc.EventType_FORWARD_EVENTS
+	case core.PaymentEvent:
+		paymentEvent := &torqrpc.PaymentEvent{
+			AmountPaid:           e.AmountPaid,
+			FeeMsat:              e.FeeMsat,
+			PaymentStatus:        e.PaymentStatus.String(),
+			PaymentFailureReason: e.PaymentFailureReason.String(),
+			OutgoingChannelId:    toInt64(e.OutgoingChannelId),
+			IncomingChannelId:    toInt64(e.IncomingChannelId),
+		}
+		if e.RebalanceAmountMsat != nil {
+			paymentEvent.RebalanceAmountMsat = *e.RebalanceAmountMsat
+		}
+		torqEvent := newEvent(e.EventData)
+		torqEvent.Event = &torqrpc.Event_Payment{Payment: paymentEvent}
+		return torqEvent, torqrpc.EventType_PAYMENT_EVENTS
+	case core.InvoiceEvent:
+		torqEvent := newEvent(e.EventData)
+		torqEvent.Event = &torqrpc.Event_Invoice{Invoice: &torqrpc.InvoiceEvent{
+			ChannelId:         int64(e.ChannelId),
+			AddIndex:          e.AddIndex,
+			ValueMsat:         e.ValueMSat,
+			State:             e.State.String(),
+			AmountPaidMsat:    e.AmountPaidMsat,
+			SettledDate:       formatTime(&e.SettledDate),
+			DestinationNodeId: toInt64(e.DestinationNodeId),
+		}}
+		return torqEvent, torqrpc.EventType_INVOICE_EVENTS
+	case core.ChannelEvent:
+		torqEvent := newEvent(e.EventData)
+		torqEvent.Event = &torqrpc.Event_Channel{Channel: &torqrpc.ChannelEvent{
+			ChannelId: int64(e.ChannelId),
+			Type:      e.Type.String(),
+		}}
+		return torqEvent, torqrpc.EventType_CHANNEL_EVENTS
+	case core.ChannelGraphEvent:
+		torqEvent := newEvent(e.EventData)
+		torqEvent.Event = &torqrpc.Event_ChannelGraph{ChannelGraph: &torqrpc.ChannelGraphEvent{
+			ChannelId:        toInt64(e.ChannelId),
+			AnnouncingNodeId: toInt64(e.AnnouncingNodeId),
+			ConnectingNodeId: toInt64(e.ConnectingNodeId),
+			Disabled:         e.Disabled,
+			TimeLockDelta:    e.TimeLockDelta,
+			MinHtlcMsat:      e.MinHtlcMsat,
+			MaxHtlcMsat:      e.MaxHtlcMsat,
+			FeeBaseMsat:      e.FeeBaseMsat,
+			FeeRateMilliMsat: e.FeeRateMilliMsat,
+		}}
+		return torqEvent, torqrpc.EventType_CHANNEL_GRAPH_EVENTS
+	case core.PeerEvent:
+		torqEvent := newEvent(e.EventData)
+		torqEvent.Event = &torqrpc.Event_Peer{Peer: &torqrpc.PeerEvent{
+			PeerNodeId: int64(e.EventNodeId),
+			Type:       e.Type.String(),
+		}}
+		return torqEvent, torqrpc.EventType_PEER_EVENTS
+	}
+	return nil, torqrpc.EventType_ALL_EVENTS
+}
+
+func newEvent(eventData core.EventData) *torqrpc.Event {
+	return &torqrpc.Event{
+		NodeId:    int64(eventData.NodeId),
+		EventTime: formatTime(&eventData.EventTime),
+	}
+}
+
+func toInt64(value *int) int64 {
+	if value == nil {
+		return 0
+	}
+	return int64(*value)
+}
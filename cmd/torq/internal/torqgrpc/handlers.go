@@ -0,0 +1,365 @@
+package torqgrpc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/audit"
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/channels"
+	"github.com/lncapital/torq/internal/flow"
+	"github.com/lncapital/torq/internal/forwards"
+	"github.com/lncapital/torq/internal/lightning"
+	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/payments"
+	"github.com/lncapital/torq/internal/workflows"
+	"github.com/lncapital/torq/proto/torqrpc"
+)
+
+func (s *Server) ListChannels(ctx context.Context,
+	request *torqrpc.ListChannelsRequest) (*torqrpc.ListChannelsResponse, error) {
+
+	nodeIds, err := getNodeIds(ctx, request.NodeIds)
+	if err != nil {
+		return nil, err
+	}
+	channelsBody, err := channels.GetChannelsByNodeIds(nodeIds)
+	if err != nil {
+		return nil, internalError(err, "Getting channels.")
+	}
+	response := &torqrpc.ListChannelsResponse{}
+	for _, channelBody := range channelsBody {
+		response.Channels = append(response.Channels, &torqrpc.Channel{
+			NodeId:                  int64(channelBody.NodeId),
+			PeerNodeId:              int64(channelBody.PeerNodeId),
+			ChannelId:               int64(channelBody.ChannelId),
+			ChannelPoint:            channelBody.ChannelPoint,
+			ShortChannelId:          channelBody.ShortChannelId,
+			LndShortChannelId:       channelBody.LNDShortChannelId,
+			PeerAlias:               channelBody.PeerAlias,
+			RemotePubkey:            channelBody.RemotePubkey,
+			Active:                  channelBody.Active,
+			RemoteActive:            channelBody.RemoteActive,
+			Private:                 channelBody.Private,
+			Initiator:               channelBody.Initiator,
+			FundedOn:                formatTime(channelBody.FundedOn),
+			Capacity:                channelBody.Capacity,
+			LocalBalance:            channelBody.LocalBalance,
+			RemoteBalance:           channelBody.RemoteBalance,
+			UnsettledBalance:        channelBody.UnsettledBalance,
+			FeeBase:                 channelBody.FeeBase,
+			FeeRateMilliMsat:        channelBody.FeeRateMilliMsat,
+			MinHtlc:                 channelBody.MinHtlc,
+			MaxHtlc:                 channelBody.MaxHtlc,
+			TimeLockDelta:           channelBody.TimeLockDelta,
+			RemoteFeeBase:           channelBody.RemoteFeeBase,
+			RemoteFeeRateMilliMsat:  channelBody.RemoteFeeRateMilliMsat,
+			RemoteMinHtlc:           channelBody.RemoteMinHtlc,
+			RemoteMaxHtlc:           channelBody.RemoteMaxHtlc,
+			RemoteTimeLockDelta:     channelBody.RemoteTimeLockDelta,
+			PendingTotalHtlcsCount:  int64(channelBody.PendingTotalHTLCsCount),
+			PendingTotalHtlcsAmount: channelBody.PendingTotalHTLCsAmount,
+		})
+	}
+	return response, nil
+}
+
+// parseDates parses the from and to dates (YYYY-MM-DD) of a request
+func parseDates(from string, to string) (time.Time, time.Time, error) {
+	fromTime, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return time.Time{}, time.Time{}, status.Error(codes.InvalidArgument, "Invalid from date")
+	}
+	toTime, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return time.Time{}, time.Time{}, status.Error(codes.InvalidArgument, "Invalid to date")
+	}
+	return fromTime, toTime, nil
+}
+
+func (s *Server) ListForwards(ctx context.Context,
+	request *torqrpc.ListForwardsRequest) (*torqrpc.ListForwardsResponse, error) {
+
+	nodeIds, err := getNodeIds(ctx, request.NodeIds)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := parseDates(request.From, request.To)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := forwards.GetForwardsTableData(s.db, nodeIds, from, to)
+	if err != nil {
+		return nil, internalError(err, "Getting forwards.")
+	}
+	response := &torqrpc.ListForwardsResponse{}
+	for _, row := range rows {
+		forward := &torqrpc.Forward{
+			Alias:             row.Alias.String,
+			PubKey:            row.PubKey.String,
+			ChannelPoint:      row.ChannelPoint,
+			ShortChannelId:    row.ShortChannelID.String,
+			LndShortChannelId: row.LNDShortChannelId.String,
+			Open:              row.Open,
+			Capacity:          row.Capacity,
+			AmountOut:         row.AmountOut,
+			AmountIn:          row.AmountIn,
+			AmountTotal:       row.AmountTotal,
+			RevenueOut:        row.RevenueOut,
+			RevenueIn:         row.RevenueIn,
+			RevenueTotal:      row.RevenueTotal,
+			CountOut:          row.CountOut,
+			CountIn:           row.CountIn,
+			CountTotal:        row.CountTotal,
+			TurnoverOut:       row.TurnoverOut,
+			TurnoverIn:        row.TurnoverIn,
+			TurnoverTotal:     row.TurnoverTotal,
+		}
+		if row.ChannelID != nil {
+			forward.ChannelId = int64(*row.ChannelID)
+		}
+		for _, localNodeId := range row.LocalNodeIds {
+			forward.LocalNodeIds = append(forward.LocalNodeIds, int64(localNodeId))
+		}
+		response.Forwards = append(response.Forwards, forward)
+	}
+	return response, nil
+}
+
+func (s *Server) GetFlow(ctx context.Context, request *torqrpc.GetFlowRequest) (*torqrpc.GetFlowResponse, error) {
+	nodeIds, err := getNodeIds(ctx, request.NodeIds)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := parseDates(request.From, request.To)
+	if err != nil {
+		return nil, err
+	}
+	chanIds := []string{"all"}
+	if len(request.ChannelIds) != 0 {
+		chanIds = nil
+		for _, channelId := range request.ChannelIds {
+			chanIds = append(chanIds, strconv.FormatInt(channelId, 10))
+		}
+	}
+	channelFlows, err := flow.GetFlow(s.db, nodeIds, chanIds, from, to)
+	if err != nil {
+		return nil, internalError(err, "Getting flow.")
+	}
+	response := &torqrpc.GetFlowResponse{}
+	for _, channelFlow := range channelFlows {
+		flowData := &torqrpc.ChannelFlow{
+			Alias:                  channelFlow.Alias.String,
+			PubKey:                 channelFlow.PubKey.String,
+			FundingTransactionHash: channelFlow.FundingTransactionHash,
+			FundingOutputIndex:     int64(channelFlow.FundingOutputIndex),
+			AmountOut:              channelFlow.AmountOut,
+			AmountIn:               channelFlow.AmountIn,
+			RevenueOut:             channelFlow.RevenueOut,
+			RevenueIn:              channelFlow.RevenueIn,
+			CountOut:               channelFlow.CountOut,
+			CountIn:                channelFlow.CountIn,
+		}
+		if channelFlow.ChannelId != nil {
+			flowData.ChannelId, _ = strconv.ParseInt(*channelFlow.ChannelId, 10, 64)
+		}
+		response.Flow = append(response.Flow, flowData)
+	}
+	return response, nil
+}
+
+func (s *Server) ListPayments(ctx context.Context,
+	request *torqrpc.ListPaymentsRequest) (*torqrpc.ListPaymentsResponse, error) {
+
+	nodeIds, err := getNodeIds(ctx, request.NodeIds)
+	if err != nil {
+		return nil, err
+	}
+	limit := request.Limit
+	if limit == 0 || limit > ah.MaximumLimit {
+		limit = ah.MaximumLimit
+	}
+	filter := sq.And{}
+	if request.From != "" {
+		from, err := time.Parse(time.RFC3339, request.From)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid from date-time")
+		}
+		filter = append(filter, sq.GtOrEq{"date": from})
+	}
+	if request.To != "" {
+		to, err := time.Parse(time.RFC3339, request.To)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid to date-time")
+		}
+		filter = append(filter, sq.Lt{"date": to})
+	}
+	if request.Status != "" {
+		filter = append(filter, sq.Eq{"status": request.Status})
+	}
+	r, total, err := payments.GetPayments(s.db, nodeIds, filter, []string{"date DESC"}, limit, request.Offset)
+	if err != nil {
+		return nil, internalError(err, "Getting payments.")
+	}
+	response := &torqrpc.ListPaymentsResponse{Total: total}
+	for _, payment := range r {
+		paymentData := &torqrpc.Payment{
+			PaymentIndex:            payment.PaymentIndex,
+			Date:                    formatTime(&payment.Date),
+			Status:                  payment.Status,
+			Value:                   payment.Value,
+			Fee:                     payment.Fee,
+			Ppm:                     payment.PPM,
+			FailureReason:           payment.FailureReason,
+			PaymentHash:             payment.PaymentHash,
+			IsMpp:                   payment.IsMPP,
+			CountSuccessfulAttempts: int64(payment.CountSuccessfulAttempts),
+			CountFailedAttempts:     int64(payment.CountFailedAttempts),
+		}
+		if payment.DestinationPubKey != nil {
+			paymentData.DestinationPubKey = *payment.DestinationPubKey
+		}
+		if payment.PaymentRequest != nil {
+			paymentData.PaymentRequest = *payment.PaymentRequest
+		}
+		if payment.IsRebalance != nil {
+			paymentData.IsRebalance = *payment.IsRebalance
+		}
+		if payment.SecondsInFlight != nil {
+			paymentData.SecondsInFlight = *payment.SecondsInFlight
+		}
+		response.Payments = append(response.Payments, paymentData)
+	}
+	return response, nil
+}
+
+func (s *Server) UpdateRoutingPolicy(ctx context.Context,
+	request *torqrpc.UpdateRoutingPolicyRequest) (*torqrpc.UpdateRoutingPolicyResponse, error) {
+
+	if err := verifyNodeId(ctx, request.NodeId); err != nil {
+		return nil, err
+	}
+	routingPolicyUpdateRequest := lightning_helpers.RoutingPolicyUpdateRequest{
+		CommunicationRequest: lightning_helpers.CommunicationRequest{NodeId: int(request.NodeId)},
+		Db:                   s.db,
+		// DISABLE the rate limiter (like the REST API)
+		RateLimitSeconds: 1,
+		RateLimitCount:   10,
+		ChannelId:        int(request.ChannelId),
+		FeeRateMilliMsat: request.FeeRateMilliMsat,
+		FeeBaseMsat:      request.FeeBaseMsat,
+		MaxHtlcMsat:      request.MaxHtlcMsat,
+		MinHtlcMsat:      request.MinHtlcMsat,
+		TimeLockDelta:    request.TimeLockDelta,
+	}
+	response, err := lightning.SetRoutingPolicy(ctx, routingPolicyUpdateRequest)
+	audit.Record(s.db, getActor(ctx), "gRPC "+torqrpc.Torq_UpdateRoutingPolicy_FullMethodName,
+		"channel", fmt.Sprintf("%v", request.ChannelId),
+		map[string]interface{}{
+			"nodeId":           request.NodeId,
+			"feeRateMilliMsat": request.FeeRateMilliMsat,
+			"feeBaseMsat":      request.FeeBaseMsat,
+			"maxHtlcMsat":      request.MaxHtlcMsat,
+			"minHtlcMsat":      request.MinHtlcMsat,
+			"timeLockDelta":    request.TimeLockDelta,
+		}, err)
+	if err != nil {
+		return nil, internalError(err, "Problem when setting routing policy")
+	}
+	updateResponse := &torqrpc.UpdateRoutingPolicyResponse{
+		Success: response.Status == lightning_helpers.Active,
+		Message: response.Message,
+		Error:   response.Error,
+	}
+	for _, failedUpdate := range response.FailedUpdates {
+		updateResponse.FailedUpdates = append(updateResponse.FailedUpdates, &torqrpc.FailedRequest{
+			Reason: failedUpdate.Reason,
+			Error:  failedUpdate.Error,
+		})
+	}
+	return updateResponse, nil
+}
+
+func (s *Server) RequestRebalance(ctx context.Context,
+	request *torqrpc.RequestRebalanceRequest) (*torqrpc.RequestRebalanceResponse, error) {
+
+	if err := verifyNodeId(ctx, request.NodeId); err != nil {
+		return nil, err
+	}
+	if !cache.IsChannelBalanceCacheStreamActive(int(request.NodeId)) {
+		return nil, status.Error(codes.FailedPrecondition, "The node for the provided nodeId is not active (yet?).")
+	}
+	rebalanceRequests := lightning_helpers.RebalanceRequests{
+		CommunicationRequest: lightning_helpers.CommunicationRequest{NodeId: int(request.NodeId)},
+	}
+	for _, rebalance := range request.Requests {
+		rebalanceRequest := lightning_helpers.RebalanceRequest{
+			Origin:             lightning_helpers.RebalanceManual,
+			OriginId:           int(rebalance.OriginId),
+			OriginReference:    rebalance.OriginReference,
+			IncomingChannelId:  int(rebalance.IncomingChannelId),
+			OutgoingChannelId:  int(rebalance.OutgoingChannelId),
+			AmountMsat:         rebalance.AmountMsat,
+			MaximumCostMsat:    rebalance.MaximumCostMsat,
+			MaximumConcurrency: int(rebalance.MaximumConcurrency),
+		}
+		for _, channelId := range rebalance.ChannelIds {
+			rebalanceRequest.ChannelIds = append(rebalanceRequest.ChannelIds, int(channelId))
+		}
+		rebalanceRequests.Requests = append(rebalanceRequests.Requests, rebalanceRequest)
+	}
+	// The rebalancers keep running after the call returns
+	responses := workflows.RebalanceRequests(detachedContext{ctx}, s.db, rebalanceRequests, int(request.NodeId))
+	var rebalanceErr error
+	if len(responses) > 0 && responses[0].Error != "" {
+		rebalanceErr = status.Error(codes.InvalidArgument, responses[0].Error)
+	}
+	audit.Record(s.db, getActor(ctx), "gRPC "+torqrpc.Torq_RequestRebalance_FullMethodName,
+		"node", fmt.Sprintf("%v", request.NodeId), nil, rebalanceErr)
+	if rebalanceErr != nil {
+		return nil, rebalanceErr
+	}
+	response := &torqrpc.RequestRebalanceResponse{}
+	for _, rebalanceResponse := range responses {
+		response.Results = append(response.Results, &torqrpc.RebalanceResult{
+			IncomingChannelId: int64(rebalanceResponse.Request.IncomingChannelId),
+			OutgoingChannelId: int64(rebalanceResponse.Request.OutgoingChannelId),
+			Success:           rebalanceResponse.Status == lightning_helpers.Active,
+			Message:           rebalanceResponse.Message,
+			Error:             rebalanceResponse.Error,
+		})
+	}
+	return response, nil
+}
+
+func (s *Server) TriggerWorkflow(ctx context.Context,
+	request *torqrpc.TriggerWorkflowRequest) (*torqrpc.TriggerWorkflowResponse, error) {
+
+	// Workflows act on all Torq nodes, like the REST API tokens restricted to a node can't trigger them
+	if getApiToken(ctx).NodeId != nil {
+		return nil, status.Error(codes.PermissionDenied, "forbidden: token is restricted to a node")
+	}
+	reference, err := workflows.TriggerWorkflow(s.db, workflows.WorkflowToTrigger{
+		WorkflowVersionId:     int(request.WorkflowVersionId),
+		WorkflowVersionNodeId: int(request.WorkflowVersionNodeId),
+	})
+	audit.Record(s.db, getActor(ctx), "gRPC "+torqrpc.Torq_TriggerWorkflow_FullMethodName,
+		"workflowVersionNode", fmt.Sprintf("%v", request.WorkflowVersionNodeId),
+		map[string]interface{}{"workflowVersionId": request.WorkflowVersionId}, err)
+	if err != nil {
+		return nil, internalError(err, fmt.Sprintf("Triggering workflow version node for workflowVersionNodeId: %v",
+			request.WorkflowVersionNodeId))
+	}
+	if reference == "" {
+		return nil, status.Error(codes.FailedPrecondition,
+			"The workflow version node is not part of the workflow version.")
+	}
+	return &torqrpc.TriggerWorkflowResponse{Reference: reference}, nil
+}
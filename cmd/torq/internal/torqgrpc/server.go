@@ -0,0 +1,259 @@
+package torqgrpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/lncapital/torq/internal/api_tokens"
+	"github.com/lncapital/torq/internal/audit"
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/leader_election"
+	"github.com/lncapital/torq/internal/services_helpers"
+	"github.com/lncapital/torq/proto/torqrpc"
+)
+
+type apiTokenKey struct{}
+
+// methodScope is the API resource of a call and the HTTP method of the equivalent REST route,
+// together they determine the scope an API token needs i.e. read:channels for ListChannels.
+type methodScope struct {
+	resource   string
+	httpMethod string
+}
+
+// The scopes of the calls, SubscribeEvents checks the scope of every event type (see getEventTypeResources)
+func getMethodScopes() map[string]methodScope {
+	return map[string]methodScope{
+		torqrpc.Torq_ListChannels_FullMethodName:        {"channels", http.MethodGet},
+		torqrpc.Torq_ListForwards_FullMethodName:        {"forwards", http.MethodGet},
+		torqrpc.Torq_GetFlow_FullMethodName:             {"flow", http.MethodGet},
+		torqrpc.Torq_ListPayments_FullMethodName:        {"payments", http.MethodGet},
+		torqrpc.Torq_UpdateRoutingPolicy_FullMethodName: {"lightning", http.MethodPut},
+		torqrpc.Torq_RequestRebalance_FullMethodName:    {"automation", http.MethodPost},
+		torqrpc.Torq_TriggerWorkflow_FullMethodName:     {"workflows", http.MethodPost},
+	}
+}
+
+type Server struct {
+	torqrpc.UnimplementedTorqServer
+	db *sqlx.DB
+	// getActiveApiToken returns nil when the token does not exist or is no longer active
+	getActiveApiToken func(token string) (*api_tokens.ApiToken, error)
+	// isLeader returns false when this instance is a follower, followers only serve read-only calls
+	isLeader func() bool
+}
+
+func NewServer(db *sqlx.DB) *Server {
+	return &Server{
+		db: db,
+		getActiveApiToken: func(token string) (*api_tokens.ApiToken, error) {
+			return api_tokens.GetActiveApiToken(db, token, time.Now().UTC())
+		},
+		isLeader: leader_election.IsLeader,
+	}
+}
+
+// NewGrpcServer returns a gRPC server with the Torq service that authenticates every call with an API token
+func NewGrpcServer(server *Server, options ...grpc.ServerOption) *grpc.Server {
+	grpcServer := grpc.NewServer(append(options,
+		grpc.UnaryInterceptor(server.unaryInterceptor),
+		grpc.StreamInterceptor(server.streamInterceptor))...)
+	torqrpc.RegisterTorqServer(grpcServer, server)
+	return grpcServer
+}
+
+// Start serves the gRPC API with TLS when certificateFile and keyFile are set.
+// Without TLS the API tokens are sent in plain text so a TLS terminating proxy is required in front of it.
+func Start(host string, port int, certificateFile string, keyFile string, db *sqlx.DB) error {
+	var options []grpc.ServerOption
+	if certificateFile != "" || keyFile != "" {
+		transportCredentials, err := credentials.NewServerTLSFromFile(certificateFile, keyFile)
+		if err != nil {
+			return errors.Wrap(err, "Loading the gRPC TLS certificate")
+		}
+		options = append(options, grpc.Creds(transportCredentials))
+	} else {
+		log.Warn().Msg("The gRPC API is served without TLS, use a TLS terminating proxy to protect the API tokens")
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return errors.Wrap(err, "Listening for gRPC")
+	}
+	fmt.Println("Listening for gRPC on port " + strconv.Itoa(port))
+	if err := NewGrpcServer(NewServer(db), options...).Serve(listener); err != nil {
+		return errors.Wrap(err, "Serving gRPC")
+	}
+	return nil
+}
+
+func (s *Server) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	ctx, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authenticatedStream replaces the context of the stream with the authenticated context
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (as authenticatedStream) Context() context.Context {
+	return as.ctx
+}
+
+func (s *Server) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	ctx, err := s.authenticate(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticate checks the API token in the authorization metadata ("Bearer torq_...") has the scope for the call
+// and returns the context with the API token.
+func (s *Server) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	torqService := cache.GetCurrentCoreServiceState(services_helpers.RootService)
+	if torqService.Status != services_helpers.Active {
+		return nil, status.Error(codes.Unavailable, "initializing")
+	}
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("authorization")) != 0 {
+		authorization = md.Get("authorization")[0]
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+	if !strings.HasPrefix(authorization, "Bearer ") || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	apiToken, err := s.getActiveApiToken(token)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain API token")
+		return nil, status.Error(codes.Internal, "Failed to obtain API token")
+	}
+	if apiToken == nil {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	ctx = context.WithValue(ctx, apiTokenKey{}, *apiToken)
+	// SubscribeEvents is read-only and checks the scope of every requested event type
+	if fullMethod == torqrpc.Torq_SubscribeEvents_FullMethodName {
+		return ctx, nil
+	}
+	scope, exists := getMethodScopes()[fullMethod]
+	if !exists {
+		// A new call must get a scope before API tokens can use it
+		return nil, status.Error(codes.PermissionDenied, "forbidden: the call has no scope")
+	}
+	// Like leader_election.LeaderWriteRequired for the REST API
+	if scope.httpMethod != http.MethodGet && !s.isLeader() {
+		return nil, status.Error(codes.Unavailable, "This Torq instance is a follower, changes are made on the leader")
+	}
+	if !apiToken.IsAllowed(scope.resource, scope.httpMethod, fullMethod) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden: missing scope")
+	}
+	return ctx, nil
+}
+
+func getApiToken(ctx context.Context) api_tokens.ApiToken {
+	apiToken, _ := ctx.Value(apiTokenKey{}).(api_tokens.ApiToken)
+	return apiToken
+}
+
+func getActor(ctx context.Context) audit.Actor {
+	apiToken := getApiToken(ctx)
+	return audit.Actor{
+		Type: audit.ActorApiToken,
+		Id:   fmt.Sprintf("%v", apiToken.ApiTokenId),
+		Name: apiToken.Name,
+	}
+}
+
+// getNodeIds returns the requested Torq nodes or all Torq nodes when none are requested.
+// Nodes the API token can't access are removed.
+func getNodeIds(ctx context.Context, requestedNodeIds []int64) ([]int, error) {
+	torqNodeIds := cache.GetAllTorqNodeIds()
+	var nodeIds []int
+	if len(requestedNodeIds) == 0 {
+		nodeIds = torqNodeIds
+	}
+	for _, requestedNodeId := range requestedNodeIds {
+		if !slices.Contains(torqNodeIds, int(requestedNodeId)) {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown nodeId %v", requestedNodeId)
+		}
+		nodeIds = append(nodeIds, int(requestedNodeId))
+	}
+	apiToken := getApiToken(ctx)
+	if apiToken.NodeId == nil {
+		return nodeIds, nil
+	}
+	var restrictedNodeIds []int
+	for _, nodeId := range nodeIds {
+		if nodeId == *apiToken.NodeId {
+			restrictedNodeIds = append(restrictedNodeIds, nodeId)
+		}
+	}
+	return restrictedNodeIds, nil
+}
+
+// verifyNodeId checks the Torq node of an action exists and that the API token can access it
+func verifyNodeId(ctx context.Context, nodeId int64) error {
+	if !slices.Contains(cache.GetAllTorqNodeIds(), int(nodeId)) {
+		return status.Errorf(codes.InvalidArgument, "Unknown nodeId %v", nodeId)
+	}
+	apiToken := getApiToken(ctx)
+	if apiToken.NodeId != nil && *apiToken.NodeId != int(nodeId) {
+		return status.Error(codes.PermissionDenied, "forbidden: token is restricted to another node")
+	}
+	return nil
+}
+
+// detachedContext keeps the values of a call (i.e. the trace) without being cancelled when the call returns
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+// internalError logs the error and only returns the cause like server_errors.WrapLogAndSendServerError
+func internalError(err error, message string) error {
+	err = errors.Wrap(err, message)
+	log.Error().Err(err).Send()
+	return status.Error(codes.Internal, errors.Cause(err).Error())
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,253 @@
+package torqgrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lncapital/torq/internal/api_tokens"
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/services_helpers"
+	"github.com/lncapital/torq/proto/torqrpc"
+)
+
+const testToken = "torq_test"
+
+// startTestServer serves the Torq service in-process and returns a client with the API token
+func startTestServer(t *testing.T, apiToken api_tokens.ApiToken,
+	isLeader bool) (torqrpc.TorqClient, context.Context) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go cache.ServiceCacheHandler(cache.ServicesCacheChannel, ctx)
+	go cache.NodesCacheHandler(cache.NodesCacheChannel, ctx)
+	go cache.EventsCacheHandler(cache.EventsCacheChannel, ctx)
+	cache.InitStates(true)
+	cache.InitRootService(cancel)
+	cache.SetActiveCoreServiceState(services_helpers.RootService)
+	cache.SetTorqNode(1, "first", core.Active, "pubkey1", core.Bitcoin, core.MainNet)
+	cache.SetTorqNode(2, "second", core.Active, "pubkey2", core.Bitcoin, core.MainNet)
+
+	server := &Server{
+		getActiveApiToken: func(token string) (*api_tokens.ApiToken, error) {
+			if token != testToken {
+				return nil, nil
+			}
+			return &apiToken, nil
+		},
+		isLeader: func() bool { return isLeader },
+	}
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := NewGrpcServer(server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dialing the test server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return torqrpc.NewTorqClient(conn), metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+testToken)
+}
+
+func TestAuthentication(t *testing.T) {
+	nodeId := 1
+	client, ctx := startTestServer(t, api_tokens.ApiToken{
+		Scopes: []string{"read:forwards", string(api_tokens.ScopeWritePolicy)},
+		NodeId: &nodeId,
+	}, true)
+
+	testCases := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"missing token", func() error {
+			_, err := client.ListChannels(context.Background(), &torqrpc.ListChannelsRequest{})
+			return err
+		}, codes.Unauthenticated},
+		{"unknown token", func() error {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer torq_unknown")
+			_, err := client.ListChannels(ctx, &torqrpc.ListChannelsRequest{})
+			return err
+		}, codes.Unauthenticated},
+		{"missing scope", func() error {
+			_, err := client.ListChannels(ctx, &torqrpc.ListChannelsRequest{})
+			return err
+		}, codes.PermissionDenied},
+		{"route scope for another route", func() error {
+			_, err := client.TriggerWorkflow(ctx, &torqrpc.TriggerWorkflowRequest{})
+			return err
+		}, codes.PermissionDenied},
+		{"restricted to another node", func() error {
+			_, err := client.UpdateRoutingPolicy(ctx, &torqrpc.UpdateRoutingPolicyRequest{NodeId: 2, ChannelId: 1})
+			return err
+		}, codes.PermissionDenied},
+		{"unknown node", func() error {
+			_, err := client.UpdateRoutingPolicy(ctx, &torqrpc.UpdateRoutingPolicyRequest{NodeId: 3, ChannelId: 1})
+			return err
+		}, codes.InvalidArgument},
+		{"event type without scope", func() error {
+			stream, err := client.SubscribeEvents(ctx, &torqrpc.SubscribeEventsRequest{
+				Types: []torqrpc.EventType{torqrpc.EventType_PAYMENT_EVENTS},
+			})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return err
+		}, codes.PermissionDenied},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if code := status.Code(tc.call()); code != tc.code {
+				t.Errorf("got code %v, want %v", code, tc.code)
+			}
+		})
+	}
+}
+
+func TestCallsWithoutScope(t *testing.T) {
+	nodeId := 1
+	apiToken := api_tokens.ApiToken{Scopes: []string{"write:workflows", "write:lightning"}, NodeId: &nodeId}
+	client, ctx := startTestServer(t, apiToken, true)
+
+	// Workflows act on all Torq nodes
+	_, err := client.TriggerWorkflow(ctx, &torqrpc.TriggerWorkflowRequest{WorkflowVersionNodeId: 1})
+	if code := status.Code(err); code != codes.PermissionDenied {
+		t.Errorf("TriggerWorkflow restricted to a node got code %v, want %v", code, codes.PermissionDenied)
+	}
+
+	// Calls are denied until they get a scope
+	server := &Server{
+		getActiveApiToken: func(string) (*api_tokens.ApiToken, error) { return &apiToken, nil },
+		isLeader:          func() bool { return true },
+	}
+	incomingCtx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("authorization", "Bearer "+testToken))
+	_, err = server.authenticate(incomingCtx, "/torqrpc.Torq/NewCall")
+	if code := status.Code(err); code != codes.PermissionDenied {
+		t.Errorf("Call without scope got code %v, want %v", code, codes.PermissionDenied)
+	}
+	if _, err = server.authenticate(incomingCtx, torqrpc.Torq_SubscribeEvents_FullMethodName); err != nil {
+		t.Errorf("SubscribeEvents got %v, want the scopes of the event types to be checked by the call", err)
+	}
+}
+
+func TestDetachedContext(t *testing.T) {
+	type key struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	detached := detachedContext{ctx}
+	cancel()
+	if detached.Err() != nil || detached.Done() != nil {
+		t.Errorf("got a cancelled context, want it to outlive the call")
+	}
+	if detached.Value(key{}) != "value" {
+		t.Errorf("got value %v, want the values of the call", detached.Value(key{}))
+	}
+}
+
+func TestFollower(t *testing.T) {
+	client, ctx := startTestServer(t, api_tokens.ApiToken{
+		Scopes: []string{"read:forwards", "write:lightning", "write:automation", "write:workflows"},
+	}, false)
+
+	testCases := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"UpdateRoutingPolicy", func() error {
+			_, err := client.UpdateRoutingPolicy(ctx, &torqrpc.UpdateRoutingPolicyRequest{NodeId: 1, ChannelId: 1})
+			return err
+		}, codes.Unavailable},
+		{"RequestRebalance", func() error {
+			_, err := client.RequestRebalance(ctx, &torqrpc.RequestRebalanceRequest{NodeId: 1})
+			return err
+		}, codes.Unavailable},
+		{"TriggerWorkflow", func() error {
+			_, err := client.TriggerWorkflow(ctx, &torqrpc.TriggerWorkflowRequest{WorkflowVersionNodeId: 1})
+			return err
+		}, codes.Unavailable},
+		// Read-only calls are served by followers, the invalid date makes it fail before the database is used
+		{"ListForwards", func() error {
+			_, err := client.ListForwards(ctx, &torqrpc.ListForwardsRequest{From: "invalid"})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if code := status.Code(tc.call()); code != tc.code {
+				t.Errorf("got code %v, want %v", code, tc.code)
+			}
+		})
+	}
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	nodeId := 1
+	client, ctx := startTestServer(t, api_tokens.ApiToken{
+		Scopes: []string{"read:forwards"},
+		NodeId: &nodeId,
+	}, true)
+
+	stream, err := client.SubscribeEvents(ctx, &torqrpc.SubscribeEventsRequest{})
+	if err != nil {
+		t.Fatalf("SubscribeEvents: %v", err)
+	}
+
+	channelId := 5
+	// The events are published until one is received as the subscription is created asynchronously.
+	// Only the forward of the node the token is restricted to can be received.
+	received := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-received:
+				return
+			case <-ticker.C:
+				cache.PublishEvent(core.PaymentEvent{EventData: core.EventData{NodeId: 1}, AmountPaid: 1000})
+				cache.PublishEvent(core.ForwardEvent{EventData: core.EventData{NodeId: 2}, FeeMsat: 2000})
+				cache.PublishEvent(core.ForwardEvent{
+					EventData:         core.EventData{NodeId: 1, EventTime: time.Now()},
+					FeeMsat:           1000,
+					IncomingChannelId: &channelId,
+				})
+			}
+		}
+	}()
+	event, err := stream.Recv()
+	close(received)
+	if err != nil {
+		t.Fatalf("Receiving event: %v", err)
+	}
+
+	if event.NodeId != 1 {
+		t.Errorf("got nodeId %v, want 1", event.NodeId)
+	}
+	forward := event.GetForward()
+	if forward == nil {
+		t.Fatalf("got %v, want a forward event", event.Event)
+	}
+	if forward.FeeMsat != 1000 || forward.IncomingChannelId != 5 || forward.OutgoingChannelId != 0 {
+		t.Errorf("got forward %v", forward)
+	}
+}
@@ -0,0 +1,321 @@
+package torqsrv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"golang.org/x/exp/slices"
+
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+type streamEventType string
+
+const (
+	forwardStreamEvent        = streamEventType("forward")
+	paymentStreamEvent        = streamEventType("payment")
+	invoiceStreamEvent        = streamEventType("invoice")
+	channelStreamEvent        = streamEventType("channel")
+	channelBalanceStreamEvent = streamEventType("channelBalance")
+	channelGraphStreamEvent   = streamEventType("channelGraph")
+	nodeGraphStreamEvent      = streamEventType("nodeGraph")
+	peerStreamEvent           = streamEventType("peer")
+	htlcStreamEvent           = streamEventType("htlc")
+	transactionStreamEvent    = streamEventType("transaction")
+	blockStreamEvent          = streamEventType("block")
+	// eventsMissedStreamEvent is sent first when a stream is resumed but not all events since lastEventId are kept,
+	// the client should reload the state it derives from the events.
+	eventsMissedStreamEvent = streamEventType("eventsMissed")
+	// streamEndedStreamEvent is sent last when the client did not keep up with the events,
+	// the stream can be resumed with the eventId of the last received event.
+	streamEndedStreamEvent = streamEventType("streamEnded")
+)
+
+const (
+	// The maximum number of event streams that can be open at the same time
+	maximumEventStreams = 50
+	// Keeps idle connections open through proxies
+	eventStreamHeartbeatInterval = 30 * time.Second
+	// A websocket client that does not accept a message within this time is disconnected
+	eventStreamWriteTimeout = 10 * time.Second
+)
+
+var errMissingEventScope = errors.New("forbidden: missing scope") //nolint:gochecknoglobals
+
+// eventStreamSlots limits the number of open event streams (see maximumEventStreams)
+var eventStreamSlots = make(chan struct{}, maximumEventStreams) //nolint:gochecknoglobals
+
+// The API resource an API token needs to read to receive the events of a type
+func getStreamEventTypeResources() map[streamEventType]string {
+	return map[streamEventType]string{
+		forwardStreamEvent:        "forwards",
+		paymentStreamEvent:        "payments",
+		invoiceStreamEvent:        "invoices",
+		channelStreamEvent:        "channels",
+		channelBalanceStreamEvent: "channels",
+		channelGraphStreamEvent:   "channels",
+		htlcStreamEvent:           "channels",
+		nodeGraphStreamEvent:      "peers",
+		peerStreamEvent:           "peers",
+		transactionStreamEvent:    "on-chain-tx",
+		blockStreamEvent:          "on-chain-tx",
+	}
+}
+
+// streamEvent is sent to the client, the event is the JSON encoded core event i.e. core.ForwardEvent
+type streamEvent struct {
+	EventId uint64          `json:"eventId,omitempty"`
+	Type    streamEventType `json:"type"`
+	NodeId  int             `json:"nodeId,omitempty"`
+	Event   any             `json:"event,omitempty"`
+}
+
+type eventStreamRequest struct {
+	eventTypes  []streamEventType
+	nodeIds     []int
+	lastEventId uint64
+}
+
+// RegisterEventStreamRoutes registers the event stream as Server-Sent Events and as WebSocket.
+// Both are filtered with the (repeatable) type and nodeId query parameters and
+// can be resumed with the lastEventId query parameter (or the Last-Event-ID header for Server-Sent Events).
+func RegisterEventStreamRoutes(r *gin.RouterGroup) {
+	r.GET("sse", eventStreamSseHandler)
+	r.GET("ws", eventStreamWebsocketHandler)
+}
+
+func parseEventStreamRequest(c *gin.Context) (eventStreamRequest, error) {
+	var request eventStreamRequest
+	var err error
+	request.nodeIds, err = auth.GetNodeIds(c)
+	if err != nil {
+		return eventStreamRequest{}, err
+	}
+	lastEventId := c.GetHeader("Last-Event-ID")
+	if c.Query("lastEventId") != "" {
+		lastEventId = c.Query("lastEventId")
+	}
+	if lastEventId != "" {
+		request.lastEventId, err = strconv.ParseUint(lastEventId, 10, 64)
+		if err != nil {
+			return eventStreamRequest{}, errors.Newf("Invalid lastEventId %v", lastEventId)
+		}
+	}
+	resources := getStreamEventTypeResources()
+	var requestedEventTypes []streamEventType
+	for _, value := range c.QueryArray("type") {
+		if _, exists := resources[streamEventType(value)]; !exists {
+			return eventStreamRequest{}, errors.Newf("Unknown type %v", value)
+		}
+		requestedEventTypes = append(requestedEventTypes, streamEventType(value))
+	}
+	apiToken := auth.GetApiToken(c)
+	for eventType, resource := range resources {
+		if len(requestedEventTypes) != 0 && !slices.Contains(requestedEventTypes, eventType) {
+			continue
+		}
+		if apiToken != nil && !apiToken.IsAllowed(resource, http.MethodGet, c.FullPath()) {
+			if len(requestedEventTypes) != 0 {
+				return eventStreamRequest{}, errors.Wrapf(errMissingEventScope, "Type %v", eventType)
+			}
+			continue
+		}
+		request.eventTypes = append(request.eventTypes, eventType)
+	}
+	if len(request.eventTypes) == 0 {
+		return eventStreamRequest{}, errMissingEventScope
+	}
+	return request, nil
+}
+
+// startEventStream validates the request and takes a slot of the open event streams,
+// false when the request was refused. The slot is returned with endEventStream.
+func startEventStream(c *gin.Context) (eventStreamRequest, bool) {
+	request, err := parseEventStreamRequest(c)
+	if err != nil {
+		if errors.Is(err, errMissingEventScope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return eventStreamRequest{}, false
+		}
+		server_errors.SendBadRequest(c, err.Error())
+		return eventStreamRequest{}, false
+	}
+	select {
+	case eventStreamSlots <- struct{}{}:
+		return request, true
+	default:
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many open event streams"})
+		return eventStreamRequest{}, false
+	}
+}
+
+func endEventStream() {
+	<-eventStreamSlots
+}
+
+// getStreamEvent converts the published event, false when the client did not request it
+func getStreamEvent(request eventStreamRequest, publishedEvent cache.PublishedEvent) (streamEvent, bool) {
+	event := streamEvent{EventId: publishedEvent.EventId, Event: publishedEvent.Event}
+	switch e := publishedEvent.Event.(type) {
+	case core.ForwardEvent:
+		event.Type, event.NodeId = forwardStreamEvent, e.NodeId
+	case core.PaymentEvent:
+		event.Type, event.NodeId = paymentStreamEvent, e.NodeId
+	case core.InvoiceEvent:
+		event.Type, event.NodeId = invoiceStreamEvent, e.NodeId
+	case core.ChannelEvent:
+		event.Type, event.NodeId = channelStreamEvent, e.NodeId
+	case core.ChannelBalanceEvent:
+		event.Type, event.NodeId = channelBalanceStreamEvent, e.NodeId
+	case core.ChannelGraphEvent:
+		event.Type, event.NodeId = channelGraphStreamEvent, e.NodeId
+	case core.NodeGraphEvent:
+		event.Type, event.NodeId = nodeGraphStreamEvent, e.NodeId
+	case core.PeerEvent:
+		event.Type, event.NodeId = peerStreamEvent, e.NodeId
+	case core.HtlcEvent:
+		event.Type, event.NodeId = htlcStreamEvent, e.NodeId
+	case core.TransactionEvent:
+		event.Type, event.NodeId = transactionStreamEvent, e.NodeId
+	case core.BlockEvent:
+		event.Type, event.NodeId = blockStreamEvent, e.NodeId
+	default:
+		return streamEvent{}, false
+	}
+	if !slices.Contains(request.eventTypes, event.Type) || !slices.Contains(request.nodeIds, event.NodeId) {
+		return streamEvent{}, false
+	}
+	return event, true
+}
+
+// streamEvents sends the events of the subscription until the client disconnects (done) or falls behind
+func streamEvents(request eventStreamRequest, done <-chan struct{}, send func(event streamEvent) error,
+	heartbeat func() error) error {
+
+	subscription := cache.SubscribeEvents(request.lastEventId)
+	defer cache.UnsubscribeEvents(subscription.SubscriptionId)
+	if subscription.Missed {
+		if err := send(streamEvent{Type: eventsMissedStreamEvent}); err != nil {
+			return err
+		}
+	}
+	ticker := time.NewTicker(eventStreamHeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-ticker.C:
+			if err := heartbeat(); err != nil {
+				return err
+			}
+		case publishedEvent, ok := <-subscription.Events:
+			if !ok {
+				return send(streamEvent{Type: streamEndedStreamEvent})
+			}
+			event, requested := getStreamEvent(request, publishedEvent)
+			if !requested {
+				continue
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func eventStreamSseHandler(c *gin.Context) {
+	request, ok := startEventStream(c)
+	if !ok {
+		return
+	}
+	defer endEventStream()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	err := streamEvents(request, c.Request.Context().Done(),
+		func(event streamEvent) error {
+			data, err := json.Marshal(event)
+			if err != nil {
+				return errors.Wrap(err, "JSON encoding event")
+			}
+			message := fmt.Sprintf("event: %v\ndata: %s\n\n", event.Type, data)
+			if event.EventId != 0 {
+				message = fmt.Sprintf("id: %v\n", event.EventId) + message
+			}
+			if _, err = c.Writer.WriteString(message); err != nil {
+				return errors.Wrap(err, "Writing event")
+			}
+			c.Writer.Flush()
+			return nil
+		},
+		func() error {
+			if _, err := c.Writer.WriteString(": heartbeat\n\n"); err != nil {
+				return errors.Wrap(err, "Writing heartbeat")
+			}
+			c.Writer.Flush()
+			return nil
+		})
+	if err != nil {
+		log.Debug().Err(err).Msg("Event stream (SSE) ended")
+	}
+}
+
+func eventStreamWebsocketHandler(c *gin.Context) {
+	request, ok := startEventStream(c)
+	if !ok {
+		return
+	}
+	defer endEventStream()
+
+	wsUpgrade := getWebsocketUpgrader()
+	conn, err := wsUpgrade.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Debug().Err(err).Msg("Event stream WebSocket upgrade")
+		return
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Debug().Err(err).Msg("Event stream WebSocket close failure")
+		}
+	}()
+
+	// Messages from the client are ignored, reading is required to process close and pong messages
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	err = streamEvents(request, done,
+		func(event streamEvent) error {
+			if err := conn.SetWriteDeadline(time.Now().Add(eventStreamWriteTimeout)); err != nil {
+				return errors.Wrap(err, "Setting write deadline")
+			}
+			return errors.Wrap(conn.WriteJSON(event), "Writing event")
+		},
+		func() error {
+			return errors.Wrap(conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventStreamWriteTimeout)),
+				"Writing ping")
+		})
+	if err != nil {
+		log.Debug().Err(err).Msg("Event stream (WebSocket) ended")
+	}
+}
@@ -13,35 +13,45 @@ import (
 	"github.com/gin-gonic/contrib/gzip"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"github.com/ulule/limiter/v3"
 	mgin "github.com/ulule/limiter/v3/drivers/middleware/gin"
 	"github.com/ulule/limiter/v3/drivers/store/memory"
 
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/api_tokens"
+	"github.com/lncapital/torq/internal/audit"
 	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/automation"
 	"github.com/lncapital/torq/internal/categories"
 	"github.com/lncapital/torq/internal/channel_history"
 	"github.com/lncapital/torq/internal/channels"
 	"github.com/lncapital/torq/internal/corridors"
+	"github.com/lncapital/torq/internal/data_retention"
 	"github.com/lncapital/torq/internal/flow"
 	"github.com/lncapital/torq/internal/forwards"
 	"github.com/lncapital/torq/internal/invoices"
+	"github.com/lncapital/torq/internal/leader_election"
 	"github.com/lncapital/torq/internal/lightning"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/messages"
+	"github.com/lncapital/torq/internal/metrics"
 	"github.com/lncapital/torq/internal/nodes"
 	"github.com/lncapital/torq/internal/on_chain_tx"
 	"github.com/lncapital/torq/internal/payments"
 	"github.com/lncapital/torq/internal/peers"
 	"github.com/lncapital/torq/internal/services"
 	"github.com/lncapital/torq/internal/settings"
+	"github.com/lncapital/torq/internal/spending"
 	"github.com/lncapital/torq/internal/tags"
+	"github.com/lncapital/torq/internal/users"
 	"github.com/lncapital/torq/internal/views"
 	"github.com/lncapital/torq/internal/workflows"
+	"github.com/lncapital/torq/pkg/tracing"
 	"github.com/lncapital/torq/web"
 )
 
-func Start(host string, port int, apiPswd string, cookiePath string, db *sqlx.DB, autoLogin bool) error {
+func Start(host string, port int, apiPswd string, cookiePath string, db *sqlx.DB, autoLogin bool,
+	metricsUsername string, metricsPassword string) error {
 	r := gin.Default()
 
 	if err := auth.RefreshCookieFile(cookiePath); err != nil {
@@ -55,6 +65,9 @@ func Start(host string, port int, apiPswd string, cookiePath string, db *sqlx.DB
 
 	registerRoutes(r, db, apiPswd, cookiePath, autoLogin)
 
+	metrics.Register(workflows.RebalancerCollector{})
+	metrics.RegisterMetricsRoutes(r, metricsUsername, metricsPassword)
+
 	fmt.Println("Listening on port " + strconv.Itoa(port))
 
 	if err := r.Run(host + ":" + strconv.Itoa(port)); err != nil {
@@ -113,23 +126,31 @@ func equalASCIIFold(s, t string) bool {
 }
 
 func registerRoutes(r *gin.Engine, db *sqlx.DB, apiPwd string, cookiePath string, autoLogin bool) {
-	r.Use(gzip.Gzip(gzip.DefaultCompression))
 	applyCors(r)
+	// The probes for orchestration don't need a session and answer while Torq is initializing
+	services.RegisterHealthRoutes(r, db)
+	// The event streams are registered before gzip as compressed events would be held back
+	eventStreamRoutes := r.Group("/api/events", auth.AuthRequired(db, autoLogin), auth.TorqRequired)
+	{
+		RegisterEventStreamRoutes(eventStreamRoutes)
+	}
+	r.Use(gzip.Gzip(gzip.DefaultCompression))
 	// Websocket
 	ws := r.Group("/ws")
-	ws.Use(auth.AuthRequired(autoLogin))
+	ws.Use(auth.AuthRequired(db, autoLogin))
 	ws.GET("", func(c *gin.Context) {
 		err := WebsocketHandler(c, db)
 		log.Debug().Msgf("WebsocketHandler: %v", err)
 	})
 
-	api := r.Group("/api")
+	// The probes, event streams and websocket are not traced as they are frequent or long-lived
+	api := r.Group("/api", tracing.Middleware())
 
 	api.POST("/logout", auth.Logout)
 
 	// Limit login attempts to 10 per minute.
 	rl := NewLoginRateLimitMiddleware()
-	api.POST("/login", rl, auth.Login(apiPwd))
+	api.POST("/login", rl, auth.Login(db, apiPwd))
 	api.POST("/cookie-login", rl, auth.CookieLogin(cookiePath))
 	api.GET("auto-login-setting", rl, auth.AutoLoginSetting(autoLogin))
 
@@ -143,93 +164,144 @@ func registerRoutes(r *gin.Engine, db *sqlx.DB, apiPwd string, cookiePath string
 		services.RegisterUnauthenticatedRoutes(unauthorisedServicesRoutes, db)
 	}
 
-	api.Use(auth.AuthRequired(autoLogin)).Use(auth.TorqRequired)
+	// The OpenAPI document of the public API is available without authentication
+	v1Endpoints := getV1Endpoints(db)
+	api.GET("/v1/openapi.json",
+		ah.SendOpenApiDocument(ah.GetOpenApiDocument("Torq API", apiV1Version, "/api/v1", v1Endpoints)))
+
+	// Followers (high availability) serve the read-only API, changes are made on the leader
+	api.Use(auth.AuthRequired(db, autoLogin)).Use(auth.TorqRequired).Use(leader_election.LeaderWriteRequired).
+		Use(audit.Middleware(db))
 	{
+		// Viewers have read-only access, operators can make changes (fees, rebalances, workflows, ...)
+		// and only admins can manage node connections, settings, users and API tokens.
+		// API tokens can only access route groups for which they have a scope.
+		operatorWrite := auth.WriteRoleRequired(users.RoleOperator)
 
-		tableViewRoutes := api.Group("/table-views")
+		api.GET("/me", auth.CurrentUser(autoLogin))
+
+		// The versioned public API
+		registerV1Routes(api.Group("/v1"), v1Endpoints, operatorWrite)
+
+		tableViewRoutes := api.Group("/table-views", auth.ScopeRequired("table-views"), operatorWrite)
 		{
 			views.RegisterTableViewRoutes(tableViewRoutes, db)
 		}
 
-		categoryRoutes := api.Group("/categories")
+		categoryRoutes := api.Group("/categories", auth.ScopeRequired("categories"), operatorWrite)
 		{
 			categories.RegisterCategoryRoutes(categoryRoutes, db)
 		}
 
-		tagRoutes := api.Group("/tags")
+		tagRoutes := api.Group("/tags", auth.ScopeRequired("tags"), operatorWrite)
 		{
 			tags.RegisterTagRoutes(tagRoutes, db)
 		}
 
-		corridorRoutes := api.Group("/corridors")
+		corridorRoutes := api.Group("/corridors", auth.ScopeRequired("corridors"), operatorWrite)
 		{
 			corridors.RegisterCorridorRoutes(corridorRoutes, db)
 		}
 
-		paymentRoutes := api.Group("/payments")
+		paymentRoutes := api.Group("/payments", auth.ScopeRequired("payments"), operatorWrite)
 		{
 			payments.RegisterPaymentsRoutes(paymentRoutes, db)
 		}
 
-		invoiceRoutes := api.Group("/invoices")
+		invoiceRoutes := api.Group("/invoices", auth.ScopeRequired("invoices"), operatorWrite)
 		{
 			invoices.RegisterInvoicesRoutes(invoiceRoutes, db)
 		}
 
-		onChainTx := api.Group("/on-chain-tx")
+		onChainTx := api.Group("/on-chain-tx", auth.ScopeRequired("on-chain-tx"), operatorWrite)
 		{
 			on_chain_tx.RegisterOnChainTxsRoutes(onChainTx, db)
 		}
 
-		peerRoutes := api.Group("/peers")
+		peerRoutes := api.Group("/peers", auth.ScopeRequired("peers"), operatorWrite)
 		{
 			peers.RegisterPeerRoutes(peerRoutes, db)
 		}
 
-		nodeRoutes := api.Group("/nodes")
+		nodeRoutes := api.Group("/nodes", auth.ScopeRequired("nodes"), operatorWrite)
 		{
 			nodes.RegisterNodeRoutes(nodeRoutes, db)
 		}
 
-		channelRoutes := api.Group("/channels")
+		channelRoutes := api.Group("/channels", auth.ScopeRequired("channels"), operatorWrite)
 		{
 			channel_history.RegisterChannelHistoryRoutes(channelRoutes, db)
 			channels.RegisterChannelRoutes(channelRoutes, db)
 		}
 
-		forwardRoutes := api.Group("/forwards")
+		forwardRoutes := api.Group("/forwards", auth.ScopeRequired("forwards"), operatorWrite)
 		{
 			forwards.RegisterForwardsRoutes(forwardRoutes, db)
 		}
 
-		flowRoutes := api.Group("/flow")
+		flowRoutes := api.Group("/flow", auth.ScopeRequired("flow"), operatorWrite)
 		{
 			flow.RegisterFlowRoutes(flowRoutes, db)
 		}
 
-		lightningRoutes := api.Group("/lightning")
+		lightningRoutes := api.Group("/lightning", auth.ScopeRequired("lightning"), operatorWrite)
 		{
 			lightning.RegisterLightningRoutes(lightningRoutes, db)
 		}
 
-		workflowRoutes := api.Group("/workflows")
+		// Workflows act on all Torq nodes so API tokens restricted to a node can't use them
+		workflowRoutes := api.Group("/workflows", auth.ScopeRequired("workflows"), auth.AllNodesRequired,
+			operatorWrite)
 		{
 			workflows.RegisterWorkflowRoutes(workflowRoutes, db)
 		}
 
-		automationRoutes := api.Group("/automation")
+		automationRoutes := api.Group("/automation", auth.ScopeRequired("automation"), operatorWrite)
 		{
 			automation.RegisterAutomationRoutes(automationRoutes, db)
 		}
 
-		messageRoutes := api.Group("messages")
+		messageRoutes := api.Group("messages", auth.ScopeRequired("messages"), operatorWrite)
 		{
 			messages.RegisterMessagesRoutes(messageRoutes)
 		}
 
-		settingRoutes := api.Group("settings")
+		settingRoutes := api.Group("settings", auth.ScopeRequired("settings"), auth.WriteRoleRequired(users.RoleAdmin))
 		{
 			settings.RegisterSettingRoutes(settingRoutes, db)
+			data_retention.RegisterDataRetentionRoutes(
+				settingRoutes.Group("dataRetention", auth.RoleRequired(users.RoleAdmin)), db)
+			logging.RegisterLoggingRoutes(settingRoutes.Group("logging", auth.RoleRequired(users.RoleAdmin)))
+		}
+
+		// Changing users or creating API tokens (which can't provide a second factor) needs a recent second factor
+		userRoutes := api.Group("/users", auth.RoleRequired(users.RoleAdmin), auth.WriteFreshFactorRequired(db))
+		{
+			users.RegisterUserRoutes(userRoutes, db)
+		}
+
+		apiTokenRoutes := api.Group("/api-tokens", auth.RoleRequired(users.RoleAdmin), auth.WriteFreshFactorRequired(db))
+		{
+			api_tokens.RegisterApiTokenRoutes(apiTokenRoutes, db)
+		}
+
+		auditRoutes := api.Group("/audit", auth.RoleRequired(users.RoleAdmin))
+		{
+			audit.RegisterAuditRoutes(auditRoutes, db)
+		}
+
+		// Operators can see the spending requests, only admins can change the limits or decide on requests
+		spendingRoutes := api.Group("/spending", auth.RoleRequired(users.RoleOperator),
+			auth.WriteRoleRequired(users.RoleAdmin))
+		{
+			spending.RegisterSpendingRoutes(spendingRoutes, db)
+			lightning.RegisterSpendingApprovalRoutes(spendingRoutes, db)
+		}
+
+		// Every user manages the TOTP of their own login, API tokens can't
+		totpRoutes := api.Group("/totp", auth.RoleRequired(users.RoleViewer))
+		{
+			auth.RegisterTotpRoutes(totpRoutes, db)
 		}
 
 		api.GET("/ping", func(c *gin.Context) {
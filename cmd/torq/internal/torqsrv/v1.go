@@ -0,0 +1,44 @@
+package torqsrv
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/internal/channels"
+	"github.com/lncapital/torq/internal/forwards"
+	"github.com/lncapital/torq/internal/invoices"
+	"github.com/lncapital/torq/internal/lightning"
+	"github.com/lncapital/torq/internal/payments"
+	"github.com/lncapital/torq/internal/peers"
+	"github.com/lncapital/torq/internal/tags"
+	"github.com/lncapital/torq/internal/workflows"
+)
+
+// apiV1Version is the version of the OpenAPI document of /api/v1.
+// Endpoints and fields can be added but not changed or removed without a new API version.
+const apiV1Version = "1.0.0"
+
+func getV1Endpoints(db *sqlx.DB) []ah.Endpoint {
+	var endpoints []ah.Endpoint
+	endpoints = append(endpoints, channels.GetV1Endpoints(db)...)
+	endpoints = append(endpoints, forwards.GetV1Endpoints(db)...)
+	endpoints = append(endpoints, payments.GetV1Endpoints(db)...)
+	endpoints = append(endpoints, invoices.GetV1Endpoints(db)...)
+	endpoints = append(endpoints, peers.GetV1Endpoints(db)...)
+	endpoints = append(endpoints, tags.GetV1Endpoints(db)...)
+	endpoints = append(endpoints, workflows.GetV1Endpoints(db)...)
+	endpoints = append(endpoints, lightning.GetV1Endpoints(db)...)
+	return endpoints
+}
+
+// registerV1Routes registers the endpoints with the same scope and role checks as the route groups of the web UI
+func registerV1Routes(r *gin.RouterGroup, endpoints []ah.Endpoint, operatorWrite gin.HandlerFunc) {
+	for _, endpoint := range endpoints {
+		handlers := []gin.HandlerFunc{auth.ScopeRequired(endpoint.Resource), operatorWrite}
+		handlers = append(handlers, endpoint.Middleware...)
+		handlers = append(handlers, endpoint.Handler)
+		r.Handle(endpoint.Method, endpoint.Path, handlers...)
+	}
+}
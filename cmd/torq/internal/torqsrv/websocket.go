@@ -1,20 +1,26 @@
 package torqsrv
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/lncapital/torq/internal/audit"
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/internal/leader_election"
 	"github.com/lncapital/torq/internal/lightning"
 	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/spending"
+	"github.com/lncapital/torq/internal/users"
 	"github.com/lncapital/torq/pkg/server_errors"
+	"github.com/lncapital/torq/pkg/tracing"
 
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/jmoiron/sqlx"
-
-	"github.com/rs/zerolog/log"
 )
 
 type wsRequest struct {
@@ -26,23 +32,72 @@ type Pong struct {
 	Message string `json:"message"`
 }
 
+// wsSpendingApprovalRequired is sent when a payment exceeds a spending limit and waits for approval
+type wsSpendingApprovalRequired struct {
+	Type            string           `json:"type"`
+	SpendingRequest spending.Request `json:"spendingRequest"`
+}
+
 type wsError struct {
 	Type  string                    `json:"type"`
 	Error server_errors.ServerError `json:"error"`
 }
 
-func processWsReq(webSocketResponseChannel chan<- interface{}, req wsRequest) {
+func processWsReq(db *sqlx.DB, webSocketResponseChannel chan<- interface{}, req wsRequest, role users.Role,
+	actor audit.Actor, factorSession auth.FactorSession) {
 	switch req.Type {
 	case "ping":
 		webSocketResponseChannel <- Pong{Message: "pong"}
 		return
 	case "newPayment":
+		// The websocket isn't traced by the API middleware, every payment is a trace of its own
+		ctx, span := tracing.StartSpan(context.Background(), "WS newPayment")
+		defer span.End()
+		if !leader_election.IsLeader() {
+			sendError(errors.New("follower: payments are sent by the leader"), req, webSocketResponseChannel)
+			break
+		}
+		if role < users.RoleOperator {
+			sendError(errors.New("forbidden: sending payments requires the operator role"), req, webSocketResponseChannel)
+			break
+		}
+		fresh, err := factorSession.IsFresh(db, time.Now())
+		if err != nil {
+			sendError(errors.Wrap(err, "Checking second factor"), req, webSocketResponseChannel)
+			break
+		}
+		if !fresh {
+			sendError(errors.New("forbidden: verify your second factor first"), req, webSocketResponseChannel)
+			break
+		}
 		if req.NewPaymentRequest == nil {
 			sendError(fmt.Errorf("unknown NewPaymentRequest for type: %s", req.Type), req, webSocketResponseChannel)
 			break
 		}
+		spendingRequest, err := lightning.AuthorizeSpending(ctx, db, actor, spending.ActionNewPayment,
+			req.NewPaymentRequest.NodeId, *req.NewPaymentRequest)
+		if err != nil {
+			sendError(errors.Wrap(err, "Evaluating spending limits"), req, webSocketResponseChannel)
+			break
+		}
+		if spendingRequest.Status != spending.Approved {
+			webSocketResponseChannel <- wsSpendingApprovalRequired{
+				Type:            "SpendingApprovalRequired",
+				SpendingRequest: spendingRequest,
+			}
+			break
+		}
 		req.NewPaymentRequest.ProgressReportChannel = webSocketResponseChannel
-		_, err := lightning.NewPayment(*req.NewPaymentRequest)
+		response, err := lightning.NewPayment(ctx, *req.NewPaymentRequest)
+		spending.RecordResult(db, actor, spendingRequest, response.Hash, err)
+		audit.Record(db, actor, "WS newPayment", "node", fmt.Sprintf("%v", req.NewPaymentRequest.NodeId),
+			map[string]interface{}{
+				"invoice":          req.NewPaymentRequest.Invoice,
+				"amountMsat":       req.NewPaymentRequest.AmtMSat,
+				"feeLimitMsat":     req.NewPaymentRequest.FeeLimitMsat,
+				"allowSelfPayment": req.NewPaymentRequest.AllowSelfPayment,
+				"dest":             req.NewPaymentRequest.Dest,
+			}, err)
 		if err != nil {
 			sendError(err, req, webSocketResponseChannel)
 		}
@@ -51,8 +106,9 @@ func processWsReq(webSocketResponseChannel chan<- interface{}, req wsRequest) {
 	}
 }
 
-func WebsocketHandler(c *gin.Context, db *sqlx.DB) error {
-	var wsUpgrade = websocket.Upgrader{
+// getWebsocketUpgrader returns an upgrader that only accepts requests from the same host (or the frontend dev server)
+func getWebsocketUpgrader() websocket.Upgrader {
+	return websocket.Upgrader{
 		ReadBufferSize:  1024,
 		WriteBufferSize: 1024,
 		CheckOrigin: func(r *http.Request) bool {
@@ -71,6 +127,10 @@ func WebsocketHandler(c *gin.Context, db *sqlx.DB) error {
 			return equalASCIIFold(u.Host, r.Host)
 		},
 	}
+}
+
+func WebsocketHandler(c *gin.Context, db *sqlx.DB) error {
+	wsUpgrade := getWebsocketUpgrader()
 	webSocketResponseChannel := make(chan interface{})
 	done := make(chan struct{})
 
@@ -85,7 +145,8 @@ func WebsocketHandler(c *gin.Context, db *sqlx.DB) error {
 		}
 	}(conn)
 
-	go processWebsocketRequests(conn, db, done, webSocketResponseChannel)
+	go processWebsocketRequests(conn, db, auth.GetRole(c), audit.GetRequestActor(c), auth.GetFactorSession(c), done,
+		webSocketResponseChannel)
 
 	for {
 		select {
@@ -103,6 +164,9 @@ func WebsocketHandler(c *gin.Context, db *sqlx.DB) error {
 
 func processWebsocketRequests(conn *websocket.Conn,
 	db *sqlx.DB,
+	role users.Role,
+	actor audit.Actor,
+	factorSession auth.FactorSession,
 	done chan<- struct{},
 	webSocketResponseChannel chan<- interface{}) {
 
@@ -119,7 +183,7 @@ func processWebsocketRequests(conn *websocket.Conn,
 			log.Debug().Err(err).Msg("WebSocket Handshake Error.")
 			return
 		case nil:
-			go processWsReq(webSocketResponseChannel, req)
+			go processWsReq(db, webSocketResponseChannel, req, role, actor, factorSession)
 		default:
 			serverError := server_errors.SingleServerError("Could not parse request, please check that your JSON is correctly formated.")
 			wsr := wsError{
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof" //nolint:gosec
@@ -10,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/cockroachdb/errors"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/jmoiron/sqlx"
@@ -24,12 +26,20 @@ import (
 	"github.com/lncapital/torq/cmd/torq/internal/notifications"
 	"github.com/lncapital/torq/cmd/torq/internal/services"
 	"github.com/lncapital/torq/cmd/torq/internal/subscribe"
+	"github.com/lncapital/torq/cmd/torq/internal/torqcli"
+	"github.com/lncapital/torq/cmd/torq/internal/torqgrpc"
 	"github.com/lncapital/torq/cmd/torq/internal/torqsrv"
 	"github.com/lncapital/torq/cmd/torq/internal/vector_ping"
+	"github.com/lncapital/torq/internal/backup"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/corridors"
 	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/internal/flow"
+	"github.com/lncapital/torq/internal/leader_election"
+	"github.com/lncapital/torq/internal/lnd"
+	"github.com/lncapital/torq/internal/logging"
+	"github.com/lncapital/torq/internal/peer_scores"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/internal/settings"
 	"github.com/lncapital/torq/internal/tags"
@@ -37,6 +47,8 @@ import (
 	"github.com/lncapital/torq/internal/workflows"
 	"github.com/lncapital/torq/pkg/cln_connect"
 	"github.com/lncapital/torq/pkg/lnd_connect"
+	"github.com/lncapital/torq/pkg/secrets"
+	"github.com/lncapital/torq/pkg/tracing"
 )
 
 var debuglevels = map[string]zerolog.Level{ //nolint:gochecknoglobals
@@ -86,6 +98,47 @@ func main() {
 			Value: "info",
 			Usage: "Specify different debuglevels (panic|fatal|error|warn|info|debug|trace)",
 		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name: "torq.log.levels",
+			Usage: "Levels of subsystems that differ from torq.debuglevel, i.e. rebalancer=debug,lnd=warn (subsystems: " +
+				"torq, api, cache, lnd, cln, streams, rebalancer, workflows, notifications, ping)",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:  "torq.log.file",
+			Usage: "Also write the JSON log lines to this file",
+		}),
+		altsrc.NewIntFlag(&cli.IntFlag{
+			Name:  "torq.log.file-max-size",
+			Value: 100,
+			Usage: "Size in MB after which the log file is rotated",
+		}),
+		altsrc.NewIntFlag(&cli.IntFlag{
+			Name:  "torq.log.file-max-backups",
+			Value: 5,
+			Usage: "Number of rotated log files to keep",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:  "torq.log.syslog",
+			Usage: "Also send the log lines to a syslog server (udp://host:port or tcp://host:port)",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:  "torq.log.otlp",
+			Usage: "Also send the log lines to an OpenTelemetry collector (http://host:4318/v1/logs)",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:  "torq.tracing.exporter",
+			Usage: "Export OpenTelemetry traces of the API, workflows, rebalancer and node requests (stdout|otlp)",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:  "torq.tracing.otlp",
+			Value: "http://localhost:4318/v1/traces",
+			Usage: "OpenTelemetry collector endpoint of the otlp exporter (OTLP/HTTP JSON)",
+		}),
+		altsrc.NewFloat64Flag(&cli.Float64Flag{
+			Name:  "torq.tracing.sample-ratio",
+			Value: 1,
+			Usage: "Fraction of the traces to export (0 to 1)",
+		}),
 		altsrc.NewStringFlag(&cli.StringFlag{
 			Name:  "torq.cookie-path",
 			Usage: "Path to auth cookie file",
@@ -104,16 +157,62 @@ func main() {
 			Value: "8080",
 			Usage: "Port to serve the HTTP API",
 		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:  "torq.grpc-port",
+			Usage: "Port to serve the gRPC API (disabled when not set)",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:  "torq.grpc-tls-cert-file",
+			Usage: "Path to the TLS certificate of the gRPC API (without it the gRPC API needs a TLS proxy)",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:  "torq.grpc-tls-key-file",
+			Usage: "Path to the TLS key of the gRPC API",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name: "torq.secrets-key-file",
+			Usage: "Path to the file with the (base64) key that encrypts node credentials and notification tokens " +
+				"(alternatively set " + secrets.KeyEnvironmentVariable + ")",
+		}),
 		altsrc.NewBoolFlag(&cli.BoolFlag{
 			Name:  "torq.no-sub",
 			Value: false,
 			Usage: "Start the server without subscribing to node data",
 		}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{
+			Name:  "torq.ha.enabled",
+			Value: false,
+			Usage: "Elect a leader between the Torq instances sharing the database, only the leader runs the services " +
+				"that act on nodes while followers serve the read-only API and UI",
+		}),
+		altsrc.NewIntFlag(&cli.IntFlag{
+			Name:  "torq.ha.failover-timeout",
+			Value: 15,
+			Usage: "Seconds after which a follower takes over from a leader that is gone",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:  "torq.ha.instance",
+			Usage: "Name of this instance in the leader election (defaults to the hostname)",
+		}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{
+			Name:  "torq.nodes-strict",
+			Value: false,
+			Usage: "Disable the nodes that are not declared in the config ([[nodes]], lnd.url and cln.url)",
+		}),
 		altsrc.NewBoolFlag(&cli.BoolFlag{
 			Name:  "torq.auto-login",
 			Value: false,
 			Usage: "Allows logging in without a password",
 		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:  "torq.metrics-username",
+			Value: "metrics",
+			Usage: "Username used to access the Prometheus metrics endpoint",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:  "torq.metrics-password",
+			Usage: "Password used to access the Prometheus metrics endpoint (no authentication when empty)",
+		}),
 
 		// Torq database
 		altsrc.NewStringFlag(&cli.StringFlag{
@@ -180,15 +279,50 @@ func main() {
 		Usage: "Start the main daemon",
 		Action: func(c *cli.Context) error {
 
-			zerolog.SetGlobalLevel(zerolog.InfoLevel)
 			if debuglevel, ok := debuglevels[strings.ToLower(c.String("torq.debuglevel"))]; ok {
-				zerolog.SetGlobalLevel(debuglevel)
+				logging.SetDefaultLevel(debuglevel)
 				log.Debug().Msgf("DebugLevel: %v enabled", debuglevel)
 			}
+			err := logging.SetLevels(c.String("torq.log.levels"))
+			if err != nil {
+				return errors.Wrap(err, "Setting subsystem log levels")
+			}
+			err = logging.ConfigureOutputs(logging.OutputConfig{
+				FilePath:       c.String("torq.log.file"),
+				FileMaxSizeMB:  c.Int("torq.log.file-max-size"),
+				FileMaxBackups: c.Int("torq.log.file-max-backups"),
+				SyslogAddress:  c.String("torq.log.syslog"),
+				OtlpEndpoint:   c.String("torq.log.otlp"),
+			})
+			if err != nil {
+				return errors.Wrap(err, "Configuring log outputs")
+			}
+
+			shutdownTracing, err := tracing.Start(tracing.Config{
+				Exporter:     tracing.Exporter(c.String("torq.tracing.exporter")),
+				OtlpEndpoint: c.String("torq.tracing.otlp"),
+				SampleRatio:  c.Float64("torq.tracing.sample-ratio"),
+			})
+			if err != nil {
+				return errors.Wrap(err, "Starting tracing")
+			}
+			defer func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := shutdownTracing(ctx); err != nil {
+					log.Error().Err(err).Msg("Flushing traces")
+				}
+			}()
 
 			// Print startup message
 			fmt.Printf("Starting Torq %s\n", build.ExtendedVersion())
 
+			secretsKey, err := secrets.LoadKey(c.String("torq.secrets-key-file"))
+			if err != nil {
+				return errors.Wrap(err, "Loading secrets key")
+			}
+			secrets.SetKey(secretsKey)
+
 			fmt.Println("Connecting to the Torq database")
 			db, err := database.PgConnect(c.String("db.name"), c.String("db.user"),
 				c.String("db.password"), c.String("db.host"), c.String("db.port"))
@@ -210,9 +344,13 @@ func main() {
 			go cache.SettingsCacheHandle(cache.SettingsCacheChannel, ctxGlobal)
 			go cache.NodesCacheHandler(cache.NodesCacheChannel, ctxGlobal)
 			go cache.NodeAliasesCacheHandler(cache.NodeAliasesCacheChannel, ctxGlobal)
+			go cache.ChannelFlowsCacheHandler(cache.ChannelFlowsCacheChannel, ctxGlobal)
+			go cache.PeerScoresCacheHandler(cache.PeerScoresCacheChannel, ctxGlobal)
 			go cache.ChannelsCacheHandler(cache.ChannelsCacheChannel, ctxGlobal)
 			go cache.TaggedCacheHandler(cache.TaggedCacheChannel, ctxGlobal)
 			go cache.TriggersCacheHandler(cache.TriggersCacheChannel, ctxGlobal)
+			go cache.EventsCacheHandler(cache.EventsCacheChannel, ctxGlobal)
+			go cache.NodePermissionsCacheHandler(cache.NodePermissionsCacheChannel, ctxGlobal)
 			go tags.TagsCacheHandler(tags.TagsCacheChannel, ctxGlobal)
 			go workflows.RebalanceCacheHandler(workflows.RebalancesCacheChannel, ctxGlobal)
 			go cache.ServiceCacheHandler(cache.ServicesCacheChannel, ctxGlobal)
@@ -233,17 +371,29 @@ func main() {
 
 			// This function initiates the database migration(s) and parses command line parameters
 			// When done the RootService is set to Initialising
-			go migrateAndProcessArguments(db, c)
+			go migrateAndProcessArguments(ctxGlobal, db, c)
 
-			go servicesMonitor(db)
+			if c.Bool("torq.ha.enabled") {
+				err = startLeaderElection(ctxGlobal, c, db)
+				if err != nil {
+					return errors.Wrap(err, "Starting leader election")
+				}
+			}
+
+			go servicesMonitor(db, c)
 
 			if c.String("torq.pprof.path") != "" {
 				go pprofStartup(c)
 			}
 
+			if c.Int("torq.grpc-port") != 0 {
+				go grpcStartup(c, db)
+			}
+
 			if err = torqsrv.Start(c.String("torq.network-interface"), c.Int("torq.port"), c.String("torq.password"),
 				c.String("torq.cookie-path"),
-				db, c.Bool("torq.auto-login")); err != nil {
+				db, c.Bool("torq.auto-login"),
+				c.String("torq.metrics-username"), c.String("torq.metrics-password")); err != nil {
 				return errors.Wrap(err, "Starting torq webserver")
 			}
 
@@ -277,6 +427,194 @@ func main() {
 		},
 	}
 
+	generateSecretsKey := &cli.Command{
+		Name:  "generate_secrets_key",
+		Usage: "Prints a new key to encrypt node credentials and notification tokens",
+		Action: func(c *cli.Context) error {
+			key, err := secrets.GenerateKey()
+			if err != nil {
+				return err
+			}
+			fmt.Println(key)
+			return nil
+		},
+	}
+
+	rekeySecrets := &cli.Command{
+		Name: "rekey_secrets",
+		Usage: "Re-encrypts node credentials and notification tokens with a new key (stop Torq first), " +
+			"the current key is read from --torq.secrets-key-file or " + secrets.KeyEnvironmentVariable,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "new-key-file",
+				Usage:    "Path to the file with the new (base64) key",
+				Required: true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			oldKey, err := secrets.LoadKey(c.String("torq.secrets-key-file"))
+			if err != nil {
+				return errors.Wrap(err, "Loading current secrets key")
+			}
+			newKey, err := secrets.LoadKey(c.String("new-key-file"))
+			if err != nil {
+				return errors.Wrap(err, "Loading new secrets key")
+			}
+
+			db, err := database.PgConnect(c.String("db.name"), c.String("db.user"),
+				c.String("db.password"), c.String("db.host"), c.String("db.port"))
+			if err != nil {
+				return errors.Wrap(err, "Database connect")
+			}
+
+			defer func() {
+				cerr := db.Close()
+				if err == nil {
+					err = cerr
+				}
+			}()
+
+			err = database.MigrateUp(db)
+			if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+				return errors.Wrap(err, "Migrating database up")
+			}
+
+			err = settings.RekeySecrets(db, oldKey, newKey)
+			if err != nil {
+				return errors.Wrap(err, "Re-keying secrets")
+			}
+
+			fmt.Println("Node credentials and notification tokens are encrypted with the new key, " +
+				"start Torq with the new key")
+			return nil
+		},
+	}
+
+	migrateDown := &cli.Command{
+		Name:  "migrate_down",
+		Usage: "Migrates the database down to an earlier version (stop Torq first)",
+		Flags: []cli.Flag{
+			&cli.UintFlag{
+				Name:     "to",
+				Usage:    "The migration version to migrate down to",
+				Required: true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			db, err := database.PgConnect(c.String("db.name"), c.String("db.user"),
+				c.String("db.password"), c.String("db.host"), c.String("db.port"))
+			if err != nil {
+				return errors.Wrap(err, "Database connect")
+			}
+
+			defer func() {
+				cerr := db.Close()
+				if err == nil {
+					err = cerr
+				}
+			}()
+
+			err = database.MigrateDown(db, c.Uint("to"))
+			if err != nil {
+				return errors.Wrap(err, "Migrating database down")
+			}
+
+			return nil
+		},
+	}
+
+	backupCommand := &cli.Command{
+		Name: "backup",
+		Usage: "Exports the configuration (nodes, tags, categories, corridors, workflows, table views and settings) " +
+			"to a JSON file",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "file",
+				Usage:    "Path of the backup file",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "without-secrets",
+				Usage: "Leave out node credentials and notification tokens, the nodes are restored disabled",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			db, err := database.PgConnect(c.String("db.name"), c.String("db.user"),
+				c.String("db.password"), c.String("db.host"), c.String("db.port"))
+			if err != nil {
+				return errors.Wrap(err, "Database connect")
+			}
+
+			defer func() {
+				cerr := db.Close()
+				if err == nil {
+					err = cerr
+				}
+			}()
+
+			b, err := backup.Export(db, c.Bool("without-secrets"))
+			if err != nil {
+				return errors.Wrap(err, "Exporting configuration")
+			}
+			content, err := json.MarshalIndent(b, "", "  ")
+			if err != nil {
+				return errors.Wrap(err, "Marshalling backup")
+			}
+			err = os.WriteFile(c.String("file"), content, 0600)
+			if err != nil {
+				return errors.Wrap(err, "Writing backup file")
+			}
+
+			fmt.Printf("Configuration of migration version %v exported to %v\n", b.Version, c.String("file"))
+			return nil
+		},
+	}
+
+	restoreCommand := &cli.Command{
+		Name: "restore",
+		Usage: "Restores the configuration from a backup file (stop Torq first), the database must be at the " +
+			"migration version of the backup",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "file",
+				Usage:    "Path of the backup file",
+				Required: true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			content, err := os.ReadFile(c.String("file"))
+			if err != nil {
+				return errors.Wrap(err, "Reading backup file")
+			}
+			var b backup.Backup
+			err = json.Unmarshal(content, &b)
+			if err != nil {
+				return errors.Wrap(err, "Parsing backup file")
+			}
+
+			db, err := database.PgConnect(c.String("db.name"), c.String("db.user"),
+				c.String("db.password"), c.String("db.host"), c.String("db.port"))
+			if err != nil {
+				return errors.Wrap(err, "Database connect")
+			}
+
+			defer func() {
+				cerr := db.Close()
+				if err == nil {
+					err = cerr
+				}
+			}()
+
+			err = backup.Import(db, b)
+			if err != nil {
+				return errors.Wrap(err, "Restoring configuration")
+			}
+
+			fmt.Printf("Configuration restored from %v\n", c.String("file"))
+			return nil
+		},
+	}
+
 	app.Flags = cmdFlags
 
 	app.Before = altsrc.InitInputSourceWithContext(cmdFlags, loadFlags())
@@ -284,7 +622,13 @@ func main() {
 	app.Commands = cli.Commands{
 		start,
 		migrateUp,
+		migrateDown,
+		backupCommand,
+		restoreCommand,
+		generateSecretsKey,
+		rekeySecrets,
 	}
+	app.Commands = append(app.Commands, torqcli.GetCommands()...)
 
 	err = app.Run(os.Args)
 	if err != nil {
@@ -302,20 +646,47 @@ func pprofStartup(c *cli.Context) {
 	}
 }
 
+func grpcStartup(c *cli.Context, db *sqlx.DB) {
+	err := torqgrpc.Start(c.String("torq.network-interface"), c.Int("torq.grpc-port"),
+		c.String("torq.grpc-tls-cert-file"), c.String("torq.grpc-tls-key-file"), db)
+	if err != nil {
+		log.Error().Err(err).Msg("Torq could not start the gRPC API")
+	}
+}
+
 func loadFlags() func(context *cli.Context) (altsrc.InputSourceContext, error) {
 	return func(context *cli.Context) (altsrc.InputSourceContext, error) {
 		if _, err := os.Stat(context.String("config")); err != nil {
 			return altsrc.NewMapInputSource("", map[interface{}]interface{}{}), nil
 		}
-		tomlSource, err := altsrc.NewTomlSourceFromFile(context.String("config"))
+		var config map[string]interface{}
+		_, err := toml.DecodeFile(context.String("config"), &config)
 		if err != nil {
 			return nil, errors.Wrap(err, "Creating new toml config from file")
 		}
-		return tomlSource, nil
+		// The declared nodes are read by settings.LoadDeclaredNodes, altsrc does not support arrays of tables
+		delete(config, "nodes")
+		return altsrc.NewMapInputSource(context.String("config"), getFlagValues(config)), nil
+	}
+}
+
+// getFlagValues converts the TOML tables to the nested maps (and integers to int) that altsrc expects
+func getFlagValues(table map[string]interface{}) map[interface{}]interface{} {
+	values := make(map[interface{}]interface{})
+	for key, value := range table {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			values[key] = getFlagValues(v)
+		case int64:
+			values[key] = int(v)
+		default:
+			values[key] = value
+		}
 	}
+	return values
 }
 
-func migrateAndProcessArguments(db *sqlx.DB, c *cli.Context) {
+func migrateAndProcessArguments(ctx context.Context, db *sqlx.DB, c *cli.Context) {
 	fmt.Println("Checking for migrations..")
 	// Check if the database needs to be migrated.
 	err := database.MigrateUp(db)
@@ -326,6 +697,14 @@ func migrateAndProcessArguments(db *sqlx.DB, c *cli.Context) {
 		return
 	}
 
+	err = settings.InitializeSecrets(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Torq could not initialize the secrets encryption.")
+		cache.CancelCoreService(services_helpers.RootService)
+		cache.SetFailedCoreServiceState(services_helpers.RootService)
+		return
+	}
+
 	for {
 		// if node specified on cmd flags then check if we already know about it
 		if c.String("lnd.url") != "" &&
@@ -456,15 +835,79 @@ func migrateAndProcessArguments(db *sqlx.DB, c *cli.Context) {
 		break
 	}
 
+	declaredNodes, err := settings.LoadDeclaredNodes(c.String("config"))
+	if err != nil {
+		log.Error().Err(err).Msg("Torq could not read the nodes declared in the config.")
+		cache.CancelCoreService(services_helpers.RootService)
+		cache.SetFailedCoreServiceState(services_helpers.RootService)
+		return
+	}
+	// The declared nodes are reconciled by the leader when the caches are set up (see servicesMonitor)
+
+	// Rotated credential files (i.e. LND renewing its tls certificate) are picked up without a restart
+	go settings.StartCredentialsWatcher(ctx, db, getCredentialPaths(c, declaredNodes), leader_election.IsLeader)
+
 	cache.SetPendingCoreServiceState(services_helpers.RootService)
 }
 
+func getCredentialPaths(c *cli.Context, declaredNodes []settings.DeclaredNode) []settings.NodeCredentialPaths {
+	credentialPaths := settings.GetDeclaredNodeCredentialPaths(declaredNodes)
+	declared := make(map[string]bool)
+	for _, paths := range credentialPaths {
+		declared[paths.GRPCAddress] = true
+	}
+	// A node declared in [[nodes]] overrules the lnd and cln flags
+	if !declared[c.String("lnd.url")] && c.String("lnd.url") != "" && c.String("lnd.macaroon-path") != "" && c.String("lnd.tls-path") != "" {
+		credentialPaths = append(credentialPaths, settings.NodeCredentialPaths{
+			Implementation:     core.LND,
+			GRPCAddress:        c.String("lnd.url"),
+			CertificatePath:    c.String("lnd.tls-path"),
+			AuthenticationPath: c.String("lnd.macaroon-path"),
+		})
+	}
+	if !declared[c.String("cln.url")] && c.String("cln.url") != "" && c.String("cln.certificate-path") != "" &&
+		c.String("cln.key-path") != "" && c.String("cln.ca-certificate-path") != "" {
+		credentialPaths = append(credentialPaths, settings.NodeCredentialPaths{
+			Implementation:     core.CLN,
+			GRPCAddress:        c.String("cln.url"),
+			CertificatePath:    c.String("cln.certificate-path"),
+			AuthenticationPath: c.String("cln.key-path"),
+			CaCertificatePath:  c.String("cln.ca-certificate-path"),
+		})
+	}
+	return credentialPaths
+}
+
+func startLeaderElection(ctx context.Context, c *cli.Context, db *sqlx.DB) error {
+	if c.Int("torq.ha.failover-timeout") < 3 {
+		return errors.New("torq.ha.failover-timeout should be at least 3 seconds")
+	}
+	instanceId := c.String("torq.ha.instance")
+	if instanceId == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return errors.Wrap(err, "Obtaining hostname as instance name")
+		}
+		instanceId = hostname
+	}
+	// Until elected this instance is a follower so it doesn't start any services
+	leader_election.Enable(instanceId, time.Duration(c.Int("torq.ha.failover-timeout"))*time.Second)
+	go leader_election.Start(ctx, db)
+	return nil
+}
+
 const hangingTimeoutInSeconds = 120
 const failureTimeoutInSeconds = 60
 
-func servicesMonitor(db *sqlx.DB) {
+// Followers don't run the services that keep the caches up to date so they reload them from the database
+const followerCacheRefreshInterval = 1 * time.Minute
+
+func servicesMonitor(db *sqlx.DB, c *cli.Context) {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
+	// The term in which the caches and desired states were set up as the leader
+	var leaderTerm int
+	var followerCachesRefreshedOn time.Time
 	for {
 		<-ticker.C
 
@@ -476,43 +919,27 @@ func servicesMonitor(db *sqlx.DB) {
 
 		switch cache.GetCurrentCoreServiceState(services_helpers.RootService).Status {
 		case services_helpers.Pending:
-			log.Info().Msg("Torq is setting up caches.")
-
-			err := settings.InitializeSettingsCache(db)
-			if err != nil {
-				log.Error().Err(err).Msg("Failed to obtain settings for SettingsCache cache.")
-			}
-
-			err = settings.InitializeNodesCache(db)
-			if err != nil {
-				log.Error().Err(err).Msg("Failed to obtain torq nodes for NodeCache cache.")
-			}
-
-			err = settings.InitializeChannelsCache(db)
-			if err != nil {
-				log.Error().Err(err).Msg("Failed to obtain channels for ChannelCache cache.")
-			}
-
-			settings.InitializeNodeAliasesCache(db)
-
-			err = settings.InitializeTaggedCache(db)
-			if err != nil {
-				log.Error().Err(err).Msg("Failed to obtain tags for TaggedCache cache.")
-			}
-
-			err = tags.InitializeTagsCache(db)
-			if err != nil {
-				log.Error().Err(err).Msg("Failed to obtain tags for TagCache cache.")
+			// The term is obtained before the role so an election in between is processed again when Active
+			leaderTerm = leader_election.GetTerm()
+			if leader_election.IsLeader() {
+				err := reconcileDeclaredNodes(db, c)
+				if err != nil {
+					log.Error().Err(err).Msg("Torq could not update the nodes declared in the config.")
+					cache.CancelCoreService(services_helpers.RootService)
+					cache.SetFailedCoreServiceState(services_helpers.RootService)
+					continue
+				}
 			}
 
-			log.Info().Msg("Loading caches in memory.")
-			err = corridors.RefreshCorridorCache(db)
-			if err != nil {
-				log.Error().Err(err).Msg("Torq cannot be initialized (Loading caches in memory).")
-			}
+			log.Info().Msg("Torq is setting up caches.")
+			initializeCaches(db)
 			cache.SetInitializingCoreServiceState(services_helpers.RootService)
 			continue
 		case services_helpers.Initializing:
+			if !leader_election.IsLeader() {
+				log.Info().Msg("Torq initialization is done (follower, services are started when elected).")
+				break
+			}
 			allGood := true
 			for _, coreServiceType := range services_helpers.GetCoreServiceTypes() {
 				if coreServiceType != services_helpers.RootService {
@@ -528,6 +955,14 @@ func servicesMonitor(db *sqlx.DB) {
 			}
 			log.Info().Msg("Torq initialization is done.")
 		case services_helpers.Active:
+			if !leader_election.IsLeader() {
+				break
+			}
+			term := leader_election.GetTerm()
+			if term != leaderTerm {
+				processTorqPromotion(db, c)
+				leaderTerm = term
+			}
 			for _, coreServiceType := range services_helpers.GetCoreServiceTypes() {
 				handleCoreServiceStateDelta(db, coreServiceType)
 			}
@@ -539,6 +974,18 @@ func servicesMonitor(db *sqlx.DB) {
 		// This function actually perform an action (and only once) the first time the RootService becomes active.
 		processTorqInitialBoot(db)
 
+		// Only the leader acts on nodes, a follower stops the services it ran as a leader
+		if !leader_election.IsLeader() {
+			cancelLeaderServices()
+			if time.Since(followerCachesRefreshedOn) >= followerCacheRefreshInterval {
+				refreshFollowerCaches(db)
+				followerCachesRefreshedOn = time.Now()
+			}
+			continue
+		}
+		// A follower refreshes its caches as soon as it steps down
+		followerCachesRefreshedOn = time.Time{}
+
 		// We end up here when the main Torq service AND all non node specific services have the desired states
 		for _, nodeId := range cache.GetLndNodeIds() {
 			// check channel events first only if that one works we start the others
@@ -560,10 +1007,125 @@ func servicesMonitor(db *sqlx.DB) {
 	}
 }
 
+// reconcileDeclaredNodes adds, updates or disables the nodes declared in the config. Only the leader writes to the
+// database so a follower reconciles its declared nodes when it's elected.
+func reconcileDeclaredNodes(db *sqlx.DB, c *cli.Context) error {
+	declaredNodes, err := settings.LoadDeclaredNodes(c.String("config"))
+	if err != nil {
+		return errors.Wrap(err, "Reading the nodes declared in the config")
+	}
+	return errors.Wrap(settings.ReconcileDeclaredNodes(db, declaredNodes, c.Bool("torq.nodes-strict"),
+		c.String("lnd.url"), c.String("cln.url")), "Updating the nodes declared in the config")
+}
+
+func initializeCaches(db *sqlx.DB) {
+	err := settings.InitializeSettingsCache(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain settings for SettingsCache cache.")
+	}
+
+	err = settings.InitializeNodesCache(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain torq nodes for NodeCache cache.")
+	}
+
+	err = settings.InitializeChannelsCache(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain channels for ChannelCache cache.")
+	}
+
+	settings.InitializeNodeAliasesCache(db)
+
+	err = settings.InitializeTaggedCache(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain tags for TaggedCache cache.")
+	}
+
+	err = tags.InitializeTagsCache(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain tags for TagCache cache.")
+	}
+
+	err = flow.RefreshChannelFlowCache(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain channel flows for ChannelFlowCache cache.")
+	}
+
+	err = peer_scores.RefreshPeerScoreCache(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain peer scores for PeerScoreCache cache.")
+	}
+
+	log.Info().Msg("Loading caches in memory.")
+	err = corridors.RefreshCorridorCache(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Torq cannot be initialized (Loading caches in memory).")
+	}
+}
+
+// refreshFollowerCaches reloads the caches of a follower, including the channel states that the leader obtains from
+// the nodes, so the read-only API and UI show what the leader stored.
+func refreshFollowerCaches(db *sqlx.DB) {
+	log.Debug().Msg("Torq is refreshing the caches (follower).")
+	initializeCaches(db)
+	err := settings.InitializeChannelStatesCache(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain stored channel states for ChannelStatesCache cache.")
+	}
+}
+
+// processTorqPromotion prepares a follower that was elected: the caches and desired states it loaded as a follower are
+// outdated and the nodes declared in its config were not reconciled yet.
+func processTorqPromotion(db *sqlx.DB, c *cli.Context) {
+	log.Info().Msg("Torq is elected, setting up caches and desired states.")
+	err := reconcileDeclaredNodes(db, c)
+	if err != nil {
+		log.Error().Err(err).Msg("Torq could not update the nodes declared in the config.")
+	}
+	initializeCaches(db)
+	// The channel states of a follower were read from the database, the node services obtain them from the nodes
+	for _, nodeId := range cache.GetAllTorqNodeIds() {
+		cache.RemoveChannelStatesFromCache(nodeId)
+	}
+	setDesiredNodeServiceStates(db)
+}
+
+func cancelLeaderServices() {
+	for _, coreServiceType := range services_helpers.GetCoreServiceTypes() {
+		if coreServiceType != services_helpers.RootService &&
+			cache.GetCurrentCoreServiceState(coreServiceType).Status != services_helpers.Inactive {
+
+			log.Info().Msgf("%v Inactivation (follower).", coreServiceType.String())
+			cache.CancelCoreService(coreServiceType)
+		}
+	}
+	for _, nodeId := range cache.GetLndNodeIds() {
+		cancelLeaderNodeServices(services_helpers.GetLndServiceTypes(), nodeId)
+	}
+	for _, nodeId := range cache.GetClnNodeIds() {
+		cancelLeaderNodeServices(services_helpers.GetClnServiceTypes(), nodeId)
+	}
+}
+
+func cancelLeaderNodeServices(serviceTypes []services_helpers.ServiceType, nodeId int) {
+	for _, serviceType := range serviceTypes {
+		if cache.GetCurrentNodeServiceState(serviceType, nodeId).Status != services_helpers.Inactive {
+			log.Info().Msgf("%v Inactivation for nodeId: %v (follower).", serviceType.String(), nodeId)
+			cache.CancelNodeService(serviceType, nodeId)
+		}
+	}
+}
+
 func processTorqInitialBoot(db *sqlx.DB) {
 	if cache.GetCurrentCoreServiceState(services_helpers.RootService).Status != services_helpers.Initializing {
 		return
 	}
+	setDesiredNodeServiceStates(db)
+	cache.SetActiveCoreServiceState(services_helpers.RootService)
+}
+
+// setDesiredNodeServiceStates sets the desired states of the node services from the node connection details
+func setDesiredNodeServiceStates(db *sqlx.DB) {
 	for _, torqNode := range cache.GetActiveTorqNodeSettings() {
 		var implementation core.Implementation
 		var grpcAddress string
@@ -639,7 +1201,6 @@ func processTorqInitialBoot(db *sqlx.DB) {
 			CustomSettings:         customSettings,
 		})
 	}
-	cache.SetActiveCoreServiceState(services_helpers.RootService)
 }
 
 func handleNodeServiceDelta(db *sqlx.DB,
@@ -767,6 +1328,15 @@ func bootService(db *sqlx.DB, serviceType services_helpers.ServiceType, nodeId i
 				cache.SetFailedNodeServiceState(serviceType, nodeId)
 				return
 			}
+			if !lnd.IsServiceAllowed(conn, serviceType, nodeId) {
+				log.Warn().Msgf("%v deactivated for node id: %v since the macaroon does not allow it",
+					serviceType.String(), nodeId)
+				if err = conn.Close(); err != nil {
+					log.Debug().Err(err).Msg("Failed to close gRPC connection.")
+				}
+				cache.SetDeniedService(serviceType, nodeId)
+				return
+			}
 		case core.CLN:
 			nodeConnectionDetails := cache.GetNodeConnectionDetails(nodeId)
 			conn, err = cln_connect.Connect(
@@ -1,5 +1,15 @@
 package api_helpers
 
+import (
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// MaximumLimit is the page size of paginated endpoints when no (or a larger) limit is requested
+const MaximumLimit = 1000
+
 type Pagination struct {
 	Total  uint64 `json:"total"`
 	Limit  uint64 `json:"limit"`
@@ -10,3 +20,38 @@ type ApiResponse struct {
 	Data       interface{} `json:"data"`
 	Pagination Pagination  `json:"pagination"`
 }
+
+// GetPagination parses the limit and offset query parameters
+func GetPagination(c *gin.Context) (Pagination, error) {
+	pagination := Pagination{Limit: MaximumLimit}
+	var err error
+	if c.Query("limit") != "" {
+		pagination.Limit, err = strconv.ParseUint(c.Query("limit"), 10, 64)
+		if err != nil {
+			return Pagination{}, errors.New("Limit must be a positive number")
+		}
+		if pagination.Limit == 0 || pagination.Limit > MaximumLimit {
+			pagination.Limit = MaximumLimit
+		}
+	}
+	if c.Query("offset") != "" {
+		pagination.Offset, err = strconv.ParseUint(c.Query("offset"), 10, 64)
+		if err != nil {
+			return Pagination{}, errors.New("Offset must be a positive number")
+		}
+	}
+	return pagination, nil
+}
+
+// GetPage sets the total and returns the bounds of the page for results that are paginated in memory
+func (pagination *Pagination) GetPage(total int) (int, int) {
+	pagination.Total = uint64(total)
+	if pagination.Offset >= pagination.Total {
+		return total, total
+	}
+	end := pagination.Offset + pagination.Limit
+	if end > pagination.Total {
+		end = pagination.Total
+	}
+	return int(pagination.Offset), int(end)
+}
@@ -0,0 +1,373 @@
+package api_helpers
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+// Endpoint describes a route of the public API, it's used to register the route and to generate the OpenAPI document
+type Endpoint struct {
+	Method string
+	// Path relative to the API version in gin syntax i.e. /channels/:channelId
+	Path string
+	// Resource is the route group used for the scopes of API tokens i.e. channels
+	Resource    string
+	Summary     string
+	Description string
+	Parameters  []Parameter
+	// RequestBody and Response are (zero) values of the types, they are only used for the schema
+	RequestBody interface{}
+	Response    interface{}
+	// Paginated responses are wrapped in ApiResponse with Response as the type of the items
+	Paginated bool
+	// AcceptedResponse is sent with status 202 when the request is accepted but not executed (yet)
+	AcceptedResponse interface{}
+	Middleware       []gin.HandlerFunc
+	Handler          gin.HandlerFunc
+}
+
+// Parameter is a query parameter, path parameters are taken from the path
+type Parameter struct {
+	Name        string
+	Description string
+	// Type is the OpenAPI type i.e. integer, string or boolean
+	Type     string
+	Format   string
+	Required bool
+	// Multiple parameters can be given by repeating the parameter
+	Multiple bool
+}
+
+// NodeIdParameter is the filter of the endpoints that return data of the Torq nodes, see auth.GetNodeIds
+//
+//nolint:gochecknoglobals
+var NodeIdParameter = Parameter{
+	Name:        "nodeId",
+	Description: "Only for these Torq nodes (all nodes by default)",
+	Type:        "integer",
+	Multiple:    true,
+}
+
+//nolint:gochecknoglobals
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	rawJsonType   = reflect.TypeOf(json.RawMessage{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// GetOpenApiPath converts the gin path to an OpenAPI path i.e. /channels/:channelId to /channels/{channelId}
+func GetOpenApiPath(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// GetOpenApiDocument generates the OpenAPI (3.0) document of the endpoints served under basePath
+func GetOpenApiDocument(title string, version string, basePath string,
+	endpoints []Endpoint) map[string]interface{} {
+
+	generator := schemaGenerator{
+		components: make(map[string]interface{}),
+		names:      make(map[reflect.Type]string),
+	}
+	errorSchema := generator.getSchema(reflect.TypeOf(server_errors.ServerError{}))
+	paths := make(map[string]map[string]interface{})
+	for _, endpoint := range endpoints {
+		openApiPath := GetOpenApiPath(endpoint.Path)
+		if paths[openApiPath] == nil {
+			paths[openApiPath] = make(map[string]interface{})
+		}
+		paths[openApiPath][strings.ToLower(endpoint.Method)] = generator.getOperation(endpoint, errorSchema)
+	}
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   title,
+			"version": version,
+		},
+		"servers": []map[string]interface{}{{"url": basePath}},
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": generator.components,
+			"securitySchemes": map[string]interface{}{
+				"apiToken": map[string]interface{}{
+					"type":        "http",
+					"scheme":      "bearer",
+					"description": "API token (torq_...) with a read or write scope for the resource",
+				},
+				"session": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "cookie",
+					"name": "torq_session",
+				},
+			},
+		},
+		"security": []map[string]interface{}{
+			{"apiToken": []string{}},
+			{"session": []string{}},
+		},
+	}
+}
+
+type schemaGenerator struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+}
+
+func (g *schemaGenerator) getOperation(endpoint Endpoint, errorSchema map[string]interface{}) map[string]interface{} {
+	var parameters []map[string]interface{}
+	for _, segment := range strings.Split(endpoint.Path, "/") {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+		schema := map[string]interface{}{"type": "string"}
+		if strings.HasSuffix(segment, "Id") {
+			schema = map[string]interface{}{"type": "integer"}
+		}
+		parameters = append(parameters, map[string]interface{}{
+			"name": segment[1:], "in": "path", "required": true, "schema": schema,
+		})
+	}
+	for _, parameter := range endpoint.Parameters {
+		schema := map[string]interface{}{"type": parameter.Type}
+		if parameter.Format != "" {
+			schema["format"] = parameter.Format
+		}
+		if parameter.Multiple {
+			schema = map[string]interface{}{"type": "array", "items": schema}
+		}
+		openApiParameter := map[string]interface{}{
+			"name": parameter.Name, "in": "query", "required": parameter.Required, "schema": schema,
+		}
+		if parameter.Description != "" {
+			openApiParameter["description"] = parameter.Description
+		}
+		parameters = append(parameters, openApiParameter)
+	}
+	if endpoint.Paginated {
+		parameters = append(parameters,
+			map[string]interface{}{"name": "limit", "in": "query", "required": false,
+				"schema": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": MaximumLimit}},
+			map[string]interface{}{"name": "offset", "in": "query", "required": false,
+				"schema": map[string]interface{}{"type": "integer", "minimum": 0}})
+	}
+
+	responseSchema := map[string]interface{}{}
+	if endpoint.Response != nil {
+		responseSchema = g.getSchema(reflect.TypeOf(endpoint.Response))
+	}
+	if endpoint.Paginated {
+		responseSchema = map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"data":       map[string]interface{}{"type": "array", "items": responseSchema},
+				"pagination": g.getSchema(reflect.TypeOf(Pagination{})),
+			},
+		}
+	}
+	errorContent := map[string]interface{}{"application/json": map[string]interface{}{"schema": errorSchema}}
+	operation := map[string]interface{}{
+		"summary":     endpoint.Summary,
+		"tags":        []string{endpoint.Resource},
+		"operationId": getOperationId(endpoint),
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "Success",
+				"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": responseSchema}},
+			},
+			"400": map[string]interface{}{"description": "Invalid request", "content": errorContent},
+			"401": map[string]interface{}{"description": "Not authenticated"},
+			"403": map[string]interface{}{"description": "Missing role or scope"},
+			"500": map[string]interface{}{"description": "Server error", "content": errorContent},
+		},
+	}
+	responses := operation["responses"].(map[string]interface{})
+	if strings.Contains(endpoint.Path, ":") {
+		responses["404"] = map[string]interface{}{"description": "Not found", "content": errorContent}
+	}
+	if endpoint.AcceptedResponse != nil {
+		responses["202"] = map[string]interface{}{
+			"description": "Accepted",
+			"content": map[string]interface{}{"application/json": map[string]interface{}{
+				"schema": g.getSchema(reflect.TypeOf(endpoint.AcceptedResponse)),
+			}},
+		}
+	}
+	if endpoint.Description != "" {
+		operation["description"] = endpoint.Description
+	}
+	if len(parameters) != 0 {
+		operation["parameters"] = parameters
+	}
+	if endpoint.RequestBody != nil {
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": g.getSchema(reflect.TypeOf(endpoint.RequestBody)),
+				},
+			},
+		}
+	}
+	return operation
+}
+
+// getOperationId returns i.e. getChannelsChannelId for GET /channels/:channelId
+func getOperationId(endpoint Endpoint) string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(endpoint.Method))
+	for _, segment := range strings.FieldsFunc(endpoint.Path, func(r rune) bool {
+		return r == '/' || r == '-' || r == ':'
+	}) {
+		sb.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+	}
+	return sb.String()
+}
+
+// getSchema returns the JSON schema of the type as it's marshalled by encoding/json.
+// Named structs are added to the components and referenced.
+func (g *schemaGenerator) getSchema(t reflect.Type) map[string]interface{} {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		nullable = true
+		t = t.Elem()
+	}
+	schema := g.getNonNullableSchema(t)
+	if nullable {
+		if _, isReference := schema["$ref"]; isReference {
+			return map[string]interface{}{"allOf": []interface{}{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+	}
+	return schema
+}
+
+func (g *schemaGenerator) getNonNullableSchema(t reflect.Type) map[string]interface{} {
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t == rawJsonType:
+		return map[string]interface{}{}
+	case t.PkgPath() == "gopkg.in/guregu/null.v4":
+		return getNullSchema(t)
+	case t.PkgPath() == "github.com/jmoiron/sqlx/types" && t.Name() == "JSONText":
+		return map[string]interface{}{}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "format": "int32", "minimum": 0}
+	case reflect.Uint, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+	case reflect.Float32:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": g.getSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.getSchema(t.Elem())}
+	case reflect.Struct:
+		return g.getStructSchema(t)
+	}
+	// Interfaces, channels and functions can be anything (or are not marshalled)
+	return map[string]interface{}{}
+}
+
+func getNullSchema(t reflect.Type) map[string]interface{} {
+	switch t.Name() {
+	case "String":
+		return map[string]interface{}{"type": "string", "nullable": true}
+	case "Int":
+		return map[string]interface{}{"type": "integer", "format": "int64", "nullable": true}
+	case "Float":
+		return map[string]interface{}{"type": "number", "format": "double", "nullable": true}
+	case "Bool":
+		return map[string]interface{}{"type": "boolean", "nullable": true}
+	case "Time":
+		return map[string]interface{}{"type": "string", "format": "date-time", "nullable": true}
+	}
+	return map[string]interface{}{"nullable": true}
+}
+
+func (g *schemaGenerator) getStructSchema(t reflect.Type) map[string]interface{} {
+	if t.Name() == "" {
+		return g.getStructProperties(t)
+	}
+	name, exists := g.names[t]
+	if !exists {
+		name = path.Base(t.PkgPath()) + "." + t.Name()
+		g.names[t] = name
+		// Register the name before generating the properties so recursive types terminate
+		g.components[name] = map[string]interface{}{}
+		g.components[name] = g.getStructProperties(t)
+	}
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+func (g *schemaGenerator) getStructProperties(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	g.addStructProperties(t, properties)
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		schema["description"] = "Custom JSON encoding, enumerations are encoded as text."
+	}
+	return schema
+}
+
+func (g *schemaGenerator) addStructProperties(t reflect.Type, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			for fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				g.addStructProperties(fieldType, properties)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = g.getSchema(field.Type)
+	}
+}
+
+// SendOpenApiDocument is a handler that serves the (pre-generated) OpenAPI document
+func SendOpenApiDocument(document map[string]interface{}) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, document)
+	}
+}
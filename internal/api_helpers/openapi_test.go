@@ -0,0 +1,112 @@
+package api_helpers
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type testEmbedded struct {
+	Id int `json:"id"`
+}
+
+type testItem struct {
+	testEmbedded
+	Name     string            `json:"name"`
+	Alias    *string           `json:"alias"`
+	Amount   null.Int          `json:"amount"`
+	On       time.Time         `json:"on"`
+	Children []testItem        `json:"children"`
+	Labels   map[string]string `json:"labels"`
+	Secret   string            `json:"-"`
+	internal int
+}
+
+func TestGetOpenApiPath(t *testing.T) {
+	if path := GetOpenApiPath("/tags/:tagId/tag"); path != "/tags/{tagId}/tag" {
+		t.Fatalf("unexpected path %v", path)
+	}
+	operationId := getOperationId(Endpoint{Method: http.MethodGet, Path: "/workflows/:workflowId/logs"})
+	if operationId != "getWorkflowsWorkflowIdLogs" {
+		t.Fatalf("unexpected operationId %v", operationId)
+	}
+}
+
+func TestGetSchema(t *testing.T) {
+	generator := schemaGenerator{
+		components: make(map[string]interface{}),
+		names:      make(map[reflect.Type]string),
+	}
+	schema := generator.getSchema(reflect.TypeOf(testItem{}))
+	if !reflect.DeepEqual(schema, map[string]interface{}{"$ref": "#/components/schemas/api_helpers.testItem"}) {
+		t.Fatalf("unexpected schema %v", schema)
+	}
+	properties := generator.components["api_helpers.testItem"].(map[string]interface{})["properties"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"id":     map[string]interface{}{"type": "integer", "format": "int64"},
+		"name":   map[string]interface{}{"type": "string"},
+		"alias":  map[string]interface{}{"type": "string", "nullable": true},
+		"amount": map[string]interface{}{"type": "integer", "format": "int64", "nullable": true},
+		"on":     map[string]interface{}{"type": "string", "format": "date-time"},
+		"children": map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"$ref": "#/components/schemas/api_helpers.testItem"},
+		},
+		"labels": map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": map[string]interface{}{"type": "string"},
+		},
+	}
+	if !reflect.DeepEqual(properties, expected) {
+		t.Fatalf("unexpected properties\n%v\nexpected\n%v", properties, expected)
+	}
+}
+
+func TestGetOpenApiDocument(t *testing.T) {
+	document := GetOpenApiDocument("Test", "1.0.0", "/api/v1", []Endpoint{
+		{Method: http.MethodGet, Path: "/items", Resource: "items", Response: testItem{}, Paginated: true},
+		{Method: http.MethodPut, Path: "/items/:itemId", Resource: "items", RequestBody: testItem{}, Response: testItem{}},
+	})
+	if _, err := json.Marshal(document); err != nil {
+		t.Fatalf("marshalling document: %v", err)
+	}
+	paths := document["paths"].(map[string]map[string]interface{})
+	list := paths["/items"]["get"].(map[string]interface{})
+	if len(list["parameters"].([]map[string]interface{})) != 2 {
+		t.Fatalf("expected the limit and offset parameters on the paginated endpoint")
+	}
+	update := paths["/items/{itemId}"]["put"].(map[string]interface{})
+	parameters := update["parameters"].([]map[string]interface{})
+	if len(parameters) != 1 || parameters[0]["name"] != "itemId" || parameters[0]["in"] != "path" {
+		t.Fatalf("unexpected parameters %v", parameters)
+	}
+	if _, exists := update["responses"].(map[string]interface{})["404"]; !exists {
+		t.Fatalf("expected a not found response for a path with parameters")
+	}
+}
+
+func TestGetPage(t *testing.T) {
+	tests := []struct {
+		pagination Pagination
+		total      int
+		start      int
+		end        int
+	}{
+		{Pagination{Limit: 10}, 5, 0, 5},
+		{Pagination{Limit: 2, Offset: 2}, 5, 2, 4},
+		{Pagination{Limit: 10, Offset: 4}, 5, 4, 5},
+		{Pagination{Limit: 10, Offset: 7}, 5, 5, 5},
+	}
+	for _, test := range tests {
+		pagination := test.pagination
+		start, end := pagination.GetPage(test.total)
+		if start != test.start || end != test.end || pagination.Total != uint64(test.total) {
+			t.Errorf("GetPage(%v) of %v = %v, %v (total %v)", test.pagination, test.total, start, end,
+				pagination.Total)
+		}
+	}
+}
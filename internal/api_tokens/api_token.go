@@ -0,0 +1,124 @@
+package api_tokens
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"golang.org/x/exp/slices"
+)
+
+// Tokens are passed as "Authorization: Bearer torq_..."
+const TokenPrefix = "torq_"
+
+// Number of characters (after TokenPrefix) stored to recognise a token
+const tokenPrefixLength = 8
+
+type Scope string
+
+// Route specific scopes grant access to a single route without the write scope of the route group
+const (
+	ScopeWritePolicy      Scope = "write:policy"
+	ScopeWorkflowsTrigger Scope = "workflows:trigger"
+)
+
+// The resources are the API route groups that can be accessed with a token.
+// Every resource has a read (GET) and a write (all other methods) scope i.e. read:forwards and write:forwards
+func getResources() []string {
+	return []string{
+		"automation",
+		"categories",
+		"channels",
+		"corridors",
+		"flow",
+		"forwards",
+		"invoices",
+		"lightning",
+		"messages",
+		"nodes",
+		"on-chain-tx",
+		"payments",
+		"peers",
+		"settings",
+		"table-views",
+		"tags",
+		"workflows",
+	}
+}
+
+// The routes (method and full path) that can be accessed with a route specific scope
+func getRouteScopes() map[string]Scope {
+	return map[string]Scope{
+		http.MethodPut + " /api/lightning/updateRoutingPolicy": ScopeWritePolicy,
+		http.MethodPost + " /api/workflows/trigger":            ScopeWorkflowsTrigger,
+		http.MethodPut + " /api/v1/lightning/routing-policy":   ScopeWritePolicy,
+		http.MethodPost + " /api/v1/workflows/trigger":         ScopeWorkflowsTrigger,
+		http.MethodPut + " /torqrpc.Torq/UpdateRoutingPolicy":  ScopeWritePolicy,
+		http.MethodPost + " /torqrpc.Torq/TriggerWorkflow":     ScopeWorkflowsTrigger,
+	}
+}
+
+func GetScopes() []Scope {
+	var scopes []Scope
+	for _, resource := range getResources() {
+		scopes = append(scopes, Scope("read:"+resource), Scope("write:"+resource))
+	}
+	return append(scopes, ScopeWritePolicy, ScopeWorkflowsTrigger)
+}
+
+func (s Scope) IsValid() bool {
+	return slices.Contains(GetScopes(), s)
+}
+
+type ApiToken struct {
+	ApiTokenId  int            `json:"apiTokenId" db:"api_token_id"`
+	Name        string         `json:"name" db:"name"`
+	TokenHash   string         `json:"-" db:"token_hash"`
+	TokenPrefix string         `json:"tokenPrefix" db:"token_prefix"`
+	Scopes      pq.StringArray `json:"scopes" db:"scopes"`
+	NodeId      *int           `json:"nodeId" db:"node_id"`
+	ExpiresOn   *time.Time     `json:"expiresOn" db:"expires_on"`
+	LastUsedOn  *time.Time     `json:"lastUsedOn" db:"last_used_on"`
+	RevokedOn   *time.Time     `json:"revokedOn" db:"revoked_on"`
+	CreatedOn   time.Time      `json:"createdOn" db:"created_on"`
+}
+
+type ApiTokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []Scope    `json:"scopes"`
+	NodeId    *int       `json:"nodeId"`
+	ExpiresOn *time.Time `json:"expiresOn"`
+}
+
+// CreatedApiToken is the only time the token itself is returned
+type CreatedApiToken struct {
+	ApiToken
+	Token string `json:"token"`
+}
+
+func (apiToken ApiToken) HasScope(scope Scope) bool {
+	return slices.Contains(apiToken.Scopes, string(scope))
+}
+
+// IsAllowed checks the token has a scope for the request i.e. read:forwards for GET /api/forwards
+// or a route specific scope like write:policy for PUT /api/lightning/updateRoutingPolicy
+func (apiToken ApiToken) IsAllowed(resource string, method string, fullPath string) bool {
+	action := "write"
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		action = "read"
+	}
+	if slices.Contains(getResources(), resource) && apiToken.HasScope(Scope(action+":"+resource)) {
+		return true
+	}
+	routeScope, exists := getRouteScopes()[method+" "+strings.TrimSuffix(fullPath, "/")]
+	return exists && apiToken.HasScope(routeScope)
+}
+
+func (apiToken ApiToken) IsActive(now time.Time) bool {
+	if apiToken.RevokedOn != nil {
+		return false
+	}
+	return apiToken.ExpiresOn == nil || apiToken.ExpiresOn.After(now)
+}
@@ -0,0 +1,54 @@
+package api_tokens
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestApiTokenIsAllowed(t *testing.T) {
+	apiToken := ApiToken{Scopes: []string{"read:forwards", string(ScopeWritePolicy), string(ScopeWorkflowsTrigger)}}
+
+	testCases := []struct {
+		name     string
+		resource string
+		method   string
+		fullPath string
+		allowed  bool
+	}{
+		{"read scope", "forwards", http.MethodGet, "/api/forwards", true},
+		{"read scope does not allow writes", "forwards", http.MethodPost, "/api/forwards", false},
+		{"no scope for resource", "payments", http.MethodGet, "/api/payments", false},
+		{"route scope for policy", "lightning", http.MethodPut, "/api/lightning/updateRoutingPolicy", true},
+		{"route scope does not allow other routes", "lightning", http.MethodPost, "/api/lightning/close", false},
+		{"route scope for workflow trigger", "workflows", http.MethodPost, "/api/workflows/trigger", true},
+		{"route scope does not allow reads", "workflows", http.MethodGet, "/api/workflows", false},
+		{"unknown resource", "users", http.MethodGet, "/api/users", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := apiToken.IsAllowed(tc.resource, tc.method, tc.fullPath); got != tc.allowed {
+				t.Errorf("IsAllowed got %v, want %v", got, tc.allowed)
+			}
+		})
+	}
+}
+
+func TestApiTokenIsActive(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	if !(ApiToken{}).IsActive(now) {
+		t.Errorf("Expected a token without expiry to be active")
+	}
+	if !(ApiToken{ExpiresOn: &future}).IsActive(now) {
+		t.Errorf("Expected a token that expires in the future to be active")
+	}
+	if (ApiToken{ExpiresOn: &past}).IsActive(now) {
+		t.Errorf("Expected an expired token to be inactive")
+	}
+	if (ApiToken{RevokedOn: &past}).IsActive(now) {
+		t.Errorf("Expected a revoked token to be inactive")
+	}
+}
@@ -0,0 +1,104 @@
+package api_tokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/lncapital/torq/internal/database"
+)
+
+// The last use of a token is only stored once per interval to avoid a database write for every request
+const lastUsedOnInterval = time.Minute
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func GetApiTokens(db *sqlx.DB) ([]ApiToken, error) {
+	var apiTokens []ApiToken
+	err := db.Select(&apiTokens, `SELECT * FROM api_token ORDER BY created_on DESC;`)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []ApiToken{}, nil
+		}
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return apiTokens, nil
+}
+
+// GetActiveApiToken returns the token when it exists and is not expired nor revoked, otherwise it returns nil.
+// The last use of the token is recorded.
+func GetActiveApiToken(db *sqlx.DB, token string, now time.Time) (*ApiToken, error) {
+	var apiToken ApiToken
+	err := db.Get(&apiToken, `SELECT * FROM api_token WHERE token_hash=$1;`, hashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	if !apiToken.IsActive(now) {
+		return nil, nil
+	}
+	if apiToken.LastUsedOn == nil || now.Sub(*apiToken.LastUsedOn) > lastUsedOnInterval {
+		_, err = db.Exec(`UPDATE api_token SET last_used_on=$1 WHERE api_token_id=$2;`, now, apiToken.ApiTokenId)
+		if err != nil {
+			return nil, errors.Wrap(err, database.SqlExecutionError)
+		}
+		apiToken.LastUsedOn = &now
+	}
+	return &apiToken, nil
+}
+
+func addApiToken(db *sqlx.DB, apiTokenRequest ApiTokenRequest) (CreatedApiToken, error) {
+	tokenBytes := make([]byte, 32)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return CreatedApiToken{}, errors.Wrap(err, "Generating random token")
+	}
+	token := hex.EncodeToString(tokenBytes)
+	createdApiToken := CreatedApiToken{
+		ApiToken: ApiToken{
+			Name:        apiTokenRequest.Name,
+			TokenHash:   hashToken(TokenPrefix + token),
+			TokenPrefix: TokenPrefix + token[:tokenPrefixLength],
+			NodeId:      apiTokenRequest.NodeId,
+			ExpiresOn:   apiTokenRequest.ExpiresOn,
+			CreatedOn:   time.Now().UTC(),
+		},
+		Token: TokenPrefix + token,
+	}
+	for _, scope := range apiTokenRequest.Scopes {
+		createdApiToken.Scopes = append(createdApiToken.Scopes, string(scope))
+	}
+	err = db.QueryRowx(`INSERT INTO api_token (name, token_hash, token_prefix, scopes, node_id, expires_on, created_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING api_token_id;`,
+		createdApiToken.Name, createdApiToken.TokenHash, createdApiToken.TokenPrefix,
+		pq.Array(createdApiToken.Scopes), createdApiToken.NodeId, createdApiToken.ExpiresOn,
+		createdApiToken.CreatedOn).Scan(&createdApiToken.ApiTokenId)
+	if err != nil {
+		return CreatedApiToken{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return createdApiToken, nil
+}
+
+func revokeApiToken(db *sqlx.DB, apiTokenId int) (int64, error) {
+	res, err := db.Exec(`UPDATE api_token SET revoked_on=$1 WHERE api_token_id=$2 AND revoked_on IS NULL;`,
+		time.Now().UTC(), apiTokenId)
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlAffectedRowsCheckError)
+	}
+	return rowsAffected, nil
+}
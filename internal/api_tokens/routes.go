@@ -0,0 +1,86 @@
+package api_tokens
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/exp/slices"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+func RegisterApiTokenRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("", func(c *gin.Context) { getApiTokensHandler(c, db) })
+	r.GET("scopes", func(c *gin.Context) { c.JSON(http.StatusOK, GetScopes()) })
+	r.POST("", func(c *gin.Context) { addApiTokenHandler(c, db) })
+	r.DELETE(":apiTokenId", func(c *gin.Context) { revokeApiTokenHandler(c, db) })
+}
+
+func getApiTokensHandler(c *gin.Context, db *sqlx.DB) {
+	apiTokens, err := GetApiTokens(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting API tokens.")
+		return
+	}
+	c.JSON(http.StatusOK, apiTokens)
+}
+
+func addApiTokenHandler(c *gin.Context, db *sqlx.DB) {
+	var apiTokenRequest ApiTokenRequest
+	if err := c.BindJSON(&apiTokenRequest); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if strings.TrimSpace(apiTokenRequest.Name) == "" {
+		server_errors.SendUnprocessableEntity(c, "Failed to find name in the request.")
+		return
+	}
+	if len(apiTokenRequest.Scopes) == 0 {
+		server_errors.SendUnprocessableEntity(c, "Failed to find scopes in the request.")
+		return
+	}
+	for _, scope := range apiTokenRequest.Scopes {
+		if !scope.IsValid() {
+			server_errors.SendBadRequestFieldError(c,
+				server_errors.SingleFieldError("scopes", fmt.Sprintf("Unknown scope %v.", scope)))
+			return
+		}
+	}
+	if apiTokenRequest.NodeId != nil && !slices.Contains(cache.GetAllTorqNodeIds(), *apiTokenRequest.NodeId) {
+		server_errors.SendBadRequestFieldError(c,
+			server_errors.SingleFieldError("nodeId", "Node is not managed by Torq."))
+		return
+	}
+	if apiTokenRequest.ExpiresOn != nil && !apiTokenRequest.ExpiresOn.After(time.Now()) {
+		server_errors.SendBadRequestFieldError(c,
+			server_errors.SingleFieldError("expiresOn", "Expiry must be in the future."))
+		return
+	}
+	createdApiToken, err := addApiToken(db, apiTokenRequest)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Adding API token %v.", apiTokenRequest.Name))
+		return
+	}
+	c.JSON(http.StatusOK, createdApiToken)
+}
+
+func revokeApiTokenHandler(c *gin.Context, db *sqlx.DB) {
+	apiTokenId, err := strconv.Atoi(c.Param("apiTokenId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse apiTokenId in the request.")
+		return
+	}
+	count, err := revokeApiToken(db, apiTokenId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Revoking API token for apiTokenId: %v.", apiTokenId))
+		return
+	}
+	c.JSON(http.StatusOK, map[string]interface{}{"message": fmt.Sprintf("Successfully revoked %v API token(s).", count)})
+}
@@ -0,0 +1,156 @@
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+type ActorType int
+
+const (
+	ActorUser ActorType = iota + 1
+	ActorApiToken
+	ActorWorkflow
+	ActorBot
+)
+
+func (at ActorType) String() string {
+	switch at {
+	case ActorUser:
+		return "user"
+	case ActorApiToken:
+		return "apiToken"
+	case ActorWorkflow:
+		return "workflow"
+	case ActorBot:
+		return "bot"
+	}
+	return "unknown"
+}
+
+type Result string
+
+const (
+	ResultSuccess Result = "success"
+	ResultFailure Result = "failure"
+)
+
+const redactedValue = "[REDACTED]"
+
+type Actor struct {
+	Type ActorType
+	Id   string
+	Name string
+}
+
+type Entry struct {
+	AuditLogId int64     `json:"auditLogId" db:"audit_log_id"`
+	CreatedOn  time.Time `json:"createdOn" db:"created_on"`
+	ActorType  ActorType `json:"actorType" db:"actor_type"`
+	ActorId    *string   `json:"actorId" db:"actor_id"`
+	ActorName  *string   `json:"actorName" db:"actor_name"`
+	Action     string    `json:"action" db:"action"`
+	EntityType *string   `json:"entityType" db:"entity_type"`
+	EntityId   *string   `json:"entityId" db:"entity_id"`
+	// Parameters is a JSON object
+	Parameters *string `json:"parameters" db:"parameters"`
+	Result     Result  `json:"result" db:"result"`
+	StatusCode *int    `json:"statusCode" db:"status_code"`
+	Error      *string `json:"error" db:"error"`
+}
+
+// Record adds an entry to the audit log. Failing to store the entry is logged but does not fail the action.
+// The parameters are marshalled to JSON after redacting secrets.
+func Record(db *sqlx.DB, actor Actor, action string, entityType string, entityId string,
+	parameters map[string]interface{}, actionErr error) {
+
+	entry := Entry{
+		CreatedOn:  time.Now().UTC(),
+		ActorType:  actor.Type,
+		ActorId:    nilIfEmpty(actor.Id),
+		ActorName:  nilIfEmpty(actor.Name),
+		Action:     action,
+		EntityType: nilIfEmpty(entityType),
+		EntityId:   nilIfEmpty(entityId),
+		Result:     ResultSuccess,
+	}
+	if actionErr != nil {
+		entry.Result = ResultFailure
+		errorString := actionErr.Error()
+		entry.Error = &errorString
+	}
+	addEntryWithParameters(db, entry, parameters)
+}
+
+func addEntryWithParameters(db *sqlx.DB, entry Entry, parameters map[string]interface{}) {
+	if len(parameters) != 0 {
+		marshalledParameters, err := json.Marshal(Redact(parameters))
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to marshal the audit log parameters for action: %v", entry.Action)
+		} else {
+			parametersString := string(marshalledParameters)
+			entry.Parameters = &parametersString
+		}
+	}
+	err := addEntry(db, entry)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to store the audit log entry for action: %v", entry.Action)
+	}
+}
+
+func isSecret(key string) bool {
+	// References like apiTokenId are not secret
+	if strings.HasSuffix(key, "Id") || strings.HasSuffix(key, "Ids") {
+		return false
+	}
+	key = strings.ToLower(key)
+	// TOTP and recovery codes
+	if key == "code" || key == "totp" || strings.Contains(key, "recoverycode") {
+		return true
+	}
+	for _, secret := range []string{"password", "macaroon", "tls", "cert", "secret", "token", "credential",
+		"seed", "accesskey", "privatekey", "rune"} {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact replaces the values of keys that (might) contain secrets, nested objects and arrays are redacted too.
+func Redact(parameters map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(parameters))
+	for key, value := range parameters {
+		if isSecret(key) {
+			redacted[key] = redactedValue
+			continue
+		}
+		redacted[key] = redactValue(value)
+	}
+	return redacted
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return Redact(v)
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i := range v {
+			redacted[i] = redactValue(v[i])
+		}
+		return redacted
+	}
+	return value
+}
+
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
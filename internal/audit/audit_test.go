@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	parameters := map[string]interface{}{
+		"nodeId":   float64(1),
+		"password": "hunter2",
+		"grpcDetails": map[string]interface{}{
+			"macaroonData": "0201",
+			"tlsData":      "-----BEGIN",
+			"grpcAddress":  "127.0.0.1:10009",
+		},
+		"apiTokenId": float64(3),
+		"tokens": []interface{}{
+			map[string]interface{}{"accessToken": "abc", "name": "bot"},
+		},
+	}
+	expected := map[string]interface{}{
+		"nodeId":   float64(1),
+		"password": redactedValue,
+		"grpcDetails": map[string]interface{}{
+			"macaroonData": redactedValue,
+			"tlsData":      redactedValue,
+			"grpcAddress":  "127.0.0.1:10009",
+		},
+		"apiTokenId": float64(3),
+		"tokens":     redactedValue,
+	}
+	redacted := Redact(parameters)
+	if !reflect.DeepEqual(redacted, expected) {
+		t.Errorf("Redact() = %v, want %v", redacted, expected)
+	}
+	if parameters["password"] != "hunter2" {
+		t.Error("Redact() modified the original parameters")
+	}
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/database"
+)
+
+type EntryFilter struct {
+	From       *time.Time
+	To         *time.Time
+	ActorType  *ActorType
+	ActorName  string
+	Action     string
+	EntityType string
+	EntityId   string
+	Result     Result
+	Limit      uint64
+	Offset     uint64
+}
+
+func addEntry(db *sqlx.DB, entry Entry) error {
+	_, err := db.Exec(`INSERT INTO audit_log
+		(created_on, actor_type, actor_id, actor_name, action, entity_type, entity_id, parameters, result,
+		 status_code, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);`,
+		entry.CreatedOn, entry.ActorType, entry.ActorId, entry.ActorName, entry.Action, entry.EntityType,
+		entry.EntityId, entry.Parameters, entry.Result, entry.StatusCode, entry.Error)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
+// getEntries returns the entries matching the filter (newest first) and the total number of matching entries
+func getEntries(db *sqlx.DB, filter EntryFilter) ([]Entry, uint64, error) {
+	where := `
+		FROM audit_log
+		WHERE ($1::timestamptz IS NULL OR created_on >= $1) AND
+			($2::timestamptz IS NULL OR created_on <= $2) AND
+			($3::integer IS NULL OR actor_type = $3) AND
+			($4 = '' OR actor_name ILIKE '%' || $4 || '%') AND
+			($5 = '' OR action ILIKE '%' || $5 || '%') AND
+			($6 = '' OR entity_type = $6) AND
+			($7 = '' OR entity_id = $7) AND
+			($8 = '' OR result = $8)`
+	args := []interface{}{filter.From, filter.To, filter.ActorType, filter.ActorName, filter.Action,
+		filter.EntityType, filter.EntityId, filter.Result}
+
+	var total uint64
+	err := db.Get(&total, `SELECT count(*)`+where+`;`, args...)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+
+	query := `SELECT *` + where + ` ORDER BY created_on DESC, audit_log_id DESC`
+	if filter.Limit != 0 {
+		query += ` LIMIT $9 OFFSET $10`
+		args = append(args, filter.Limit, filter.Offset)
+	}
+	var entries []Entry
+	err = db.Select(&entries, query+`;`, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []Entry{}, total, nil
+		}
+		return nil, 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,156 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/auth"
+)
+
+// The keys (path parameter or top-level body field) that identify the target of an action, in order of preference
+func getEntityKeys() []struct{ key, entityType string } {
+	return []struct{ key, entityType string }{
+		{"channelId", "channel"},
+		{"workflowVersionNodeId", "workflowVersionNode"},
+		{"workflowVersionId", "workflowVersion"},
+		{"workflowId", "workflow"},
+		{"tagId", "tag"},
+		{"categoryId", "category"},
+		{"corridorId", "corridor"},
+		{"userId", "user"},
+		{"apiTokenId", "apiToken"},
+		{"spendingRequestId", "spendingRequest"},
+		{"spendingLimitId", "spendingLimit"},
+		{"viewId", "tableView"},
+		{"nodeId", "node"},
+	}
+}
+
+// Middleware records every state-changing request (anything other than GET, HEAD or OPTIONS) in the audit log
+// with the actor, the route, the target entity, the redacted request parameters and the response status.
+func Middleware(db *sqlx.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		parameters := getRequestParameters(c)
+
+		c.Next()
+
+		entry := Entry{
+			CreatedOn: time.Now().UTC(),
+			Action:    c.Request.Method + " " + c.FullPath(),
+			Result:    ResultSuccess,
+		}
+		actor := GetRequestActor(c)
+		entry.ActorType = actor.Type
+		entry.ActorId = nilIfEmpty(actor.Id)
+		entry.ActorName = nilIfEmpty(actor.Name)
+		entityType, entityId := getEntity(c, parameters)
+		entry.EntityType = nilIfEmpty(entityType)
+		entry.EntityId = nilIfEmpty(entityId)
+		statusCode := c.Writer.Status()
+		entry.StatusCode = &statusCode
+		if statusCode >= http.StatusBadRequest {
+			entry.Result = ResultFailure
+		}
+		if len(c.Errors) != 0 {
+			errorString := c.Errors.String()
+			entry.Error = &errorString
+		}
+		addEntryWithParameters(db, entry, parameters)
+	}
+}
+
+// GetRequestActor returns the API token or the (session) user of the request
+func GetRequestActor(c *gin.Context) Actor {
+	if apiToken := auth.GetApiToken(c); apiToken != nil {
+		return Actor{
+			Type: ActorApiToken,
+			Id:   fmt.Sprintf("%v", apiToken.ApiTokenId),
+			Name: apiToken.Name,
+		}
+	}
+	actor := Actor{Type: ActorUser}
+	session := sessions.Default(c)
+	if username, ok := session.Get(auth.Userkey).(string); ok {
+		actor.Name = username
+	}
+	if userId, ok := session.Get(auth.UserIdKey).(int); ok && userId != 0 {
+		actor.Id = fmt.Sprintf("%v", userId)
+	}
+	return actor
+}
+
+// getRequestParameters returns the path, query and JSON body parameters of the request.
+// Other bodies (i.e. file uploads) are not recorded.
+func getRequestParameters(c *gin.Context) map[string]interface{} {
+	parameters := make(map[string]interface{})
+	for _, param := range c.Params {
+		parameters[param.Key] = param.Value
+	}
+	for key, values := range c.Request.URL.Query() {
+		parameters[key] = strings.Join(values, ",")
+	}
+	if c.Request.Body == nil {
+		return parameters
+	}
+	if c.ContentType() != gin.MIMEJSON {
+		if c.Request.ContentLength > 0 {
+			parameters["body"] = fmt.Sprintf("<%v omitted>", c.ContentType())
+		}
+		return parameters
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return parameters
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	var bodyValue interface{}
+	if json.Unmarshal(body, &bodyValue) != nil {
+		return parameters
+	}
+	if bodyObject, ok := bodyValue.(map[string]interface{}); ok {
+		for key, value := range bodyObject {
+			parameters[key] = value
+		}
+		return parameters
+	}
+	parameters["body"] = bodyValue
+	return parameters
+}
+
+func getEntity(c *gin.Context, parameters map[string]interface{}) (string, string) {
+	for _, entityKey := range getEntityKeys() {
+		value, exists := parameters[entityKey.key]
+		if !exists || value == nil {
+			continue
+		}
+		switch v := value.(type) {
+		case float64:
+			return entityKey.entityType, fmt.Sprintf("%v", int64(v))
+		case string:
+			if v != "" {
+				return entityKey.entityType, v
+			}
+		}
+	}
+	// Fall back to the route group i.e. lightning for /api/lightning/sendcoins
+	parts := strings.Split(strings.TrimPrefix(c.FullPath(), "/"), "/")
+	if len(parts) > 1 {
+		return parts[1], ""
+	}
+	return "", ""
+}
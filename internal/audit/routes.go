@@ -0,0 +1,158 @@
+package audit
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+// The maximum number of entries in one page
+const maximumAuditLogLimit = 1000
+
+func RegisterAuditRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("", func(c *gin.Context) { getAuditLogHandler(c, db) })
+	r.GET("csv", func(c *gin.Context) { getAuditLogCsvHandler(c, db) })
+}
+
+func getAuditLogHandler(c *gin.Context, db *sqlx.DB) {
+	filter, err := parseEntryFilter(c)
+	if err != nil {
+		server_errors.SendBadRequest(c, err.Error())
+		return
+	}
+	if filter.Limit == 0 || filter.Limit > maximumAuditLogLimit {
+		filter.Limit = maximumAuditLogLimit
+	}
+	entries, total, err := getEntries(db, filter)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting audit log.")
+		return
+	}
+	c.JSON(http.StatusOK, ah.ApiResponse{
+		Data: entries, Pagination: ah.Pagination{
+			Total:  total,
+			Limit:  filter.Limit,
+			Offset: filter.Offset,
+		}})
+}
+
+// getAuditLogCsvHandler exports all entries matching the filter (the limit is optional)
+func getAuditLogCsvHandler(c *gin.Context, db *sqlx.DB) {
+	filter, err := parseEntryFilter(c)
+	if err != nil {
+		server_errors.SendBadRequest(c, err.Error())
+		return
+	}
+	entries, _, err := getEntries(db, filter)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting audit log.")
+		return
+	}
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition",
+		fmt.Sprintf("attachment; filename=\"torq-audit-%v.csv\"", time.Now().UTC().Format("20060102-150405")))
+	c.Status(http.StatusOK)
+	err = writeCsv(csv.NewWriter(c.Writer), entries)
+	if err != nil {
+		_ = c.Error(err)
+	}
+}
+
+func writeCsv(w *csv.Writer, entries []Entry) error {
+	err := w.Write([]string{"auditLogId", "createdOn", "actorType", "actorId", "actorName", "action",
+		"entityType", "entityId", "parameters", "result", "statusCode", "error"})
+	if err != nil {
+		return errors.Wrap(err, "Writing CSV header")
+	}
+	for _, entry := range entries {
+		statusCode := ""
+		if entry.StatusCode != nil {
+			statusCode = strconv.Itoa(*entry.StatusCode)
+		}
+		err = w.Write([]string{
+			strconv.FormatInt(entry.AuditLogId, 10),
+			entry.CreatedOn.UTC().Format(time.RFC3339),
+			entry.ActorType.String(),
+			stringValue(entry.ActorId),
+			stringValue(entry.ActorName),
+			entry.Action,
+			stringValue(entry.EntityType),
+			stringValue(entry.EntityId),
+			stringValue(entry.Parameters),
+			string(entry.Result),
+			statusCode,
+			stringValue(entry.Error),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "Writing CSV line for auditLogId: %v", entry.AuditLogId)
+		}
+	}
+	w.Flush()
+	return errors.Wrap(w.Error(), "Flushing CSV")
+}
+
+func parseEntryFilter(c *gin.Context) (EntryFilter, error) {
+	filter := EntryFilter{
+		ActorName:  c.Query("actorName"),
+		Action:     c.Query("action"),
+		EntityType: c.Query("entityType"),
+		EntityId:   c.Query("entityId"),
+		Result:     Result(c.Query("result")),
+	}
+	var err error
+	if filter.From, err = parseTime(c.Query("from")); err != nil {
+		return EntryFilter{}, errors.Wrap(err, "Can't process from")
+	}
+	if filter.To, err = parseTime(c.Query("to")); err != nil {
+		return EntryFilter{}, errors.Wrap(err, "Can't process to")
+	}
+	if c.Query("actorType") != "" {
+		actorType, err := strconv.Atoi(c.Query("actorType"))
+		if err != nil {
+			return EntryFilter{}, errors.Wrap(err, "Can't process actorType")
+		}
+		filter.ActorType = (*ActorType)(&actorType)
+	}
+	if c.Query("limit") != "" {
+		if filter.Limit, err = strconv.ParseUint(c.Query("limit"), 10, 64); err != nil {
+			return EntryFilter{}, errors.Wrap(err, "Limit must be a positive number")
+		}
+	}
+	if c.Query("offset") != "" {
+		if filter.Offset, err = strconv.ParseUint(c.Query("offset"), 10, 64); err != nil {
+			return EntryFilter{}, errors.Wrap(err, "Offset must be a positive number")
+		}
+	}
+	return filter, nil
+}
+
+// parseTime accepts both a timestamp (RFC3339) and a date
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Parsing time %v", value)
+		}
+	}
+	return &t, nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -1,25 +1,48 @@
 package auth
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/subtle"
 	"encoding/hex"
+	"encoding/json"
+	"io"
 	"net/http"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
 
+	"github.com/lncapital/torq/internal/api_tokens"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/services_helpers"
+	"github.com/lncapital/torq/internal/totp"
+	"github.com/lncapital/torq/internal/users"
 )
 
 const Userkey = "user"
 
+// UserIdKey is the session key of the id of a user from the users table.
+// Sessions without a user id belong to the built-in admin.
+const UserIdKey = "userId"
+
+// RoleKey is the key of the role of the logged-in user in the gin context
+const RoleKey = "role"
+
+// ApiTokenKey is the key of the API token in the gin context when the request is authenticated with a bearer token
+const ApiTokenKey = "apiToken"
+
+// scopeGrantedKey is set in the gin context when ScopeRequired granted access to an API token
+const scopeGrantedKey = "scopeGranted"
+
 func CreateSession(r *gin.Engine, apiPwd string) error {
 	cookiePwd := []byte(apiPwd)
 	if len(cookiePwd) == 0 {
@@ -73,10 +96,29 @@ func TorqRequired(c *gin.Context) {
 }
 
 // AuthRequired is a simple middleware to check the session
-func AuthRequired(autoLogin bool) gin.HandlerFunc {
+// It stores the role of the user in the gin context, the role is looked up on every request
+// so changing the role or removing a user is effective immediately.
+func AuthRequired(db *sqlx.DB, autoLogin bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
+		if token, ok := getBearerToken(c); ok {
+			apiToken, err := api_tokens.GetActiveApiToken(db, token, time.Now().UTC())
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to obtain API token")
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to obtain API token"})
+				return
+			}
+			if apiToken == nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+				return
+			}
+			c.Set(ApiTokenKey, *apiToken)
+			c.Next()
+			return
+		}
+
 		if autoLogin {
+			c.Set(RoleKey, users.RoleAdmin)
 			c.Next()
 			return
 		}
@@ -88,13 +130,230 @@ func AuthRequired(autoLogin bool) gin.HandlerFunc {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 			return
 		}
+		userId, ok := session.Get(UserIdKey).(int)
+		if !ok || userId == 0 {
+			c.Set(UserIdKey, 0)
+			c.Set(RoleKey, users.RoleAdmin)
+			c.Next()
+			return
+		}
+		torqUser, err := users.GetUserById(db, userId)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to obtain user for userId: %v", userId)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to obtain user"})
+			return
+		}
+		if torqUser == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		c.Set(UserIdKey, userId)
+		c.Set(RoleKey, torqUser.Role)
 		// Continue down the chain to handler etc
 		c.Next()
 	}
 }
 
+// GetRole returns the role of the logged-in user (as set by AuthRequired)
+func GetRole(c *gin.Context) users.Role {
+	role, ok := c.Get(RoleKey)
+	if !ok {
+		return 0
+	}
+	userRole, ok := role.(users.Role)
+	if !ok {
+		return 0
+	}
+	return userRole
+}
+
+func getBearerToken(c *gin.Context) (string, bool) {
+	authorization := c.GetHeader("Authorization")
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return "", false
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+	return token, token != ""
+}
+
+// GetApiToken returns the API token when the request is authenticated with a bearer token (as set by AuthRequired)
+func GetApiToken(c *gin.Context) *api_tokens.ApiToken {
+	value, ok := c.Get(ApiTokenKey)
+	if !ok {
+		return nil
+	}
+	apiToken, ok := value.(api_tokens.ApiToken)
+	if !ok {
+		return nil
+	}
+	return &apiToken
+}
+
+// ScopeRequired is a middleware to check an API token has a scope for the route group (resource) and
+// that it does not address another node than the one it's restricted to.
+// Requests authenticated with a session are not affected.
+func ScopeRequired(resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiToken := GetApiToken(c)
+		if apiToken == nil {
+			c.Next()
+			return
+		}
+		if !apiToken.IsAllowed(resource, c.Request.Method, c.FullPath()) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden: missing scope"})
+			return
+		}
+		if apiToken.NodeId != nil {
+			nodeIds, err := getRequestNodeIds(c)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			for _, nodeId := range nodeIds {
+				if nodeId != *apiToken.NodeId {
+					c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden: token is restricted to another node"})
+					return
+				}
+			}
+		}
+		c.Set(scopeGrantedKey, true)
+		c.Next()
+	}
+}
+
+// getRequestNodeIds returns the nodeIds referenced in the path, query or (JSON) body of the request
+func getRequestNodeIds(c *gin.Context) ([]int, error) {
+	var nodeIds []int
+	for _, value := range append([]string{c.Param("nodeId")}, c.QueryArray("nodeId")...) {
+		if value == "" {
+			continue
+		}
+		nodeId, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Parsing nodeId %v", value)
+		}
+		nodeIds = append(nodeIds, nodeId)
+	}
+	if c.Request.Body == nil || c.ContentType() != gin.MIMEJSON {
+		return nodeIds, nil
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Reading request body")
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	var request struct {
+		NodeId *int `json:"nodeId"`
+	}
+	// Bodies that are not a JSON object (i.e. arrays) don't reference a node at the top level
+	if json.Unmarshal(body, &request) == nil && request.NodeId != nil {
+		nodeIds = append(nodeIds, *request.NodeId)
+	}
+	return nodeIds, nil
+}
+
+// RestrictNodeIds removes the nodes an API token is not allowed to access
+func RestrictNodeIds(c *gin.Context, nodeIds []int) []int {
+	apiToken := GetApiToken(c)
+	if apiToken == nil || apiToken.NodeId == nil {
+		return nodeIds
+	}
+	var restrictedNodeIds []int
+	for _, nodeId := range nodeIds {
+		if nodeId == *apiToken.NodeId {
+			restrictedNodeIds = append(restrictedNodeIds, nodeId)
+		}
+	}
+	return restrictedNodeIds
+}
+
+// GetNodeIds returns the Torq nodes requested with the (repeatable) nodeId query parameter or all Torq nodes
+// when none are requested. Nodes the API token can't access are removed.
+func GetNodeIds(c *gin.Context) ([]int, error) {
+	torqNodeIds := cache.GetAllTorqNodeIds()
+	values := c.QueryArray("nodeId")
+	if len(values) == 0 {
+		return RestrictNodeIds(c, torqNodeIds), nil
+	}
+	var nodeIds []int
+	for _, value := range values {
+		nodeId, err := strconv.Atoi(value)
+		if err != nil || !slices.Contains(torqNodeIds, nodeId) {
+			return nil, errors.Newf("Unknown nodeId %v", value)
+		}
+		nodeIds = append(nodeIds, nodeId)
+	}
+	return RestrictNodeIds(c, nodeIds), nil
+}
+
+// GetRestrictedNodeId returns the Torq node an API token is restricted to or nil when all nodes can be accessed
+func GetRestrictedNodeId(c *gin.Context) *int {
+	apiToken := GetApiToken(c)
+	if apiToken == nil {
+		return nil
+	}
+	return apiToken.NodeId
+}
+
+// AllNodesRequired is a middleware for route groups that act on all Torq nodes (i.e. workflows),
+// API tokens restricted to a node can't use them.
+func AllNodesRequired(c *gin.Context) {
+	if GetRestrictedNodeId(c) != nil {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden: token is restricted to a node"})
+		return
+	}
+	c.Next()
+}
+
+// SessionAdminRequired is a middleware for node credentials (macaroons, certificates and keys).
+// Only logged-in admins can access them, API tokens can't whatever their scopes are.
+func SessionAdminRequired(c *gin.Context) {
+	if GetApiToken(c) != nil || GetRole(c) < users.RoleAdmin {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	c.Next()
+}
+
+// hasAccess checks the role of the logged-in user or for API tokens that ScopeRequired granted access
+func hasAccess(c *gin.Context, role users.Role) bool {
+	if GetApiToken(c) != nil {
+		return c.GetBool(scopeGrantedKey)
+	}
+	return GetRole(c) >= role
+}
+
+// RoleRequired is a middleware to check the logged-in user has at least the given role
+func RoleRequired(role users.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hasAccess(c, role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// WriteRoleRequired is a middleware to check the logged-in user has at least the given role
+// for any request that is not read-only (GET, HEAD or OPTIONS).
+func WriteRoleRequired(role users.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if !hasAccess(c, role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+		c.Next()
+	}
+}
+
 // Login creates a user session, logging them in given the right username and password
-func Login(apiPwd string) gin.HandlerFunc {
+// The built-in admin logs in with the torq password, all other users are stored in the database.
+func Login(db *sqlx.DB, apiPwd string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		session := sessions.Default(c)
 		username := c.PostForm("username")
@@ -106,20 +365,93 @@ func Login(apiPwd string) gin.HandlerFunc {
 			return
 		}
 
-		// Check for username and password match, usually from a database
-		if username != "admin" || password != apiPwd {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication failed"})
+		userId := 0
+		role := users.RoleAdmin
+		if username == users.BuiltInAdminUsername {
+			if subtle.ConstantTimeCompare([]byte(password), []byte(apiPwd)) != 1 {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication failed"})
+				return
+			}
+		} else {
+			torqUser, err := users.CheckPassword(db, username, password)
+			if err != nil {
+				log.Error().Err(err).Msgf("Failed to verify the password of user %v", username)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify password"})
+				return
+			}
+			if torqUser == nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication failed"})
+				return
+			}
+			userId = torqUser.UserId
+			role = torqUser.Role
+		}
+
+		// With TOTP enabled the session is only created when the code (or a recovery code) is valid as well
+		now := time.Now().UTC()
+		if retryAfter := totpAttempts.getRetryAfter(userId, now); retryAfter > 0 {
+			sendTooManyAttempts(c, retryAfter)
+			return
+		}
+		totpEnabled, err := totp.IsEnabled(db, userId)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to check TOTP of user %v", username)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check TOTP"})
 			return
 		}
+		if totpEnabled {
+			code := c.PostForm("totp")
+			if strings.TrimSpace(code) == "" {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Second factor required", "totpRequired": true})
+				return
+			}
+			valid, retryAfter, err := verifyTotp(db, userId, code, now)
+			if err != nil {
+				log.Error().Err(err).Msgf("Failed to verify the TOTP of user %v", username)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify TOTP"})
+				return
+			}
+			if retryAfter > 0 {
+				sendTooManyAttempts(c, retryAfter)
+				return
+			}
+			if !valid {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication failed", "totpRequired": true})
+				return
+			}
+			if err = markFactorVerified(session); err != nil {
+				log.Error().Err(err).Msg("Failed to mark second factor verified")
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
+				return
+			}
+		}
 
 		// Save the username in the session
-		// set this to the users ID when moving to multi users setup
 		session.Set(Userkey, username)
+		session.Set(UserIdKey, userId)
 		if err := session.Save(); err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
 			return
 		}
-		c.JSON(http.StatusOK, gin.H{"message": "Successfully authenticated user"})
+		c.JSON(http.StatusOK, gin.H{"message": "Successfully authenticated user", "role": role})
+	}
+}
+
+// CurrentUser returns the username and role of the logged-in user
+func CurrentUser(autoLogin bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiToken := GetApiToken(c); apiToken != nil {
+			c.JSON(http.StatusOK, gin.H{"apiToken": apiToken})
+			return
+		}
+		username := users.BuiltInAdminUsername
+		if !autoLogin {
+			if user, ok := sessions.Default(c).Get(Userkey).(string); ok {
+				username = user
+			}
+		}
+		role := GetRole(c)
+		c.JSON(http.StatusOK, gin.H{"username": username, "role": role, "roleName": role.String()})
 	}
 }
 
@@ -162,8 +494,10 @@ func CookieLogin(cookiePath string) gin.HandlerFunc {
 		}
 
 		// Save the username in the session
-		// set this to the users ID when moving to multi users setup
+		// access to the cookie file grants admin rights
+		// it doesn't ask for TOTP but sensitive operations still require a verified second factor
 		session.Set(Userkey, "SSOUser")
+		session.Set(UserIdKey, 0)
 		if err := session.Save(); err != nil {
 			log.Error().Err(err).Msg("Failed to save session")
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
@@ -176,7 +510,10 @@ func CookieLogin(cookiePath string) gin.HandlerFunc {
 func Logout(c *gin.Context) {
 	session := sessions.Default(c)
 
+	forgetFactor(session)
 	session.Delete(Userkey)
+	session.Delete(UserIdKey)
+	session.Delete(factorKey)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Successfully logged out"})
 }
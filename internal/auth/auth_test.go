@@ -0,0 +1,327 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/lncapital/torq/internal/api_tokens"
+	"github.com/lncapital/torq/internal/users"
+)
+
+//nolint:gochecknoglobals
+var methods = []string{http.MethodGet, http.MethodHead, http.MethodOptions,
+	http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+func isReadOnly(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// newRoleRouter serves /test with the middleware for a user with the role or an API token (role 0)
+func newRoleRouter(middleware gin.HandlerFunc, role users.Role, scopeGranted bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if role == 0 {
+			c.Set(ApiTokenKey, api_tokens.ApiToken{})
+			c.Set(scopeGrantedKey, scopeGranted)
+		} else {
+			c.Set(RoleKey, role)
+		}
+	}, middleware)
+	for _, method := range methods {
+		r.Handle(method, "/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+	}
+	return r
+}
+
+func TestRoleRequired(t *testing.T) {
+	tests := []struct {
+		name         string
+		required     users.Role
+		role         users.Role
+		scopeGranted bool
+		allowed      bool
+	}{
+		{"Viewer for viewer", users.RoleViewer, users.RoleViewer, false, true},
+		{"Operator for viewer", users.RoleViewer, users.RoleOperator, false, true},
+		{"Admin for viewer", users.RoleViewer, users.RoleAdmin, false, true},
+		{"Viewer for operator", users.RoleOperator, users.RoleViewer, false, false},
+		{"Operator for operator", users.RoleOperator, users.RoleOperator, false, true},
+		{"Admin for operator", users.RoleOperator, users.RoleAdmin, false, true},
+		{"Viewer for admin", users.RoleAdmin, users.RoleViewer, false, false},
+		{"Operator for admin", users.RoleAdmin, users.RoleOperator, false, false},
+		{"Admin for admin", users.RoleAdmin, users.RoleAdmin, false, true},
+		{"API token with scope", users.RoleAdmin, 0, true, true},
+		{"API token without scope", users.RoleViewer, 0, false, false},
+	}
+	for _, test := range tests {
+		r := newRoleRouter(RoleRequired(test.required), test.role, test.scopeGranted)
+		for _, method := range methods {
+			t.Run(test.name+" "+method, func(t *testing.T) {
+				expected := http.StatusForbidden
+				if test.allowed {
+					expected = http.StatusOK
+				}
+				response := httptest.NewRecorder()
+				r.ServeHTTP(response, httptest.NewRequest(method, "/test", nil))
+				if response.Code != expected {
+					t.Errorf("%v /test = %v, expected %v", method, response.Code, expected)
+				}
+			})
+		}
+	}
+}
+
+func TestWriteRoleRequired(t *testing.T) {
+	tests := []struct {
+		name         string
+		required     users.Role
+		role         users.Role
+		scopeGranted bool
+		writeAllowed bool
+	}{
+		{"Viewer for operator", users.RoleOperator, users.RoleViewer, false, false},
+		{"Operator for operator", users.RoleOperator, users.RoleOperator, false, true},
+		{"Admin for operator", users.RoleOperator, users.RoleAdmin, false, true},
+		{"Viewer for admin", users.RoleAdmin, users.RoleViewer, false, false},
+		{"Operator for admin", users.RoleAdmin, users.RoleOperator, false, false},
+		{"Admin for admin", users.RoleAdmin, users.RoleAdmin, false, true},
+		{"API token with scope", users.RoleAdmin, 0, true, true},
+		{"API token without scope", users.RoleOperator, 0, false, false},
+	}
+	for _, test := range tests {
+		r := newRoleRouter(WriteRoleRequired(test.required), test.role, test.scopeGranted)
+		for _, method := range methods {
+			t.Run(test.name+" "+method, func(t *testing.T) {
+				expected := http.StatusForbidden
+				if test.writeAllowed || isReadOnly(method) {
+					expected = http.StatusOK
+				}
+				response := httptest.NewRecorder()
+				r.ServeHTTP(response, httptest.NewRequest(method, "/test", nil))
+				if response.Code != expected {
+					t.Errorf("%v /test = %v, expected %v", method, response.Code, expected)
+				}
+			})
+		}
+	}
+}
+
+func TestLogin(t *testing.T) {
+	db := newFakeUserDb(t, map[string]users.Role{
+		"viewer":   users.RoleViewer,
+		"operator": users.RoleOperator,
+		"admin2":   users.RoleAdmin,
+	})
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	if err := CreateSession(r, "torq-password"); err != nil {
+		t.Fatal(err)
+	}
+	r.POST("/login", Login(db, "torq-password"))
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		expected int
+		role     users.Role
+	}{
+		{"Built-in admin", users.BuiltInAdminUsername, "torq-password", http.StatusOK, users.RoleAdmin},
+		{"Built-in admin wrong password", users.BuiltInAdminUsername, "password", http.StatusUnauthorized, 0},
+		{"Viewer", "viewer", "viewer-password", http.StatusOK, users.RoleViewer},
+		{"Operator", "operator", "operator-password", http.StatusOK, users.RoleOperator},
+		{"Admin", "admin2", "admin2-password", http.StatusOK, users.RoleAdmin},
+		{"Wrong password", "viewer", "operator-password", http.StatusUnauthorized, 0},
+		// The torq password is only for the built-in admin
+		{"Torq password", "admin2", "torq-password", http.StatusUnauthorized, 0},
+		{"Unknown user", "unknown", "unknown-password", http.StatusUnauthorized, 0},
+		{"Empty password", "viewer", " ", http.StatusBadRequest, 0},
+		{"Empty username", "", "viewer-password", http.StatusBadRequest, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			form := url.Values{"username": {test.username}, "password": {test.password}}
+			request := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(form.Encode()))
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			response := httptest.NewRecorder()
+			r.ServeHTTP(response, request)
+			if response.Code != test.expected {
+				t.Fatalf("POST /login = %v, expected %v", response.Code, test.expected)
+			}
+			cookie := response.Header().Get("Set-Cookie")
+			if test.expected != http.StatusOK {
+				if cookie != "" {
+					t.Errorf("POST /login set cookie %v, expected no session", cookie)
+				}
+				return
+			}
+			var body struct {
+				Role users.Role `json:"role"`
+			}
+			if err := json.Unmarshal(response.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Role != test.role || !strings.HasPrefix(cookie, "torq_session=") {
+				t.Errorf("POST /login = role %v with cookie %v, expected role %v with a session",
+					body.Role, cookie, test.role)
+			}
+		})
+	}
+}
+
+// newFakeUserDb returns a database with users (with password username-password) and without TOTP
+func newFakeUserDb(t *testing.T, roles map[string]users.Role) *sqlx.DB {
+	fakeUsers := make(map[string][]driver.Value)
+	userId := 0
+	for username, role := range roles {
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(username+"-password"), bcrypt.MinCost)
+		if err != nil {
+			t.Fatal(err)
+		}
+		userId++
+		fakeUsers[username] = []driver.Value{int64(userId), username, string(passwordHash), int64(role),
+			time.Now(), time.Now()}
+	}
+	return sqlx.NewDb(sql.OpenDB(fakeConnector{users: fakeUsers}), "postgres")
+}
+
+type fakeConnector struct {
+	users map[string][]driver.Value
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return fakeConn(c), nil
+}
+
+func (c fakeConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("Use the connector")
+}
+
+type fakeConn struct {
+	users map[string][]driver.Value
+}
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("Prepared statements are not supported")
+}
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("Transactions are not supported")
+}
+
+func (c fakeConn) Close() error {
+	return nil
+}
+
+func (c fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	switch {
+	case strings.Contains(query, "FROM torq_user WHERE username"):
+		rows := &fakeRows{columns: []string{"user_id", "username", "password_hash", "role", "created_on", "updated_on"}}
+		if user, exists := c.users[args[0].Value.(string)]; exists {
+			rows.values = [][]driver.Value{user}
+		}
+		return rows, nil
+	case strings.Contains(query, "FROM user_totp"):
+		return &fakeRows{columns: []string{"user_id", "secret", "recovery_code_hashes", "last_used_step",
+			"enabled_on", "created_on", "updated_on"}}, nil
+	}
+	return nil, errors.Newf("Unexpected query %v", query)
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestNodeRestrictions(t *testing.T) {
+	nodeId := 1
+	tests := []struct {
+		name               string
+		apiToken           *api_tokens.ApiToken
+		role               users.Role
+		allNodesAllowed    bool
+		credentialsAllowed bool
+	}{
+		{"Admin", nil, users.RoleAdmin, true, true},
+		{"Operator", nil, users.RoleOperator, true, false},
+		{"Viewer", nil, users.RoleViewer, true, false},
+		{"API token", &api_tokens.ApiToken{}, 0, true, false},
+		{"API token restricted to a node", &api_tokens.ApiToken{NodeId: &nodeId}, 0, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if test.apiToken != nil {
+					c.Set(ApiTokenKey, *test.apiToken)
+					c.Set(scopeGrantedKey, true)
+				} else {
+					c.Set(RoleKey, test.role)
+				}
+			})
+			r.GET("/workflows", AllNodesRequired, func(c *gin.Context) { c.Status(http.StatusOK) })
+			r.GET("/credentials", SessionAdminRequired, func(c *gin.Context) { c.Status(http.StatusOK) })
+			r.GET("/node", func(c *gin.Context) {
+				restrictedNodeId := GetRestrictedNodeId(c)
+				if (restrictedNodeId != nil) != (test.apiToken != nil && test.apiToken.NodeId != nil) {
+					t.Errorf("GetRestrictedNodeId = %v, expected the node of the API token", restrictedNodeId)
+				}
+			})
+
+			for path, allowed := range map[string]bool{
+				"/workflows":   test.allNodesAllowed,
+				"/credentials": test.credentialsAllowed,
+				"/node":        true,
+			} {
+				expected := http.StatusForbidden
+				if allowed {
+					expected = http.StatusOK
+				}
+				response := httptest.NewRecorder()
+				r.ServeHTTP(response, httptest.NewRequest(http.MethodGet, path, nil))
+				if response.Code != expected {
+					t.Errorf("GET %v = %v, expected %v", path, response.Code, expected)
+				}
+			}
+		})
+	}
+}
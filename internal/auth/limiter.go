@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/totp"
+)
+
+// The first failed codes of a user are not delayed (typos, clock drift)
+const freeFailedAttempts = 3
+
+// After this many failed codes in a row the user is locked out for lockoutDuration
+const maxFailedAttempts = 10
+
+const lockoutDuration = 15 * time.Minute
+
+// Failures are forgotten when the last one is older than failureMemory
+const failureMemory = time.Hour
+
+type failedAttempts struct {
+	count        int
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+// attemptLimiter limits guessing TOTP codes and recovery codes per user.
+// Every failed code after freeFailedAttempts doubles the delay before the next code is checked,
+// maxFailedAttempts locks the user out. A valid code resets the failures.
+type attemptLimiter struct {
+	sync.Mutex
+	failures map[int]failedAttempts
+}
+
+//nolint:gochecknoglobals
+var totpAttempts = &attemptLimiter{failures: make(map[int]failedAttempts)}
+
+// getRetryAfter returns how long the user has to wait before a code is checked again
+func (l *attemptLimiter) getRetryAfter(userId int, now time.Time) time.Duration {
+	l.Lock()
+	defer l.Unlock()
+	failures, exists := l.failures[userId]
+	if !exists || !now.Before(failures.blockedUntil) {
+		return 0
+	}
+	return failures.blockedUntil.Sub(now)
+}
+
+func (l *attemptLimiter) fail(userId int, now time.Time) {
+	l.Lock()
+	defer l.Unlock()
+	for id, failures := range l.failures {
+		if now.Sub(failures.lastFailure) > failureMemory && !now.Before(failures.blockedUntil) {
+			delete(l.failures, id)
+		}
+	}
+	failures := l.failures[userId]
+	failures.count++
+	failures.lastFailure = now
+	switch {
+	case failures.count >= maxFailedAttempts:
+		failures.blockedUntil = now.Add(lockoutDuration)
+	case failures.count >= freeFailedAttempts:
+		failures.blockedUntil = now.Add(time.Second << (failures.count - freeFailedAttempts))
+	}
+	l.failures[userId] = failures
+}
+
+func (l *attemptLimiter) succeed(userId int) {
+	l.Lock()
+	defer l.Unlock()
+	delete(l.failures, userId)
+}
+
+// verifyTotp is totp.Verify (codes and recovery codes) behind the attempt limiter.
+// It returns the time to wait when the code was not checked.
+func verifyTotp(db *sqlx.DB, userId int, code string, now time.Time) (bool, time.Duration, error) {
+	if retryAfter := totpAttempts.getRetryAfter(userId, now); retryAfter > 0 {
+		return false, retryAfter, nil
+	}
+	valid, err := totp.Verify(db, userId, code, now)
+	if err != nil {
+		return false, 0, err
+	}
+	if !valid {
+		totpAttempts.fail(userId, now)
+		return false, 0, nil
+	}
+	totpAttempts.succeed(userId)
+	return true, 0, nil
+}
+
+// enableTotp is totp.Enable behind the attempt limiter.
+// It returns the time to wait when the code was not checked.
+func enableTotp(db *sqlx.DB, userId int, code string, now time.Time) ([]string, bool, time.Duration, error) {
+	if retryAfter := totpAttempts.getRetryAfter(userId, now); retryAfter > 0 {
+		return nil, false, retryAfter, nil
+	}
+	recoveryCodes, ok, err := totp.Enable(db, userId, code, now)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	if !ok {
+		totpAttempts.fail(userId, now)
+		return nil, false, 0, nil
+	}
+	totpAttempts.succeed(userId)
+	return recoveryCodes, true, 0, nil
+}
+
+// sendTooManyAttempts tells the user to wait before trying another code
+func sendTooManyAttempts(c *gin.Context, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests,
+		gin.H{"error": "Too many failed codes, try again later", "retryAfter": seconds})
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAttemptLimiter(t *testing.T) {
+	limiter := &attemptLimiter{failures: make(map[int]failedAttempts)}
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 1; i < freeFailedAttempts; i++ {
+		limiter.fail(1, now)
+		if retryAfter := limiter.getRetryAfter(1, now); retryAfter != 0 {
+			t.Fatalf("Retry after %v failures = %v, expected no delay", i, retryAfter)
+		}
+	}
+	// The delay doubles with every failure
+	expected := time.Second
+	for i := freeFailedAttempts; i < maxFailedAttempts; i++ {
+		limiter.fail(1, now)
+		if retryAfter := limiter.getRetryAfter(1, now); retryAfter != expected {
+			t.Fatalf("Retry after %v failures = %v, expected %v", i, retryAfter, expected)
+		}
+		now = now.Add(expected)
+		expected *= 2
+	}
+	limiter.fail(1, now)
+	if retryAfter := limiter.getRetryAfter(1, now); retryAfter != lockoutDuration {
+		t.Fatalf("Retry after %v failures = %v, expected the lockout", maxFailedAttempts, retryAfter)
+	}
+	if retryAfter := limiter.getRetryAfter(2, now); retryAfter != 0 {
+		t.Errorf("Retry of another user = %v, expected no delay", retryAfter)
+	}
+
+	// After the lockout the next failure locks out again
+	now = now.Add(lockoutDuration)
+	if retryAfter := limiter.getRetryAfter(1, now); retryAfter != 0 {
+		t.Fatalf("Retry after the lockout = %v, expected no delay", retryAfter)
+	}
+	limiter.fail(1, now)
+	if retryAfter := limiter.getRetryAfter(1, now); retryAfter != lockoutDuration {
+		t.Errorf("Retry after a failure following the lockout = %v, expected the lockout", retryAfter)
+	}
+
+	limiter.succeed(1)
+	if retryAfter := limiter.getRetryAfter(1, now); retryAfter != 0 {
+		t.Errorf("Retry after a valid code = %v, expected no delay", retryAfter)
+	}
+
+	// Old failures are forgotten
+	limiter.fail(3, now)
+	limiter.fail(3, now)
+	limiter.fail(4, now.Add(failureMemory+time.Second))
+	if _, exists := limiter.failures[3]; exists {
+		t.Errorf("Failures = %v, expected the old failures to be removed", limiter.failures)
+	}
+}
+
+// Locked out users are rejected before the code is checked, so no database is needed.
+func TestTotpRoutesTooManyAttempts(t *testing.T) {
+	defer func() { totpAttempts = &attemptLimiter{failures: make(map[int]failedAttempts)} }()
+	now := time.Now().UTC()
+	for i := 0; i < maxFailedAttempts; i++ {
+		totpAttempts.fail(0, now)
+		totpAttempts.fail(1, now)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	if err := CreateSession(r, "password"); err != nil {
+		t.Fatal(err)
+	}
+	r.POST("/login", Login(nil, "password"))
+	totpRoutes := r.Group("/totp", func(c *gin.Context) { c.Set(UserIdKey, 1) })
+	RegisterTotpRoutes(totpRoutes, nil)
+
+	// Recovery codes are verified by the same routes, they are limited like codes
+	for _, path := range []string{"/totp/verify", "/totp/enable", "/totp/disable", "/totp/recovery-codes"} {
+		for _, code := range []string{"123456", "abcde-fghij"} {
+			response := httptest.NewRecorder()
+			r.ServeHTTP(response, httptest.NewRequest(http.MethodPost, path,
+				strings.NewReader(`{"code": "`+code+`"}`)))
+			if response.Code != http.StatusTooManyRequests || response.Header().Get("Retry-After") == "" {
+				t.Errorf("POST %v with %v = %v, expected too many requests with Retry-After",
+					path, code, response.Code)
+			}
+		}
+	}
+
+	form := url.Values{"username": {"admin"}, "password": {"password"}, "totp": {"123456"}}
+	request := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	response := httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	if response.Code != http.StatusTooManyRequests {
+		t.Errorf("POST /login = %v, expected too many requests", response.Code)
+	}
+}
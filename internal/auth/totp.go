@@ -0,0 +1,316 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/totp"
+	"github.com/lncapital/torq/internal/users"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+// factorKey is the session key of the random key that identifies the session in freshFactors
+const factorKey = "factorKey"
+
+// Sensitive operations require a second factor that was verified within this duration
+const freshFactorDuration = 5 * time.Minute
+
+// freshFactors holds when the second factor was last verified per session.
+// It's kept in memory (and not in the cookie) so websockets that are already open see a new verification.
+//
+//nolint:gochecknoglobals
+var freshFactors = struct {
+	sync.RWMutex
+	verifiedOn map[string]time.Time
+}{verifiedOn: make(map[string]time.Time)}
+
+type totpCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// FactorSession identifies the second factor state of a request.
+// It can be obtained during the request and checked later (i.e. for websocket messages).
+type FactorSession struct {
+	exempt bool
+	userId int
+	key    string
+}
+
+// GetFactorSession returns the second factor state of the request.
+// API tokens and auto login are exempt, they can't provide a second factor.
+func GetFactorSession(c *gin.Context) FactorSession {
+	if GetApiToken(c) != nil {
+		return FactorSession{exempt: true}
+	}
+	userId, ok := c.Get(UserIdKey)
+	if !ok {
+		return FactorSession{exempt: true}
+	}
+	key, _ := sessions.Default(c).Get(factorKey).(string)
+	return FactorSession{userId: userId.(int), key: key}
+}
+
+// IsFresh returns true when the user has no TOTP enabled or when it was verified recently
+func (fs FactorSession) IsFresh(db *sqlx.DB, now time.Time) (bool, error) {
+	if fs.exempt {
+		return true, nil
+	}
+	enabled, err := totp.IsEnabled(db, fs.userId)
+	if err != nil {
+		return false, errors.Wrapf(err, "Checking TOTP for userId: %v", fs.userId)
+	}
+	if !enabled {
+		return true, nil
+	}
+	freshFactors.RLock()
+	defer freshFactors.RUnlock()
+	verifiedOn, exists := freshFactors.verifiedOn[fs.key]
+	return exists && fs.key != "" && now.Sub(verifiedOn) <= freshFactorDuration, nil
+}
+
+// markFactorVerified records in the session that the second factor was just verified
+func markFactorVerified(session sessions.Session) error {
+	key, ok := session.Get(factorKey).(string)
+	if !ok || key == "" {
+		keyBytes := make([]byte, 32)
+		_, err := rand.Read(keyBytes)
+		if err != nil {
+			return errors.Wrap(err, "Generating random factor key")
+		}
+		key = hex.EncodeToString(keyBytes)
+		session.Set(factorKey, key)
+	}
+	now := time.Now()
+	freshFactors.Lock()
+	defer freshFactors.Unlock()
+	for existingKey, verifiedOn := range freshFactors.verifiedOn {
+		if now.Sub(verifiedOn) > freshFactorDuration {
+			delete(freshFactors.verifiedOn, existingKey)
+		}
+	}
+	freshFactors.verifiedOn[key] = now
+	return nil
+}
+
+func forgetFactor(session sessions.Session) {
+	key, ok := session.Get(factorKey).(string)
+	if !ok {
+		return
+	}
+	freshFactors.Lock()
+	defer freshFactors.Unlock()
+	delete(freshFactors.verifiedOn, key)
+}
+
+// FreshFactorRequired is a middleware for sensitive operations (i.e. moving funds).
+// Users with TOTP enabled need to have verified a code within the last minutes.
+func FreshFactorRequired(db *sqlx.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fresh, err := GetFactorSession(c).IsFresh(db, time.Now())
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to check second factor")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check second factor"})
+			return
+		}
+		if !fresh {
+			c.AbortWithStatusJSON(http.StatusForbidden,
+				gin.H{"error": "forbidden: verify your second factor first", "totpRequired": true})
+			return
+		}
+		c.Next()
+	}
+}
+
+// WriteFreshFactorRequired is FreshFactorRequired for any request that is not read-only (GET, HEAD or OPTIONS)
+func WriteFreshFactorRequired(db *sqlx.DB) gin.HandlerFunc {
+	freshFactorRequired := FreshFactorRequired(db)
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		freshFactorRequired(c)
+	}
+}
+
+func RegisterTotpRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("", func(c *gin.Context) { getTotpStatusHandler(c, db) })
+	r.POST("enroll", func(c *gin.Context) { enrollTotpHandler(c, db) })
+	r.POST("enable", func(c *gin.Context) { enableTotpHandler(c, db) })
+	r.POST("verify", func(c *gin.Context) { verifyTotpHandler(c, db) })
+	r.POST("recovery-codes", func(c *gin.Context) { regenerateRecoveryCodesHandler(c, db) })
+	r.POST("disable", func(c *gin.Context) { disableTotpHandler(c, db) })
+}
+
+// getSessionUserId returns the user of the session, TOTP is not available for API tokens and auto login
+func getSessionUserId(c *gin.Context) (int, bool) {
+	if GetApiToken(c) != nil {
+		return 0, false
+	}
+	userId, ok := c.Get(UserIdKey)
+	if !ok {
+		return 0, false
+	}
+	return userId.(int), true
+}
+
+func getTotpStatusHandler(c *gin.Context, db *sqlx.DB) {
+	userId, ok := getSessionUserId(c)
+	if !ok {
+		c.JSON(http.StatusOK, totp.Status{})
+		return
+	}
+	status, err := totp.GetStatus(db, userId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting TOTP status")
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+func enrollTotpHandler(c *gin.Context, db *sqlx.DB) {
+	userId, ok := getSessionUserId(c)
+	if !ok {
+		server_errors.SendBadRequest(c, "TOTP is only available for logged in users")
+		return
+	}
+	username, _ := sessions.Default(c).Get(Userkey).(string)
+	if userId == 0 {
+		username = users.BuiltInAdminUsername
+	}
+	enrollment, ok, err := totp.Enroll(db, userId, username)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Enrolling TOTP")
+		return
+	}
+	if !ok {
+		server_errors.SendBadRequest(c, "TOTP is already enabled, disable it first")
+		return
+	}
+	c.JSON(http.StatusOK, enrollment)
+}
+
+func enableTotpHandler(c *gin.Context, db *sqlx.DB) {
+	userId, ok := getSessionUserId(c)
+	if !ok {
+		server_errors.SendBadRequest(c, "TOTP is only available for logged in users")
+		return
+	}
+	var request totpCodeRequest
+	if err := c.BindJSON(&request); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	recoveryCodes, ok, retryAfter, err := enableTotp(db, userId, request.Code, time.Now().UTC())
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Enabling TOTP")
+		return
+	}
+	if retryAfter > 0 {
+		sendTooManyAttempts(c, retryAfter)
+		return
+	}
+	if !ok {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("code", "Invalid code"))
+		return
+	}
+	if !saveFactorVerified(c) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recoveryCodes": recoveryCodes})
+}
+
+func verifyTotpHandler(c *gin.Context, db *sqlx.DB) {
+	userId, ok := getSessionUserId(c)
+	if !ok {
+		server_errors.SendBadRequest(c, "TOTP is only available for logged in users")
+		return
+	}
+	if !bindAndVerifyCode(c, db, userId) {
+		return
+	}
+	if !saveFactorVerified(c) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Successfully verified second factor"})
+}
+
+func regenerateRecoveryCodesHandler(c *gin.Context, db *sqlx.DB) {
+	userId, ok := getSessionUserId(c)
+	if !ok {
+		server_errors.SendBadRequest(c, "TOTP is only available for logged in users")
+		return
+	}
+	if !bindAndVerifyCode(c, db, userId) {
+		return
+	}
+	recoveryCodes, err := totp.RegenerateRecoveryCodes(db, userId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Generating recovery codes")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recoveryCodes": recoveryCodes})
+}
+
+func disableTotpHandler(c *gin.Context, db *sqlx.DB) {
+	userId, ok := getSessionUserId(c)
+	if !ok {
+		server_errors.SendBadRequest(c, "TOTP is only available for logged in users")
+		return
+	}
+	if !bindAndVerifyCode(c, db, userId) {
+		return
+	}
+	_, err := totp.RemoveTotp(db, userId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Disabling TOTP")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Successfully disabled TOTP"})
+}
+
+// bindAndVerifyCode verifies the code (or recovery code) of the request, it sends the error response when it fails
+func bindAndVerifyCode(c *gin.Context, db *sqlx.DB, userId int) bool {
+	var request totpCodeRequest
+	if err := c.BindJSON(&request); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return false
+	}
+	valid, retryAfter, err := verifyTotp(db, userId, request.Code, time.Now().UTC())
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Verifying TOTP code")
+		return false
+	}
+	if retryAfter > 0 {
+		sendTooManyAttempts(c, retryAfter)
+		return false
+	}
+	if !valid {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("code", "Invalid code"))
+		return false
+	}
+	return true
+}
+
+func saveFactorVerified(c *gin.Context) bool {
+	session := sessions.Default(c)
+	if err := markFactorVerified(session); err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Marking second factor verified")
+		return false
+	}
+	if err := session.Save(); err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Saving session")
+		return false
+	}
+	return true
+}
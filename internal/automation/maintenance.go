@@ -7,35 +7,75 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 
 	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/channel_history"
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/data_retention"
 	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/internal/flow"
+	"github.com/lncapital/torq/internal/peer_scores"
 	"github.com/lncapital/torq/internal/vector"
 )
 
 const maintenanceQueueTickerSeconds = 60 * 60
 const maintenanceVectorDelayMilliseconds = 500
+const channelBalanceSampleTickerSeconds = 15 * 60
 
 func MaintenanceServiceStart(ctx context.Context, db *sqlx.DB) {
 
 	ticker := time.NewTicker(maintenanceQueueTickerSeconds * time.Second)
 	defer ticker.Stop()
+	channelBalanceSampleTicker := time.NewTicker(channelBalanceSampleTickerSeconds * time.Second)
+	defer channelBalanceSampleTicker.Stop()
 
+	applyDataRetentionPolicies(db)
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-channelBalanceSampleTicker.C:
+			err := channel_history.AddChannelBalanceSamples(db)
+			if err != nil {
+				log.Error().Err(err).Msg("Couldn't store the channel balance samples.")
+			}
 		case <-ticker.C:
 			// TODO get forwards/invoices/payments without firstNodeId/secondNodeId/nodeId and assign correctly
 			processMissingChannelData(db)
 			processMissingTransactionData(db)
 			deleteWorkflowLogs(db)
+			applyDataRetentionPolicies(db)
+			downsampleChannelBalanceHistory(db)
+			err := flow.RefreshChannelFlowCache(db)
+			if err != nil {
+				log.Error().Err(err).Msg("Couldn't refresh the channel flow cache.")
+			}
+			err = peer_scores.RefreshPeerScoreCache(db)
+			if err != nil {
+				log.Error().Err(err).Msg("Couldn't refresh the peer score cache.")
+			}
 		}
 	}
 }
 
+func downsampleChannelBalanceHistory(db *sqlx.DB) {
+	rowsAffected, err := channel_history.DownsampleChannelBalanceHistory(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Couldn't downsample the channel balance history.")
+		return
+	}
+	if rowsAffected != 0 {
+		log.Info().Msgf("%v downsampled channel balance history records created.", rowsAffected)
+	}
+}
+
+func applyDataRetentionPolicies(db *sqlx.DB) {
+	err := data_retention.ApplyPolicies(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Couldn't apply the data retention policies.")
+	}
+}
+
 func deleteWorkflowLogs(db *sqlx.DB) {
 	res, err := db.Exec(`DELETE FROM workflow_version_node_log WHERE created_on < $1`,
 		time.Now().Add(7*24*time.Hour))
@@ -1,7 +1,6 @@
 package automation
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/cockroachdb/errors"
@@ -12,6 +11,7 @@ import (
 	"github.com/lncapital/torq/internal/lightning_helpers"
 	"github.com/lncapital/torq/internal/workflows"
 	"github.com/lncapital/torq/pkg/server_errors"
+	"github.com/lncapital/torq/pkg/tracing"
 )
 
 func RegisterAutomationRoutes(r *gin.RouterGroup, db *sqlx.DB) {
@@ -33,7 +33,7 @@ func rebalanceHandler(c *gin.Context, db *sqlx.DB) {
 		return
 	}
 
-	response := workflows.RebalanceRequests(context.Background(), db, rr, rr.NodeId)
+	response := workflows.RebalanceRequests(tracing.Detach(c.Request.Context()), db, rr, rr.NodeId)
 	if len(response) > 0 && response[0].Error != "" {
 		server_errors.SendBadRequest(c, response[0].Error)
 		return
@@ -8,16 +8,17 @@ import (
 
 	"github.com/jmoiron/sqlx"
 	"github.com/robfig/cron/v3"
-	"github.com/rs/zerolog/log"
 
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/internal/workflow_helpers"
 	"github.com/lncapital/torq/proto/lnrpc"
 
 	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/channel_history"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/lnd"
 	"github.com/lncapital/torq/internal/workflows"
+	"github.com/lncapital/torq/pkg/tracing"
 )
 
 const workflowTickerSeconds = 10
@@ -274,6 +275,11 @@ func ChannelBalanceEventTriggerMonitor(ctx context.Context, db *sqlx.DB) {
 			if channelBalanceEvent.NodeId == 0 || channelBalanceEvent.ChannelId == 0 {
 				continue
 			}
+			err := channel_history.AddChannelBalanceEventSnapshot(db, channelBalanceEvent)
+			if err != nil {
+				log.Error().Err(err).Msgf("Failed to store channel balance snapshot for channelId: %v",
+					channelBalanceEvent.ChannelId)
+			}
 			processEventTrigger(db, channelBalanceEvent, workflow_helpers.WorkflowNodeChannelBalanceEventTrigger)
 		}
 	}
@@ -322,7 +328,12 @@ func processWorkflowNode(ctx context.Context, db *sqlx.DB,
 	reference string,
 	events []any) {
 
+	ctx, span := tracing.StartSpan(ctx, "workflow trigger",
+		tracing.WorkflowVersionIdKey.Int(workflowTriggerNode.WorkflowVersionId),
+		tracing.WorkflowVersionNodeIdKey.Int(workflowTriggerNode.WorkflowVersionNodeId),
+		tracing.WorkflowNodeTypeKey.Int(int(workflowTriggerNode.Type)))
 	err := workflows.ProcessWorkflow(ctx, db, workflowTriggerNode, reference, events)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		log.Error().Err(err).Msgf(
 			"ScheduledTriggerMonitor failed to trigger nodes for WorkflowVersionNodeId: %v",
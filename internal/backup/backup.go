@@ -0,0 +1,207 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/database"
+)
+
+// Backup is a logical export of the configuration data of Torq. It can only be restored into a database with the same
+// migration version.
+type Backup struct {
+	Version        uint        `json:"version"`
+	CreatedOn      time.Time   `json:"createdOn"`
+	WithoutSecrets bool        `json:"withoutSecrets"`
+	Tables         []TableData `json:"tables"`
+}
+
+type TableData struct {
+	Name string          `json:"name"`
+	Rows json.RawMessage `json:"rows"`
+}
+
+type table struct {
+	name string
+	key  string
+	// The rows of the nodes and channels are referenced by the history so they are updated instead of replaced
+	upsert bool
+	// Removed from a backup without secrets, a restore without secrets keeps the current values
+	secretColumns []string
+	// Set to inactive in a backup without secrets (i.e. nodes can't connect without their credentials)
+	statusColumn string
+}
+
+// getTables returns the tables in the order they are restored (referenced tables first)
+func getTables() []table {
+	return []table{
+		{name: "node", key: "node_id", upsert: true},
+		{name: "channel", key: "channel_id", upsert: true},
+		{name: "node_connection_details", key: "node_id", upsert: true,
+			secretColumns: []string{"tls_data", "macaroon_data", "certificate_data", "key_data", "ca_certificate_data"},
+			statusColumn:  "status_id"},
+		{name: "settings", key: "settings_id", upsert: true,
+			secretColumns: []string{"slack_oauth_token", "slack_bot_app_token", "telegram_high_priority_credentials",
+				"telegram_low_priority_credentials", "secrets_key_check"}},
+		{name: "category", key: "category_id"},
+		{name: "tag", key: "tag_id"},
+		{name: "tagged_entity", key: "tagged_entity_id"},
+		{name: "corridor", key: "corridor_id"},
+		{name: "workflow", key: "workflow_id"},
+		{name: "workflow_version", key: "workflow_version_id"},
+		{name: "workflow_version_node", key: "workflow_version_node_id"},
+		{name: "workflow_version_node_link", key: "workflow_version_node_link_id"},
+		{name: "table_view", key: "table_view_id"},
+		{name: "table_view_column", key: "table_view_column_id"},
+		{name: "table_view_filter", key: "table_view_filter_id"},
+		{name: "table_view_sorting", key: "table_view_sorting_id"},
+	}
+}
+
+// Export exports the configuration data from one snapshot of the database
+func Export(db *sqlx.DB, withoutSecrets bool) (Backup, error) {
+	version, err := database.GetMigrationVersion(db)
+	if err != nil {
+		return Backup{}, err
+	}
+	tx, err := db.BeginTxx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return Backup{}, errors.Wrap(err, database.SqlBeginTransactionError)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	backup := Backup{Version: version, CreatedOn: time.Now().UTC(), WithoutSecrets: withoutSecrets}
+	for _, t := range getTables() {
+		var rows json.RawMessage
+		err = tx.Get(&rows, getExportSql(t, withoutSecrets))
+		if err != nil {
+			return Backup{}, errors.Wrapf(err, "Exporting %v", t.name)
+		}
+		backup.Tables = append(backup.Tables, TableData{Name: t.name, Rows: rows})
+	}
+	return backup, nil
+}
+
+func getExportSql(t table, withoutSecrets bool) string {
+	row := "to_jsonb(t)"
+	if withoutSecrets {
+		for _, column := range t.secretColumns {
+			row += fmt.Sprintf(" - '%v'", column)
+		}
+		if t.statusColumn != "" {
+			row += fmt.Sprintf(" || jsonb_build_object('%v', %v)", t.statusColumn, int(core.Inactive))
+		}
+	}
+	return fmt.Sprintf(`SELECT coalesce(jsonb_agg(%v ORDER BY t.%v), '[]')::text FROM %v t;`, row, t.key, t.name)
+}
+
+// Import restores the configuration data. The nodes, channels, node connection details and settings of the backup are
+// added or updated, the other tables are replaced (the workflow logs are removed as well). Torq must be stopped.
+func Import(db *sqlx.DB, backup Backup) error {
+	version, err := database.GetMigrationVersion(db)
+	if err != nil {
+		return err
+	}
+	if backup.Version != version {
+		return errors.Newf("The backup is from migration version %v and the database is at version %v, "+
+			"use migrate_up or migrate_down --to %v first", backup.Version, version, backup.Version)
+	}
+	rows := make(map[string]json.RawMessage)
+	for _, tableData := range backup.Tables {
+		rows[tableData.Name] = tableData.Rows
+	}
+	tables := getTables()
+	for _, t := range tables {
+		if _, exists := rows[t.name]; !exists {
+			return errors.Newf("The backup has no %v table", t.name)
+		}
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, database.SqlBeginTransactionError)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// The logs reference the workflow nodes and the channel groups reference the tags and categories
+	for _, name := range []string{"workflow_version_node_log", "channel_group"} {
+		_, err = tx.Exec(fmt.Sprintf(`DELETE FROM %v;`, name))
+		if err != nil {
+			return errors.Wrapf(err, "Deleting %v", name)
+		}
+	}
+	for i := len(tables) - 1; i >= 0; i-- {
+		if tables[i].upsert {
+			continue
+		}
+		_, err = tx.Exec(fmt.Sprintf(`DELETE FROM %v;`, tables[i].name))
+		if err != nil {
+			return errors.Wrapf(err, "Deleting %v", tables[i].name)
+		}
+	}
+	for _, t := range tables {
+		var columns []string
+		err = tx.Select(&columns, `
+			SELECT column_name
+			FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = $1
+			ORDER BY ordinal_position;`, t.name)
+		if err != nil {
+			return errors.Wrap(err, database.SqlExecutionError)
+		}
+		_, err = tx.Exec(getImportSql(t, columns, backup.WithoutSecrets), string(rows[t.name]))
+		if err != nil {
+			return errors.Wrapf(err, "Restoring %v", t.name)
+		}
+		_, err = tx.Exec(fmt.Sprintf(`
+			SELECT setval(pg_get_serial_sequence('%v', '%v'), coalesce(max(%v), 0) + 1, false) FROM %v;`,
+			t.name, t.key, t.key, t.name))
+		if err != nil {
+			return errors.Wrapf(err, "Updating the sequence of %v", t.name)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, database.SqlCommitTransactionError)
+	}
+	return nil
+}
+
+func getImportSql(t table, columns []string, withoutSecrets bool) string {
+	query := fmt.Sprintf(`INSERT INTO %v SELECT * FROM jsonb_populate_recordset(NULL::%v, $1::jsonb)`, t.name, t.name)
+	if !t.upsert {
+		return query + ";"
+	}
+	var updates []string
+	for _, column := range columns {
+		if column == t.key || (withoutSecrets && (column == t.statusColumn || contains(t.secretColumns, column))) {
+			continue
+		}
+		updates = append(updates, fmt.Sprintf(`"%v" = EXCLUDED."%v"`, column, column))
+	}
+	if len(updates) == 0 {
+		return fmt.Sprintf(`%v ON CONFLICT (%v) DO NOTHING;`, query, t.key)
+	}
+	return fmt.Sprintf(`%v ON CONFLICT (%v) DO UPDATE SET %v;`, query, t.key, strings.Join(updates, ", "))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package backup
+
+import (
+	"testing"
+)
+
+func TestGetExportSql(t *testing.T) {
+	ncd := getTable(t, "node_connection_details")
+	expected := `SELECT coalesce(jsonb_agg(to_jsonb(t) ORDER BY t.node_id), '[]')::text FROM node_connection_details t;`
+	if query := getExportSql(ncd, false); query != expected {
+		t.Errorf("getExportSql() = %v, expected %v", query, expected)
+	}
+	expected = `SELECT coalesce(jsonb_agg(to_jsonb(t) - 'tls_data' - 'macaroon_data' - 'certificate_data' - 'key_data' - ` +
+		`'ca_certificate_data' || jsonb_build_object('status_id', 0) ORDER BY t.node_id), '[]')::text ` +
+		`FROM node_connection_details t;`
+	if query := getExportSql(ncd, true); query != expected {
+		t.Errorf("getExportSql() without secrets = %v, expected %v", query, expected)
+	}
+}
+
+func TestGetImportSql(t *testing.T) {
+	columns := []string{"node_id", "name", "macaroon_data", "status_id"}
+	tests := []struct {
+		name           string
+		table          string
+		withoutSecrets bool
+		expected       string
+	}{
+		{"Replaced", "tag", false,
+			`INSERT INTO tag SELECT * FROM jsonb_populate_recordset(NULL::tag, $1::jsonb);`},
+		{"Updated", "node_connection_details", false,
+			`INSERT INTO node_connection_details SELECT * FROM jsonb_populate_recordset(NULL::node_connection_details, ` +
+				`$1::jsonb) ON CONFLICT (node_id) DO UPDATE SET "name" = EXCLUDED."name", ` +
+				`"macaroon_data" = EXCLUDED."macaroon_data", "status_id" = EXCLUDED."status_id";`},
+		{"Updated without secrets", "node_connection_details", true,
+			`INSERT INTO node_connection_details SELECT * FROM jsonb_populate_recordset(NULL::node_connection_details, ` +
+				`$1::jsonb) ON CONFLICT (node_id) DO UPDATE SET "name" = EXCLUDED."name";`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query := getImportSql(getTable(t, test.table), columns, test.withoutSecrets)
+			if query != test.expected {
+				t.Errorf("getImportSql() = %v, expected %v", query, test.expected)
+			}
+		})
+	}
+}
+
+func TestTableOrder(t *testing.T) {
+	restored := make(map[string]bool)
+	references := map[string][]string{
+		"channel":                    {"node"},
+		"node_connection_details":    {"node"},
+		"tag":                        {"category"},
+		"tagged_entity":              {"tag", "node", "channel"},
+		"corridor":                   {"category", "tag", "node", "channel"},
+		"workflow_version":           {"workflow"},
+		"workflow_version_node":      {"workflow_version"},
+		"workflow_version_node_link": {"workflow_version", "workflow_version_node"},
+		"table_view_column":          {"table_view"},
+		"table_view_filter":          {"table_view"},
+		"table_view_sorting":         {"table_view"},
+	}
+	for _, table := range getTables() {
+		for _, referenced := range references[table.name] {
+			if !restored[referenced] {
+				t.Errorf("%v is restored before %v", table.name, referenced)
+			}
+		}
+		restored[table.name] = true
+	}
+}
+
+func getTable(t *testing.T, name string) table {
+	for _, table := range getTables() {
+		if table.name == name {
+			return table
+		}
+	}
+	t.Fatalf("Table %v not found", name)
+	return table{}
+}
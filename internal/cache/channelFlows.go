@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+var ChannelFlowsCacheChannel = make(chan ChannelFlowCache) //nolint:gochecknoglobals
+
+type ChannelFlowCacheOperationType uint
+
+const (
+	readChannelFlow ChannelFlowCacheOperationType = iota
+	writeChannelFlows
+)
+
+type ChannelFlowCache struct {
+	Type         ChannelFlowCacheOperationType
+	NodeId       int
+	ChannelId    int
+	ChannelFlows []ChannelFlowSettingsCache
+	Out          chan<- *ChannelFlowSettingsCache
+}
+
+// ChannelFlowSettingsCache contains the forwarded amounts of a channel from the point of view of a torq node.
+type ChannelFlowSettingsCache struct {
+	NodeId    int `json:"nodeId"`
+	ChannelId int `json:"channelId"`
+	// Amount in sats (Satoshis) that entered the torq node through this channel (increasing the local balance)
+	AmountIn int64 `json:"amountIn"`
+	// Amount in sats (Satoshis) that left the torq node through this channel (decreasing the local balance)
+	AmountOut int64     `json:"amountOut"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+}
+
+func ChannelFlowsCacheHandler(ch <-chan ChannelFlowCache, ctx context.Context) {
+	channelFlowsCache := make(map[nodeIdType]map[channelIdType]ChannelFlowSettingsCache, 0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case channelFlowCache := <-ch:
+			channelFlowsCache = handleChannelFlowOperation(channelFlowCache, channelFlowsCache)
+		}
+	}
+}
+
+func handleChannelFlowOperation(channelFlowCache ChannelFlowCache,
+	channelFlowsCache map[nodeIdType]map[channelIdType]ChannelFlowSettingsCache) map[nodeIdType]map[channelIdType]ChannelFlowSettingsCache {
+
+	switch channelFlowCache.Type {
+	case readChannelFlow:
+		if channelFlowCache.NodeId == 0 || channelFlowCache.ChannelId == 0 {
+			log.Error().Msgf("No empty ChannelId (%v) nor NodeId (%v) allowed",
+				channelFlowCache.ChannelId, channelFlowCache.NodeId)
+			channelFlowCache.Out <- nil
+			break
+		}
+		channelFlow, exists := channelFlowsCache[nodeIdType(channelFlowCache.NodeId)][channelIdType(channelFlowCache.ChannelId)]
+		if !exists {
+			channelFlowCache.Out <- nil
+			break
+		}
+		channelFlowCache.Out <- &channelFlow
+	case writeChannelFlows:
+		// The flows are always replaced as a whole so channels without forwards lose their stale flow
+		channelFlowsCache = make(map[nodeIdType]map[channelIdType]ChannelFlowSettingsCache, 0)
+		for _, channelFlow := range channelFlowCache.ChannelFlows {
+			if channelFlow.NodeId == 0 || channelFlow.ChannelId == 0 {
+				continue
+			}
+			if channelFlowsCache[nodeIdType(channelFlow.NodeId)] == nil {
+				channelFlowsCache[nodeIdType(channelFlow.NodeId)] = make(map[channelIdType]ChannelFlowSettingsCache)
+			}
+			channelFlowsCache[nodeIdType(channelFlow.NodeId)][channelIdType(channelFlow.ChannelId)] = channelFlow
+		}
+	}
+	return channelFlowsCache
+}
+
+func GetChannelFlow(nodeId int, channelId int) *ChannelFlowSettingsCache {
+	channelFlowResponseChannel := make(chan *ChannelFlowSettingsCache)
+	channelFlowCache := ChannelFlowCache{
+		NodeId:    nodeId,
+		ChannelId: channelId,
+		Type:      readChannelFlow,
+		Out:       channelFlowResponseChannel,
+	}
+	ChannelFlowsCacheChannel <- channelFlowCache
+	return <-channelFlowResponseChannel
+}
+
+func SetChannelFlows(channelFlows []ChannelFlowSettingsCache) {
+	channelFlowCache := ChannelFlowCache{
+		ChannelFlows: channelFlows,
+		Type:         writeChannelFlows,
+	}
+	ChannelFlowsCacheChannel <- channelFlowCache
+}
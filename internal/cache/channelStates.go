@@ -4,9 +4,9 @@ import (
 	"context"
 	"time"
 
-	"github.com/rs/zerolog/log"
 	"golang.org/x/exp/slices"
 
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/proto/lnrpc"
 
 	"github.com/lncapital/torq/internal/core"
@@ -47,32 +47,32 @@ const (
 type ChannelBalanceStateHtlcInclude uint
 
 const (
-	// pendingHtlcsLocalBalanceAdjustedDownwards:
+	// PendingHtlcsLocalBalanceAdjustedDownwards:
 	//   LocalBalance = ConfirmedLocalBalance - PendingDecreasingForwardHTLCsAmount - PendingPaymentHTLCsAmount
-	pendingHtlcsLocalBalanceAdjustedDownwards ChannelBalanceStateHtlcInclude = iota
-	// pendingHtlcsRemoteBalanceAdjustedDownwards:
+	PendingHtlcsLocalBalanceAdjustedDownwards ChannelBalanceStateHtlcInclude = iota
+	// PendingHtlcsRemoteBalanceAdjustedDownwards:
 	//   RemoteBalance = ConfirmedRemoteBalance - PendingIncreasingForwardHTLCsAmount - PendingInvoiceHTLCsAmount
-	pendingHtlcsRemoteBalanceAdjustedDownwards
-	// pendingHtlcsLocalAndRemoteBalanceAdjustedDownwards:
+	PendingHtlcsRemoteBalanceAdjustedDownwards
+	// PendingHtlcsLocalAndRemoteBalanceAdjustedDownwards:
 	//   LocalBalance = ConfirmedLocalBalance - PendingDecreasingForwardHTLCsAmount - PendingPaymentHTLCsAmount
 	//   RemoteBalance = ConfirmedRemoteBalance - PendingIncreasingForwardHTLCsAmount - PendingInvoiceHTLCsAmount
-	pendingHtlcsLocalAndRemoteBalanceAdjustedDownwards
-	// pendingHtlcsLocalBalanceAdjustedUpwards:
+	PendingHtlcsLocalAndRemoteBalanceAdjustedDownwards
+	// PendingHtlcsLocalBalanceAdjustedUpwards:
 	//   LocalBalance = ConfirmedLocalBalance + PendingIncreasingForwardHTLCsAmount + PendingInvoiceHTLCsAmount
-	pendingHtlcsLocalBalanceAdjustedUpwards
+	PendingHtlcsLocalBalanceAdjustedUpwards
 	//   RemoteBalance = ConfirmedRemoteBalance + PendingDecreasingForwardHTLCsAmount + PendingPaymentHTLCsAmount
-	pendingHtlcsRemoteBalanceAdjustedUpwards
-	// pendingHtlcsLocalAndRemoteBalanceAdjustedUpwards:
+	PendingHtlcsRemoteBalanceAdjustedUpwards
+	// PendingHtlcsLocalAndRemoteBalanceAdjustedUpwards:
 	//   LocalBalance = ConfirmedLocalBalance + PendingIncreasingForwardHTLCsAmount + PendingInvoiceHTLCsAmount
 	//   RemoteBalance = ConfirmedRemoteBalance + PendingDecreasingForwardHTLCsAmount + PendingPaymentHTLCsAmount
-	pendingHtlcsLocalAndRemoteBalanceAdjustedUpwards
+	PendingHtlcsLocalAndRemoteBalanceAdjustedUpwards
 )
 
 type ChannelStateInclude uint
 
 const (
-	allLocalAndRemoteActiveChannels ChannelStateInclude = iota
-	allChannels
+	AllLocalAndRemoteActiveChannels ChannelStateInclude = iota
+	AllChannels
 )
 
 type Htlc struct {
@@ -336,10 +336,10 @@ func handleChannelStateSettingsOperation(channelStateCache ChannelStateCache,
 					log.Error().Msgf("Channel from channel cache that doesn't exist in channelState cache.")
 					continue
 				}
-				if settings.LocalDisabled && channelStateCache.StateInclude != allChannels {
+				if settings.LocalDisabled && channelStateCache.StateInclude != AllChannels {
 					continue
 				}
-				if settings.RemoteDisabled && channelStateCache.StateInclude == allLocalAndRemoteActiveChannels {
+				if settings.RemoteDisabled && channelStateCache.StateInclude == AllLocalAndRemoteActiveChannels {
 					continue
 				}
 				capacity := channelSetting.Capacity
@@ -413,6 +413,7 @@ func handleChannelStateSettingsOperation(channelStateCache ChannelStateCache,
 					}
 					if channelBalanceEvent.BalanceDelta != 0 {
 						ChannelBalanceChanges <- channelBalanceEvent
+						PublishEvent(channelBalanceEvent)
 					}
 				}
 			}
@@ -499,7 +500,8 @@ func handleChannelStateSettingsOperation(channelStateCache ChannelStateCache,
 				}
 			}
 		} else {
-			log.Error().Msgf("Received channel event for uncached node with nodeId: %v", channelStateCache.NodeId)
+			log.Error().Int(logging.NodeIdField, channelStateCache.NodeId).Msg(
+				"Received channel event for uncached node")
 		}
 	case writeChannelStateRoutingPolicy:
 		if !isNodeReady(channelStateSettingsStatusCache, channelStateCache.NodeId,
@@ -535,7 +537,8 @@ func handleChannelStateSettingsOperation(channelStateCache ChannelStateCache,
 				}
 			}
 		} else {
-			log.Error().Msgf("Received channel graph event for uncached node with nodeId: %v", channelStateCache.NodeId)
+			log.Error().Int(logging.NodeIdField, channelStateCache.NodeId).Msg(
+				"Received channel graph event for uncached node")
 		}
 	case writeChannelStateUpdateBalance:
 		if channelStateCache.ChannelId == 0 || channelStateCache.NodeId == 0 {
@@ -592,6 +595,7 @@ func handleChannelStateSettingsOperation(channelStateCache ChannelStateCache,
 					PeerLocalBalancePerMilleRatio: int(channelStateSetting.PeerLocalBalance / channelStateSetting.PeerChannelCapacity * 1000),
 				}
 				ChannelBalanceChanges <- channelBalanceEvent
+				PublishEvent(channelBalanceEvent)
 			} else {
 				channelSettings := GetChannelSettingByChannelId(channelStateCache.ChannelId)
 				if channelSettings.Status == core.Open {
@@ -599,7 +603,8 @@ func handleChannelStateSettingsOperation(channelStateCache ChannelStateCache,
 				}
 			}
 		} else {
-			log.Error().Msgf("Received channel balance update for uncached node with nodeId: %v", channelStateCache.NodeId)
+			log.Error().Int(logging.NodeIdField, channelStateCache.NodeId).Msg(
+				"Received channel balance update for uncached node")
 		}
 	case writeChannelStateUpdateHtlcEvent:
 		if (channelStateCache.HtlcEvent.OutgoingChannelId == nil || *channelStateCache.HtlcEvent.OutgoingChannelId == 0) &&
@@ -680,7 +685,8 @@ func handleChannelStateSettingsOperation(channelStateCache ChannelStateCache,
 				}
 			}
 		} else {
-			log.Error().Msgf("Received HTLC channel balance update for uncached node with nodeId: %v", channelStateCache.HtlcEvent.NodeId)
+			log.Error().Int(logging.NodeIdField, channelStateCache.HtlcEvent.NodeId).Msg(
+				"Received HTLC channel balance update for uncached node")
 		}
 	case removeChannelStateFromCache:
 		for nodeId := range channelStateSettingsByChannelIdCache {
@@ -777,7 +783,7 @@ func GetChannelBalanceStates(nodeId int, forceResponse bool, channelStateInclude
 		ForceResponse:    forceResponse,
 		HtlcInclude:      htlcInclude,
 		StateInclude:     channelStateInclude,
-		Type:             readChannelBalanceState,
+		Type:             readAllChannelBalanceStates,
 		BalanceStatesOut: channelBalanceStateResponseChannel,
 	}
 	ChannelStatesCacheChannel <- channelStateCache
@@ -902,7 +908,8 @@ func isNodeReady(channelStateSettingsStatusCache map[nodeIdType]core.Status, nod
 	if channelStateSettingsStatusCache[nodeIdType(nodeId)] != core.Active {
 		deactivationTime, exists := channelStateSettingsDeactivationTimeCache[nodeIdType(nodeId)]
 		if exists && time.Since(deactivationTime).Seconds() < toleratedSubscriptionDowntimeSeconds {
-			log.Debug().Msgf("Node flagged as active even tough subscription is temporary down for nodeId: %v", nodeId)
+			log.Debug().Int(logging.NodeIdField, nodeId).Msg(
+				"Node flagged as active even tough subscription is temporary down")
 		} else if !forceResponse {
 			return false
 		}
@@ -913,20 +920,20 @@ func isNodeReady(channelStateSettingsStatusCache map[nodeIdType]core.Status, nod
 func processHtlcInclude(channelStateCache ChannelStateCache, settings ChannelStateSettingsCache, capacity int64) ChannelBalanceStateSettingsCache {
 	localBalance := settings.LocalBalance
 	remoteBalance := settings.RemoteBalance
-	if channelStateCache.HtlcInclude == pendingHtlcsLocalBalanceAdjustedDownwards ||
-		channelStateCache.HtlcInclude == pendingHtlcsLocalAndRemoteBalanceAdjustedDownwards {
+	if channelStateCache.HtlcInclude == PendingHtlcsLocalBalanceAdjustedDownwards ||
+		channelStateCache.HtlcInclude == PendingHtlcsLocalAndRemoteBalanceAdjustedDownwards {
 		localBalance = settings.LocalBalance - settings.PendingOutgoingHtlcAmount
 	}
-	if channelStateCache.HtlcInclude == pendingHtlcsRemoteBalanceAdjustedDownwards ||
-		channelStateCache.HtlcInclude == pendingHtlcsLocalAndRemoteBalanceAdjustedDownwards {
+	if channelStateCache.HtlcInclude == PendingHtlcsRemoteBalanceAdjustedDownwards ||
+		channelStateCache.HtlcInclude == PendingHtlcsLocalAndRemoteBalanceAdjustedDownwards {
 		remoteBalance = settings.RemoteBalance - settings.PendingIncomingHtlcAmount
 	}
-	if channelStateCache.HtlcInclude == pendingHtlcsLocalBalanceAdjustedUpwards ||
-		channelStateCache.HtlcInclude == pendingHtlcsLocalAndRemoteBalanceAdjustedUpwards {
+	if channelStateCache.HtlcInclude == PendingHtlcsLocalBalanceAdjustedUpwards ||
+		channelStateCache.HtlcInclude == PendingHtlcsLocalAndRemoteBalanceAdjustedUpwards {
 		localBalance = settings.LocalBalance + settings.PendingIncomingHtlcAmount
 	}
-	if channelStateCache.HtlcInclude == pendingHtlcsRemoteBalanceAdjustedUpwards ||
-		channelStateCache.HtlcInclude == pendingHtlcsLocalAndRemoteBalanceAdjustedUpwards {
+	if channelStateCache.HtlcInclude == PendingHtlcsRemoteBalanceAdjustedUpwards ||
+		channelStateCache.HtlcInclude == PendingHtlcsLocalAndRemoteBalanceAdjustedUpwards {
 		remoteBalance = settings.RemoteBalance + settings.PendingOutgoingHtlcAmount
 	}
 	return ChannelBalanceStateSettingsCache{
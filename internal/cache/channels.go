@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
-	"github.com/rs/zerolog/log"
 
 	"github.com/lncapital/torq/internal/core"
 )
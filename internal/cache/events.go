@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"context"
+)
+
+var EventsCacheChannel = make(chan EventCache) //nolint:gochecknoglobals
+
+// The number of events a subscriber can fall behind before it's unsubscribed,
+// this is also the number of events that are kept to resume a subscription.
+const eventSubscriptionBufferSize = 1000
+
+type EventCacheOperationType uint
+
+const (
+	subscribeEvents EventCacheOperationType = iota
+	unsubscribeEvents
+	publishEvent
+)
+
+type EventCache struct {
+	Type           EventCacheOperationType
+	SubscriptionId int
+	EventId        uint64
+	Event          any
+	Out            chan<- EventSubscription
+}
+
+// PublishedEvent is an event with its ID, IDs increase with every published event and restart with Torq.
+type PublishedEvent struct {
+	EventId uint64
+	Event   any
+}
+
+type EventSubscription struct {
+	SubscriptionId int
+	// Missed is set when a subscription is resumed but (some) events since the requested event ID are no longer kept
+	Missed bool
+	// Events is closed when the subscription ends, either by UnsubscribeEvents or because the subscriber fell behind
+	Events <-chan PublishedEvent
+}
+
+type eventsState struct {
+	subscriptions      map[int]chan PublishedEvent
+	lastSubscriptionId int
+	lastEventId        uint64
+	// history are the most recent events (oldest first) to resume subscriptions
+	history []PublishedEvent
+}
+
+func EventsCacheHandler(ch <-chan EventCache, ctx context.Context) {
+	state := eventsState{subscriptions: make(map[int]chan PublishedEvent)}
+	for {
+		select {
+		case <-ctx.Done():
+			for _, events := range state.subscriptions {
+				close(events)
+			}
+			return
+		case eventCache := <-ch:
+			handleEventOperation(eventCache, &state)
+		}
+	}
+}
+
+func handleEventOperation(eventCache EventCache, state *eventsState) {
+	switch eventCache.Type {
+	case subscribeEvents:
+		state.lastSubscriptionId++
+		events := make(chan PublishedEvent, eventSubscriptionBufferSize)
+		state.subscriptions[state.lastSubscriptionId] = events
+		subscription := EventSubscription{SubscriptionId: state.lastSubscriptionId, Events: events}
+		if eventCache.EventId != 0 {
+			subscription.Missed = eventCache.EventId > state.lastEventId ||
+				(len(state.history) != 0 && eventCache.EventId+1 < state.history[0].EventId)
+			for _, event := range state.history {
+				if event.EventId > eventCache.EventId {
+					events <- event
+				}
+			}
+		}
+		eventCache.Out <- subscription
+	case unsubscribeEvents:
+		events, exists := state.subscriptions[eventCache.SubscriptionId]
+		if exists {
+			close(events)
+			delete(state.subscriptions, eventCache.SubscriptionId)
+		}
+	case publishEvent:
+		state.lastEventId++
+		event := PublishedEvent{EventId: state.lastEventId, Event: eventCache.Event}
+		state.history = append(state.history, event)
+		if len(state.history) > eventSubscriptionBufferSize {
+			state.history = state.history[len(state.history)-eventSubscriptionBufferSize:]
+		}
+		for subscriptionId, events := range state.subscriptions {
+			select {
+			case events <- event:
+			default:
+				log.Warn().Msgf("Event subscription %v is not keeping up and is unsubscribed", subscriptionId)
+				close(events)
+				delete(state.subscriptions, subscriptionId)
+			}
+		}
+	}
+}
+
+// SubscribeEvents returns a subscription to the events (i.e. core.ForwardEvent) as they are processed.
+// When afterEventId is not 0 the kept events since that event are sent first.
+func SubscribeEvents(afterEventId uint64) EventSubscription {
+	eventSubscriptionResponseChannel := make(chan EventSubscription)
+	EventsCacheChannel <- EventCache{
+		Type:    subscribeEvents,
+		EventId: afterEventId,
+		Out:     eventSubscriptionResponseChannel,
+	}
+	return <-eventSubscriptionResponseChannel
+}
+
+func UnsubscribeEvents(subscriptionId int) {
+	EventsCacheChannel <- EventCache{
+		Type:           unsubscribeEvents,
+		SubscriptionId: subscriptionId,
+	}
+}
+
+func PublishEvent(event any) {
+	EventsCacheChannel <- EventCache{
+		Type:  publishEvent,
+		Event: event,
+	}
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"testing"
+)
+
+func TestEventSubscriptionResume(t *testing.T) {
+	state := eventsState{subscriptions: make(map[int]chan PublishedEvent)}
+	for i := 0; i < eventSubscriptionBufferSize+10; i++ {
+		handleEventOperation(EventCache{Type: publishEvent, Event: i}, &state)
+	}
+	if len(state.history) != eventSubscriptionBufferSize {
+		t.Fatalf("got history of %v events, want %v", len(state.history), eventSubscriptionBufferSize)
+	}
+
+	testCases := []struct {
+		name         string
+		afterEventId uint64
+		missed       bool
+		replayed     int
+		firstEventId uint64
+	}{
+		{"live only", 0, false, 0, 0},
+		{"resume from kept event", eventSubscriptionBufferSize + 5, false, 5, eventSubscriptionBufferSize + 6},
+		{"resume from oldest kept event", 10, false, eventSubscriptionBufferSize, 11},
+		{"resume from dropped event", 5, true, eventSubscriptionBufferSize, 11},
+		{"resume from unknown event (restart)", eventSubscriptionBufferSize + 20, true, 0, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := make(chan EventSubscription, 1)
+			handleEventOperation(EventCache{Type: subscribeEvents, EventId: tc.afterEventId, Out: out}, &state)
+			subscription := <-out
+			defer handleEventOperation(
+				EventCache{Type: unsubscribeEvents, SubscriptionId: subscription.SubscriptionId}, &state)
+
+			if subscription.Missed != tc.missed {
+				t.Errorf("got missed %v, want %v", subscription.Missed, tc.missed)
+			}
+			if len(subscription.Events) != tc.replayed {
+				t.Fatalf("got %v replayed events, want %v", len(subscription.Events), tc.replayed)
+			}
+			if tc.replayed != 0 {
+				if event := <-subscription.Events; event.EventId != tc.firstEventId {
+					t.Errorf("got first eventId %v, want %v", event.EventId, tc.firstEventId)
+				}
+			}
+		})
+	}
+}
+
+func TestEventSubscriptionFallingBehind(t *testing.T) {
+	state := eventsState{subscriptions: make(map[int]chan PublishedEvent)}
+	out := make(chan EventSubscription, 1)
+	handleEventOperation(EventCache{Type: subscribeEvents, Out: out}, &state)
+	subscription := <-out
+
+	for i := 0; i <= eventSubscriptionBufferSize; i++ {
+		handleEventOperation(EventCache{Type: publishEvent, Event: i}, &state)
+	}
+
+	if len(state.subscriptions) != 0 {
+		t.Errorf("got %v subscriptions, want the subscription that fell behind to be removed", len(state.subscriptions))
+	}
+	received := 0
+	for range subscription.Events {
+		received++
+	}
+	if received != eventSubscriptionBufferSize {
+		t.Errorf("got %v events before the subscription was closed, want %v", received, eventSubscriptionBufferSize)
+	}
+}
@@ -3,7 +3,7 @@ package cache
 import (
 	"context"
 
-	"github.com/rs/zerolog/log"
+	"github.com/lncapital/torq/internal/logging"
 )
 
 var NodeAliasesCacheChannel = make(chan NodeAliasCache) //nolint:gochecknoglobals
@@ -54,7 +54,7 @@ func handleNodeAliasOperation(nodeAliasCache NodeAliasCache, nodeAliasesByNodeId
 			break
 		}
 		if nodeAliasCache.Alias == "" {
-			log.Debug().Msgf("No empty Alias allowed (nodeId: %v)", nodeAliasCache.NodeId)
+			log.Debug().Int(logging.NodeIdField, nodeAliasCache.NodeId).Msg("No empty Alias allowed")
 			break
 		}
 		nodeAliasesByNodeIdCache[nodeIdType(nodeAliasCache.NodeId)] = nodeAliasCache.Alias
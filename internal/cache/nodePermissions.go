@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/lncapital/torq/internal/services_helpers"
+)
+
+var NodePermissionsCacheChannel = make(chan NodePermissionsCache) //nolint:gochecknoglobals
+
+type NodePermissionsCacheOperationType uint
+
+const (
+	readNodePermissions NodePermissionsCacheOperationType = iota
+	writeNodePermissions
+	readDeniedServices
+	addDeniedService
+	removeDeniedServices
+)
+
+type NodePermissionsCache struct {
+	Type            NodePermissionsCacheOperationType
+	NodeId          int
+	NodePermissions NodePermissions
+	ServiceType     services_helpers.ServiceType
+	Out             chan<- *NodePermissions
+	ServiceTypesOut chan<- []services_helpers.ServiceType
+}
+
+// NodePermissions are the permissions of the macaroon Torq uses to connect to an LND node
+type NodePermissions struct {
+	// The macaroon (as stored) the permissions were checked for
+	MacaroonFileBytes []byte
+	// False when LND could not check the permissions, nothing is denied then
+	Checked bool
+	// The LND RPC methods used by Torq that the macaroon is not allowed to call
+	DeniedMethods []string
+	// The permissions (entity:action) the macaroon grants
+	GrantedPermissions []string
+	// The permissions (entity:action) LND requires for each RPC method
+	MethodPermissions map[string][]string
+	CheckedOn         time.Time
+}
+
+func NodePermissionsCacheHandler(ch <-chan NodePermissionsCache, ctx context.Context) {
+	nodePermissionsCache := make(map[nodeIdType]NodePermissions, 0)
+	deniedServicesCache := make(map[nodeIdType][]services_helpers.ServiceType, 0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case nodePermissionCache := <-ch:
+			handleNodePermissionsOperation(nodePermissionCache, nodePermissionsCache, deniedServicesCache)
+		}
+	}
+}
+
+func handleNodePermissionsOperation(nodePermissionsCache NodePermissionsCache,
+	nodePermissionsCacheByNodeId map[nodeIdType]NodePermissions,
+	deniedServicesByNodeId map[nodeIdType][]services_helpers.ServiceType) {
+
+	nodeId := nodeIdType(nodePermissionsCache.NodeId)
+	switch nodePermissionsCache.Type {
+	case readNodePermissions:
+		nodePermissions, exists := nodePermissionsCacheByNodeId[nodeId]
+		if !exists {
+			nodePermissionsCache.Out <- nil
+			break
+		}
+		nodePermissionsCache.Out <- &nodePermissions
+	case writeNodePermissions:
+		nodePermissionsCacheByNodeId[nodeId] = nodePermissionsCache.NodePermissions
+	case readDeniedServices:
+		nodePermissionsCache.ServiceTypesOut <- slices.Clone(deniedServicesByNodeId[nodeId])
+	case addDeniedService:
+		if !slices.Contains(deniedServicesByNodeId[nodeId], nodePermissionsCache.ServiceType) {
+			deniedServicesByNodeId[nodeId] = append(deniedServicesByNodeId[nodeId], nodePermissionsCache.ServiceType)
+		}
+	case removeDeniedServices:
+		nodePermissionsCache.ServiceTypesOut <- deniedServicesByNodeId[nodeId]
+		delete(deniedServicesByNodeId, nodeId)
+	}
+}
+
+// GetNodePermissions returns nil when the permissions were not checked yet
+func GetNodePermissions(nodeId int) *NodePermissions {
+	nodePermissionsResponseChannel := make(chan *NodePermissions)
+	NodePermissionsCacheChannel <- NodePermissionsCache{
+		Type:   readNodePermissions,
+		NodeId: nodeId,
+		Out:    nodePermissionsResponseChannel,
+	}
+	return <-nodePermissionsResponseChannel
+}
+
+func SetNodePermissions(nodeId int, nodePermissions NodePermissions) {
+	NodePermissionsCacheChannel <- NodePermissionsCache{
+		Type:            writeNodePermissions,
+		NodeId:          nodeId,
+		NodePermissions: nodePermissions,
+	}
+}
+
+// GetDeniedServices returns the services of the node that are inactive because the macaroon does not allow them
+func GetDeniedServices(nodeId int) []services_helpers.ServiceType {
+	serviceTypesResponseChannel := make(chan []services_helpers.ServiceType)
+	NodePermissionsCacheChannel <- NodePermissionsCache{
+		Type:            readDeniedServices,
+		NodeId:          nodeId,
+		ServiceTypesOut: serviceTypesResponseChannel,
+	}
+	return <-serviceTypesResponseChannel
+}
+
+// SetDeniedService deactivates the service of the node because the macaroon does not allow it,
+// it's activated again when the node connection details change (see RestartNodeServices).
+func SetDeniedService(serviceType services_helpers.ServiceType, nodeId int) {
+	NodePermissionsCacheChannel <- NodePermissionsCache{
+		Type:        addDeniedService,
+		NodeId:      nodeId,
+		ServiceType: serviceType,
+	}
+	SetInactiveNodeServiceState(serviceType, nodeId)
+	SetDesiredNodeServiceState(serviceType, nodeId, services_helpers.Inactive)
+}
+
+// popDeniedServices returns the denied services of the node and forgets them
+func popDeniedServices(nodeId int) []services_helpers.ServiceType {
+	serviceTypesResponseChannel := make(chan []services_helpers.ServiceType)
+	NodePermissionsCacheChannel <- NodePermissionsCache{
+		Type:            removeDeniedServices,
+		NodeId:          nodeId,
+		ServiceTypesOut: serviceTypesResponseChannel,
+	}
+	return <-serviceTypesResponseChannel
+}
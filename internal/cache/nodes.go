@@ -3,7 +3,6 @@ package cache
 import (
 	"context"
 
-	"github.com/rs/zerolog/log"
 	"golang.org/x/exp/slices"
 
 	"github.com/lncapital/torq/internal/core"
@@ -513,6 +512,22 @@ func GetPeerNodeIds(chain core.Chain, network core.Network) []int {
 	return nodeIds
 }
 
+// GetChannelPeerNodeIdsByTorqNodeIds returns the peers that have an open channel with one of the Torq nodes
+func GetChannelPeerNodeIdsByTorqNodeIds(torqNodeIds []int) []int {
+	var peerNodeIds []int
+	for _, torqNodeId := range torqNodeIds {
+		for _, channelId := range GetChannelStateChannelIds(torqNodeId, true) {
+			channelSettings := GetChannelSettingByChannelId(channelId)
+			for _, nodeId := range []int{channelSettings.FirstNodeId, channelSettings.SecondNodeId} {
+				if nodeId != torqNodeId && !slices.Contains(peerNodeIds, nodeId) {
+					peerNodeIds = append(peerNodeIds, nodeId)
+				}
+			}
+		}
+	}
+	return peerNodeIds
+}
+
 func GetChannelPeerNodeIds(chain core.Chain, network core.Network) []int {
 	nodeIdsResponseChannel := make(chan []int)
 	nodeCache := NodeCache{
@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+var PeerScoresCacheChannel = make(chan PeerScoreCache) //nolint:gochecknoglobals
+
+type PeerScoreCacheOperationType uint
+
+const (
+	readPeerScore PeerScoreCacheOperationType = iota
+	readAllPeerScores
+	writePeerScores
+)
+
+type PeerScoreCache struct {
+	Type       PeerScoreCacheOperationType
+	NodeId     int
+	PeerScores []PeerScoreSettingsCache
+	Out        chan<- *PeerScoreSettingsCache
+	AllOut     chan<- []PeerScoreSettingsCache
+}
+
+// PeerScoreSettingsCache contains the composite score (0-100) of a remote node and the data it's based on.
+type PeerScoreSettingsCache struct {
+	NodeId    int                `json:"nodeId"`
+	Score     float64            `json:"score"`
+	Breakdown PeerScoreBreakdown `json:"breakdown"`
+	UpdatedOn time.Time          `json:"updatedOn"`
+}
+
+type PeerScoreBreakdown struct {
+	// Fraction of the time the peer was connected
+	Uptime *float64 `json:"uptime"`
+	// Number of forwards towards the peer that were settled
+	ForwardSuccessCount int64 `json:"forwardSuccessCount"`
+	// Number of forwards towards the peer that failed
+	ForwardFailureCount int64 `json:"forwardFailureCount"`
+	// Fees in sats (Satoshis) earned from forwards towards the peer
+	Revenue int64 `json:"revenue"`
+	// Number of channels with the peer that were force closed (including breaches) since the start
+	ForceCloseCount int `json:"forceCloseCount"`
+	// Number of fee policy changes announced by the peer per channel
+	FeePolicyChangesPerChannel float64 `json:"feePolicyChangesPerChannel"`
+
+	// Component scores (0-100), nil when there is no data for the component
+	UptimeScore         *float64 `json:"uptimeScore"`
+	ForwardSuccessScore *float64 `json:"forwardSuccessScore"`
+	RevenueScore        *float64 `json:"revenueScore"`
+	ForceCloseScore     float64  `json:"forceCloseScore"`
+	FeeStabilityScore   float64  `json:"feeStabilityScore"`
+}
+
+func PeerScoresCacheHandler(ch <-chan PeerScoreCache, ctx context.Context) {
+	peerScoresCache := make(map[nodeIdType]PeerScoreSettingsCache, 0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case peerScoreCache := <-ch:
+			peerScoresCache = handlePeerScoreOperation(peerScoreCache, peerScoresCache)
+		}
+	}
+}
+
+func handlePeerScoreOperation(peerScoreCache PeerScoreCache,
+	peerScoresCache map[nodeIdType]PeerScoreSettingsCache) map[nodeIdType]PeerScoreSettingsCache {
+
+	switch peerScoreCache.Type {
+	case readPeerScore:
+		if peerScoreCache.NodeId == 0 {
+			log.Error().Msgf("No empty NodeId allowed")
+			peerScoreCache.Out <- nil
+			break
+		}
+		peerScore, exists := peerScoresCache[nodeIdType(peerScoreCache.NodeId)]
+		if !exists {
+			peerScoreCache.Out <- nil
+			break
+		}
+		peerScoreCache.Out <- &peerScore
+	case readAllPeerScores:
+		var peerScores []PeerScoreSettingsCache
+		for _, peerScore := range peerScoresCache {
+			peerScores = append(peerScores, peerScore)
+		}
+		peerScoreCache.AllOut <- peerScores
+	case writePeerScores:
+		peerScoresCache = make(map[nodeIdType]PeerScoreSettingsCache, 0)
+		for _, peerScore := range peerScoreCache.PeerScores {
+			if peerScore.NodeId == 0 {
+				continue
+			}
+			peerScoresCache[nodeIdType(peerScore.NodeId)] = peerScore
+		}
+	}
+	return peerScoresCache
+}
+
+func GetPeerScore(nodeId int) *PeerScoreSettingsCache {
+	peerScoreResponseChannel := make(chan *PeerScoreSettingsCache)
+	peerScoreCache := PeerScoreCache{
+		NodeId: nodeId,
+		Type:   readPeerScore,
+		Out:    peerScoreResponseChannel,
+	}
+	PeerScoresCacheChannel <- peerScoreCache
+	return <-peerScoreResponseChannel
+}
+
+func GetPeerScores() []PeerScoreSettingsCache {
+	peerScoresResponseChannel := make(chan []PeerScoreSettingsCache)
+	peerScoreCache := PeerScoreCache{
+		Type:   readAllPeerScores,
+		AllOut: peerScoresResponseChannel,
+	}
+	PeerScoresCacheChannel <- peerScoreCache
+	return <-peerScoresResponseChannel
+}
+
+func SetPeerScores(peerScores []PeerScoreSettingsCache) {
+	peerScoreCache := PeerScoreCache{
+		PeerScores: peerScores,
+		Type:       writePeerScores,
+	}
+	PeerScoresCacheChannel <- peerScoreCache
+}
@@ -4,10 +4,10 @@ import (
 	"context"
 	"time"
 
-	"github.com/rs/zerolog/log"
 	"golang.org/x/exp/slices"
 
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 )
 
@@ -77,6 +77,9 @@ type ServiceState struct {
 	InitializationTime *time.Time
 	InactivationTime   *time.Time
 	FailureTime        *time.Time
+	// Not reset when the service is activated again
+	LastFailureTime *time.Time
+	FailureCount    int
 }
 
 func (ss *ServiceState) Pending(cancelFunc context.CancelFunc) ServiceState {
@@ -133,6 +136,8 @@ func (ss *ServiceState) Failure() ServiceState {
 	now := time.Now()
 	ss.Inactivate()
 	ss.FailureTime = &now
+	ss.LastFailureTime = &now
+	ss.FailureCount++
 	return *ss
 }
 
@@ -652,8 +657,8 @@ func SetDesiredCoreServiceState(serviceType services_helpers.ServiceType, servic
 }
 
 func SetDesiredNodeServiceState(serviceType services_helpers.ServiceType, nodeId int, serviceStatus services_helpers.ServiceStatus) {
-	log.Info().Msgf("%v desired state is now %v with nodeId: %v.", serviceType.String(), serviceStatus.String(),
-		nodeId)
+	log.Info().Int(logging.NodeIdField, nodeId).Msgf(
+		"%v desired state is now %v", serviceType.String(), serviceStatus.String())
 
 	serviceCache := ServiceCache{
 		ServiceType:   serviceType,
@@ -737,7 +742,7 @@ func CancelCoreService(serviceType services_helpers.ServiceType) {
 }
 
 func CancelNodeService(serviceType services_helpers.ServiceType, nodeId int) {
-	log.Debug().Msgf("%v cancellation requested for nodeId: %v", serviceType.String(), nodeId)
+	log.Debug().Int(logging.NodeIdField, nodeId).Msgf("%v cancellation requested", serviceType.String())
 	serviceCache := ServiceCache{
 		ServiceType: serviceType,
 		NodeId:      nodeId,
@@ -759,8 +764,8 @@ func SetFailedCoreServiceState(serviceType services_helpers.ServiceType) {
 
 func SetFailedNodeServiceState(serviceType services_helpers.ServiceType, nodeId int) {
 	inactive := services_helpers.Inactive
-	log.Debug().Msgf("%v updating current state to %v (due to failure) for nodeId: %v", serviceType.String(),
-		(&inactive).String(), nodeId)
+	log.Debug().Int(logging.NodeIdField, nodeId).Msgf(
+		"%v updating current state to %v (due to failure)", serviceType.String(), (&inactive).String())
 	serviceCache := ServiceCache{
 		ServiceType: serviceType,
 		NodeId:      nodeId,
@@ -780,8 +785,8 @@ func setCoreServiceStatus(serviceType services_helpers.ServiceType, serviceStatu
 }
 
 func setNodeServiceStatus(serviceType services_helpers.ServiceType, nodeId int, serviceStatus services_helpers.ServiceStatus) {
-	log.Debug().Msgf("%v updating current state to %v for nodeId: %v", serviceType.String(),
-		serviceStatus.String(), nodeId)
+	log.Debug().Int(logging.NodeIdField, nodeId).Msgf("%v updating current state to %v", serviceType.String(),
+		serviceStatus.String())
 
 	serviceCache := ServiceCache{
 		ServiceType:   serviceType,
@@ -961,6 +966,31 @@ recheck:
 	}
 }
 
+// RestartNodeServices cancels the running services of the node, the desired states are unchanged so the services are
+// booted again with the current node connection details. Services the previous macaroon did not allow are activated.
+func RestartNodeServices(nodeId int) {
+	ncd := GetNodeConnectionDetails(nodeId)
+	var serviceTypes []services_helpers.ServiceType
+	switch ncd.Implementation {
+	case core.LND:
+		serviceTypes = services_helpers.GetLndServiceTypes()
+	case core.CLN:
+		serviceTypes = services_helpers.GetClnServiceTypes()
+	}
+	for _, serviceType := range serviceTypes {
+		if GetCurrentNodeServiceState(serviceType, nodeId).Status != services_helpers.Inactive {
+			log.Info().Int(logging.NodeIdField, nodeId).Msgf("%v restart", serviceType.String())
+			CancelNodeService(serviceType, nodeId)
+		}
+	}
+	// The permissions of a new macaroon are checked again when the services boot
+	for _, serviceType := range popDeniedServices(nodeId) {
+		log.Info().Int(logging.NodeIdField, nodeId).Msgf(
+			"%v activated again to check the permissions.", serviceType.String())
+		SetDesiredNodeServiceState(serviceType, nodeId, services_helpers.Active)
+	}
+}
+
 func ActivateLndService(ctx context.Context,
 	nodeId int,
 	customSettings core.NodeConnectionDetailCustomSettings,
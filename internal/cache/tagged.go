@@ -4,7 +4,6 @@ import (
 	"context"
 	"sort"
 
-	"github.com/rs/zerolog/log"
 	"golang.org/x/exp/slices"
 )
 
@@ -5,8 +5,6 @@ import (
 	"sort"
 	"time"
 
-	"github.com/rs/zerolog/log"
-
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/workflow_helpers"
 )
@@ -23,13 +23,58 @@ type ChannelBalance struct {
 	Balances       []*Balance `json:"balances"`
 }
 
+// getChannelBalance reads the balances from the stored balance history. Balances from before the first
+// stored snapshot (i.e. before the history was recorded) are rebuilt from the forwards, payments and invoices.
 func getChannelBalance(db *sqlx.DB, channelIdString string, from time.Time, to time.Time) (ChannelBalance, error) {
-	channelId, err := strconv.ParseUint(channelIdString, 10, 64)
+	channelId, err := strconv.Atoi(channelIdString)
 	if err != nil {
-		return ChannelBalance{}, errors.Wrapf(err, "Converting LND short channel id %v", channelIdString)
+		return ChannelBalance{}, errors.Wrapf(err, "Converting channel id %v", channelIdString)
 	}
 
 	cb := ChannelBalance{ChannelId: channelIdString}
+
+	firstSnapshotTime, err := getFirstChannelBalanceSnapshotTime(db, channelId)
+	if err != nil {
+		return cb, errors.Wrap(err, "Getting first channel balance snapshot")
+	}
+	if firstSnapshotTime == nil || from.Before(*firstSnapshotTime) {
+		cb.Balances, err = getReplayedChannelBalances(db, channelId, from, to, firstSnapshotTime)
+		if err != nil {
+			return cb, errors.Wrap(err, "Getting replayed channel balances")
+		}
+	}
+	if firstSnapshotTime != nil {
+		storedBalances, err := getStoredChannelBalances(db, channelId, from, to)
+		if err != nil {
+			return cb, errors.Wrap(err, "Getting stored channel balances")
+		}
+		cb.Balances = appendStoredChannelBalances(cb.Balances, storedBalances)
+	}
+
+	// Get the short channel id using the cache
+	channel := cache.GetChannelSettingByChannelId(channelId)
+	if channel.ShortChannelId != nil {
+		cb.ShortChannelId = *channel.ShortChannelId
+	}
+
+	return cb, nil
+}
+
+// appendStoredChannelBalances appends the stored balances to the replayed balances. The capacity difference
+// of the first stored balance is relative to the last replayed balance.
+func appendStoredChannelBalances(replayedBalances []*Balance, storedBalances []*Balance) []*Balance {
+	if len(replayedBalances) != 0 && len(storedBalances) != 0 {
+		capacityDiff := storedBalances[0].OutboundCapacity - replayedBalances[len(replayedBalances)-1].OutboundCapacity
+		storedBalances[0].CapacityDiff = &capacityDiff
+	}
+	return append(replayedBalances, storedBalances...)
+}
+
+// getReplayedChannelBalances rebuilds the balances by replaying forwards, payments and invoices.
+// When before is provided only balances before that time are returned.
+func getReplayedChannelBalances(db *sqlx.DB, channelId int, from time.Time, to time.Time,
+	before *time.Time) ([]*Balance, error) {
+
 	q := `WITH
 		   initial_balance as (
 				SELECT (event->>'local_balance')::bigint
@@ -78,41 +123,24 @@ func getChannelBalance(db *sqlx.DB, channelIdString string, from time.Time, to t
 			) a
 		) b
 	where time::timestamp AT TIME ZONE ($4) between $2::timestamp and $3::timestamp
+		and ($5::timestamptz is null or time < $5)
 ;`
 
-	rows, err := db.Queryx(q, channelId, from, to, cache.GetSettings().PreferredTimeZone)
+	rows, err := db.Queryx(q, channelId, from, to, cache.GetSettings().PreferredTimeZone, before)
 	if err != nil {
-		return cb, errors.Wrap(err, "SQL run query")
+		return nil, errors.Wrap(err, "SQL run query")
 	}
 	defer rows.Close()
+	var balances []*Balance
 	for rows.Next() {
 		b := Balance{}
 
 		err = rows.StructScan(&b)
 		if err != nil {
-			return cb, errors.Wrap(err, "SQL struct scan")
+			return nil, errors.Wrap(err, "SQL struct scan")
 		}
 
-		if len(cb.Balances) == 0 {
-			cb.Balances = []*Balance{&b}
-		} else {
-			cb.Balances = append(cb.Balances, &b)
-		}
-
-	}
-
-	// To integer but check that the number will fit into int64
-	channelIdInt, err := strconv.Atoi(channelIdString)
-	if err != nil {
-		panic(err)
+		balances = append(balances, &b)
 	}
-
-	// Get the short channel id using the cache
-	channel := cache.GetChannelSettingByChannelId(channelIdInt)
-	if channel.ShortChannelId != nil {
-		cb.ShortChannelId = *channel.ShortChannelId
-	}
-
-	return cb, nil
-
+	return balances, nil
 }
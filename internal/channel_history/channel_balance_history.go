@@ -0,0 +1,203 @@
+package channel_history
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/database"
+)
+
+type balanceResolution int
+
+const (
+	balanceResolutionRaw = balanceResolution(iota)
+	balanceResolutionHourly
+	balanceResolutionDaily
+)
+
+// Snapshots are kept in full detail for 7 days, per hour up to 90 days and per day after that.
+const (
+	balanceRawRetention    = 7 * 24 * time.Hour
+	balanceHourlyRetention = 90 * 24 * time.Hour
+)
+
+// AddChannelBalanceEventSnapshot stores the balance after a ChannelBalanceEvent in the balance history.
+func AddChannelBalanceEventSnapshot(db *sqlx.DB, channelBalanceEvent core.ChannelBalanceEvent) error {
+	eventTime := channelBalanceEvent.EventTime
+	if eventTime.IsZero() {
+		eventTime = time.Now()
+	}
+	return addChannelBalanceSnapshot(db, eventTime, channelBalanceEvent.NodeId, channelBalanceEvent.ChannelId,
+		channelBalanceEvent.LocalBalance, channelBalanceEvent.RemoteBalance, channelBalanceEvent.Capacity)
+}
+
+// AddChannelBalanceSamples stores the current balance of all channels of the active torq nodes.
+// This makes sure there is a data point even when a channel didn't have any balance changes.
+func AddChannelBalanceSamples(db *sqlx.DB) error {
+	sampleTime := time.Now()
+	for _, torqNode := range cache.GetActiveTorqNodeSettings() {
+		for _, channelState := range cache.GetChannelStates(torqNode.NodeId, false) {
+			channelSettings := cache.GetChannelSettingByChannelId(channelState.ChannelId)
+			err := addChannelBalanceSnapshot(db, sampleTime, torqNode.NodeId, channelState.ChannelId,
+				channelState.LocalBalance, channelState.RemoteBalance, channelSettings.Capacity)
+			if err != nil {
+				return errors.Wrapf(err, "Adding channel balance sample for channelId: %v", channelState.ChannelId)
+			}
+		}
+	}
+	return nil
+}
+
+func addChannelBalanceSnapshot(db *sqlx.DB, snapshotTime time.Time, nodeId int, channelId int,
+	localBalance int64, remoteBalance int64, capacity int64) error {
+
+	if nodeId == 0 || channelId == 0 {
+		return nil
+	}
+	_, err := db.Exec(`
+		INSERT INTO channel_balance_history
+			(time, node_id, channel_id, local_balance, remote_balance, capacity, resolution)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);`,
+		snapshotTime, nodeId, channelId, localBalance, remoteBalance, capacity, balanceResolutionRaw)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
+// DownsampleChannelBalanceHistory replaces old snapshots by the last known balance per hour or per day.
+// It returns the number of downsampled records that were created.
+func DownsampleChannelBalanceHistory(db *sqlx.DB) (int64, error) {
+	hourlyCutoff, dailyCutoff := getDownsampleCutoffs(time.Now())
+	hourlyRowsAffected, err := downsampleChannelBalanceHistory(db, hourlyCutoff, "1 hour", balanceResolutionHourly)
+	if err != nil {
+		return 0, errors.Wrap(err, "Downsampling channel balance history per hour")
+	}
+	dailyRowsAffected, err := downsampleChannelBalanceHistory(db, dailyCutoff, "1 day", balanceResolutionDaily)
+	if err != nil {
+		return 0, errors.Wrap(err, "Downsampling channel balance history per day")
+	}
+	return hourlyRowsAffected + dailyRowsAffected, nil
+}
+
+// getDownsampleCutoffs returns the times before which snapshots are downsampled per hour and per day.
+// The cutoffs are aligned with the UTC hours and days of the time_bucket function.
+func getDownsampleCutoffs(now time.Time) (time.Time, time.Time) {
+	return now.Add(-balanceRawRetention).Truncate(time.Hour), now.Add(-balanceHourlyRetention).Truncate(24 * time.Hour)
+}
+
+// downsampleChannelBalanceHistory keeps the last snapshot of each bucket (with its original time) for all
+// snapshots before the cutoff that have a finer resolution than the requested one.
+// The cutoff needs to be aligned with the bucket width so buckets are never split.
+func downsampleChannelBalanceHistory(db *sqlx.DB, cutoff time.Time, bucketWidth string,
+	resolution balanceResolution) (int64, error) {
+
+	res, err := db.Exec(`
+		WITH deleted AS (
+			DELETE FROM channel_balance_history
+			WHERE time < $1 AND resolution < $2
+			RETURNING time, node_id, channel_id, local_balance, remote_balance, capacity
+		)
+		INSERT INTO channel_balance_history
+			(time, node_id, channel_id, local_balance, remote_balance, capacity, resolution)
+		SELECT max(time), node_id, channel_id,
+			last(local_balance, time), last(remote_balance, time), last(capacity, time), $2
+		FROM deleted
+		GROUP BY time_bucket($3::interval, time), node_id, channel_id;`,
+		cutoff, resolution, bucketWidth)
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlAffectedRowsCheckError)
+	}
+	return rowsAffected, nil
+}
+
+func getFirstChannelBalanceSnapshotTime(db *sqlx.DB, channelId int) (*time.Time, error) {
+	var firstSnapshotTime *time.Time
+	err := db.Get(&firstSnapshotTime, `SELECT min(time) FROM channel_balance_history WHERE channel_id = $1;`,
+		channelId)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return firstSnapshotTime, nil
+}
+
+func getStoredChannelBalances(db *sqlx.DB, channelId int, from time.Time, to time.Time) ([]*Balance, error) {
+	var balances []*Balance
+	err := db.Select(&balances, `
+		SELECT time AS date,
+			remote_balance AS inbound_capacity,
+			local_balance AS outbound_capacity,
+			local_balance - lag(local_balance) OVER (ORDER BY time) AS capacity_diff
+		FROM channel_balance_history
+		WHERE channel_id = $1
+			AND time::timestamp AT TIME ZONE ($4) BETWEEN $2::timestamp AND $3::timestamp
+		ORDER BY time;`,
+		channelId, from, to, cache.GetSettings().PreferredTimeZone)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return balances, nil
+}
+
+type dailyChannelBalance struct {
+	Date          time.Time `db:"date"`
+	LocalBalance  int64     `db:"local_balance"`
+	RemoteBalance int64     `db:"remote_balance"`
+}
+
+// addDailyChannelBalances sets the balance at the end of each day (summed over the requested channels)
+// on the channel history records.
+func addDailyChannelBalances(db *sqlx.DB, nodeIds []int, all bool, channelIds []int, from time.Time,
+	to time.Time, records []*ChannelHistoryRecords) error {
+
+	var dailyBalances []dailyChannelBalance
+	err := db.Select(&dailyBalances, `
+		SELECT date::timestamp AT TIME ZONE ($5) AS date,
+			sum(local_balance)::bigint AS local_balance,
+			sum(remote_balance)::bigint AS remote_balance
+		FROM (
+			SELECT time_bucket('1 days', time::timestamp AT TIME ZONE ($5)) AS date,
+				channel_id,
+				last(local_balance, time) AS local_balance,
+				last(remote_balance, time) AS remote_balance
+			FROM channel_balance_history
+			WHERE ($3 OR channel_id = ANY ($4))
+				AND time::timestamp AT TIME ZONE ($5) >= $1::timestamp
+				AND time::timestamp AT TIME ZONE ($5) <= $2::timestamp
+				AND node_id = ANY ($6)
+			GROUP BY date, channel_id
+		) AS b
+		GROUP BY date
+		ORDER BY date;`,
+		from, to, all, pq.Array(channelIds), cache.GetSettings().PreferredTimeZone, pq.Array(nodeIds))
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	setDailyChannelBalances(records, dailyBalances)
+	return nil
+}
+
+// setDailyChannelBalances sets the balances on the records of the same day, days without a stored balance
+// are left empty.
+func setDailyChannelBalances(records []*ChannelHistoryRecords, dailyBalances []dailyChannelBalance) {
+	for _, dailyBalance := range dailyBalances {
+		for _, record := range records {
+			if record.Date.Equal(dailyBalance.Date) {
+				localBalance := dailyBalance.LocalBalance
+				remoteBalance := dailyBalance.RemoteBalance
+				record.LocalBalance = &localBalance
+				record.RemoteBalance = &remoteBalance
+				break
+			}
+		}
+	}
+}
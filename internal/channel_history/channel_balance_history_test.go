@@ -0,0 +1,401 @@
+package channel_history
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/testutil"
+)
+
+type balanceSnapshot struct {
+	Time          time.Time         `db:"time"`
+	ChannelId     int               `db:"channel_id"`
+	LocalBalance  int64             `db:"local_balance"`
+	RemoteBalance int64             `db:"remote_balance"`
+	Resolution    balanceResolution `db:"resolution"`
+}
+
+func TestGetDownsampleCutoffs(t *testing.T) {
+	testCases := []struct {
+		name   string
+		now    time.Time
+		hourly time.Time
+		daily  time.Time
+	}{
+		{
+			name:   "aligned",
+			now:    time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC),
+			hourly: time.Date(2023, 6, 23, 0, 0, 0, 0, time.UTC),
+			daily:  time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "truncated to the hour and day",
+			now:    time.Date(2023, 6, 30, 17, 45, 12, 0, time.UTC),
+			hourly: time.Date(2023, 6, 23, 17, 0, 0, 0, time.UTC),
+			daily:  time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Buckets are UTC days so the cutoffs don't depend on the time zone of now
+		{
+			name:   "time zone with a half hour offset",
+			now:    time.Date(2023, 6, 30, 2, 15, 0, 0, time.FixedZone("IST", 5*60*60+30*60)),
+			hourly: time.Date(2023, 6, 22, 20, 0, 0, 0, time.UTC),
+			daily:  time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hourly, daily := getDownsampleCutoffs(tc.now)
+			if !hourly.Equal(tc.hourly) {
+				t.Errorf("hourly cutoff got %v, want %v", hourly, tc.hourly)
+			}
+			if !daily.Equal(tc.daily) {
+				t.Errorf("daily cutoff got %v, want %v", daily, tc.daily)
+			}
+		})
+	}
+}
+
+func TestSetDailyChannelBalances(t *testing.T) {
+	march1 := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	march2 := time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC)
+	brussels, err := time.LoadLocation("Europe/Brussels")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		recordDates   []time.Time
+		dailyBalances []dailyChannelBalance
+		// nil when the record has no balance
+		localBalances []*int64
+	}{
+		{
+			name:          "no stored balances",
+			recordDates:   []time.Time{march1, march2},
+			localBalances: []*int64{nil, nil},
+		},
+		{
+			name:        "balance for every day",
+			recordDates: []time.Time{march1, march2},
+			dailyBalances: []dailyChannelBalance{
+				{Date: march1, LocalBalance: 100, RemoteBalance: 900},
+				{Date: march2, LocalBalance: 200, RemoteBalance: 800},
+			},
+			localBalances: []*int64{int64Pointer(100), int64Pointer(200)},
+		},
+		{
+			name:        "day without a balance",
+			recordDates: []time.Time{march1, march2},
+			dailyBalances: []dailyChannelBalance{
+				{Date: march2, LocalBalance: 200, RemoteBalance: 800},
+			},
+			localBalances: []*int64{nil, int64Pointer(200)},
+		},
+		{
+			name:        "balance outside the records",
+			recordDates: []time.Time{march2},
+			dailyBalances: []dailyChannelBalance{
+				{Date: march1, LocalBalance: 100, RemoteBalance: 900},
+			},
+			localBalances: []*int64{nil},
+		},
+		// The same instant in another location is the same day
+		{
+			name:        "records in the preferred time zone",
+			recordDates: []time.Time{time.Date(2023, 3, 1, 0, 0, 0, 0, brussels)},
+			dailyBalances: []dailyChannelBalance{
+				{Date: time.Date(2023, 2, 28, 23, 0, 0, 0, time.UTC), LocalBalance: 100, RemoteBalance: 900},
+			},
+			localBalances: []*int64{int64Pointer(100)},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var records []*ChannelHistoryRecords
+			for _, recordDate := range tc.recordDates {
+				records = append(records, &ChannelHistoryRecords{Date: recordDate})
+			}
+			setDailyChannelBalances(records, tc.dailyBalances)
+			for i, record := range records {
+				assertBalance(t, record.Date, "local", record.LocalBalance, tc.localBalances[i])
+				if tc.localBalances[i] == nil {
+					continue
+				}
+				remoteBalance := 1000 - *tc.localBalances[i]
+				assertBalance(t, record.Date, "remote", record.RemoteBalance, &remoteBalance)
+			}
+		})
+	}
+}
+
+func TestDownsampleChannelBalanceHistory(t *testing.T) {
+	db, channelIds, cancel := initChannelBalanceHistoryTestDatabase(t)
+	defer cancel()
+
+	cutoff := time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name         string
+		bucketWidth  string
+		resolution   balanceResolution
+		snapshots    []balanceSnapshot
+		rowsAffected int64
+		expected     []balanceSnapshot
+	}{
+		{
+			name:        "last snapshot per hour",
+			bucketWidth: "1 hour",
+			resolution:  balanceResolutionHourly,
+			snapshots: []balanceSnapshot{
+				{Time: time.Date(2023, 3, 9, 10, 5, 0, 0, time.UTC), LocalBalance: 100},
+				{Time: time.Date(2023, 3, 9, 10, 40, 0, 0, time.UTC), LocalBalance: 200},
+				{Time: time.Date(2023, 3, 9, 11, 10, 0, 0, time.UTC), LocalBalance: 300},
+				{Time: time.Date(2023, 3, 10, 0, 30, 0, 0, time.UTC), LocalBalance: 400},
+			},
+			rowsAffected: 2,
+			expected: []balanceSnapshot{
+				{Time: time.Date(2023, 3, 9, 10, 40, 0, 0, time.UTC), LocalBalance: 200,
+					Resolution: balanceResolutionHourly},
+				{Time: time.Date(2023, 3, 9, 11, 10, 0, 0, time.UTC), LocalBalance: 300,
+					Resolution: balanceResolutionHourly},
+				{Time: time.Date(2023, 3, 10, 0, 30, 0, 0, time.UTC), LocalBalance: 400},
+			},
+		},
+		{
+			name:        "last snapshot per day for raw and hourly snapshots",
+			bucketWidth: "1 day",
+			resolution:  balanceResolutionDaily,
+			snapshots: []balanceSnapshot{
+				{Time: time.Date(2023, 3, 8, 23, 0, 0, 0, time.UTC), LocalBalance: 100,
+					Resolution: balanceResolutionHourly},
+				{Time: time.Date(2023, 3, 9, 0, 0, 0, 0, time.UTC), LocalBalance: 200,
+					Resolution: balanceResolutionHourly},
+				{Time: time.Date(2023, 3, 9, 23, 59, 0, 0, time.UTC), LocalBalance: 300},
+			},
+			rowsAffected: 2,
+			expected: []balanceSnapshot{
+				{Time: time.Date(2023, 3, 8, 23, 0, 0, 0, time.UTC), LocalBalance: 100,
+					Resolution: balanceResolutionDaily},
+				{Time: time.Date(2023, 3, 9, 23, 59, 0, 0, time.UTC), LocalBalance: 300,
+					Resolution: balanceResolutionDaily},
+			},
+		},
+		{
+			name:        "buckets per channel",
+			bucketWidth: "1 hour",
+			resolution:  balanceResolutionHourly,
+			snapshots: []balanceSnapshot{
+				{Time: time.Date(2023, 3, 9, 10, 5, 0, 0, time.UTC), LocalBalance: 100},
+				{Time: time.Date(2023, 3, 9, 10, 10, 0, 0, time.UTC), ChannelId: 1, LocalBalance: 500},
+				{Time: time.Date(2023, 3, 9, 10, 40, 0, 0, time.UTC), LocalBalance: 200},
+			},
+			rowsAffected: 2,
+			expected: []balanceSnapshot{
+				{Time: time.Date(2023, 3, 9, 10, 10, 0, 0, time.UTC), ChannelId: 1, LocalBalance: 500,
+					Resolution: balanceResolutionHourly},
+				{Time: time.Date(2023, 3, 9, 10, 40, 0, 0, time.UTC), LocalBalance: 200,
+					Resolution: balanceResolutionHourly},
+			},
+		},
+		{
+			name:        "coarser snapshots are kept",
+			bucketWidth: "1 hour",
+			resolution:  balanceResolutionHourly,
+			snapshots: []balanceSnapshot{
+				{Time: time.Date(2023, 3, 9, 10, 5, 0, 0, time.UTC), LocalBalance: 100,
+					Resolution: balanceResolutionDaily},
+				{Time: time.Date(2023, 3, 9, 11, 5, 0, 0, time.UTC), LocalBalance: 200,
+					Resolution: balanceResolutionHourly},
+			},
+			expected: []balanceSnapshot{
+				{Time: time.Date(2023, 3, 9, 10, 5, 0, 0, time.UTC), LocalBalance: 100,
+					Resolution: balanceResolutionDaily},
+				{Time: time.Date(2023, 3, 9, 11, 5, 0, 0, time.UTC), LocalBalance: 200,
+					Resolution: balanceResolutionHourly},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			addBalanceSnapshots(t, db, channelIds, tc.snapshots)
+			rowsAffected, err := downsampleChannelBalanceHistory(db, cutoff, tc.bucketWidth, tc.resolution)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rowsAffected != tc.rowsAffected {
+				t.Errorf("rowsAffected got %v, want %v", rowsAffected, tc.rowsAffected)
+			}
+
+			var snapshots []balanceSnapshot
+			err = db.Select(&snapshots, `
+				SELECT time, channel_id, local_balance, remote_balance, resolution
+				FROM channel_balance_history
+				ORDER BY time, channel_id;`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(snapshots) != len(tc.expected) {
+				t.Fatalf("snapshots got %v, want %v", snapshots, tc.expected)
+			}
+			for i, expected := range tc.expected {
+				expected.ChannelId = channelIds[expected.ChannelId]
+				expected.RemoteBalance = 1000 - expected.LocalBalance
+				if !snapshots[i].Time.Equal(expected.Time) || snapshots[i].ChannelId != expected.ChannelId ||
+					snapshots[i].LocalBalance != expected.LocalBalance ||
+					snapshots[i].RemoteBalance != expected.RemoteBalance ||
+					snapshots[i].Resolution != expected.Resolution {
+					t.Errorf("snapshot %v got %+v, want %+v", i, snapshots[i], expected)
+				}
+			}
+		})
+	}
+}
+
+func TestAddDailyChannelBalances(t *testing.T) {
+	db, channelIds, cancel := initChannelBalanceHistoryTestDatabase(t)
+	defer cancel()
+
+	addBalanceSnapshots(t, db, channelIds, []balanceSnapshot{
+		{Time: time.Date(2023, 3, 1, 8, 0, 0, 0, time.UTC), LocalBalance: 100},
+		{Time: time.Date(2023, 3, 1, 20, 0, 0, 0, time.UTC), LocalBalance: 150},
+		{Time: time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC), ChannelId: 1, LocalBalance: 300},
+		{Time: time.Date(2023, 3, 1, 23, 30, 0, 0, time.UTC), ChannelId: 1, LocalBalance: 350},
+		{Time: time.Date(2023, 3, 2, 9, 0, 0, 0, time.UTC), LocalBalance: 200},
+	})
+	var nodeId int
+	err := db.Get(&nodeId, `SELECT first_node_id FROM channel WHERE channel_id=$1;`, channelIds[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	brussels, err := time.LoadLocation("Europe/Brussels")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name       string
+		timeZone   string
+		nodeIds    []int
+		all        bool
+		channelIds []int
+		// one record per day starting on the first of March
+		localBalances []*int64
+	}{
+		{
+			name:          "all channels",
+			timeZone:      "UTC",
+			nodeIds:       []int{nodeId},
+			all:           true,
+			localBalances: []*int64{int64Pointer(500), int64Pointer(200), nil},
+		},
+		{
+			name:          "one channel",
+			timeZone:      "UTC",
+			nodeIds:       []int{nodeId},
+			channelIds:    []int{channelIds[1]},
+			localBalances: []*int64{int64Pointer(350), nil, nil},
+		},
+		{
+			name:          "other node",
+			timeZone:      "UTC",
+			nodeIds:       []int{nodeId + 1000},
+			all:           true,
+			localBalances: []*int64{nil, nil, nil},
+		},
+		// 23:30 UTC is the next day in Brussels
+		{
+			name:          "preferred time zone",
+			timeZone:      "Europe/Brussels",
+			nodeIds:       []int{nodeId},
+			all:           true,
+			localBalances: []*int64{int64Pointer(450), int64Pointer(550), nil},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cache.SetSettings("week", "en", "saturday", tc.timeZone, "", true, nil, nil, nil, nil)
+			location := time.UTC
+			if tc.timeZone != "UTC" {
+				location = brussels
+			}
+			var records []*ChannelHistoryRecords
+			for day := 1; day <= len(tc.localBalances); day++ {
+				records = append(records, &ChannelHistoryRecords{Date: time.Date(2023, 3, day, 0, 0, 0, 0, location)})
+			}
+			err := addDailyChannelBalances(db, tc.nodeIds, tc.all, tc.channelIds,
+				time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 3, 3, 23, 59, 59, 0, time.UTC), records)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, record := range records {
+				assertBalance(t, record.Date, "local", record.LocalBalance, tc.localBalances[i])
+			}
+		})
+	}
+}
+
+// initChannelBalanceHistoryTestDatabase returns the test database with the channel ids of the test channels
+func initChannelBalanceHistoryTestDatabase(t *testing.T) (*sqlx.DB, []int, func()) {
+	srv, err := testutil.InitTestDBConn()
+	if err != nil {
+		panic(err)
+	}
+
+	db, cancel, err := srv.NewTestDatabase(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var channelIds []int
+	err = db.Select(&channelIds, `SELECT channel_id FROM channel ORDER BY lnd_short_channel_id;`)
+	if err != nil {
+		cancel()
+		t.Fatal(err)
+	}
+	return db, channelIds, cancel
+}
+
+// addBalanceSnapshots replaces the balance history with the snapshots, the ChannelId of a snapshot is the index of
+// the test channel and the remote balance is the rest of the capacity of 1000.
+func addBalanceSnapshots(t *testing.T, db *sqlx.DB, channelIds []int, snapshots []balanceSnapshot) {
+	_, err := db.Exec(`DELETE FROM channel_balance_history;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, snapshot := range snapshots {
+		channelId := channelIds[snapshot.ChannelId]
+		_, err = db.Exec(`
+			INSERT INTO channel_balance_history
+				(time, node_id, channel_id, local_balance, remote_balance, capacity, resolution)
+			SELECT $1, first_node_id, channel_id, $3, $4, 1000, $5
+			FROM channel
+			WHERE channel_id=$2;`,
+			snapshot.Time, channelId, snapshot.LocalBalance, 1000-snapshot.LocalBalance, snapshot.Resolution)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func assertBalance(t *testing.T, date time.Time, name string, got *int64, want *int64) {
+	t.Helper()
+	if (got == nil) != (want == nil) || (got != nil && *got != *want) {
+		t.Errorf("%v balance of %v got %v, want %v", name, date, formatBalance(got), formatBalance(want))
+	}
+}
+
+func formatBalance(balance *int64) string {
+	if balance == nil {
+		return "none"
+	}
+	return strconv.FormatInt(*balance, 10)
+}
+
+func int64Pointer(value int64) *int64 {
+	return &value
+}
@@ -0,0 +1,89 @@
+package channel_history
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lncapital/torq/internal/cache"
+)
+
+func TestAppendStoredChannelBalances(t *testing.T) {
+	testCases := []struct {
+		name               string
+		replayedBalances   []*Balance
+		storedBalances     []*Balance
+		outboundCapacities []int64
+		capacityDiffs      []*int64
+	}{
+		{
+			name: "no balances",
+		},
+		{
+			name:               "only replayed balances",
+			replayedBalances:   []*Balance{{OutboundCapacity: 100}, {OutboundCapacity: 150, CapacityDiff: int64Pointer(50)}},
+			outboundCapacities: []int64{100, 150},
+			capacityDiffs:      []*int64{nil, int64Pointer(50)},
+		},
+		{
+			name:               "only stored balances",
+			storedBalances:     []*Balance{{OutboundCapacity: 100}, {OutboundCapacity: 80, CapacityDiff: int64Pointer(-20)}},
+			outboundCapacities: []int64{100, 80},
+			capacityDiffs:      []*int64{nil, int64Pointer(-20)},
+		},
+		{
+			name:               "first stored balance relative to the last replayed balance",
+			replayedBalances:   []*Balance{{OutboundCapacity: 100}, {OutboundCapacity: 150, CapacityDiff: int64Pointer(50)}},
+			storedBalances:     []*Balance{{OutboundCapacity: 120}, {OutboundCapacity: 200, CapacityDiff: int64Pointer(80)}},
+			outboundCapacities: []int64{100, 150, 120, 200},
+			capacityDiffs:      []*int64{nil, int64Pointer(50), int64Pointer(-30), int64Pointer(80)},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			balances := appendStoredChannelBalances(tc.replayedBalances, tc.storedBalances)
+			if len(balances) != len(tc.outboundCapacities) {
+				t.Fatalf("balances got %v, want %v", len(balances), len(tc.outboundCapacities))
+			}
+			for i, balance := range balances {
+				if balance.OutboundCapacity != tc.outboundCapacities[i] {
+					t.Errorf("outbound capacity %v got %v, want %v", i, balance.OutboundCapacity, tc.outboundCapacities[i])
+				}
+				assertBalance(t, balance.Date, "capacity diff", balance.CapacityDiff, tc.capacityDiffs[i])
+			}
+		})
+	}
+}
+
+func TestGetChannelBalanceFromHistory(t *testing.T) {
+	db, channelIds, cancel := initChannelBalanceHistoryTestDatabase(t)
+	defer cancel()
+	cache.SetSettings("week", "en", "saturday", "UTC", "", true, nil, nil, nil, nil)
+
+	addBalanceSnapshots(t, db, channelIds, []balanceSnapshot{
+		{Time: time.Date(2023, 3, 1, 8, 0, 0, 0, time.UTC), LocalBalance: 100},
+		{Time: time.Date(2023, 3, 2, 8, 0, 0, 0, time.UTC), LocalBalance: 250},
+		{Time: time.Date(2023, 3, 3, 8, 0, 0, 0, time.UTC), LocalBalance: 200},
+	})
+
+	channelBalance, err := getChannelBalance(db, strconv.Itoa(channelIds[0]),
+		time.Date(2023, 3, 1, 8, 0, 0, 0, time.UTC), time.Date(2023, 3, 4, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Balance{
+		{InboundCapacity: 900, OutboundCapacity: 100},
+		{InboundCapacity: 750, OutboundCapacity: 250, CapacityDiff: int64Pointer(150)},
+		{InboundCapacity: 800, OutboundCapacity: 200, CapacityDiff: int64Pointer(-50)},
+	}
+	if len(channelBalance.Balances) != len(expected) {
+		t.Fatalf("balances got %v, want %v", len(channelBalance.Balances), len(expected))
+	}
+	for i, balance := range channelBalance.Balances {
+		if balance.InboundCapacity != expected[i].InboundCapacity ||
+			balance.OutboundCapacity != expected[i].OutboundCapacity {
+			t.Errorf("balance %v got %+v, want %+v", i, *balance, expected[i])
+		}
+		assertBalance(t, balance.Date, "capacity diff", balance.CapacityDiff, expected[i].CapacityDiff)
+	}
+}
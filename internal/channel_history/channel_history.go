@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/database"
 	"github.com/lncapital/torq/internal/tags"
 
 	"github.com/cockroachdb/errors"
@@ -37,12 +38,21 @@ type ChannelHistoryRecords struct {
 	CountIn *uint64 `json:"countIn"`
 	// Number of total forwards.
 	CountTotal *uint64 `json:"countTotal"`
+
+	// The local balance in sats (Satoshis) at the end of the day
+	LocalBalance *int64 `json:"localBalance"`
+	// The remote balance in sats (Satoshis) at the end of the day
+	RemoteBalance *int64 `json:"remoteBalance"`
 }
 
 func getChannelHistory(db *sqlx.DB, nodeIds []int, all bool, channelIds []int, from time.Time,
 	to time.Time) (r []*ChannelHistoryRecords,
 	err error) {
 
+	// Complete hours (and days) are read from the forward aggregates when their buckets fit the days of the time zone
+	preferredTimeZone := cache.GetSettings().PreferredTimeZone
+	forwards := database.GetForwardsSql("($1::timestamp::timestamptz AT TIME ZONE ($5))",
+		"($2::timestamp::timestamptz AT TIME ZONE ($5))", database.GetForwardAggregates(preferredTimeZone, from, to))
 	sql := `
 		select
 		    (coalesce(i.date, o.date)::timestamp AT TIME ZONE ($5)) as date,
@@ -61,8 +71,8 @@ func getChannelHistory(db *sqlx.DB, nodeIds []int, all bool, channelIds []int, f
 				   outgoing_channel_id channel_id,
 				   floor(sum(outgoing_amount_msat)/1000) as amount,
 				   floor(sum(fee_msat)/1000) as revenue,
-				   count(time) as count
-			from forward
+				   sum(forward_count) as count
+			from ` + forwards + ` as forward
 			where ($3 or outgoing_channel_id = ANY ($4))
 				and time::timestamp AT TIME ZONE ($5) >= $1::timestamp
 				and time::timestamp AT TIME ZONE ($5) <= $2::timestamp
@@ -74,8 +84,8 @@ func getChannelHistory(db *sqlx.DB, nodeIds []int, all bool, channelIds []int, f
 				   incoming_channel_id as channel_id,
 				   floor(sum(incoming_amount_msat)/1000) as amount,
 				   floor(sum(fee_msat)/1000) as revenue,
-				   count(time) as count
-			from forward
+				   sum(forward_count) as count
+			from ` + forwards + ` as forward
 			where ($3 or incoming_channel_id = ANY ($4))
 				and time::timestamp AT TIME ZONE ($5) >= $1::timestamp
 				and time::timestamp AT TIME ZONE ($5) <= $2::timestamp
@@ -86,7 +96,7 @@ func getChannelHistory(db *sqlx.DB, nodeIds []int, all bool, channelIds []int, f
 		order by date;
 	`
 
-	rows, err := db.Queryx(sql, from, to, all, pq.Array(channelIds), cache.GetSettings().PreferredTimeZone, pq.Array(nodeIds))
+	rows, err := db.Queryx(sql, from, to, all, pq.Array(channelIds), preferredTimeZone, pq.Array(nodeIds))
 	if err != nil {
 		return nil, errors.Wrapf(err, "Getting channel history")
 	}
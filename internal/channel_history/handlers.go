@@ -10,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/channels"
 	"github.com/lncapital/torq/internal/core"
@@ -112,7 +113,7 @@ func getChannelHistoryHandler(c *gin.Context, db *sqlx.DB) {
 	}
 
 	chain := core.Bitcoin
-	networkNodeIds := cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network))
+	networkNodeIds := auth.RestrictNodeIds(c, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network)))
 
 	// Get the total values for the whole requested time range (from - to)
 	r, err := getChannelTotal(db, networkNodeIds, all, channelIds, from, to)
@@ -137,8 +138,9 @@ func getChannelHistoryHandler(c *gin.Context, db *sqlx.DB) {
 	}
 	r.History = chanHistory
 
+	err = addDailyChannelBalances(db, networkNodeIds, all, channelIds, from, to, r.History)
 	if err != nil {
-		server_errors.LogAndSendServerError(c, err)
+		server_errors.WrapLogAndSendServerError(c, err, "Getting channel balance history")
 		return
 	}
 
@@ -183,7 +185,7 @@ func getChannelEventHistoryHandler(c *gin.Context, db *sqlx.DB) {
 	network := c.Query("network")
 	chain := c.Query("chain")
 
-	r.Events, err = getChannelEventHistory(db, cache.GetAllTorqNodeIdsByNetwork(core.GetChain(chain), core.GetNetwork(network)), channelIds, from, to)
+	r.Events, err = getChannelEventHistory(db, auth.RestrictNodeIds(c, cache.GetAllTorqNodeIdsByNetwork(core.GetChain(chain), core.GetNetwork(network))), channelIds, from, to)
 	if err != nil {
 		server_errors.LogAndSendServerError(c, err)
 		return
@@ -261,7 +263,7 @@ func getChannelReBalancingHandler(c *gin.Context, db *sqlx.DB) {
 	}
 
 	chain := core.Bitcoin
-	networkNodeIds := cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network))
+	networkNodeIds := auth.RestrictNodeIds(c, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network)))
 
 	var all = false
 	if len(lndShortChannelIdStrings) == 1 && lndShortChannelIdStrings[0] == "all" {
@@ -319,7 +321,7 @@ func getTotalOnchainCostHandler(c *gin.Context, db *sqlx.DB) {
 	}
 
 	chain := core.Bitcoin
-	networkNodeIds := cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network))
+	networkNodeIds := auth.RestrictNodeIds(c, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network)))
 
 	if all {
 		r.OnChainCost, err = getTotalOnChainCost(db, networkNodeIds, from, to)
@@ -262,15 +262,13 @@ func AddChannel(db *sqlx.DB, channel Channel) (Channel, error) {
 	return channel, nil
 }
 
-func getChannelsWithStatus(db *sqlx.DB, network core.Network, status []core.ChannelStatus) ([]Channel, error) {
+func getChannelsWithStatus(db *sqlx.DB, nodeIds []int, status []core.ChannelStatus) ([]Channel, error) {
 	var channels []Channel
-	bitcoin := core.Bitcoin
 	err := db.Select(&channels, `
 		SELECT *
 		FROM channel
 		WHERE (first_node_id = ANY($1) OR second_node_id = ANY($1)) AND status_id = ANY($2)
-		`, pq.Array(cache.GetAllActiveTorqNodeIds(&bitcoin, &network)),
-		pq.Array(status))
+		`, pq.Array(nodeIds), pq.Array(status))
 	if err != nil {
 		if errors.As(err, &sql.ErrNoRows) {
 			return nil, nil
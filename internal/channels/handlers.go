@@ -14,8 +14,10 @@ import (
 
 	"github.com/lncapital/torq/proto/lnrpc"
 
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/flow"
 	"github.com/lncapital/torq/internal/tags"
 
 	"github.com/lncapital/torq/pkg/server_errors"
@@ -87,6 +89,10 @@ type ChannelBody struct {
 	OneMl                        string               `json:"oneMl"`
 	PeerAlias                    string               `json:"peerAlias"`
 	Private                      bool                 `json:"private"`
+	NetFlowPerDay                *float64             `json:"netFlowPerDay"`
+	DaysUntilDepleted            *float64             `json:"daysUntilDepleted"`
+	DaysUntilSaturated           *float64             `json:"daysUntilSaturated"`
+	PeerScore                    *float64             `json:"peerScore"`
 }
 
 type PendingHtlcs struct {
@@ -157,10 +163,8 @@ type PendingOrClosedChannel struct {
 	ClosedOnSecondsDelta    *uint64    `json:"closedOnSecondsDelta"`
 }
 
-func GetChannelsByNetwork(network core.Network) ([]ChannelBody, error) {
+func GetChannelsByNodeIds(nodeIds []int) ([]ChannelBody, error) {
 	var channelsBody []ChannelBody
-	chain := core.Bitcoin
-	nodeIds := cache.GetAllTorqNodeIdsByNetwork(chain, network)
 	for _, nodeId := range nodeIds {
 		// Force Response because we don't care about balance accuracy
 		channelIds := cache.GetChannelStateChannelIds(nodeId, true)
@@ -175,6 +179,12 @@ func GetChannelsByNetwork(network core.Network) ([]ChannelBody, error) {
 
 func GetChannelsByIds(nodeId int, channelIds []int) ([]ChannelBody, error) {
 	var channelsBody []ChannelBody
+	// The forecast uses the balances that are available (so excluding pending HTLCs that are moving away)
+	balanceStates := make(map[int]cache.ChannelBalanceStateSettingsCache)
+	for _, balanceState := range cache.GetChannelBalanceStates(nodeId, true, cache.AllChannels,
+		cache.PendingHtlcsLocalAndRemoteBalanceAdjustedDownwards) {
+		balanceStates[balanceState.ChannelId] = balanceState
+	}
 	for _, channelId := range channelIds {
 		// Force Response because we don't care about balance accuracy
 		channel := cache.GetChannelState(nodeId, channelId, true)
@@ -264,6 +274,20 @@ func GetChannelsByIds(nodeId int, channelIds []int) ([]ChannelBody, error) {
 			Private:                      channelSettings.Private,
 		}
 
+		peerScore := cache.GetPeerScore(channel.RemoteNodeId)
+		if peerScore != nil {
+			chanBody.PeerScore = &peerScore.Score
+		}
+
+		balanceState, exists := balanceStates[channelSettings.ChannelId]
+		if exists {
+			forecast := flow.GetLiquidityForecast(nodeId, channelSettings.ChannelId,
+				balanceState.LocalBalance, balanceState.RemoteBalance)
+			chanBody.NetFlowPerDay = forecast.NetFlowPerDay
+			chanBody.DaysUntilDepleted = forecast.DaysUntilDepleted
+			chanBody.DaysUntilSaturated = forecast.DaysUntilSaturated
+		}
+
 		if channelSettings.FundingBlockHeight != nil {
 			delta := cache.GetBlockHeight() - *channelSettings.FundingBlockHeight
 			chanBody.FundingBlockHeightDelta = &delta
@@ -292,7 +316,8 @@ func getChannelListHandler(c *gin.Context) {
 		return
 	}
 
-	channelsBody, err := GetChannelsByNetwork(core.Network(network))
+	nodeIds := auth.RestrictNodeIds(c, cache.GetAllTorqNodeIdsByNetwork(core.Bitcoin, core.Network(network)))
+	channelsBody, err := GetChannelsByNodeIds(nodeIds)
 	if err != nil {
 		server_errors.WrapLogAndSendServerError(c, err, "Get channel tags for channel")
 		return
@@ -307,9 +332,10 @@ func getClosedChannelsListHandler(c *gin.Context, db *sqlx.DB) {
 		return
 	}
 
-	channels, err := getChannelsWithStatus(db, core.Network(network),
-		[]core.ChannelStatus{core.CooperativeClosed, core.LocalForceClosed, core.RemoteForceClosed,
-			core.BreachClosed, core.FundingCancelledClosed, core.AbandonedClosed})
+	bitcoin := core.Bitcoin
+	torqNetwork := core.Network(network)
+	nodeIds := auth.RestrictNodeIds(c, cache.GetAllActiveTorqNodeIds(&bitcoin, &torqNetwork))
+	closedChannels, err := getClosedChannels(db, nodeIds)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, server_errors.SingleServerError(err.Error()))
 		err = errors.Wrap(err, "Problem getting closed channels from db")
@@ -317,6 +343,17 @@ func getClosedChannelsListHandler(c *gin.Context, db *sqlx.DB) {
 		return
 	}
 
+	c.JSON(http.StatusOK, closedChannels)
+}
+
+func getClosedChannels(db *sqlx.DB, nodeIds []int) ([]PendingOrClosedChannel, error) {
+	channels, err := getChannelsWithStatus(db, nodeIds,
+		[]core.ChannelStatus{core.CooperativeClosed, core.LocalForceClosed, core.RemoteForceClosed,
+			core.BreachClosed, core.FundingCancelledClosed, core.AbandonedClosed})
+	if err != nil {
+		return nil, err
+	}
+
 	closedChannels := make([]PendingOrClosedChannel, len(channels))
 	torqNodeIds := cache.GetAllTorqNodeIds()
 
@@ -379,7 +416,7 @@ func getClosedChannelsListHandler(c *gin.Context, db *sqlx.DB) {
 
 	}
 
-	c.JSON(http.StatusOK, closedChannels)
+	return closedChannels, nil
 }
 
 func getChannelsPendingListHandler(c *gin.Context, db *sqlx.DB) {
@@ -389,7 +426,10 @@ func getChannelsPendingListHandler(c *gin.Context, db *sqlx.DB) {
 		return
 	}
 
-	channels, err := getChannelsWithStatus(db, core.Network(network),
+	bitcoin := core.Bitcoin
+	torqNetwork := core.Network(network)
+	nodeIds := auth.RestrictNodeIds(c, cache.GetAllActiveTorqNodeIds(&bitcoin, &torqNetwork))
+	channels, err := getChannelsWithStatus(db, nodeIds,
 		[]core.ChannelStatus{core.Opening, core.Closing})
 
 	if err != nil {
@@ -0,0 +1,113 @@
+package channels
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+func GetV1Endpoints(db *sqlx.DB) []ah.Endpoint {
+	return []ah.Endpoint{
+		{
+			Method:     http.MethodGet,
+			Path:       "/channels",
+			Resource:   "channels",
+			Summary:    "List the open and pending channels of the nodes",
+			Parameters: []ah.Parameter{ah.NodeIdParameter},
+			Response:   ChannelBody{},
+			Paginated:  true,
+			Handler:    getV1ChannelsHandler,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/channels/:channelId",
+			Resource:   "channels",
+			Summary:    "Get an open or pending channel",
+			Parameters: []ah.Parameter{ah.NodeIdParameter},
+			Response:   ChannelBody{},
+			Handler:    getV1ChannelHandler,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/channels/closed",
+			Resource:   "channels",
+			Summary:    "List the closed channels of the nodes",
+			Parameters: []ah.Parameter{ah.NodeIdParameter},
+			Response:   PendingOrClosedChannel{},
+			Paginated:  true,
+			Handler:    func(c *gin.Context) { getV1ClosedChannelsHandler(c, db) },
+		},
+	}
+}
+
+func getV1ChannelsHandler(c *gin.Context) {
+	pagination, err := ah.GetPagination(c)
+	if err != nil {
+		server_errors.SendBadRequestFromError(c, err)
+		return
+	}
+	nodeIds, err := auth.GetNodeIds(c)
+	if err != nil {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", err.Error()))
+		return
+	}
+	channelsBody, err := GetChannelsByNodeIds(nodeIds)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting channels.")
+		return
+	}
+	start, end := pagination.GetPage(len(channelsBody))
+	c.JSON(http.StatusOK, ah.ApiResponse{Data: channelsBody[start:end], Pagination: pagination})
+}
+
+func getV1ChannelHandler(c *gin.Context) {
+	channelId, err := strconv.Atoi(c.Param("channelId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse channelId in the request.")
+		return
+	}
+	nodeIds, err := auth.GetNodeIds(c)
+	if err != nil {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", err.Error()))
+		return
+	}
+	channelsBody, err := GetChannelsByNodeIds(nodeIds)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Getting channel for channelId: %v", channelId))
+		return
+	}
+	for _, channelBody := range channelsBody {
+		if channelBody.ChannelId == channelId {
+			c.JSON(http.StatusOK, channelBody)
+			return
+		}
+	}
+	server_errors.SendNotFound(c, "Channel not found")
+}
+
+func getV1ClosedChannelsHandler(c *gin.Context, db *sqlx.DB) {
+	pagination, err := ah.GetPagination(c)
+	if err != nil {
+		server_errors.SendBadRequestFromError(c, err)
+		return
+	}
+	nodeIds, err := auth.GetNodeIds(c)
+	if err != nil {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", err.Error()))
+		return
+	}
+	closedChannels, err := getClosedChannels(db, nodeIds)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting closed channels.")
+		return
+	}
+	start, end := pagination.GetPage(len(closedChannels))
+	c.JSON(http.StatusOK, ah.ApiResponse{Data: closedChannels[start:end], Pagination: pagination})
+}
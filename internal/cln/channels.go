@@ -8,13 +8,13 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/channels"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/graph_events"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/nodes"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/cln"
@@ -106,8 +106,8 @@ func listAndProcessChannels(ctx context.Context, db *sqlx.DB, client client_List
 
 	for _, openChannelId := range openChannelIds {
 		if !processedChannelIds[openChannelId] {
-			log.Info().Msgf("Channel with channelId: %v got dropped from the list for nodeId: %v",
-				openChannelId, nodeSettings.NodeId)
+			log.Info().Int(logging.NodeIdField, nodeSettings.NodeId).Msgf(
+				"Channel with channelId: %v got dropped from the list", openChannelId)
 			channel, err := channels.GetChannel(db, openChannelId)
 			if err != nil {
 				return errors.Wrapf(err, "obtaining dropped channel with channelId: %v for nodeId: %v",
@@ -138,7 +138,7 @@ func listAndProcessChannels(ctx context.Context, db *sqlx.DB, client client_List
 	}
 
 	if bootStrapping {
-		log.Info().Msgf("Initial import of peers is done for nodeId: %v", nodeSettings.NodeId)
+		log.Info().Int(logging.NodeIdField, nodeSettings.NodeId).Msg("Initial import of peers is done")
 		cache.SetActiveNodeServiceState(serviceType, nodeSettings.NodeId)
 	}
 	return nil
@@ -11,14 +11,16 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
-	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/graph_events"
 	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/pkg/cln_connect"
+	"github.com/lncapital/torq/pkg/tracing"
 	"github.com/lncapital/torq/proto/cln"
 )
 
@@ -66,7 +68,7 @@ func getConnection(nodeId int) (*grpc.ClientConn, error) {
 		conn, err := cln_connect.Connect(ncd.GRPCAddress, ncd.CertificateFileBytes, ncd.KeyFileBytes,
 			ncd.CaCertificateFileBytes)
 		if err != nil {
-			log.Error().Err(err).Msgf("GRPC connection Failed for node id: %v", nodeId)
+			log.Error().Err(err).Int(logging.NodeIdField, nodeId).Msg("GRPC connection Failed")
 			return nil, errors.Wrapf(err, "Connecting to GRPC.")
 		}
 		connectionWrapper.connections[nodeId] = conn
@@ -77,7 +79,7 @@ func getConnection(nodeId int) (*grpc.ClientConn, error) {
 		if exists && existingConnection != nil {
 			err = existingConnection.Close()
 			if err != nil {
-				log.Error().Err(err).Msgf("GRPC close connection failed for node id: %v", nodeId)
+				log.Error().Err(err).Int(logging.NodeIdField, nodeId).Msg("GRPC close connection failed")
 			}
 		}
 	}
@@ -88,10 +90,10 @@ type lightningService struct {
 	limit chan struct{}
 }
 
-func Information(
+func Information(ctx context.Context,
 	request lightning_helpers.InformationRequest) lightning_helpers.InformationResponse {
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.InformationResponse); ok {
 		return res
@@ -99,10 +101,10 @@ func Information(
 	return lightning_helpers.InformationResponse{}
 }
 
-func SignMessage(
+func SignMessage(ctx context.Context,
 	request lightning_helpers.SignMessageRequest) lightning_helpers.SignMessageResponse {
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.SignMessageResponse); ok {
 		return res
@@ -110,10 +112,10 @@ func SignMessage(
 	return lightning_helpers.SignMessageResponse{}
 }
 
-func SignatureVerification(
+func SignatureVerification(ctx context.Context,
 	request lightning_helpers.SignatureVerificationRequest) lightning_helpers.SignatureVerificationResponse {
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.SignatureVerificationResponse); ok {
 		return res
@@ -121,10 +123,10 @@ func SignatureVerification(
 	return lightning_helpers.SignatureVerificationResponse{}
 }
 
-func RoutingPolicyUpdate(
+func RoutingPolicyUpdate(ctx context.Context,
 	request lightning_helpers.RoutingPolicyUpdateRequest) lightning_helpers.RoutingPolicyUpdateResponse {
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.RoutingPolicyUpdateResponse); ok {
 		return res
@@ -132,10 +134,10 @@ func RoutingPolicyUpdate(
 	return lightning_helpers.RoutingPolicyUpdateResponse{}
 }
 
-func ConnectPeer(
+func ConnectPeer(ctx context.Context,
 	request lightning_helpers.ConnectPeerRequest) lightning_helpers.ConnectPeerResponse {
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 60, request, responseChan)
+	processConcurrent(ctx, 60, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.ConnectPeerResponse); ok {
 		return res
@@ -143,10 +145,10 @@ func ConnectPeer(
 	return lightning_helpers.ConnectPeerResponse{}
 }
 
-func DisconnectPeer(
+func DisconnectPeer(ctx context.Context,
 	request lightning_helpers.DisconnectPeerRequest) lightning_helpers.DisconnectPeerResponse {
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 60, request, responseChan)
+	processConcurrent(ctx, 60, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.DisconnectPeerResponse); ok {
 		return res
@@ -154,9 +156,11 @@ func DisconnectPeer(
 	return lightning_helpers.DisconnectPeerResponse{}
 }
 
-func WalletBalance(request lightning_helpers.WalletBalanceRequest) lightning_helpers.WalletBalanceResponse {
+func WalletBalance(ctx context.Context,
+	request lightning_helpers.WalletBalanceRequest) lightning_helpers.WalletBalanceResponse {
+
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.WalletBalanceResponse); ok {
 		return res
@@ -164,9 +168,9 @@ func WalletBalance(request lightning_helpers.WalletBalanceRequest) lightning_hel
 	return lightning_helpers.WalletBalanceResponse{}
 }
 
-func ListPeers(request lightning_helpers.ListPeersRequest) lightning_helpers.ListPeersResponse {
+func ListPeers(ctx context.Context, request lightning_helpers.ListPeersRequest) lightning_helpers.ListPeersResponse {
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 60, request, responseChan)
+	processConcurrent(ctx, 60, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.ListPeersResponse); ok {
 		return res
@@ -174,9 +178,9 @@ func ListPeers(request lightning_helpers.ListPeersRequest) lightning_helpers.Lis
 	return lightning_helpers.ListPeersResponse{}
 }
 
-func NewAddress(request lightning_helpers.NewAddressRequest) lightning_helpers.NewAddressResponse {
+func NewAddress(ctx context.Context, request lightning_helpers.NewAddressRequest) lightning_helpers.NewAddressResponse {
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.NewAddressResponse); ok {
 		return res
@@ -184,9 +188,11 @@ func NewAddress(request lightning_helpers.NewAddressRequest) lightning_helpers.N
 	return lightning_helpers.NewAddressResponse{}
 }
 
-func OpenChannel(request lightning_helpers.OpenChannelRequest) lightning_helpers.OpenChannelResponse {
+func OpenChannel(ctx context.Context,
+	request lightning_helpers.OpenChannelRequest) lightning_helpers.OpenChannelResponse {
+
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 300, request, responseChan)
+	processConcurrent(ctx, 300, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.OpenChannelResponse); ok {
 		return res
@@ -194,9 +200,11 @@ func OpenChannel(request lightning_helpers.OpenChannelRequest) lightning_helpers
 	return lightning_helpers.OpenChannelResponse{}
 }
 
-func CloseChannel(request lightning_helpers.CloseChannelRequest) lightning_helpers.CloseChannelResponse {
+func CloseChannel(ctx context.Context,
+	request lightning_helpers.CloseChannelRequest) lightning_helpers.CloseChannelResponse {
+
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 300, request, responseChan)
+	processConcurrent(ctx, 300, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.CloseChannelResponse); ok {
 		return res
@@ -204,9 +212,9 @@ func CloseChannel(request lightning_helpers.CloseChannelRequest) lightning_helpe
 	return lightning_helpers.CloseChannelResponse{}
 }
 
-func NewInvoice(request lightning_helpers.NewInvoiceRequest) lightning_helpers.NewInvoiceResponse {
+func NewInvoice(ctx context.Context, request lightning_helpers.NewInvoiceRequest) lightning_helpers.NewInvoiceResponse {
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.NewInvoiceResponse); ok {
 		return res
@@ -214,9 +222,11 @@ func NewInvoice(request lightning_helpers.NewInvoiceRequest) lightning_helpers.N
 	return lightning_helpers.NewInvoiceResponse{}
 }
 
-func OnChainPayment(request lightning_helpers.OnChainPaymentRequest) lightning_helpers.OnChainPaymentResponse {
+func OnChainPayment(ctx context.Context,
+	request lightning_helpers.OnChainPaymentRequest) lightning_helpers.OnChainPaymentResponse {
+
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.OnChainPaymentResponse); ok {
 		return res
@@ -224,9 +234,9 @@ func OnChainPayment(request lightning_helpers.OnChainPaymentRequest) lightning_h
 	return lightning_helpers.OnChainPaymentResponse{}
 }
 
-func NewPayment(request lightning_helpers.NewPaymentRequest) lightning_helpers.NewPaymentResponse {
+func NewPayment(ctx context.Context, request lightning_helpers.NewPaymentRequest) lightning_helpers.NewPaymentResponse {
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 120, request, responseChan)
+	processConcurrent(ctx, 120, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.NewPaymentResponse); ok {
 		return res
@@ -240,21 +250,28 @@ var serviceSequential = lightningService{limit: make(chan struct{}, 1)}
 var serviceConcurrent = lightningService{limit: make(chan struct{}, concurrentWorkLimit)} //nolint:gochecknoglobals
 
 func processSequential(ctx context.Context, timeoutInSeconds int, req any, responseChan chan any) {
+	// The span includes waiting for a free slot and ends once the request is processed.
+	// Only the trace of ctx is kept, the request is not cancelled with its caller.
+	ctx, span := tracing.StartNodeRequest(tracing.Detach(ctx), core.CLN.String(), req)
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutInSeconds)*time.Second)
 
 	select {
 	case <-ctx.Done():
+		tracing.EndSpan(span, ctx.Err())
 		cancel()
 		return
 	case serviceSequential.limit <- struct{}{}:
 	}
 
-	go processRequestSequential(ctx, cancel, req, responseChan)
+	go processRequestSequential(ctx, cancel, span, req, responseChan)
 }
 
-func processRequestSequential(ctx context.Context, cancel context.CancelFunc, req any, responseChan chan<- any) {
+func processRequestSequential(ctx context.Context, cancel context.CancelFunc, span trace.Span, req any,
+	responseChan chan<- any) {
+
 	defer func() {
+		tracing.EndSpan(span, ctx.Err())
 		cancel()
 		<-serviceSequential.limit
 	}()
@@ -270,21 +287,28 @@ func processRequestSequential(ctx context.Context, cancel context.CancelFunc, re
 }
 
 func processConcurrent(ctx context.Context, timeoutInSeconds int, req any, responseChan chan any) {
+	// The span includes waiting for a free slot and ends once the request is processed.
+	// Only the trace of ctx is kept, the request is not cancelled with its caller.
+	ctx, span := tracing.StartNodeRequest(tracing.Detach(ctx), core.CLN.String(), req)
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutInSeconds)*time.Second)
 
 	select {
 	case <-ctx.Done():
+		tracing.EndSpan(span, ctx.Err())
 		cancel()
 		return
 	case serviceConcurrent.limit <- struct{}{}:
 	}
 
-	go processRequestConcurrent(ctx, cancel, req, responseChan)
+	go processRequestConcurrent(ctx, cancel, span, req, responseChan)
 }
 
-func processRequestConcurrent(ctx context.Context, cancel context.CancelFunc, req any, responseChan chan<- any) {
+func processRequestConcurrent(ctx context.Context, cancel context.CancelFunc, span trace.Span, req any,
+	responseChan chan<- any) {
+
 	defer func() {
+		tracing.EndSpan(span, ctx.Err())
 		cancel()
 		<-serviceConcurrent.limit
 	}()
@@ -466,7 +490,9 @@ func processRoutingPolicyUpdateRequest(ctx context.Context,
 		return *response
 	}
 
+	_, span := tracing.StartCacheRequest(ctx, "GetChannelState")
 	channelState := cache.GetChannelState(request.NodeId, request.ChannelId, true)
+	span.End()
 	if channelState == nil {
 		return lightning_helpers.RoutingPolicyUpdateResponse{
 			CommunicationResponse: lightning_helpers.CommunicationResponse{
@@ -508,8 +534,8 @@ func processRoutingPolicyUpdateResponse(request lightning_helpers.RoutingPolicyU
 	err error) lightning_helpers.RoutingPolicyUpdateResponse {
 
 	if err != nil && resp == nil {
-		log.Error().Err(err).Msgf("Failed to update routing policy for channelId: %v on nodeId: %v",
-			request.ChannelId, request.NodeId)
+		log.Error().Err(err).Int(logging.NodeIdField, request.NodeId).Msgf(
+			"Failed to update routing policy for channelId: %v", request.ChannelId)
 		return lightning_helpers.RoutingPolicyUpdateResponse{
 			CommunicationResponse: lightning_helpers.CommunicationResponse{
 				Status: lightning_helpers.Inactive,
@@ -520,16 +546,18 @@ func processRoutingPolicyUpdateResponse(request lightning_helpers.RoutingPolicyU
 	var failedUpdateArray []lightning_helpers.FailedRequest
 	for _, failedUpdate := range resp.Channels {
 		if failedUpdate.WarningHtlcmaxTooHigh != nil {
-			log.Error().Msgf("Failed to update routing policy for channelId: %v on nodeId: %v (cln-grpc error: %v)",
-				request.ChannelId, request.NodeId, *failedUpdate.WarningHtlcmaxTooHigh)
+			log.Error().Int(logging.NodeIdField, request.NodeId).Msgf(
+				"Failed to update routing policy for channelId: %v (cln-grpc error: %v)",
+				request.ChannelId, *failedUpdate.WarningHtlcmaxTooHigh)
 			failedUpdateArray = append(failedUpdateArray, lightning_helpers.FailedRequest{
 				Reason: *failedUpdate.WarningHtlcmaxTooHigh,
 				Error:  *failedUpdate.WarningHtlcmaxTooHigh,
 			})
 		}
 		if failedUpdate.WarningHtlcminTooLow != nil {
-			log.Error().Msgf("Failed to update routing policy for channelId: %v on nodeId: %v (cln-grpc error: %v)",
-				request.ChannelId, request.NodeId, *failedUpdate.WarningHtlcminTooLow)
+			log.Error().Int(logging.NodeIdField, request.NodeId).Msgf(
+				"Failed to update routing policy for channelId: %v (cln-grpc error: %v)",
+				request.ChannelId, *failedUpdate.WarningHtlcminTooLow)
 			failedUpdateArray = append(failedUpdateArray, lightning_helpers.FailedRequest{
 				Reason: *failedUpdate.WarningHtlcminTooLow,
 				Error:  *failedUpdate.WarningHtlcminTooLow,
@@ -537,8 +565,8 @@ func processRoutingPolicyUpdateResponse(request lightning_helpers.RoutingPolicyU
 		}
 	}
 	if err != nil || len(failedUpdateArray) != 0 {
-		log.Error().Err(err).Msgf("Failed to update routing policy for channelId: %v on nodeId: %v",
-			request.ChannelId, request.NodeId)
+		log.Error().Err(err).Int(logging.NodeIdField, request.NodeId).Msgf(
+			"Failed to update routing policy for channelId: %v", request.ChannelId)
 		return lightning_helpers.RoutingPolicyUpdateResponse{
 			CommunicationResponse: lightning_helpers.CommunicationResponse{
 				Status: lightning_helpers.Inactive,
@@ -7,11 +7,11 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/channels"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/cln"
 )
@@ -74,7 +74,7 @@ func listAndProcessFunds(ctx context.Context, db *sqlx.DB, client client_ListFun
 	}
 
 	if bootStrapping {
-		log.Info().Msgf("Initial import of peers is done for nodeId: %v", nodeSettings.NodeId)
+		log.Info().Int(logging.NodeIdField, nodeSettings.NodeId).Msg("Initial import of peers is done")
 		cache.SetActiveNodeServiceState(serviceType, nodeSettings.NodeId)
 	}
 	return nil
@@ -104,7 +104,7 @@ func storeChannelFunds(db *sqlx.DB,
 			channelId = cache.GetChannelIdByFundingTransaction(&fti, &foi)
 		}
 		if channelId == 0 {
-			log.Info().Msgf("received funds for unknown channel for nodeId: %v", nodeSettings.NodeId)
+			log.Info().Int(logging.NodeIdField, nodeSettings.NodeId).Msg("received funds for unknown channel")
 			continue
 		}
 		if clnChannel.OurAmountMsat == nil || clnChannel.AmountMsat == nil {
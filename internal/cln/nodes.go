@@ -9,12 +9,12 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/graph_events"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/cln"
 )
@@ -85,7 +85,7 @@ func listAndProcessNodes(ctx context.Context, db *sqlx.DB, client client_ListNod
 		}
 
 		if bootStrapping {
-			log.Info().Msgf("Initial import of nodes is done for nodeId: %v", nodeSettings.NodeId)
+			log.Info().Int(logging.NodeIdField, nodeSettings.NodeId).Msg("Initial import of nodes is done")
 			cache.SetActiveNodeServiceState(serviceType, nodeSettings.NodeId)
 		}
 	}
@@ -7,11 +7,11 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/nodes"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/internal/settings"
@@ -64,7 +64,8 @@ func processError(ctx context.Context, serviceType services_helpers.ServiceType,
 		return
 	}
 	cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
-	log.Error().Err(err).Msgf("%v failed to process for nodeId: %v", serviceType.String(), nodeSettings.NodeId)
+	log.Error().Err(err).Int(logging.NodeIdField, nodeSettings.NodeId).Msgf(
+		"%v failed to process", serviceType.String())
 }
 
 func listAndProcessPeers(ctx context.Context, db *sqlx.DB, client client_ListPeers,
@@ -83,7 +84,7 @@ func listAndProcessPeers(ctx context.Context, db *sqlx.DB, client client_ListPee
 	}
 
 	if bootStrapping {
-		log.Info().Msgf("Initial import of peers is done for nodeId: %v", nodeSettings.NodeId)
+		log.Info().Int(logging.NodeIdField, nodeSettings.NodeId).Msg("Initial import of peers is done")
 		cache.SetActiveNodeServiceState(serviceType, nodeSettings.NodeId)
 	}
 	return nil
@@ -9,11 +9,11 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/internal/vector"
 	"github.com/lncapital/torq/proto/cln"
@@ -77,7 +77,7 @@ func listAndProcessTransactions(ctx context.Context, db *sqlx.DB, client client_
 	}
 
 	if bootStrapping {
-		log.Info().Msgf("Initial import of transactions is done for nodeId: %v", nodeSettings.NodeId)
+		log.Info().Int(logging.NodeIdField, nodeSettings.NodeId).Msg("Initial import of transactions is done")
 		cache.SetActiveNodeServiceState(serviceType, nodeSettings.NodeId)
 	}
 	return nil
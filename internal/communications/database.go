@@ -7,7 +7,6 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
-	"github.com/rs/zerolog/log"
 
 	"github.com/lncapital/torq/internal/database"
 )
@@ -22,6 +21,8 @@ type Communication struct {
 	TargetNumber              int64                   `json:"targetNumber" db:"target_number"`
 	NodeId                    int                     `json:"nodeId" db:"node_id"`
 	ChannelId                 *int                    `json:"channelId" db:"channel_id"`
+	DigestCron                *string                 `json:"digestCron" db:"digest_cron"`
+	DigestSentOn              *time.Time              `json:"digestSentOn" db:"digest_sent_on"`
 	CreatedOn                 time.Time               `json:"createdOn" db:"created_on"`
 	UpdatedOn                 time.Time               `json:"updatedOn" db:"updated_on"`
 }
@@ -114,11 +115,11 @@ func AddCommunication(db *sqlx.DB, communication Communication) (int, error) {
 	communication.UpdatedOn = communication.CreatedOn
 	err := db.QueryRowx(`INSERT INTO communication
     	(activation_flag_node_details, target_type, target_name, target_text, target_number,
-    	 node_id, channel_id, created_on, updated_on)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING communication_id;`,
+    	 node_id, channel_id, digest_cron, created_on, updated_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING communication_id;`,
 		communication.ActivationFlagNodeDetails, communication.TargetType, communication.TargetName,
 		communication.TargetText, communication.TargetNumber, communication.NodeId, communication.ChannelId,
-		communication.CreatedOn, communication.UpdatedOn).Scan(&communication.CommunicationId)
+		communication.DigestCron, communication.CreatedOn, communication.UpdatedOn).Scan(&communication.CommunicationId)
 	if err != nil {
 		return 0, errors.Wrap(err, database.SqlExecutionError)
 	}
@@ -208,12 +209,12 @@ func SetCommunication(db *sqlx.DB, communication Communication) (Communication,
 	res, err := db.Exec(`
 		UPDATE communication
 		SET activation_flag_node_details=$3, target_name=$4, target_type=$5, target_text=$6, target_number=$7,
-		    node_id=$8, channel_id=$9, updated_on=$10
+		    node_id=$8, channel_id=$9, digest_cron=$10, updated_on=$11
 		WHERE communication_id=$1 AND updated_on=$2;`,
 		communication.CommunicationId, communication.UpdatedOn,
 		communication.ActivationFlagNodeDetails, communication.TargetName, communication.TargetType,
 		communication.TargetText, communication.TargetNumber,
-		communication.NodeId, communication.ChannelId, updatedOn)
+		communication.NodeId, communication.ChannelId, communication.DigestCron, updatedOn)
 	if err != nil {
 		return Communication{}, errors.Wrap(err, database.SqlExecutionError)
 	}
@@ -228,6 +229,28 @@ func SetCommunication(db *sqlx.DB, communication Communication) (Communication,
 	return communication, nil
 }
 
+func GetCommunicationsWithDigest(db *sqlx.DB) ([]Communication, error) {
+	var communications []Communication
+	err := db.Select(&communications,
+		`SELECT * FROM communication WHERE digest_cron IS NOT NULL AND digest_cron != '' AND channel_id IS NULL;`)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(err, database.SqlExecutionError)
+		}
+	}
+	return communications, nil
+}
+
+// SetCommunicationDigestSentOn does not touch updated_on so it can't conflict with concurrent settings changes.
+func SetCommunicationDigestSentOn(db *sqlx.DB, communicationId int, digestSentOn time.Time) error {
+	_, err := db.Exec(`UPDATE communication SET digest_sent_on=$2 WHERE communication_id=$1;`,
+		communicationId, digestSentOn)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
 func GetCommunicationIdsByTargetNumber(db *sqlx.DB, chatId int64) ([]int, error) {
 	var communicationIds []int
 	err := db.Select(&communicationIds, `SELECT communication_id FROM communication WHERE target_number=$1;`, chatId)
@@ -0,0 +1,312 @@
+package communications
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/robfig/cron/v3"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/database"
+)
+
+// The digest report covers the 24 hours before it's sent
+const digestPeriod = 24 * time.Hour
+
+const digestTopChannelCount = 5
+
+type digestReport struct {
+	NodeId             int
+	From               time.Time
+	To                 time.Time
+	ForwardCount       int64
+	Revenue            int64
+	TopChannels        []digestChannelRevenue
+	RebalanceCount     int64
+	RebalanceAmount    int64
+	RebalanceCost      int64
+	OpenedChannels     []digestChannel
+	ClosedChannels     []digestChannel
+	OfflinePeerNodeIds []int
+}
+
+type digestChannelRevenue struct {
+	ChannelId    int   `db:"channel_id"`
+	Revenue      int64 `db:"revenue"`
+	AmountOut    int64 `db:"amount_out"`
+	ForwardCount int64 `db:"forward_count"`
+}
+
+type digestChannel struct {
+	ChannelId int `db:"channel_id"`
+}
+
+type digestWorkflowErrors struct {
+	WorkflowName string `db:"workflow_name"`
+	ErrorCount   int64  `db:"error_count"`
+}
+
+// digestTarget identifies the Slack channel or Telegram chat of a communication, a target can have a communication
+// for every node.
+type digestTarget struct {
+	TargetType   CommunicationTargetType
+	TargetText   string
+	TargetNumber int64
+}
+
+// sendDueDigests sends the digest report to every communication target whose digest cron schedule passed
+// since the last digest (or since the digest was configured).
+func sendDueDigests(db *sqlx.DB, now time.Time) error {
+	communications, err := GetCommunicationsWithDigest(db)
+	if err != nil {
+		return errors.Wrap(err, "Getting communications with digest")
+	}
+	reports := make(map[nodeIdType]string)
+	// Workflows are not linked to a node so their errors are sent once per target instead of in every node digest
+	var workflowErrors *string
+	workflowErrorsSent := make(map[digestTarget]bool)
+	for _, communication := range communications {
+		due, err := isDigestDue(communication, now)
+		if err != nil {
+			log.Error().Err(err).Msgf("Invalid digest cron for communicationId: %v", communication.CommunicationId)
+			continue
+		}
+		if !due {
+			continue
+		}
+		message, exists := reports[nodeIdType(communication.NodeId)]
+		if !exists {
+			report, err := getDigestReport(db, communication.NodeId, now.Add(-digestPeriod), now)
+			if err != nil {
+				log.Error().Err(err).Msgf("Failed to build the digest report for nodeId: %v", communication.NodeId)
+				continue
+			}
+			message = renderDigestReport(report)
+			reports[nodeIdType(communication.NodeId)] = message
+		}
+		target := digestTarget{
+			TargetType:   communication.TargetType,
+			TargetText:   communication.TargetText,
+			TargetNumber: communication.TargetNumber,
+		}
+		if !workflowErrorsSent[target] && workflowErrors == nil {
+			errorCounts, err := getDigestWorkflowErrors(db, now.Add(-digestPeriod), now)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to get the workflow errors of the digest")
+			} else {
+				rendered := renderDigestWorkflowErrors(errorCounts)
+				workflowErrors = &rendered
+			}
+		}
+		if !workflowErrorsSent[target] && workflowErrors != nil {
+			message = message + "\n" + *workflowErrors
+			workflowErrorsSent[target] = true
+		}
+		sendBotMessages(message, []Communication{communication})
+		err = SetCommunicationDigestSentOn(db, communication.CommunicationId, now)
+		if err != nil {
+			return errors.Wrapf(err, "Storing digest sent on for communicationId: %v", communication.CommunicationId)
+		}
+	}
+	return nil
+}
+
+func isDigestDue(communication Communication, now time.Time) (bool, error) {
+	if communication.DigestCron == nil || strings.TrimSpace(*communication.DigestCron) == "" {
+		return false, nil
+	}
+	schedule, err := cron.ParseStandard(strings.TrimSpace(*communication.DigestCron))
+	if err != nil {
+		return false, errors.Wrapf(err, "Parsing digest cron %v", *communication.DigestCron)
+	}
+	lastSentOn := communication.UpdatedOn
+	if communication.DigestSentOn != nil && communication.DigestSentOn.After(lastSentOn) {
+		lastSentOn = *communication.DigestSentOn
+	}
+	// Cron expressions are evaluated in UTC
+	return !schedule.Next(lastSentOn.UTC()).After(now), nil
+}
+
+func getDigestReport(db *sqlx.DB, nodeId int, from time.Time, to time.Time) (digestReport, error) {
+	report := digestReport{NodeId: nodeId, From: from, To: to}
+
+	err := db.QueryRow(`
+		SELECT count(*), coalesce(floor(sum(fee_msat)/1000), 0)::bigint
+		FROM forward
+		WHERE node_id=$1 AND time >= $2 AND time < $3;`, nodeId, from, to).
+		Scan(&report.ForwardCount, &report.Revenue)
+	if err != nil {
+		return digestReport{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+
+	err = db.Select(&report.TopChannels, `
+		SELECT outgoing_channel_id AS channel_id,
+			floor(sum(fee_msat)/1000)::bigint AS revenue,
+			floor(sum(outgoing_amount_msat)/1000)::bigint AS amount_out,
+			count(*) AS forward_count
+		FROM forward
+		WHERE node_id=$1 AND time >= $2 AND time < $3 AND outgoing_channel_id IS NOT NULL
+		GROUP BY outgoing_channel_id
+		ORDER BY revenue DESC, amount_out DESC
+		LIMIT $4;`, nodeId, from, to, digestTopChannelCount)
+	if err != nil {
+		return digestReport{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+
+	// Rebalances are payments where the last hop is the node itself
+	err = db.QueryRow(`
+		SELECT count(*), coalesce(floor(sum(value_msat)/1000), 0)::bigint, coalesce(floor(sum(fee_msat)/1000), 0)::bigint
+		FROM payment
+		WHERE node_id=$1 AND status = 'SUCCEEDED' AND
+			htlcs->-1->'route'->'hops'->-1->>'pub_key' = $2 AND
+			creation_timestamp >= $3 AND creation_timestamp < $4;`,
+		nodeId, cache.GetNodeSettingsByNodeId(nodeId).PublicKey, from, to).
+		Scan(&report.RebalanceCount, &report.RebalanceAmount, &report.RebalanceCost)
+	if err != nil {
+		return digestReport{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+
+	err = db.Select(&report.OpenedChannels, `
+		SELECT channel_id
+		FROM channel
+		WHERE (first_node_id=$1 OR second_node_id=$1) AND funded_on >= $2 AND funded_on < $3
+		ORDER BY funded_on;`, nodeId, from, to)
+	if err != nil {
+		return digestReport{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+
+	err = db.Select(&report.ClosedChannels, `
+		SELECT channel_id
+		FROM channel
+		WHERE (first_node_id=$1 OR second_node_id=$1) AND closed_on >= $2 AND closed_on < $3
+		ORDER BY closed_on;`, nodeId, from, to)
+	if err != nil {
+		return digestReport{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+
+	// Peers that disconnected during the period and did not reconnect since
+	err = db.Select(&report.OfflinePeerNodeIds, `
+		SELECT node_id
+		FROM (
+			SELECT DISTINCT ON (node_id) node_id, connection_status, created_on
+			FROM node_connection_history
+			WHERE torq_node_id=$1
+			ORDER BY node_id, created_on DESC
+		) AS latest
+		WHERE connection_status=$2 AND created_on >= $3 AND created_on < $4;`,
+		nodeId, core.NodeConnectionStatusDisconnected, from, to)
+	if err != nil {
+		return digestReport{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+
+	return report, nil
+}
+
+func getDigestWorkflowErrors(db *sqlx.DB, from time.Time, to time.Time) ([]digestWorkflowErrors, error) {
+	var workflowErrors []digestWorkflowErrors
+	err := db.Select(&workflowErrors, `
+		SELECT w.name AS workflow_name, count(*) AS error_count
+		FROM workflow_version_node_log wfvnl
+		JOIN workflow_version_node wfvn ON wfvn.workflow_version_node_id=wfvnl.workflow_version_node_id
+		JOIN workflow_version wfv ON wfv.workflow_version_id=wfvn.workflow_version_id
+		JOIN workflow w ON w.workflow_id=wfv.workflow_id
+		WHERE wfvnl.error_data != '' AND wfvnl.created_on >= $1 AND wfvnl.created_on < $2
+		GROUP BY w.name
+		ORDER BY error_count DESC, w.name;`, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return workflowErrors, nil
+}
+
+// renderDigestReport renders the report as plain text so it can be sent to both Slack and Telegram.
+func renderDigestReport(report digestReport) string {
+	// TODO FIXME Language from user for translations
+	var sb strings.Builder
+	nodeSettings := cache.GetNodeSettingsByNodeId(report.NodeId)
+	nodeName := nodeSettings.PublicKey
+	if nodeSettings.Name != nil && *nodeSettings.Name != "" {
+		nodeName = *nodeSettings.Name
+	}
+	sb.WriteString(fmt.Sprintf("Digest for %v (%v - %v UTC)\n\n",
+		nodeName, report.From.UTC().Format("2006-01-02 15:04"), report.To.UTC().Format("2006-01-02 15:04")))
+
+	sb.WriteString(fmt.Sprintf("Forwarding revenue: %v sats from %v forwards\n", report.Revenue, report.ForwardCount))
+	if len(report.TopChannels) != 0 {
+		sb.WriteString("Top channels:\n")
+		for _, channel := range report.TopChannels {
+			sb.WriteString(fmt.Sprintf("- %v: %v sats (%v forwards, %v sats out)\n",
+				getDigestChannelName(report.NodeId, channel.ChannelId),
+				channel.Revenue, channel.ForwardCount, channel.AmountOut))
+		}
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(fmt.Sprintf("Rebalance spend: %v sats for %v sats in %v rebalances\n\n",
+		report.RebalanceCost, report.RebalanceAmount, report.RebalanceCount))
+
+	writeDigestChannels(&sb, "Opened channels", report.NodeId, report.OpenedChannels)
+	writeDigestChannels(&sb, "Closed channels", report.NodeId, report.ClosedChannels)
+
+	if len(report.OfflinePeerNodeIds) == 0 {
+		sb.WriteString("Peers that went offline: none\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Peers that went offline: %v\n", len(report.OfflinePeerNodeIds)))
+		for _, peerNodeId := range report.OfflinePeerNodeIds {
+			sb.WriteString(fmt.Sprintf("- %v\n", getDigestNodeName(peerNodeId)))
+		}
+	}
+
+	return sb.String()
+}
+
+func renderDigestWorkflowErrors(workflowErrors []digestWorkflowErrors) string {
+	if len(workflowErrors) == 0 {
+		return "Workflow errors (all nodes): none\n"
+	}
+	var sb strings.Builder
+	sb.WriteString("Workflow errors (all nodes):\n")
+	for _, workflowError := range workflowErrors {
+		sb.WriteString(fmt.Sprintf("- %v: %v\n", workflowError.WorkflowName, workflowError.ErrorCount))
+	}
+	return sb.String()
+}
+
+func writeDigestChannels(sb *strings.Builder, heading string, nodeId int, channels []digestChannel) {
+	if len(channels) == 0 {
+		sb.WriteString(fmt.Sprintf("%v: none\n", heading))
+		return
+	}
+	sb.WriteString(fmt.Sprintf("%v: %v\n", heading, len(channels)))
+	for _, channel := range channels {
+		sb.WriteString(fmt.Sprintf("- %v\n", getDigestChannelName(nodeId, channel.ChannelId)))
+	}
+}
+
+func getDigestChannelName(nodeId int, channelId int) string {
+	channelSettings := cache.GetChannelSettingByChannelId(channelId)
+	channelName := fmt.Sprintf("%v", channelId)
+	if channelSettings.ShortChannelId != nil && *channelSettings.ShortChannelId != "" {
+		channelName = *channelSettings.ShortChannelId
+	}
+	peerNodeId := channelSettings.FirstNodeId
+	if peerNodeId == nodeId {
+		peerNodeId = channelSettings.SecondNodeId
+	}
+	if peerNodeId == 0 {
+		return channelName
+	}
+	return fmt.Sprintf("%v (%v)", getDigestNodeName(peerNodeId), channelName)
+}
+
+func getDigestNodeName(nodeId int) string {
+	alias := cache.GetNodeAlias(nodeId)
+	if alias != "" {
+		return alias
+	}
+	return cache.GetNodeSettingsByNodeId(nodeId).PublicKey
+}
@@ -0,0 +1,101 @@
+package communications
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsDigestDue(t *testing.T) {
+	dailyAtEight := "0 8 * * *"
+	invalid := "every morning"
+	empty := ""
+	configuredOn := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	sentOn := time.Date(2023, 3, 2, 8, 0, 10, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		communication Communication
+		now           time.Time
+		due           bool
+		wantErr       bool
+	}{
+		{
+			name:          "no digest",
+			communication: Communication{UpdatedOn: configuredOn},
+			now:           configuredOn.Add(48 * time.Hour),
+		},
+		{
+			name:          "empty digest",
+			communication: Communication{DigestCron: &empty, UpdatedOn: configuredOn},
+			now:           configuredOn.Add(48 * time.Hour),
+		},
+		{
+			name:          "not due yet since configured",
+			communication: Communication{DigestCron: &dailyAtEight, UpdatedOn: configuredOn},
+			now:           time.Date(2023, 3, 2, 7, 59, 0, 0, time.UTC),
+		},
+		{
+			name:          "due since configured",
+			communication: Communication{DigestCron: &dailyAtEight, UpdatedOn: configuredOn},
+			now:           time.Date(2023, 3, 2, 8, 0, 30, 0, time.UTC),
+			due:           true,
+		},
+		{
+			name:          "already sent",
+			communication: Communication{DigestCron: &dailyAtEight, UpdatedOn: configuredOn, DigestSentOn: &sentOn},
+			now:           time.Date(2023, 3, 2, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "due again the next day",
+			communication: Communication{DigestCron: &dailyAtEight, UpdatedOn: configuredOn, DigestSentOn: &sentOn},
+			now:           time.Date(2023, 3, 3, 8, 0, 0, 0, time.UTC),
+			due:           true,
+		},
+		{
+			name:          "invalid cron",
+			communication: Communication{DigestCron: &invalid, UpdatedOn: configuredOn},
+			now:           configuredOn.Add(48 * time.Hour),
+			wantErr:       true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			due, err := isDigestDue(tc.communication, tc.now)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("isDigestDue error got %v, wantErr %v", err, tc.wantErr)
+			}
+			if due != tc.due {
+				t.Errorf("isDigestDue got %v, want %v", due, tc.due)
+			}
+		})
+	}
+}
+
+func TestRenderDigestWorkflowErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		workflowErrors []digestWorkflowErrors
+		want           string
+	}{
+		{
+			name: "no errors",
+			want: "Workflow errors (all nodes): none\n",
+		},
+		{
+			name: "errors",
+			workflowErrors: []digestWorkflowErrors{
+				{WorkflowName: "Rebalance", ErrorCount: 3},
+				{WorkflowName: "Fees", ErrorCount: 1},
+			},
+			want: "Workflow errors (all nodes):\n- Rebalance: 3\n- Fees: 1\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := renderDigestWorkflowErrors(tc.workflowErrors)
+			if got != tc.want {
+				t.Errorf("renderDigestWorkflowErrors got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -10,15 +10,17 @@ import (
 	"github.com/cockroachdb/errors"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
+	"github.com/robfig/cron/v3"
 	"golang.org/x/exp/slices"
 
 	"github.com/lncapital/torq/build"
+	"github.com/lncapital/torq/internal/audit"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/lightning"
 	"github.com/lncapital/torq/internal/lightning_helpers"
 	"github.com/lncapital/torq/internal/services_helpers"
+	"github.com/lncapital/torq/internal/spending"
 )
 
 type MessageForBot struct {
@@ -121,13 +123,16 @@ const (
 	UnregisterButton = "unregister"
 	SettingsButton   = "settings"
 	PublicKeyButton  = "publickey"
+	DigestButton     = "digest"
+	ApproveButton    = "approve"
+	RejectButton     = "reject"
 
 	ActivateNodeDetailButton   = "nodeDetailsActivate"
 	DeactivateNodeDetailButton = "nodeDetailsDeactivate"
 )
 
-func getButtons() [7]string {
-	return [7]string{MenuButton, VectorButton, StatusButton, RegisterButton, UnregisterButton, SettingsButton, PublicKeyButton} //, PingButton}
+func getButtons() [10]string {
+	return [10]string{MenuButton, VectorButton, StatusButton, RegisterButton, UnregisterButton, SettingsButton, PublicKeyButton, DigestButton, ApproveButton, RejectButton} //, PingButton}
 }
 
 func Notify(ctx context.Context, db *sqlx.DB) {
@@ -149,6 +154,14 @@ func Notify(ctx context.Context, db *sqlx.DB) {
 			cache.SetInactiveCoreServiceState(serviceType)
 			return
 		case <-ticker.C:
+			err := sendDueDigests(db, time.Now())
+			if err != nil {
+				log.Error().Err(err).Msg("Sending the digest reports failed")
+			}
+			err = sendSpendingApprovalRequests(db, time.Now())
+			if err != nil {
+				log.Error().Err(err).Msg("Sending the spending approval requests failed")
+			}
 			for _, torqNodeSettings := range cache.GetActiveTorqNodeSettings() {
 				communications, err := GetCommunicationsForNodeDetails(db,
 					torqNodeSettings.NodeId,
@@ -164,7 +177,7 @@ func Notify(ctx context.Context, db *sqlx.DB) {
 						torqNodeSettings.NodeId)
 					continue
 				}
-				newInformation, err := lightning.GetInformation(torqNodeSettings.NodeId)
+				newInformation, err := lightning.GetInformation(ctx, torqNodeSettings.NodeId)
 				if err != nil {
 					if errors.Is(err, lightning.ServiceInactiveError) {
 						_, exists := informationResponses[nodeIdType(torqNodeSettings.NodeId)]
@@ -397,11 +410,20 @@ func HandleMessage(db *sqlx.DB,
 		messageForBot = processUnregisterRequest(db, communicationTargetType, messageForBot)
 	case PublicKeyButton:
 		PublicKeys[communicationTargetType][messageForBot.GetChannelIdentifier()] = publicKeyFromChannel
+	case DigestButton:
+		messageForBot = processDigestRequest(db, communicationTargetType, publicKeyFromChannel, messageForBot)
+	case ApproveButton, RejectButton:
+		messageForBot = processSpendingDecision(db, communicationTargetType, commandFromChannel, publicKeyFromChannel,
+			messageForBot)
 	case MenuButton:
 		fallthrough
 	default:
 		messageForBot.Menus = []Menu{MenuMain}
 	}
+	switch commandFromChannel {
+	case RegisterButton, UnregisterButton, DigestButton:
+		recordBotAction(db, messageForBot, commandFromChannel, publicKeyFromChannel, communicationTargetType)
+	}
 	if messageForBot.HasMessage() || messageForBot.HasMenu() {
 		switch communicationTargetType {
 		case CommunicationSlack:
@@ -432,6 +454,14 @@ func HandleButton(db *sqlx.DB,
 	default:
 		messageForBot = processSettingsRequest(db, communicationTargetType, messageFromChannel, messageForBot)
 	}
+	switch commandFromChannel {
+	case RegisterButton, UnregisterButton:
+		recordBotAction(db, messageForBot, commandFromChannel, messageFromChannel, communicationTargetType)
+	case SettingsButton:
+		if messageFromChannel != "" {
+			recordBotAction(db, messageForBot, commandFromChannel, messageFromChannel, communicationTargetType)
+		}
+	}
 	if messageForBot.HasMessage() || messageForBot.HasMenu() {
 		switch communicationTargetType {
 		case CommunicationSlack:
@@ -442,6 +472,36 @@ func HandleButton(db *sqlx.DB,
 	}
 }
 
+func getBotActor(messageForBot MessageForBot) audit.Actor {
+	actor := audit.Actor{Type: audit.ActorBot, Id: messageForBot.GetChannelIdentifier()}
+	if messageForBot.IsSlack() {
+		actor.Name = messageForBot.Slack.ReplyTo
+	}
+	if messageForBot.IsTelegram() {
+		actor.Name = messageForBot.Telegram.UserName
+	}
+	return actor
+}
+
+// recordBotAction adds the state-changing bot commands to the audit log with the chat as actor
+func recordBotAction(db *sqlx.DB,
+	messageForBot MessageForBot,
+	command string,
+	text string,
+	communicationTargetType CommunicationTargetType) {
+
+	actor := getBotActor(messageForBot)
+	var err error
+	if messageForBot.Error != "" {
+		err = errors.New(messageForBot.Error)
+	}
+	audit.Record(db, actor, "bot "+command, "communication", "", map[string]interface{}{
+		"targetType": communicationTargetType,
+		"text":       text,
+		"response":   messageForBot.Message,
+	}, err)
+}
+
 func processSettingsRequest(db *sqlx.DB,
 	communicationTargetType CommunicationTargetType,
 	settings string,
@@ -533,7 +593,7 @@ func processSettingsRequest(db *sqlx.DB,
 			log.Error().Err(err).Msgf(
 				"Failed to persist communication fro: %v parameter: %v",
 				messageForBot.GetChannelIdentifier(), settings)
-			messageForBot.Message = "We could store the settings."
+			messageForBot.Message = "We could not store the settings."
 			messageForBot.Error = err.Error()
 			return messageForBot
 		}
@@ -624,6 +684,196 @@ func processUnregisterRequest(db *sqlx.DB,
 	return messageForBot
 }
 
+// processDigestRequest shows (without parameter), disables (off) or sets (cron expression) the digest schedule.
+func processDigestRequest(db *sqlx.DB,
+	communicationTargetType CommunicationTargetType,
+	digestCron string,
+	messageForBot MessageForBot) MessageForBot {
+
+	var nodeIds []int
+	nodeIds, messageForBot = getNodeIds(db, communicationTargetType, "", messageForBot)
+	if messageForBot.HasMessage() {
+		return messageForBot
+	}
+	var communications []Communication
+	for _, nodeId := range nodeIds {
+		nodeCommunications, err := getChatCommunications(db, communicationTargetType, nodeId, messageForBot)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to obtain communication for nodeId: %v", nodeId)
+			messageForBot.Message = "Something went wrong (code: gcd)."
+			messageForBot.Error = err.Error()
+			return messageForBot
+		}
+		communications = append(communications, nodeCommunications...)
+	}
+	if len(communications) == 0 {
+		messageForBot.Message = "/register > Node Registration"
+		return messageForBot
+	}
+
+	digestCron = strings.TrimSpace(digestCron)
+	if digestCron == "" {
+		for _, communication := range communications {
+			if communication.DigestCron != nil && *communication.DigestCron != "" {
+				messageForBot.Message = fmt.Sprintf("Digest schedule: %v\n"+
+					"Use /digest off to disable or /digest <cron expression> to change it.", *communication.DigestCron)
+				return messageForBot
+			}
+		}
+		messageForBot.Message = "No digest scheduled.\nUse /digest <cron expression> i.e. /digest 0 8 * * *"
+		return messageForBot
+	}
+
+	var newDigestCron *string
+	if digestCron != "off" {
+		_, err := cron.ParseStandard(digestCron)
+		if err != nil {
+			messageForBot.Message = "We could not parse the cron expression.\nUse i.e. /digest 0 8 * * * for every day at 08:00 UTC."
+			messageForBot.Error = err.Error()
+			return messageForBot
+		}
+		newDigestCron = &digestCron
+	}
+	for _, communication := range communications {
+		communication.DigestCron = newDigestCron
+		_, err := SetCommunication(db, communication)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to persist digest cron for communicationId: %v",
+				communication.CommunicationId)
+			messageForBot.Message = "We could not store the settings."
+			messageForBot.Error = err.Error()
+			return messageForBot
+		}
+	}
+	if newDigestCron == nil {
+		messageForBot.Message = "Digest disabled."
+		return messageForBot
+	}
+	messageForBot.Message = fmt.Sprintf("Digest scheduled: %v", digestCron)
+	return messageForBot
+}
+
+// getChatCommunications returns the communications of the node that belong to the chat the message came from
+func getChatCommunications(db *sqlx.DB,
+	communicationTargetType CommunicationTargetType,
+	nodeId int,
+	messageForBot MessageForBot) ([]Communication, error) {
+
+	nodeCommunications, err := GetCommunicationsByNodeIdAndTargetTypes(db, nodeId, communicationTargetType)
+	if err != nil {
+		return nil, err
+	}
+	var communications []Communication
+	for _, communication := range nodeCommunications {
+		if messageForBot.IsSlack() && communication.TargetText != messageForBot.Slack.Channel {
+			continue
+		}
+		if messageForBot.IsTelegram() && communication.TargetNumber != messageForBot.Telegram.Id {
+			continue
+		}
+		communications = append(communications, communication)
+	}
+	return communications, nil
+}
+
+// processSpendingDecision approves or rejects a spending request that exceeds the spending limits.
+// Only chats that are registered for the node of the request can decide on it.
+func processSpendingDecision(db *sqlx.DB,
+	communicationTargetType CommunicationTargetType,
+	command string,
+	spendingRequestIdText string,
+	messageForBot MessageForBot) MessageForBot {
+
+	spendingRequestId, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(spendingRequestIdText), "#"))
+	if err != nil {
+		messageForBot.Message = fmt.Sprintf("Use /%v <request number>", command)
+		return messageForBot
+	}
+	spendingRequest, err := spending.GetRequest(db, spendingRequestId)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to obtain spending request: %v", spendingRequestId)
+		messageForBot.Message = "Something went wrong (code: gsr)."
+		messageForBot.Error = err.Error()
+		return messageForBot
+	}
+	var communications []Communication
+	if spendingRequest != nil {
+		communications, err = getChatCommunications(db, communicationTargetType, spendingRequest.NodeId, messageForBot)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to obtain communication for nodeId: %v", spendingRequest.NodeId)
+			messageForBot.Message = "Something went wrong (code: gcd)."
+			messageForBot.Error = err.Error()
+			return messageForBot
+		}
+	}
+	if len(communications) == 0 {
+		messageForBot.Message = fmt.Sprintf("Spending request %v does not exist.", spendingRequestId)
+		return messageForBot
+	}
+
+	actor := getBotActor(messageForBot)
+	var reason string
+	var decidedRequest spending.Request
+	if command == ApproveButton {
+		decidedRequest, reason, err = lightning.ApproveSpendingRequest(context.Background(), db, spendingRequestId, actor)
+	} else {
+		decidedRequest, reason, err = spending.Reject(db, spendingRequestId, actor)
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to %v spending request: %v", command, spendingRequestId)
+		messageForBot.Message = "Something went wrong (code: dsr)."
+		messageForBot.Error = err.Error()
+		return messageForBot
+	}
+	if reason != "" {
+		messageForBot.Message = reason + "."
+		return messageForBot
+	}
+	switch decidedRequest.Status {
+	case spending.Executed:
+		messageForBot.Message = fmt.Sprintf("Spending request %v approved and executed.", spendingRequestId)
+	case spending.Failed:
+		messageForBot.Message = fmt.Sprintf("Spending request %v approved but it failed: %v",
+			spendingRequestId, *decidedRequest.Error)
+	default:
+		messageForBot.Message = fmt.Sprintf("Spending request %v %v.", spendingRequestId, decidedRequest.Status)
+	}
+	return messageForBot
+}
+
+// sendSpendingApprovalRequests asks the chats of the node to approve the spending requests that exceed the limits
+func sendSpendingApprovalRequests(db *sqlx.DB, now time.Time) error {
+	spendingRequests, err := spending.GetRequestsToNotify(db)
+	if err != nil {
+		return errors.Wrap(err, "Getting spending requests to notify")
+	}
+	for _, spendingRequest := range spendingRequests {
+		communications, err := GetCommunicationsByNodeIdAndTargetTypes(db, spendingRequest.NodeId,
+			CommunicationTelegramHighPriority, CommunicationTelegramLowPriority, CommunicationSlack)
+		if err != nil {
+			return errors.Wrapf(err, "Getting communications for nodeId: %v", spendingRequest.NodeId)
+		}
+		if len(communications) != 0 {
+			// TODO FIXME Language from user for translations
+			requester := spendingRequest.GetRequester()
+			message := fmt.Sprintf("Approval required for spending request %v: %v of %v sats requested by %v (%v)",
+				spendingRequest.SpendingRequestId, spendingRequest.Operation, spendingRequest.AmountSat,
+				requester.Name, requester.Type)
+			if spendingRequest.Reason != nil {
+				message += "\n" + *spendingRequest.Reason
+			}
+			message += fmt.Sprintf("\nUse /%v %v or /%v %v",
+				ApproveButton, spendingRequest.SpendingRequestId, RejectButton, spendingRequest.SpendingRequestId)
+			sendBotMessages(message, communications)
+		}
+		err = spending.SetRequestNotifiedOn(db, spendingRequest.SpendingRequestId, now)
+		if err != nil {
+			return errors.Wrapf(err, "Storing notified on for spendingRequestId: %v", spendingRequest.SpendingRequestId)
+		}
+	}
+	return nil
+}
+
 func processStatusRequest(db *sqlx.DB,
 	communicationTargetType CommunicationTargetType,
 	publicKey string,
@@ -636,7 +886,7 @@ func processStatusRequest(db *sqlx.DB,
 		return messageForBot
 	}
 	for _, nodeId := range nodeIds {
-		information, err := lightning.GetInformation(nodeId)
+		information, err := lightning.GetInformation(context.Background(), nodeId)
 		if err != nil {
 			messageForBot.Error = err.Error()
 			messageForBot.Message = "Lightning node is offline."
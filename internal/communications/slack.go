@@ -11,10 +11,9 @@ import (
 	"github.com/slack-go/slack/slackevents"
 	"github.com/slack-go/slack/socketmode"
 
-	"github.com/rs/zerolog/log"
-
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/services_helpers"
+	"github.com/lncapital/torq/pkg/secrets"
 )
 
 func SubscribeSlack(ctx context.Context, db *sqlx.DB) {
@@ -49,9 +48,21 @@ func SubscribeSlack(ctx context.Context, db *sqlx.DB) {
 
 func getSlackClient() *slack.Client {
 	oauth, botToken := cache.GetSettings().GetSlackCredential()
+	oauth = decryptCredential(oauth)
+	botToken = decryptCredential(botToken)
 	return slack.New(oauth, slack.OptionDebug(log.Debug().Enabled()), slack.OptionAppLevelToken(botToken))
 }
 
+// decryptCredential decrypts the stored credential when the client is created
+func decryptCredential(credential string) string {
+	decrypted, err := secrets.DecryptString(credential)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to decrypt the notification credentials")
+		return ""
+	}
+	return decrypted
+}
+
 func processEvents(ctx context.Context, socketClient *socketmode.Client, db *sqlx.DB) {
 
 	serviceType := services_helpers.SlackService
@@ -8,7 +8,6 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"golang.org/x/exp/slices"
 
 	"github.com/lncapital/torq/internal/cache"
@@ -96,7 +95,7 @@ type Telegram struct {
 func getTelegramHighPriority() (*Telegram, error) {
 	telegramHighPriorityOnce.Do(func() {
 		log.Debug().Msg("Loading TelegramHighPriority client.")
-		bot, err := tgbotapi.NewBotAPI(cache.GetSettings().GetTelegramCredential(true))
+		bot, err := tgbotapi.NewBotAPI(decryptCredential(cache.GetSettings().GetTelegramCredential(true)))
 		telegramHighPriorityObject = &Telegram{
 			bot: bot,
 		}
@@ -110,7 +109,7 @@ func getTelegramHighPriority() (*Telegram, error) {
 func getTelegramLowPriority() (*Telegram, error) {
 	telegramLowPriorityOnce.Do(func() {
 		log.Debug().Msg("Loading TelegramLowPriority client.")
-		bot, err := tgbotapi.NewBotAPI(cache.GetSettings().GetTelegramCredential(false))
+		bot, err := tgbotapi.NewBotAPI(decryptCredential(cache.GetSettings().GetTelegramCredential(false)))
 		bot.Debug = log.Debug().Enabled()
 		telegramLowPriorityObject = &Telegram{
 			bot: bot,
@@ -54,6 +54,16 @@ func (s Chain) String() string {
 	return UnknownEnumString
 }
 
+func (s Implementation) String() string {
+	switch s {
+	case LND:
+		return "LND"
+	case CLN:
+		return "CLN"
+	}
+	return UnknownEnumString
+}
+
 // GetChain defaults to Bitcoin when no match is found
 func GetChain(chain string) Chain {
 	switch chain {
@@ -0,0 +1,82 @@
+package data_retention
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/database"
+)
+
+type TableSize struct {
+	TableName  string `json:"tableName" db:"table_name"`
+	Hypertable bool   `json:"hypertable" db:"hypertable"`
+	// The size of the table including its indexes, toast and (compressed) chunks
+	TotalBytes       int64 `json:"totalBytes" db:"total_bytes"`
+	Chunks           int   `json:"chunks" db:"chunks"`
+	CompressedChunks int   `json:"compressedChunks" db:"compressed_chunks"`
+}
+
+// getPolicies returns the policies of all tables with configurable retention, tables without a stored policy
+// have all parts of the policy disabled.
+func getPolicies(db *sqlx.DB) ([]Policy, error) {
+	var storedPolicies []Policy
+	err := db.Select(&storedPolicies, `SELECT * FROM data_retention;`)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	var policies []Policy
+	for _, t := range getTables() {
+		policy := Policy{TableName: t.name}
+		for _, storedPolicy := range storedPolicies {
+			if storedPolicy.TableName == t.name {
+				policy = storedPolicy
+			}
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+func setPolicy(db *sqlx.DB, policy Policy) (Policy, error) {
+	now := time.Now().UTC()
+	err := db.Get(&policy, `
+		INSERT INTO data_retention (table_name, compress_after_days, drop_data_after_days, drop_after_days,
+			created_on, updated_on)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (table_name) DO UPDATE
+		SET compress_after_days=EXCLUDED.compress_after_days, drop_data_after_days=EXCLUDED.drop_data_after_days,
+			drop_after_days=EXCLUDED.drop_after_days, updated_on=EXCLUDED.updated_on
+		RETURNING *;`,
+		policy.TableName, policy.CompressAfterDays, policy.DropDataAfterDays, policy.DropAfterDays, now)
+	if err != nil {
+		return Policy{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return policy, nil
+}
+
+// getTableSizes returns the size of all tables (largest first)
+func getTableSizes(db *sqlx.DB) ([]TableSize, error) {
+	var tableSizes []TableSize
+	err := db.Select(&tableSizes, `
+		SELECT c.relname AS table_name,
+			h.hypertable_name IS NOT NULL AS hypertable,
+			coalesce(CASE WHEN h.hypertable_name IS NULL THEN pg_total_relation_size(c.oid)
+				ELSE (SELECT total_bytes FROM hypertable_detailed_size(c.oid)) END, 0) AS total_bytes,
+			coalesce(h.num_chunks, 0) AS chunks,
+			(SELECT count(*)
+			 FROM timescaledb_information.chunks ch
+			 WHERE ch.hypertable_schema=n.nspname AND ch.hypertable_name=c.relname AND ch.is_compressed
+			) AS compressed_chunks
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid=c.relnamespace AND n.nspname='public'
+		LEFT JOIN timescaledb_information.hypertables h
+			ON h.hypertable_schema=n.nspname AND h.hypertable_name=c.relname
+		WHERE c.relkind='r'
+		ORDER BY total_bytes DESC, table_name;`)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return tableSizes, nil
+}
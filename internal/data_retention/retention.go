@@ -0,0 +1,189 @@
+package data_retention
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/database"
+)
+
+// Policy is the retention and compression of a hypertable, a nil value disables that part of the policy
+type Policy struct {
+	TableName string `json:"tableName" db:"table_name"`
+	// Chunks with data older than this are compressed
+	CompressAfterDays *int `json:"compressAfterDays" db:"compress_after_days"`
+	// The raw JSON data of rows older than this is removed, the extracted columns are kept
+	DropDataAfterDays *int `json:"dropDataAfterDays" db:"drop_data_after_days"`
+	// Chunks with data older than this are dropped
+	DropAfterDays *int       `json:"dropAfterDays" db:"drop_after_days"`
+	CreatedOn     *time.Time `json:"createdOn" db:"created_on"`
+	UpdatedOn     *time.Time `json:"updatedOn" db:"updated_on"`
+}
+
+type table struct {
+	name       string
+	timeColumn string
+	// The column compressed data is segmented by (the column queries filter on)
+	segmentBy string
+	// The raw JSON column that can be dropped, empty when the table has none
+	dataColumn string
+}
+
+// getTables returns the hypertables with configurable retention and compression
+func getTables() []table {
+	return []table{
+		{name: "htlc_event", timeColumn: "time", segmentBy: "node_id", dataColumn: "data"},
+		{name: "node_event", timeColumn: "timestamp", segmentBy: "event_node_id"},
+		{name: "routing_policy", timeColumn: "ts", segmentBy: "channel_id"},
+		{name: "node_connection_history", timeColumn: "created_on", segmentBy: "node_id"},
+	}
+}
+
+func getTable(tableName string) (table, bool) {
+	for _, t := range getTables() {
+		if t.name == tableName {
+			return t, true
+		}
+	}
+	return table{}, false
+}
+
+// ApplyPolicies makes the TimescaleDB compression and retention policies match the configured policies
+// and removes the raw JSON data that is older than configured.
+// A failing table doesn't keep the policies of the other tables from being applied.
+func ApplyPolicies(db *sqlx.DB) error {
+	policies, err := getPolicies(db)
+	if err != nil {
+		return errors.Wrap(err, "Getting data retention policies")
+	}
+	var policiesErr error
+	for _, policy := range policies {
+		t, exists := getTable(policy.TableName)
+		if !exists {
+			continue
+		}
+		err = applyPolicy(db, t, policy)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to apply the data retention policy for %v", t.name)
+			policiesErr = errors.CombineErrors(policiesErr, err)
+		}
+	}
+	return policiesErr
+}
+
+func applyPolicy(db *sqlx.DB, t table, policy Policy) error {
+	err := applyCompression(db, t, policy.CompressAfterDays)
+	if err != nil {
+		return errors.Wrapf(err, "Applying compression policy for %v", t.name)
+	}
+	err = applyRetention(db, t, policy.DropAfterDays)
+	if err != nil {
+		return errors.Wrapf(err, "Applying retention policy for %v", t.name)
+	}
+	if t.dataColumn != "" && policy.DropDataAfterDays != nil {
+		rowsAffected, err := dropData(db, t, *policy.DropDataAfterDays)
+		if err != nil {
+			return errors.Wrapf(err, "Dropping data of %v", t.name)
+		}
+		if rowsAffected != 0 {
+			log.Info().Msgf("Removed the %v of %v %v records (which were older than %v days).",
+				t.dataColumn, rowsAffected, t.name, *policy.DropDataAfterDays)
+		}
+	}
+	return nil
+}
+
+func applyCompression(db *sqlx.DB, t table, compressAfterDays *int) error {
+	if compressAfterDays == nil {
+		_, err := db.Exec(`SELECT remove_compression_policy($1, if_exists => true);`, t.name)
+		if err != nil {
+			return errors.Wrap(err, database.SqlExecutionError)
+		}
+		return nil
+	}
+	var compressionEnabled bool
+	err := db.Get(&compressionEnabled, `
+		SELECT compression_enabled
+		FROM timescaledb_information.hypertables
+		WHERE hypertable_schema='public' AND hypertable_name=$1;`, t.name)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	if !compressionEnabled {
+		// The table name and segment by column are not user input
+		_, err = db.Exec(fmt.Sprintf(`ALTER TABLE %v SET (timescaledb.compress, timescaledb.compress_segmentby = '%v');`,
+			t.name, t.segmentBy))
+		if err != nil {
+			return errors.Wrap(err, database.SqlExecutionError)
+		}
+	}
+	return replacePolicy(db, t, "policy_compression", "compress_after", *compressAfterDays,
+		`SELECT remove_compression_policy($1, if_exists => true);`,
+		`SELECT add_compression_policy($1, make_interval(days => $2));`)
+}
+
+func applyRetention(db *sqlx.DB, t table, dropAfterDays *int) error {
+	if dropAfterDays == nil {
+		_, err := db.Exec(`SELECT remove_retention_policy($1, if_exists => true);`, t.name)
+		if err != nil {
+			return errors.Wrap(err, database.SqlExecutionError)
+		}
+		return nil
+	}
+	return replacePolicy(db, t, "policy_retention", "drop_after", *dropAfterDays,
+		`SELECT remove_retention_policy($1, if_exists => true);`,
+		`SELECT add_retention_policy($1, make_interval(days => $2));`)
+}
+
+// replacePolicy replaces the TimescaleDB policy job when its interval is not the configured number of days
+func replacePolicy(db *sqlx.DB, t table, procName string, configKey string, days int,
+	removeSql string, addSql string) error {
+
+	var upToDate bool
+	err := db.Get(&upToDate, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM timescaledb_information.jobs
+			WHERE proc_name=$1 AND hypertable_schema='public' AND hypertable_name=$2
+				AND (config->>$3)::interval = make_interval(days => $4)
+		);`, procName, t.name, configKey, days)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	if upToDate {
+		return nil
+	}
+	_, err = db.Exec(removeSql, t.name)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	_, err = db.Exec(addSql, t.name, days)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	log.Info().Msgf("Data retention %v for %v set to %v days.", procName, t.name, days)
+	return nil
+}
+
+// dropData removes the raw JSON data of the rows older than the number of days.
+// Compressed chunks are updated as well which requires TimescaleDB 2.11 or newer.
+func dropData(db *sqlx.DB, t table, days int) (int64, error) {
+	// The table and column names are not user input
+	res, err := db.Exec(fmt.Sprintf(`
+		UPDATE %v
+		SET %v = NULL
+		WHERE %v < (NOW() AT TIME ZONE 'UTC') - make_interval(days => $1) AND %v IS NOT NULL;`,
+		t.name, t.dataColumn, t.timeColumn, t.dataColumn), days)
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlAffectedRowsCheckError)
+	}
+	return rowsAffected, nil
+}
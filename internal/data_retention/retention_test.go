@@ -0,0 +1,30 @@
+package data_retention
+
+import (
+	"testing"
+)
+
+func TestValidatePolicy(t *testing.T) {
+	zero := 0
+	thirty := 30
+	tests := []struct {
+		name   string
+		policy Policy
+		field  string
+	}{
+		{"Everything disabled", Policy{TableName: "node_event"}, ""},
+		{"Compress and drop data", Policy{TableName: "htlc_event", CompressAfterDays: &thirty, DropDataAfterDays: &thirty}, ""},
+		{"Unknown table", Policy{TableName: "channel", DropAfterDays: &thirty}, "tableName"},
+		{"No raw data", Policy{TableName: "routing_policy", DropDataAfterDays: &thirty}, "dropDataAfterDays"},
+		{"Compress immediately", Policy{TableName: "htlc_event", CompressAfterDays: &zero}, "compressAfterDays"},
+		{"Drop immediately", Policy{TableName: "node_connection_history", DropAfterDays: &zero}, "dropAfterDays"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field, message := validatePolicy(test.policy)
+			if field != test.field {
+				t.Errorf("validatePolicy() = %q (%v), expected %q", field, message, test.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package data_retention
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+func RegisterDataRetentionRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("", func(c *gin.Context) { getPoliciesHandler(c, db) })
+	r.PUT("", func(c *gin.Context) { setPolicyHandler(c, db) })
+	r.GET("tableSizes", func(c *gin.Context) { getTableSizesHandler(c, db) })
+}
+
+func getPoliciesHandler(c *gin.Context, db *sqlx.DB) {
+	policies, err := getPolicies(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting data retention policies.")
+		return
+	}
+	c.JSON(http.StatusOK, policies)
+}
+
+// setPolicyHandler stores the policy, the maintenance service applies it
+func setPolicyHandler(c *gin.Context, db *sqlx.DB) {
+	var policy Policy
+	if err := c.BindJSON(&policy); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if field, message := validatePolicy(policy); field != "" {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError(field, message))
+		return
+	}
+	policy, err := setPolicy(db, policy)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Setting data retention policy.")
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+func getTableSizesHandler(c *gin.Context, db *sqlx.DB) {
+	tableSizes, err := getTableSizes(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting table sizes.")
+		return
+	}
+	c.JSON(http.StatusOK, tableSizes)
+}
+
+// validatePolicy returns the invalid field and the reason, the field is empty when the policy is valid
+func validatePolicy(policy Policy) (string, string) {
+	t, exists := getTable(policy.TableName)
+	if !exists {
+		return "tableName", "The retention of this table is not configurable"
+	}
+	if policy.CompressAfterDays != nil && *policy.CompressAfterDays < 1 {
+		return "compressAfterDays", "At least 1 day is required"
+	}
+	if policy.DropDataAfterDays != nil {
+		if t.dataColumn == "" {
+			return "dropDataAfterDays", "The table has no raw data that can be dropped"
+		}
+		if *policy.DropDataAfterDays < 1 {
+			return "dropDataAfterDays", "At least 1 day is required"
+		}
+	}
+	if policy.DropAfterDays != nil && *policy.DropAfterDays < 1 {
+		return "dropAfterDays", "At least 1 day is required"
+	}
+	return "", ""
+}
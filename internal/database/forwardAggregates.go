@@ -0,0 +1,101 @@
+package database
+
+import (
+	"strings"
+	"time"
+)
+
+type ForwardAggregates int
+
+const (
+	// NoForwardAggregates reads all forwards from the forward table
+	NoForwardAggregates ForwardAggregates = iota
+	// HourlyForwardAggregates reads the complete hours from forward_hourly
+	HourlyForwardAggregates
+	// AllForwardAggregates reads the complete days from forward_daily and the other complete hours from forward_hourly
+	AllForwardAggregates
+)
+
+// GetForwardAggregates returns the aggregates that can be used when forwards from until to are grouped per day
+// in the time zone. The aggregates are bucketed in UTC so the daily aggregate can only be used when the time zone
+// has no offset during the whole range and the hourly aggregate only when the offset is always in whole hours.
+func GetForwardAggregates(timeZone string, from time.Time, to time.Time) ForwardAggregates {
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return NoForwardAggregates
+	}
+	if location == time.UTC {
+		return AllForwardAggregates
+	}
+	if to.Before(from) {
+		from, to = to, from
+	}
+	// The offset only changes at the transitions of the time zone (i.e. daylight saving time).
+	// Those are at least days apart so checking every day of the range finds all the offsets.
+	forwardAggregates := AllForwardAggregates
+	for t := from; ; t = t.Add(24 * time.Hour) {
+		if t.After(to) {
+			t = to
+		}
+		_, offset := t.In(location).Zone()
+		if offset%int(time.Hour.Seconds()) != 0 {
+			return NoForwardAggregates
+		}
+		if offset != 0 {
+			forwardAggregates = HourlyForwardAggregates
+		}
+		if !t.Before(to) {
+			return forwardAggregates
+		}
+	}
+}
+
+// GetForwardsSql returns a subquery with the columns of the forward table (time, node_id, incoming_channel_id,
+// outgoing_channel_id, incoming_amount_msat, outgoing_amount_msat and fee_msat) plus forward_count.
+// Lower and upper are SQL expressions of the (inclusive) time range in the time of the forward table.
+// Complete hours (and days) in that range that are no longer live are read from the continuous aggregates,
+// the edges of the range and the live hour (day) are read from the forward table.
+// For aggregated rows time is the start of the bucket so the caller still has to filter the time range.
+func GetForwardsSql(lower string, upper string, forwardAggregates ForwardAggregates) string {
+	if forwardAggregates == NoForwardAggregates {
+		return `(
+			select time, node_id, incoming_channel_id, outgoing_channel_id,
+				incoming_amount_msat, outgoing_amount_msat, fee_msat, 1::bigint as forward_count
+			from forward
+		)`
+	}
+	dayRange := `
+		date_trunc('day', (LOWER) + interval '1 day' - interval '1 microsecond') as day_from,
+		least(date_trunc('day', (UPPER) + interval '1 microsecond'),
+			date_trunc('day', now() AT TIME ZONE 'UTC')) as day_to`
+	if forwardAggregates == HourlyForwardAggregates {
+		dayRange = `
+		'infinity'::timestamp as day_from,
+		'infinity'::timestamp as day_to`
+	}
+	return strings.NewReplacer("LOWER", lower, "UPPER", upper).Replace(`(
+		with closed_range as (
+			select
+				date_trunc('hour', (LOWER) + interval '1 hour' - interval '1 microsecond') as hour_from,
+				least(date_trunc('hour', (UPPER) + interval '1 microsecond'),
+					date_trunc('hour', now() AT TIME ZONE 'UTC')) as hour_to,` + dayRange + `
+		)
+		select bucket as time, node_id, incoming_channel_id, outgoing_channel_id,
+			incoming_amount_msat, outgoing_amount_msat, fee_msat, forward_count
+		from forward_daily
+		where bucket >= (select day_from from closed_range)
+			and bucket < (select day_to from closed_range)
+		union all
+		select bucket as time, node_id, incoming_channel_id, outgoing_channel_id,
+			incoming_amount_msat, outgoing_amount_msat, fee_msat, forward_count
+		from forward_hourly
+		where bucket >= (select hour_from from closed_range)
+			and bucket < (select hour_to from closed_range)
+			and (bucket < (select day_from from closed_range) or bucket >= (select day_to from closed_range))
+		union all
+		select time, node_id, incoming_channel_id, outgoing_channel_id,
+			incoming_amount_msat, outgoing_amount_msat, fee_msat, 1::bigint as forward_count
+		from forward
+		where time < (select hour_from from closed_range) or time >= (select hour_to from closed_range)
+	)`)
+}
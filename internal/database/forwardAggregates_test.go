@@ -0,0 +1,71 @@
+package database
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetForwardAggregates(t *testing.T) {
+	january := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	december := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		timeZone string
+		from     time.Time
+		to       time.Time
+		expected ForwardAggregates
+	}{
+		{name: "UTC", timeZone: "UTC", from: january, to: december, expected: AllForwardAggregates},
+		{name: "no offset in winter", timeZone: "Europe/London", from: january, to: february,
+			expected: AllForwardAggregates},
+		// January and December have no offset in London but the summer in between has
+		{name: "range spanning daylight saving time", timeZone: "Europe/London", from: january, to: december,
+			expected: HourlyForwardAggregates},
+		{name: "reversed range", timeZone: "Europe/London", from: december, to: january,
+			expected: HourlyForwardAggregates},
+		{name: "whole hour offset", timeZone: "America/New_York", from: january, to: december,
+			expected: HourlyForwardAggregates},
+		{name: "half hour offset", timeZone: "Asia/Kolkata", from: january, to: february,
+			expected: NoForwardAggregates},
+		// Adelaide is 10:30 in summer and 9:30 in winter
+		{name: "half hour offset with daylight saving time", timeZone: "Australia/Adelaide", from: january,
+			to: december, expected: NoForwardAggregates},
+		// Lord Howe Island only shifts half an hour for daylight saving time (10:30 in winter, 11:00 in summer)
+		{name: "half hour shift in the range", timeZone: "Australia/Lord_Howe", from: january, to: december,
+			expected: NoForwardAggregates},
+		{name: "whole hour offset before a half hour shift", timeZone: "Australia/Lord_Howe", from: january,
+			to: february, expected: HourlyForwardAggregates},
+		{name: "unknown time zone", timeZone: "Mars/Olympus_Mons", from: january, to: december,
+			expected: NoForwardAggregates},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result := GetForwardAggregates(test.timeZone, test.from, test.to)
+			if result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetForwardsSql(t *testing.T) {
+	raw := GetForwardsSql("$1::timestamp", "$2::timestamp", NoForwardAggregates)
+	if strings.Contains(raw, "forward_hourly") || strings.Contains(raw, "forward_daily") {
+		t.Errorf("expected only the forward table without aggregates")
+	}
+
+	hourly := GetForwardsSql("$1::timestamp", "$2::timestamp", HourlyForwardAggregates)
+	if !strings.Contains(hourly, "'infinity'::timestamp as day_from") {
+		t.Errorf("expected an empty day range for hourly aggregates")
+	}
+
+	all := GetForwardsSql("$1::timestamp", "$2::timestamp", AllForwardAggregates)
+	if strings.Contains(all, "LOWER") || strings.Contains(all, "UPPER") {
+		t.Errorf("expected the range placeholders to be replaced")
+	}
+	if !strings.Contains(all, "date_trunc('day', ($1::timestamp) + interval '1 day' - interval '1 microsecond')") {
+		t.Errorf("expected the closed day range to start at the first complete day")
+	}
+}
@@ -11,10 +11,15 @@ import (
 	"github.com/golang-migrate/migrate/v4/source/httpfs"
 	"github.com/jmoiron/sqlx"
 	"github.com/lncapital/torq/database/migrations"
+	"io/fs"
 	"log"
 	"net/http"
+	"regexp"
+	"strconv"
 )
 
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_.*\.(up|down)\.psql$`) //nolint:gochecknoglobals
+
 // newMigrationInstance fetches sql files and creates a new migration instance.
 func newMigrationInstance(db *sql.DB) (*migrate.Migrate, error) {
 	sourceInstance, err := httpfs.New(http.FS(migrations.MigrationFiles), ".")
@@ -67,3 +72,106 @@ func MigrateUp(db *sqlx.DB) error {
 
 	return nil
 }
+
+// GetMigrationVersion returns the current migration version of the database (0 when no migration ran yet)
+func GetMigrationVersion(db *sqlx.DB) (uint, error) {
+	var migration struct {
+		Version uint `db:"version"`
+		Dirty   bool `db:"dirty"`
+	}
+	err := db.Get(&migration, `SELECT version, dirty FROM schema_migrations LIMIT 1;`)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "Getting migration version")
+	}
+	if migration.Dirty {
+		return 0, errors.Newf("Migration %v is dirty, run migrate_up first", migration.Version)
+	}
+	return migration.Version, nil
+}
+
+// GetLatestMigrationVersion returns the version of the latest migration of this build
+func GetLatestMigrationVersion() (uint, error) {
+	versions, err := getMigrationVersions(migrations.MigrationFiles)
+	if err != nil {
+		return 0, err
+	}
+	var latest uint
+	for version := range versions {
+		if version > latest {
+			latest = version
+		}
+	}
+	return latest, nil
+}
+
+// MigrateDown reverts the migrations after the given version. Every reverted migration needs a down migration, nothing
+// is reverted when one of them is missing.
+func MigrateDown(db *sqlx.DB, version uint) error {
+	current, err := GetMigrationVersion(db)
+	if err != nil {
+		return err
+	}
+	if version >= current {
+		return errors.Newf("The database is at version %v, it can't be migrated down to version %v", current, version)
+	}
+	missing, err := getMissingDownMigrations(migrations.MigrationFiles, version, current)
+	if err != nil {
+		return err
+	}
+	if len(missing) != 0 {
+		return errors.Newf("Migrations %v have no down migration, the database can't be migrated down to version %v",
+			missing, version)
+	}
+
+	m, err := newMigrationInstance(db.DB)
+	if err != nil {
+		return errors.Wrap(err, "Creating new migration instance")
+	}
+	log.Printf("Migrating down from version %v to version %v.", current, version)
+	err = m.Migrate(version)
+	if err != nil {
+		return errors.Wrapf(err, "Migrating database down to version %v", version)
+	}
+	log.Println("Migration done.")
+	return nil
+}
+
+// getMigrationVersions returns the versions of the migrations and whether they have a down migration
+func getMigrationVersions(files fs.FS) (map[uint]bool, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "Reading migrations")
+	}
+	versions := make(map[uint]bool)
+	for _, entry := range entries {
+		match := migrationFileRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Parsing migration version of %v", entry.Name())
+		}
+		versions[uint(version)] = versions[uint(version)] || match[2] == "down"
+	}
+	return versions, nil
+}
+
+// getMissingDownMigrations returns the migrations after version from up to version to that have no down migration
+func getMissingDownMigrations(files fs.FS, from uint, to uint) ([]uint, error) {
+	versions, err := getMigrationVersions(files)
+	if err != nil {
+		return nil, err
+	}
+	var missing []uint
+	for version := from + 1; version <= to; version++ {
+		hasDown, exists := versions[version]
+		if exists && !hasDown {
+			missing = append(missing, version)
+		}
+	}
+	return missing, nil
+}
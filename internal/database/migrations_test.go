@@ -0,0 +1,52 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/lncapital/torq/database/migrations"
+)
+
+func TestGetMissingDownMigrations(t *testing.T) {
+	files := fstest.MapFS{
+		"000001_a.up.psql":   {},
+		"000001_a.down.psql": {},
+		"000002_b.up.psql":   {},
+		"000003_c.up.psql":   {},
+		"000003_c.down.psql": {},
+		"000004_d.up.psql":   {},
+		"embed.go":           {},
+	}
+	tests := []struct {
+		name     string
+		from     uint
+		to       uint
+		expected []uint
+	}{
+		{"All down migrations", 2, 3, nil},
+		{"One missing", 1, 3, []uint{2}},
+		{"Two missing", 0, 4, []uint{2, 4}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			missing, err := getMissingDownMigrations(files, test.from, test.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(missing, test.expected) {
+				t.Errorf("getMissingDownMigrations() = %v, expected %v", missing, test.expected)
+			}
+		})
+	}
+}
+
+func TestEmbeddedDownMigrations(t *testing.T) {
+	missing, err := getMissingDownMigrations(migrations.MigrationFiles, 90, 101)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(missing, []uint{91}) {
+		t.Errorf("getMissingDownMigrations() = %v, expected the migrations after 91 to have down migrations", missing)
+	}
+}
@@ -0,0 +1,103 @@
+package flow
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/database"
+)
+
+// The net flow of a channel is based on the forwards of the last 7 days
+const channelFlowLookback = 7 * 24 * time.Hour
+
+// A channel needs at least an hour of history before a forecast is made
+const minimumChannelFlowDuration = time.Hour
+
+type LiquidityForecast struct {
+	// The net change of the local balance in sats (Satoshis) per day based on recent forwards
+	NetFlowPerDay *float64
+	// Number of days until the local balance is depleted when the net flow continues at the same rate
+	DaysUntilDepleted *float64
+	// Number of days until the remote balance is depleted when the net flow continues at the same rate
+	DaysUntilSaturated *float64
+}
+
+// RefreshChannelFlowCache loads the forwarded amounts per channel of the last 7 days into the cache.
+func RefreshChannelFlowCache(db *sqlx.DB) error {
+	to := time.Now()
+	from := to.Add(-channelFlowLookback)
+	rows, err := db.Queryx(`
+		SELECT node_id, channel_id, sum(amount_in)::bigint AS amount_in, sum(amount_out)::bigint AS amount_out
+		FROM (
+			SELECT node_id, incoming_channel_id AS channel_id,
+				floor(sum(incoming_amount_msat)/1000) AS amount_in, 0 AS amount_out
+			FROM forward
+			WHERE time >= $1 AND time <= $2
+			GROUP BY node_id, incoming_channel_id
+			UNION ALL
+			SELECT node_id, outgoing_channel_id AS channel_id,
+				0 AS amount_in, floor(sum(outgoing_amount_msat)/1000) AS amount_out
+			FROM forward
+			WHERE time >= $1 AND time <= $2
+			GROUP BY node_id, outgoing_channel_id
+		) AS f
+		WHERE channel_id IS NOT NULL
+		GROUP BY node_id, channel_id;`, from, to)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	defer rows.Close()
+	var channelFlows []cache.ChannelFlowSettingsCache
+	for rows.Next() {
+		channelFlow := cache.ChannelFlowSettingsCache{From: from, To: to}
+		err = rows.Scan(&channelFlow.NodeId, &channelFlow.ChannelId, &channelFlow.AmountIn, &channelFlow.AmountOut)
+		if err != nil {
+			return errors.Wrap(err, database.SqlScanResulSetError)
+		}
+		channelFlows = append(channelFlows, channelFlow)
+	}
+	cache.SetChannelFlows(channelFlows)
+	return nil
+}
+
+// GetLiquidityForecast projects when the channel will be depleted or saturated based on the recent net flow.
+// When there is no recent forward the net flow is zero and no depletion or saturation is expected.
+func GetLiquidityForecast(nodeId int, channelId int, localBalance int64, remoteBalance int64) LiquidityForecast {
+	channelFlow := cache.GetChannelFlow(nodeId, channelId)
+	if channelFlow == nil {
+		return forecastLiquidity(0, localBalance, remoteBalance)
+	}
+	from := channelFlow.From
+	fundedOn := cache.GetChannelSettingByChannelId(channelId).FundedOn
+	if fundedOn != nil && fundedOn.After(from) {
+		from = *fundedOn
+	}
+	duration := channelFlow.To.Sub(from)
+	if duration < minimumChannelFlowDuration {
+		return LiquidityForecast{}
+	}
+	netFlowPerDay := float64(channelFlow.AmountIn-channelFlow.AmountOut) / duration.Hours() * 24
+	return forecastLiquidity(netFlowPerDay, localBalance, remoteBalance)
+}
+
+func forecastLiquidity(netFlowPerDay float64, localBalance int64, remoteBalance int64) LiquidityForecast {
+	if localBalance < 0 {
+		localBalance = 0
+	}
+	if remoteBalance < 0 {
+		remoteBalance = 0
+	}
+	forecast := LiquidityForecast{NetFlowPerDay: &netFlowPerDay}
+	switch {
+	case netFlowPerDay < 0:
+		daysUntilDepleted := float64(localBalance) / -netFlowPerDay
+		forecast.DaysUntilDepleted = &daysUntilDepleted
+	case netFlowPerDay > 0:
+		daysUntilSaturated := float64(remoteBalance) / netFlowPerDay
+		forecast.DaysUntilSaturated = &daysUntilSaturated
+	}
+	return forecast
+}
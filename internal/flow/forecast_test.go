@@ -0,0 +1,70 @@
+package flow
+
+import (
+	"testing"
+)
+
+func TestForecastLiquidity(t *testing.T) {
+	testCases := []struct {
+		name               string
+		netFlowPerDay      float64
+		localBalance       int64
+		remoteBalance      int64
+		daysUntilDepleted  *float64
+		daysUntilSaturated *float64
+	}{
+		{
+			name:          "no flow",
+			netFlowPerDay: 0,
+			localBalance:  500_000,
+			remoteBalance: 500_000,
+		},
+		{
+			name:              "draining channel",
+			netFlowPerDay:     -100_000,
+			localBalance:      500_000,
+			remoteBalance:     500_000,
+			daysUntilDepleted: floatPointer(5),
+		},
+		{
+			name:               "filling channel",
+			netFlowPerDay:      250_000,
+			localBalance:       500_000,
+			remoteBalance:      500_000,
+			daysUntilSaturated: floatPointer(2),
+		},
+		{
+			name:              "already depleted channel",
+			netFlowPerDay:     -100_000,
+			localBalance:      -10,
+			remoteBalance:     1_000_000,
+			daysUntilDepleted: floatPointer(0),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			forecast := forecastLiquidity(tc.netFlowPerDay, tc.localBalance, tc.remoteBalance)
+			if forecast.NetFlowPerDay == nil || *forecast.NetFlowPerDay != tc.netFlowPerDay {
+				t.Errorf("NetFlowPerDay got %v, want %v", forecast.NetFlowPerDay, tc.netFlowPerDay)
+			}
+			if !equalFloatPointers(forecast.DaysUntilDepleted, tc.daysUntilDepleted) {
+				t.Errorf("DaysUntilDepleted got %v, want %v", forecast.DaysUntilDepleted, tc.daysUntilDepleted)
+			}
+			if !equalFloatPointers(forecast.DaysUntilSaturated, tc.daysUntilSaturated) {
+				t.Errorf("DaysUntilSaturated got %v, want %v", forecast.DaysUntilSaturated, tc.daysUntilSaturated)
+			}
+		})
+	}
+}
+
+func floatPointer(f float64) *float64 {
+	return &f
+}
+
+func equalFloatPointers(a *float64, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
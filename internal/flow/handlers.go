@@ -12,12 +12,14 @@ import (
 	"github.com/lib/pq"
 	"gopkg.in/guregu/null.v4"
 
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/database"
 	"github.com/lncapital/torq/pkg/server_errors"
 )
 
-type channelFlowData struct {
+type ChannelFlowData struct {
 	// Alias of remote peer
 	Alias                  null.String `json:"alias"`
 	ChannelId              *string     `json:"channelId"`
@@ -70,7 +72,7 @@ func getFlowHandler(c *gin.Context, db *sqlx.DB) {
 
 	chain := core.Bitcoin
 
-	r, err := getFlow(db, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network)), chanIds, from, to)
+	r, err := GetFlow(db, auth.RestrictNodeIds(c, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network))), chanIds, from, to)
 	if err != nil {
 		server_errors.LogAndSendServerError(c, err)
 		return
@@ -78,8 +80,8 @@ func getFlowHandler(c *gin.Context, db *sqlx.DB) {
 	c.JSON(http.StatusOK, r)
 }
 
-func getFlow(db *sqlx.DB, nodeIds []int, chanIdStrings []string, fromTime time.Time,
-	toTime time.Time) (r []*channelFlowData,
+func GetFlow(db *sqlx.DB, nodeIds []int, chanIdStrings []string, fromTime time.Time,
+	toTime time.Time) (r []*ChannelFlowData,
 	err error) {
 
 	var channelIds []int
@@ -98,7 +100,9 @@ func getFlow(db *sqlx.DB, nodeIds []int, chanIdStrings []string, fromTime time.T
 		}
 	}
 
-	const sql = `
+	// Complete hours and days are read from the forward aggregates
+	forwards := database.GetForwardsSql("$1::timestamp", "$2::timestamp", database.AllForwardAggregates)
+	sql := `
 		select
 			ne.alias,
 			c.channel_id,
@@ -127,8 +131,8 @@ func getFlow(db *sqlx.DB, nodeIds []int, chanIdStrings []string, fromTime time.T
 					outgoing_channel_id,
 					floor(sum(outgoing_amount_msat)/1000) as amount,
 					floor(sum(fee_msat)/1000) as revenue,
-					count(time) as count
-				from forward
+					sum(forward_count) as count
+				from ` + forwards + ` as forward
 				where time >= $1
 					and time <= $2
 					and ($3 or incoming_channel_id = ANY($4))
@@ -140,8 +144,8 @@ func getFlow(db *sqlx.DB, nodeIds []int, chanIdStrings []string, fromTime time.T
 					incoming_channel_id,
 					floor(sum(outgoing_amount_msat)/1000) as amount,
 					floor(sum(fee_msat)/1000) as revenue,
-					count(time) as count
-				from forward
+					sum(forward_count) as count
+				from ` + forwards + ` as forward
 				where time >= $1
 					and time <= $2
 					and ($3 or outgoing_channel_id = ANY($4))
@@ -178,7 +182,7 @@ func getFlow(db *sqlx.DB, nodeIds []int, chanIdStrings []string, fromTime time.T
 	}
 	defer rows.Close()
 	for rows.Next() {
-		c := &channelFlowData{}
+		c := &ChannelFlowData{}
 		err = rows.Scan(
 			&c.Alias,
 			&c.ChannelId,
@@ -5,8 +5,10 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/database"
 	"github.com/lncapital/torq/internal/tags"
 
 	"github.com/lib/pq"
@@ -42,7 +44,7 @@ func getForwardsTableHandler(c *gin.Context, db *sqlx.DB) {
 
 	chain := core.Bitcoin
 
-	r, err := getForwardsTableData(db, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network)), from, to)
+	r, err := GetForwardsTableData(db, auth.RestrictNodeIds(c, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network))), from, to)
 	if err != nil {
 		server_errors.LogAndSendServerError(c, err)
 		return
@@ -50,7 +52,7 @@ func getForwardsTableHandler(c *gin.Context, db *sqlx.DB) {
 	c.JSON(http.StatusOK, r)
 }
 
-type forwardsTableRow struct {
+type ForwardsTableRow struct {
 	// Alias of remote peer
 	Alias        null.String `json:"alias"`
 	ChannelTags  []tags.Tag  `json:"channelTags"`
@@ -107,9 +109,11 @@ type forwardsTableRow struct {
 	LocalNodeIds  []int   `json:"localNodeIds"`
 }
 
-func getForwardsTableData(db *sqlx.DB, nodeIds []int,
-	fromTime time.Time, toTime time.Time) (r []*forwardsTableRow, err error) {
+func GetForwardsTableData(db *sqlx.DB, nodeIds []int,
+	fromTime time.Time, toTime time.Time) (r []*ForwardsTableRow, err error) {
 
+	// Complete hours and days are read from the forward aggregates
+	forwards := database.GetForwardsSql("$1::timestamp", "$2::timestamp", database.AllForwardAggregates)
 	var sqlString = `
 		select
 			coalesce(scne.node_alias, LEFT(scn.public_key, 20)) as alias,
@@ -181,8 +185,8 @@ func getForwardsTableData(db *sqlx.DB, nodeIds []int,
 				select outgoing_channel_id channel_id,
 					   floor(sum(outgoing_amount_msat)/1000) as amount,
 					   floor(sum(fee_msat)/1000) as revenue,
-					   count(time) as count
-				from forward
+					   sum(forward_count) as count
+				from ` + forwards + ` as forward
 				where time::timestamp AT TIME ZONE $3 >= $1::timestamp AT TIME ZONE $3
 					and time::timestamp AT TIME ZONE $3 <= $2::timestamp AT TIME ZONE $3
 				group by outgoing_channel_id
@@ -191,8 +195,8 @@ func getForwardsTableData(db *sqlx.DB, nodeIds []int,
 				select incoming_channel_id as channel_id,
 					   floor(sum(incoming_amount_msat)/1000) as amount,
 					   floor(sum(fee_msat)/1000) as revenue,
-					   count(time) as count
-				from forward
+					   sum(forward_count) as count
+				from ` + forwards + ` as forward
 				where time::timestamp AT TIME ZONE $3 >= $1::timestamp AT TIME ZONE $3
 					and time::timestamp AT TIME ZONE $3 <= $2::timestamp AT TIME ZONE $3
 				group by incoming_channel_id
@@ -209,7 +213,7 @@ func getForwardsTableData(db *sqlx.DB, nodeIds []int,
 	defer rows.Close()
 
 	for rows.Next() {
-		c := &forwardsTableRow{}
+		c := &ForwardsTableRow{}
 		err = rows.Scan(
 			&c.Alias,
 			&c.FirstNodeId,
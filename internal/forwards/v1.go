@@ -0,0 +1,63 @@
+package forwards
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+func GetV1Endpoints(db *sqlx.DB) []ah.Endpoint {
+	return []ah.Endpoint{
+		{
+			Method:      http.MethodGet,
+			Path:        "/forwards",
+			Resource:    "forwards",
+			Summary:     "Forwarding summary per channel",
+			Description: "The forwards of every channel (including channels without forwards) between from and to.",
+			Parameters: []ah.Parameter{
+				{Name: "from", Type: "string", Format: "date", Required: true},
+				{Name: "to", Type: "string", Format: "date", Required: true},
+				ah.NodeIdParameter,
+			},
+			Response:  ForwardsTableRow{},
+			Paginated: true,
+			Handler:   func(c *gin.Context) { getV1ForwardsHandler(c, db) },
+		},
+	}
+}
+
+func getV1ForwardsHandler(c *gin.Context, db *sqlx.DB) {
+	pagination, err := ah.GetPagination(c)
+	if err != nil {
+		server_errors.SendBadRequestFromError(c, err)
+		return
+	}
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("from", "Invalid date"))
+		return
+	}
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("to", "Invalid date"))
+		return
+	}
+	nodeIds, err := auth.GetNodeIds(c)
+	if err != nil {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", err.Error()))
+		return
+	}
+	rows, err := GetForwardsTableData(db, nodeIds, from, to)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting forwards.")
+		return
+	}
+	start, end := pagination.GetPage(len(rows))
+	c.JSON(http.StatusOK, ah.ApiResponse{Data: rows[start:end], Pagination: pagination})
+}
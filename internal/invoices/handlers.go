@@ -9,6 +9,7 @@ import (
 	"github.com/jmoiron/sqlx"
 
 	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	qp "github.com/lncapital/torq/internal/query_parser"
@@ -119,7 +120,7 @@ func getInvoicesHandler(c *gin.Context, db *sqlx.DB) {
 
 	chain := core.Bitcoin
 
-	r, total, err := getInvoices(db, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network)), filter, sort, limit, offset)
+	r, total, err := getInvoices(db, auth.RestrictNodeIds(c, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network))), filter, sort, limit, offset)
 	if err != nil {
 		server_errors.LogAndSendServerError(c, err)
 		return
@@ -144,7 +145,7 @@ func getInvoiceHandler(c *gin.Context, db *sqlx.DB) {
 
 	chain := core.Bitcoin
 
-	r, err := getInvoiceDetails(db, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network)), c.Param("identifier"))
+	r, err := getInvoiceDetails(db, auth.RestrictNodeIds(c, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network))), c.Param("identifier"))
 	switch err.(type) {
 	case nil:
 		break
@@ -0,0 +1,103 @@
+package invoices
+
+import (
+	"net/http"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+func GetV1Endpoints(db *sqlx.DB) []ah.Endpoint {
+	return []ah.Endpoint{
+		{
+			Method:   http.MethodGet,
+			Path:     "/invoices",
+			Resource: "invoices",
+			Summary:  "List invoices, the most recent first",
+			Parameters: []ah.Parameter{
+				{Name: "from", Description: "Created on or after", Type: "string", Format: "date-time"},
+				{Name: "to", Description: "Created before", Type: "string", Format: "date-time"},
+				{Name: "state", Description: "i.e. OPEN, SETTLED, CANCELED or ACCEPTED", Type: "string"},
+				ah.NodeIdParameter,
+			},
+			Response:  Invoice{},
+			Paginated: true,
+			Handler:   func(c *gin.Context) { getV1InvoicesHandler(c, db) },
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/invoices/:identifier",
+			Resource:   "invoices",
+			Summary:    "Get an invoice with its HTLCs by payment hash, preimage or request",
+			Parameters: []ah.Parameter{ah.NodeIdParameter},
+			Response:   InvoiceDetails{},
+			Handler:    func(c *gin.Context) { getV1InvoiceHandler(c, db) },
+		},
+	}
+}
+
+func getV1InvoicesHandler(c *gin.Context, db *sqlx.DB) {
+	pagination, err := ah.GetPagination(c)
+	if err != nil {
+		server_errors.SendBadRequestFromError(c, err)
+		return
+	}
+	nodeIds, err := auth.GetNodeIds(c)
+	if err != nil {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", err.Error()))
+		return
+	}
+	filter := sq.And{}
+	if c.Query("from") != "" {
+		from, err := time.Parse(time.RFC3339, c.Query("from"))
+		if err != nil {
+			server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("from", "Invalid date-time"))
+			return
+		}
+		filter = append(filter, sq.GtOrEq{"creation_date": from})
+	}
+	if c.Query("to") != "" {
+		to, err := time.Parse(time.RFC3339, c.Query("to"))
+		if err != nil {
+			server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("to", "Invalid date-time"))
+			return
+		}
+		filter = append(filter, sq.Lt{"creation_date": to})
+	}
+	if c.Query("state") != "" {
+		filter = append(filter, sq.Eq{"invoice_state": c.Query("state")})
+	}
+	r, total, err := getInvoices(db, nodeIds, filter, []string{"creation_date DESC"}, pagination.Limit, pagination.Offset)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting invoices.")
+		return
+	}
+	pagination.Total = total
+	c.JSON(http.StatusOK, ah.ApiResponse{Data: r, Pagination: pagination})
+}
+
+func getV1InvoiceHandler(c *gin.Context, db *sqlx.DB) {
+	nodeIds, err := auth.GetNodeIds(c)
+	if err != nil {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", err.Error()))
+		return
+	}
+	r, err := getInvoiceDetails(db, nodeIds, c.Param("identifier"))
+	switch err.(type) {
+	case nil:
+		break
+	case ErrInvoiceNotFound:
+		server_errors.SendNotFound(c, err.Error())
+		return
+	default:
+		server_errors.WrapLogAndSendServerError(c, err, "Getting invoice.")
+		return
+	}
+	c.JSON(http.StatusOK, r)
+}
@@ -0,0 +1,244 @@
+package leader_election
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// advisoryLockKey is the key of the session level advisory lock that is held by the leader ("torq")
+const advisoryLockKey = int64(0x746f7271)
+
+type Role int
+
+const (
+	// Standalone is the role when high availability is disabled, the instance runs all services
+	Standalone = Role(iota)
+	// Leader is the instance that holds the lock and runs the services that act on nodes
+	Leader
+	// Follower serves the read-only API and UI while waiting for the lock
+	Follower
+)
+
+func (r Role) String() string {
+	switch r {
+	case Standalone:
+		return "standalone"
+	case Leader:
+		return "leader"
+	case Follower:
+		return "follower"
+	}
+	return "unknown"
+}
+
+type State struct {
+	Enabled    bool   `json:"enabled"`
+	Role       string `json:"role"`
+	InstanceId string `json:"instanceId,omitempty"`
+	// The maximum time it takes a follower to take over when the leader is gone
+	FailoverTimeoutSeconds int        `json:"failoverTimeoutSeconds,omitempty"`
+	LeaderSince            *time.Time `json:"leaderSince,omitempty"`
+	LastCheckTime          *time.Time `json:"lastCheckTime,omitempty"`
+}
+
+//nolint:gochecknoglobals
+var election = struct {
+	sync.RWMutex
+	role            Role
+	instanceId      string
+	failoverTimeout time.Duration
+	leaderSince     *time.Time
+	lastCheckTime   *time.Time
+	term            int
+}{role: Standalone}
+
+// Enable makes this instance a follower until it's elected by Start
+func Enable(instanceId string, failoverTimeout time.Duration) {
+	election.Lock()
+	defer election.Unlock()
+	election.role = Follower
+	election.instanceId = instanceId
+	election.failoverTimeout = failoverTimeout
+}
+
+// IsLeader returns true when this instance should run the services that act on nodes (always without high availability)
+func IsLeader() bool {
+	election.RLock()
+	defer election.RUnlock()
+	return election.role != Follower
+}
+
+// GetTerm returns the number of times this instance was elected, a change means the instance was promoted since
+func GetTerm() int {
+	election.RLock()
+	defer election.RUnlock()
+	return election.term
+}
+
+func GetState() State {
+	election.RLock()
+	defer election.RUnlock()
+	return State{
+		Enabled:                election.role != Standalone,
+		Role:                   election.role.String(),
+		InstanceId:             election.instanceId,
+		FailoverTimeoutSeconds: int(election.failoverTimeout.Seconds()),
+		LeaderSince:            election.leaderSince,
+		LastCheckTime:          election.lastCheckTime,
+	}
+}
+
+func setRole(role Role) {
+	now := time.Now().UTC()
+	election.Lock()
+	defer election.Unlock()
+	election.lastCheckTime = &now
+	if election.role == role {
+		return
+	}
+	election.role = role
+	election.leaderSince = nil
+	if role == Leader {
+		election.leaderSince = &now
+		election.term++
+		log.Info().Msgf("Torq instance %v is the leader", election.instanceId)
+		return
+	}
+	log.Warn().Msgf("Torq instance %v is a follower", election.instanceId)
+}
+
+// Start elects the leader until the context is cancelled.
+//
+// The leader holds a session level advisory lock on a dedicated connection and checks that connection every third of
+// the failover timeout. When the check fails the leader steps down before the database releases the lock. Postgres
+// (14+) closes the session when the leader did not check it within two thirds of the failover timeout so a follower,
+// trying every third of the failover timeout, takes over within the failover timeout.
+func Start(ctx context.Context, db *sqlx.DB) {
+	election.RLock()
+	instanceId := election.instanceId
+	failoverTimeout := election.failoverTimeout
+	election.RUnlock()
+
+	checkInterval := failoverTimeout / 3
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	var conn *sql.Conn
+	for {
+		var err error
+		if conn == nil {
+			conn, err = tryLock(ctx, db, instanceId, failoverTimeout-checkInterval)
+			if err != nil {
+				log.Error().Err(err).Msg("Trying to become the leader")
+			}
+			if conn != nil {
+				setRole(Leader)
+			} else {
+				setRole(Follower)
+			}
+		} else {
+			err = check(ctx, conn, checkInterval/2)
+			if err != nil {
+				log.Error().Err(err).Msg("Leader lost the connection that holds the lock, stepping down")
+				discard(conn)
+				conn = nil
+				setRole(Follower)
+			} else {
+				setRole(Leader)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if conn != nil {
+				unlock(conn)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tryLock returns the connection that holds the lock or nil when another instance is the leader
+func tryLock(ctx context.Context, db *sqlx.DB, instanceId string, idleSessionTimeout time.Duration) (*sql.Conn, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Obtaining a database connection")
+	}
+	var locked bool
+	err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1);`, advisoryLockKey).Scan(&locked)
+	if err != nil {
+		discard(conn)
+		return nil, errors.Wrap(err, "Obtaining the advisory lock")
+	}
+	if !locked {
+		err = conn.Close()
+		if err != nil {
+			log.Debug().Err(err).Msg("Failed to return the database connection.")
+		}
+		return nil, nil
+	}
+	// The instance id makes the leader visible in pg_stat_activity
+	_, err = conn.ExecContext(ctx, `SELECT set_config('application_name', $1, false);`, "torq-leader-"+instanceId)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to set the application name of the leader connection.")
+	}
+	_, err = conn.ExecContext(ctx, `SELECT set_config('idle_session_timeout', $1, false);`,
+		strconv.FormatInt(idleSessionTimeout.Milliseconds(), 10))
+	if err != nil {
+		log.Warn().Err(err).Msg("The database does not support idle_session_timeout (Postgres 14+), " +
+			"when the leader is unreachable the failover depends on TCP keepalive")
+	}
+	return conn, nil
+}
+
+func check(ctx context.Context, conn *sql.Conn, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	_, err := conn.ExecContext(ctx, `SELECT 1;`)
+	return errors.Wrap(err, "Checking the leader connection")
+}
+
+func unlock(conn *sql.Conn) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1);`, advisoryLockKey)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to release the advisory lock.")
+	}
+	discard(conn)
+}
+
+// discard closes the connection instead of returning it to the pool where it could still hold the lock
+func discard(conn *sql.Conn) {
+	err := conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	if err != nil && !errors.Is(err, driver.ErrBadConn) {
+		log.Debug().Err(err).Msg("Failed to discard the database connection.")
+	}
+	_ = conn.Close()
+}
+
+// LeaderWriteRequired is a middleware that only allows read-only requests (GET, HEAD or OPTIONS) on followers
+func LeaderWriteRequired(c *gin.Context) {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		c.Next()
+		return
+	}
+	if !IsLeader() {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable,
+			gin.H{"error": "follower", "message": "This Torq instance is a follower, changes are made on the leader"})
+		return
+	}
+	c.Next()
+}
@@ -0,0 +1,342 @@
+package leader_election
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestLeaderWriteRequired(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(LeaderWriteRequired)
+	r.GET("/channels", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.PUT("/channels", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name     string
+		role     Role
+		method   string
+		expected int
+	}{
+		{"Standalone write", Standalone, http.MethodPut, http.StatusOK},
+		{"Leader write", Leader, http.MethodPut, http.StatusOK},
+		{"Follower read", Follower, http.MethodGet, http.StatusOK},
+		{"Follower write", Follower, http.MethodPut, http.StatusServiceUnavailable},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			election.role = test.role
+			defer func() { election.role = Standalone }()
+
+			response := httptest.NewRecorder()
+			r.ServeHTTP(response, httptest.NewRequest(test.method, "/channels", nil))
+			if response.Code != test.expected {
+				t.Errorf("%v /channels = %v, expected %v", test.method, response.Code, test.expected)
+			}
+		})
+	}
+}
+
+func TestSetRole(t *testing.T) {
+	defer func() {
+		election.role = Standalone
+		election.instanceId = ""
+		election.failoverTimeout = 0
+		election.leaderSince = nil
+		election.lastCheckTime = nil
+		election.term = 0
+	}()
+
+	if !IsLeader() || GetState().Enabled {
+		t.Fatalf("Without high availability the instance should be the leader, state = %+v", GetState())
+	}
+
+	Enable("torq-1", 15*time.Second)
+	state := GetState()
+	if IsLeader() || !state.Enabled || state.Role != "follower" || state.FailoverTimeoutSeconds != 15 {
+		t.Errorf("Enable() state = %+v, expected an enabled follower", state)
+	}
+
+	setRole(Leader)
+	state = GetState()
+	if !IsLeader() || state.LeaderSince == nil || state.LastCheckTime == nil || GetTerm() != 1 {
+		t.Errorf("setRole(Leader) state = %+v, term = %v, expected a leader in term 1", state, GetTerm())
+	}
+	leaderSince := state.LeaderSince
+	setRole(Leader)
+	if GetState().LeaderSince != leaderSince || GetTerm() != 1 {
+		t.Error("setRole(Leader) again should not change leaderSince nor the term")
+	}
+
+	setRole(Follower)
+	state = GetState()
+	if IsLeader() || state.LeaderSince != nil || GetTerm() != 1 {
+		t.Errorf("setRole(Follower) state = %+v, term = %v, expected a follower", state, GetTerm())
+	}
+
+	// The caches of a follower are outdated when it's elected again
+	setRole(Leader)
+	if !IsLeader() || GetTerm() != 2 {
+		t.Errorf("setRole(Leader) after a follower term = %v, expected 2", GetTerm())
+	}
+}
+
+// fakeDatabase emulates the session level advisory lock of Postgres: the lock is released when the session
+// (connection) that holds it is closed.
+type fakeDatabase struct {
+	sync.Mutex
+	lockHolder  *fakeConn
+	unreachable bool
+	settings    map[string]string
+}
+
+func newFakeDb(database *fakeDatabase) *sqlx.DB {
+	return sqlx.NewDb(sql.OpenDB(fakeConnector{database: database}), "postgres")
+}
+
+func (d *fakeDatabase) setUnreachable(unreachable bool) {
+	d.Lock()
+	defer d.Unlock()
+	d.unreachable = unreachable
+}
+
+func (d *fakeDatabase) getLockHolder() *fakeConn {
+	d.Lock()
+	defer d.Unlock()
+	return d.lockHolder
+}
+
+func (d *fakeDatabase) getSetting(name string) string {
+	d.Lock()
+	defer d.Unlock()
+	return d.settings[name]
+}
+
+type fakeConnector struct {
+	database *fakeDatabase
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{database: c.database}, nil
+}
+
+func (c fakeConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("Use the connector")
+}
+
+type fakeConn struct {
+	database *fakeDatabase
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("Prepared statements are not supported")
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("Transactions are not supported")
+}
+
+func (c *fakeConn) Close() error {
+	c.database.Lock()
+	defer c.database.Unlock()
+	if c.database.lockHolder == c {
+		c.database.lockHolder = nil
+	}
+	return nil
+}
+
+// wait blocks like an unreachable database until the context is done
+func (c *fakeConn) wait(ctx context.Context) error {
+	c.database.Lock()
+	unreachable := c.database.unreachable
+	c.database.Unlock()
+	if !unreachable {
+		return nil
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	if !strings.Contains(query, "pg_try_advisory_lock") || args[0].Value != advisoryLockKey {
+		return nil, errors.Newf("Unexpected query %v", query)
+	}
+	c.database.Lock()
+	defer c.database.Unlock()
+	locked := c.database.lockHolder == nil || c.database.lockHolder == c
+	if locked {
+		c.database.lockHolder = c
+	}
+	return &fakeRows{value: locked}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	c.database.Lock()
+	defer c.database.Unlock()
+	switch {
+	case strings.Contains(query, "set_config"):
+		name := strings.SplitN(strings.SplitN(query, "set_config('", 2)[1], "'", 2)[0]
+		c.database.settings[name] = args[0].Value.(string)
+	case strings.Contains(query, "pg_advisory_unlock"):
+		if c.database.lockHolder == c {
+			c.database.lockHolder = nil
+		}
+	case query != `SELECT 1;`:
+		return nil, errors.Newf("Unexpected query %v", query)
+	}
+	return driver.RowsAffected(0), nil
+}
+
+type fakeRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"result"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0] = r.value
+	r.done = true
+	return nil
+}
+
+func TestTryLock(t *testing.T) {
+	ctx := context.Background()
+	database := &fakeDatabase{settings: make(map[string]string)}
+	db := newFakeDb(database)
+
+	conn, err := tryLock(ctx, db, "torq-1", 10*time.Second)
+	if err != nil || conn == nil {
+		t.Fatalf("tryLock() = %v, %v, expected the lock", conn, err)
+	}
+	if database.getSetting("application_name") != "torq-leader-torq-1" ||
+		database.getSetting("idle_session_timeout") != "10000" {
+		t.Errorf("tryLock() settings = %v, expected the application name and idle session timeout",
+			database.settings)
+	}
+
+	otherConn, err := tryLock(ctx, db, "torq-2", 10*time.Second)
+	if err != nil || otherConn != nil {
+		t.Fatalf("tryLock() while another instance is the leader = %v, %v, expected no lock", otherConn, err)
+	}
+
+	unlock(conn)
+	if database.getLockHolder() != nil {
+		t.Fatal("unlock() should release the lock")
+	}
+	otherConn, err = tryLock(ctx, db, "torq-2", 10*time.Second)
+	if err != nil || otherConn == nil {
+		t.Fatalf("tryLock() after the leader released the lock = %v, %v, expected the lock", otherConn, err)
+	}
+	// Closing the session releases the lock (when the leader stepped down)
+	discard(otherConn)
+	if database.getLockHolder() != nil {
+		t.Fatal("discard() should close the session that holds the lock")
+	}
+
+	database.setUnreachable(true)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	conn, err = tryLock(timeoutCtx, db, "torq-1", 10*time.Second)
+	if err == nil || conn != nil {
+		t.Errorf("tryLock() on an unreachable database = %v, %v, expected an error", conn, err)
+	}
+}
+
+func TestStart(t *testing.T) {
+	defer func() {
+		election.role = Standalone
+		election.instanceId = ""
+		election.failoverTimeout = 0
+		election.leaderSince = nil
+		election.lastCheckTime = nil
+		election.term = 0
+	}()
+	failoverTimeout := 600 * time.Millisecond
+	checkInterval := failoverTimeout / 3
+	idleSessionTimeout := failoverTimeout - checkInterval
+
+	database := &fakeDatabase{settings: make(map[string]string)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Enable("torq-1", failoverTimeout)
+	done := make(chan struct{})
+	go func() {
+		Start(ctx, newFakeDb(database))
+		close(done)
+	}()
+
+	waitForRole(t, true, time.Second)
+	if database.getSetting("idle_session_timeout") != strconv.FormatInt(idleSessionTimeout.Milliseconds(), 10) {
+		t.Errorf("idle_session_timeout = %v, expected %v", database.getSetting("idle_session_timeout"),
+			idleSessionTimeout)
+	}
+	otherConn, err := tryLock(context.Background(), newFakeDb(database), "torq-2", idleSessionTimeout)
+	if err != nil || otherConn != nil {
+		t.Fatalf("tryLock() of another instance = %v, %v, expected no lock", otherConn, err)
+	}
+
+	// The leader has to step down before the database closes its idle session and another instance takes over
+	database.setUnreachable(true)
+	stepDown := waitForRole(t, false, failoverTimeout)
+	database.setUnreachable(false)
+	if stepDown >= idleSessionTimeout {
+		t.Errorf("Stepped down after %v, expected before the idle session timeout of %v", stepDown, idleSessionTimeout)
+	}
+
+	// The database is reachable again (and the lock is free) so the instance becomes the leader again
+	waitForRole(t, true, failoverTimeout)
+
+	cancel()
+	<-done
+	if database.getLockHolder() != nil {
+		t.Error("Start() should release the lock when the context is cancelled")
+	}
+}
+
+// waitForRole waits until the instance is (not) the leader and returns how long it took
+func waitForRole(t *testing.T, leader bool, timeout time.Duration) time.Duration {
+	t.Helper()
+	start := time.Now()
+	for IsLeader() != leader {
+		if time.Since(start) > timeout {
+			t.Fatalf("IsLeader() is still %v after %v", !leader, timeout)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return time.Since(start)
+}
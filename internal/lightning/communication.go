@@ -1,6 +1,8 @@
 package lightning
 
 import (
+	"context"
+
 	"github.com/cockroachdb/errors"
 
 	"github.com/lncapital/torq/internal/cache"
@@ -8,12 +10,14 @@ import (
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/lightning_helpers"
 	"github.com/lncapital/torq/internal/lnd"
+	"github.com/lncapital/torq/pkg/tracing"
 )
 
-var ServiceInactiveError = errors.New("service is not active")         //nolint:gochecknoglobals
-var UnsupportedOperationError = errors.New("request is not supported") //nolint:gochecknoglobals
+var ServiceInactiveError = errors.New("service is not active")                     //nolint:gochecknoglobals
+var UnsupportedOperationError = errors.New("request is not supported")             //nolint:gochecknoglobals
+var PermissionDeniedError = errors.New("the macaroon does not allow this request") //nolint:gochecknoglobals
 
-func GetInformation(nodeId int) (lightning_helpers.InformationResponse, error) {
+func GetInformation(ctx context.Context, nodeId int) (lightning_helpers.InformationResponse, error) {
 	request := lightning_helpers.InformationRequest{
 		CommunicationRequest: lightning_helpers.CommunicationRequest{
 			NodeId: nodeId,
@@ -33,12 +37,15 @@ func GetInformation(nodeId int) (lightning_helpers.InformationResponse, error) {
 		if !cache.IsLndServiceActive(nodeId) {
 			return lightning_helpers.InformationResponse{}, ServiceInactiveError
 		}
-		response = lnd.Information(request)
+		if !lnd.IsRequestAllowed(nodeId, request) {
+			return lightning_helpers.InformationResponse{}, PermissionDeniedError
+		}
+		response = lnd.Information(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(nodeId) {
 			return lightning_helpers.InformationResponse{}, ServiceInactiveError
 		}
-		response = cln.Information(request)
+		response = cln.Information(ctx, request)
 	}
 	if response.Error != "" {
 		return lightning_helpers.InformationResponse{}, errors.New(response.Error)
@@ -46,7 +53,7 @@ func GetInformation(nodeId int) (lightning_helpers.InformationResponse, error) {
 	return response, nil
 }
 
-func SignMessage(nodeId int, message string, singleHash *bool) (string, error) {
+func SignMessage(ctx context.Context, nodeId int, message string, singleHash *bool) (string, error) {
 	request := lightning_helpers.SignMessageRequest{
 		CommunicationRequest: lightning_helpers.CommunicationRequest{
 			NodeId: nodeId,
@@ -68,12 +75,15 @@ func SignMessage(nodeId int, message string, singleHash *bool) (string, error) {
 		if !cache.IsLndServiceActive(nodeId) {
 			return "", ServiceInactiveError
 		}
-		response = lnd.SignMessage(request)
+		if !lnd.IsRequestAllowed(nodeId, request) {
+			return "", PermissionDeniedError
+		}
+		response = lnd.SignMessage(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(nodeId) {
 			return "", ServiceInactiveError
 		}
-		response = cln.SignMessage(request)
+		response = cln.SignMessage(ctx, request)
 	}
 	if response.Error != "" {
 		return "", errors.New(response.Error)
@@ -81,7 +91,7 @@ func SignMessage(nodeId int, message string, singleHash *bool) (string, error) {
 	return response.Signature, nil
 }
 
-func SignatureVerification(nodeId int, message string, signature string) (string, bool, error) {
+func SignatureVerification(ctx context.Context, nodeId int, message string, signature string) (string, bool, error) {
 	request := lightning_helpers.SignatureVerificationRequest{
 		CommunicationRequest: lightning_helpers.CommunicationRequest{
 			NodeId: nodeId,
@@ -103,12 +113,15 @@ func SignatureVerification(nodeId int, message string, signature string) (string
 		if !cache.IsLndServiceActive(nodeId) {
 			return "", false, ServiceInactiveError
 		}
-		response = lnd.SignatureVerification(request)
+		if !lnd.IsRequestAllowed(nodeId, request) {
+			return "", false, PermissionDeniedError
+		}
+		response = lnd.SignatureVerification(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(nodeId) {
 			return "", false, ServiceInactiveError
 		}
-		response = cln.SignatureVerification(request)
+		response = cln.SignatureVerification(ctx, request)
 	}
 	if response.Error != "" {
 		return "", false, errors.New(response.Error)
@@ -116,7 +129,7 @@ func SignatureVerification(nodeId int, message string, signature string) (string
 	return response.PublicKey, response.Valid, nil
 }
 
-func SetRoutingPolicy(
+func SetRoutingPolicy(ctx context.Context,
 	request lightning_helpers.RoutingPolicyUpdateRequest) (lightning_helpers.RoutingPolicyUpdateResponse, error) {
 
 	response := lightning_helpers.RoutingPolicyUpdateResponse{
@@ -126,18 +139,32 @@ func SetRoutingPolicy(
 		},
 	}
 
+	_, span := tracing.StartCacheRequest(ctx, "GetNodeConnectionDetails")
 	nodeConnectionDetails := cache.GetNodeConnectionDetails(request.NodeId)
+	span.End()
 	switch nodeConnectionDetails.Implementation {
 	case core.LND:
-		if !cache.IsLndServiceActive(request.NodeId) {
+		_, span = tracing.StartCacheRequest(ctx, "IsLndServiceActive")
+		active := cache.IsLndServiceActive(request.NodeId)
+		span.End()
+		if !active {
 			return lightning_helpers.RoutingPolicyUpdateResponse{}, ServiceInactiveError
 		}
-		response = lnd.RoutingPolicyUpdate(request)
+		_, span = tracing.StartCacheRequest(ctx, "IsRequestAllowed")
+		allowed := lnd.IsRequestAllowed(request.NodeId, request)
+		span.End()
+		if !allowed {
+			return lightning_helpers.RoutingPolicyUpdateResponse{}, PermissionDeniedError
+		}
+		response = lnd.RoutingPolicyUpdate(ctx, request)
 	case core.CLN:
-		if !cache.IsClnServiceActive(request.NodeId) {
+		_, span = tracing.StartCacheRequest(ctx, "IsClnServiceActive")
+		active := cache.IsClnServiceActive(request.NodeId)
+		span.End()
+		if !active {
 			return lightning_helpers.RoutingPolicyUpdateResponse{}, ServiceInactiveError
 		}
-		response = cln.RoutingPolicyUpdate(request)
+		response = cln.RoutingPolicyUpdate(ctx, request)
 	}
 	if response.Error != "" {
 		return lightning_helpers.RoutingPolicyUpdateResponse{}, errors.New(response.Error)
@@ -145,7 +172,7 @@ func SetRoutingPolicy(
 	return response, nil
 }
 
-func ConnectPeer(nodeId int, publicKey string, host string) (bool, error) {
+func ConnectPeer(ctx context.Context, nodeId int, publicKey string, host string) (bool, error) {
 	request := lightning_helpers.ConnectPeerRequest{
 		CommunicationRequest: lightning_helpers.CommunicationRequest{
 			NodeId: nodeId,
@@ -167,12 +194,15 @@ func ConnectPeer(nodeId int, publicKey string, host string) (bool, error) {
 		if !cache.IsLndServiceActive(nodeId) {
 			return false, ServiceInactiveError
 		}
-		response = lnd.ConnectPeer(request)
+		if !lnd.IsRequestAllowed(nodeId, request) {
+			return false, PermissionDeniedError
+		}
+		response = lnd.ConnectPeer(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(nodeId) {
 			return false, ServiceInactiveError
 		}
-		response = cln.ConnectPeer(request)
+		response = cln.ConnectPeer(ctx, request)
 	}
 	if response.Error != "" {
 		return false, errors.New(response.Error)
@@ -180,7 +210,7 @@ func ConnectPeer(nodeId int, publicKey string, host string) (bool, error) {
 	return response.RequestFailCurrentlyConnected, nil
 }
 
-func DisconnectPeer(nodeId int, peerNodeId int) (bool, error) {
+func DisconnectPeer(ctx context.Context, nodeId int, peerNodeId int) (bool, error) {
 	request := lightning_helpers.DisconnectPeerRequest{
 		CommunicationRequest: lightning_helpers.CommunicationRequest{
 			NodeId: nodeId,
@@ -201,12 +231,15 @@ func DisconnectPeer(nodeId int, peerNodeId int) (bool, error) {
 		if !cache.IsLndServiceActive(nodeId) {
 			return false, ServiceInactiveError
 		}
-		response = lnd.DisconnectPeer(request)
+		if !lnd.IsRequestAllowed(nodeId, request) {
+			return false, PermissionDeniedError
+		}
+		response = lnd.DisconnectPeer(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(nodeId) {
 			return false, ServiceInactiveError
 		}
-		response = cln.DisconnectPeer(request)
+		response = cln.DisconnectPeer(ctx, request)
 	}
 	if response.Error != "" {
 		return false, errors.New(response.Error)
@@ -214,7 +247,7 @@ func DisconnectPeer(nodeId int, peerNodeId int) (bool, error) {
 	return response.RequestFailedCurrentlyDisconnected, nil
 }
 
-func GetWalletBalance(nodeId int) (lightning_helpers.WalletBalanceResponse, error) {
+func GetWalletBalance(ctx context.Context, nodeId int) (lightning_helpers.WalletBalanceResponse, error) {
 	request := lightning_helpers.WalletBalanceRequest{
 		CommunicationRequest: lightning_helpers.CommunicationRequest{
 			NodeId: nodeId,
@@ -234,12 +267,15 @@ func GetWalletBalance(nodeId int) (lightning_helpers.WalletBalanceResponse, erro
 		if !cache.IsLndServiceActive(nodeId) {
 			return lightning_helpers.WalletBalanceResponse{}, ServiceInactiveError
 		}
-		response = lnd.WalletBalance(request)
+		if !lnd.IsRequestAllowed(nodeId, request) {
+			return lightning_helpers.WalletBalanceResponse{}, PermissionDeniedError
+		}
+		response = lnd.WalletBalance(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(nodeId) {
 			return lightning_helpers.WalletBalanceResponse{}, ServiceInactiveError
 		}
-		response = cln.WalletBalance(request)
+		response = cln.WalletBalance(ctx, request)
 	}
 	if response.Error != "" {
 		return lightning_helpers.WalletBalanceResponse{}, errors.New(response.Error)
@@ -247,7 +283,7 @@ func GetWalletBalance(nodeId int) (lightning_helpers.WalletBalanceResponse, erro
 	return response, nil
 }
 
-func ListPeers(nodeId int, latestError bool) (map[string]lightning_helpers.Peer, error) {
+func ListPeers(ctx context.Context, nodeId int, latestError bool) (map[string]lightning_helpers.Peer, error) {
 	request := lightning_helpers.ListPeersRequest{
 		CommunicationRequest: lightning_helpers.CommunicationRequest{
 			NodeId: nodeId,
@@ -268,12 +304,15 @@ func ListPeers(nodeId int, latestError bool) (map[string]lightning_helpers.Peer,
 		if !cache.IsLndServiceActive(nodeId) {
 			return nil, ServiceInactiveError
 		}
-		response = lnd.ListPeers(request)
+		if !lnd.IsRequestAllowed(nodeId, request) {
+			return nil, PermissionDeniedError
+		}
+		response = lnd.ListPeers(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(nodeId) {
 			return nil, ServiceInactiveError
 		}
-		response = cln.ListPeers(request)
+		response = cln.ListPeers(ctx, request)
 	}
 	if response.Error != "" {
 		return nil, errors.New(response.Error)
@@ -281,7 +320,7 @@ func ListPeers(nodeId int, latestError bool) (map[string]lightning_helpers.Peer,
 	return response.Peers, nil
 }
 
-func NewAddress(request lightning_helpers.NewAddressRequest) (string, error) {
+func NewAddress(ctx context.Context, request lightning_helpers.NewAddressRequest) (string, error) {
 	response := lightning_helpers.NewAddressResponse{
 		Request: request,
 		CommunicationResponse: lightning_helpers.CommunicationResponse{
@@ -295,12 +334,15 @@ func NewAddress(request lightning_helpers.NewAddressRequest) (string, error) {
 		if !cache.IsLndServiceActive(request.NodeId) {
 			return "", ServiceInactiveError
 		}
-		response = lnd.NewAddress(request)
+		if !lnd.IsRequestAllowed(request.NodeId, request) {
+			return "", PermissionDeniedError
+		}
+		response = lnd.NewAddress(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(request.NodeId) {
 			return "", ServiceInactiveError
 		}
-		response = cln.NewAddress(request)
+		response = cln.NewAddress(ctx, request)
 	}
 	if response.Error != "" {
 		return "", errors.New(response.Error)
@@ -308,7 +350,9 @@ func NewAddress(request lightning_helpers.NewAddressRequest) (string, error) {
 	return response.Address, nil
 }
 
-func OpenChannel(request lightning_helpers.OpenChannelRequest) (lightning_helpers.OpenChannelResponse, error) {
+func OpenChannel(ctx context.Context,
+	request lightning_helpers.OpenChannelRequest) (lightning_helpers.OpenChannelResponse, error) {
+
 	response := lightning_helpers.OpenChannelResponse{
 		Request: request,
 		CommunicationResponse: lightning_helpers.CommunicationResponse{
@@ -322,12 +366,15 @@ func OpenChannel(request lightning_helpers.OpenChannelRequest) (lightning_helper
 		if !cache.IsLndServiceActive(request.NodeId) {
 			return lightning_helpers.OpenChannelResponse{}, ServiceInactiveError
 		}
-		response = lnd.OpenChannel(request)
+		if !lnd.IsRequestAllowed(request.NodeId, request) {
+			return lightning_helpers.OpenChannelResponse{}, PermissionDeniedError
+		}
+		response = lnd.OpenChannel(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(request.NodeId) {
 			return lightning_helpers.OpenChannelResponse{}, ServiceInactiveError
 		}
-		response = cln.OpenChannel(request)
+		response = cln.OpenChannel(ctx, request)
 	}
 	if response.Error != "" {
 		return lightning_helpers.OpenChannelResponse{}, errors.New(response.Error)
@@ -335,7 +382,7 @@ func OpenChannel(request lightning_helpers.OpenChannelRequest) (lightning_helper
 	return response, nil
 }
 
-func BatchOpenChannel(
+func BatchOpenChannel(ctx context.Context,
 	request lightning_helpers.BatchOpenChannelRequest) (lightning_helpers.BatchOpenChannelResponse, error) {
 
 	response := lightning_helpers.BatchOpenChannelResponse{
@@ -351,7 +398,10 @@ func BatchOpenChannel(
 		if !cache.IsLndServiceActive(request.NodeId) {
 			return lightning_helpers.BatchOpenChannelResponse{}, ServiceInactiveError
 		}
-		response = lnd.BatchOpenChannel(request)
+		if !lnd.IsRequestAllowed(request.NodeId, request) {
+			return lightning_helpers.BatchOpenChannelResponse{}, PermissionDeniedError
+		}
+		response = lnd.BatchOpenChannel(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(request.NodeId) {
 			return lightning_helpers.BatchOpenChannelResponse{}, ServiceInactiveError
@@ -364,7 +414,9 @@ func BatchOpenChannel(
 	return response, nil
 }
 
-func CloseChannel(request lightning_helpers.CloseChannelRequest) (lightning_helpers.CloseChannelResponse, error) {
+func CloseChannel(ctx context.Context,
+	request lightning_helpers.CloseChannelRequest) (lightning_helpers.CloseChannelResponse, error) {
+
 	response := lightning_helpers.CloseChannelResponse{
 		Request: request,
 		CommunicationResponse: lightning_helpers.CommunicationResponse{
@@ -378,12 +430,15 @@ func CloseChannel(request lightning_helpers.CloseChannelRequest) (lightning_help
 		if !cache.IsLndServiceActive(request.NodeId) {
 			return lightning_helpers.CloseChannelResponse{}, ServiceInactiveError
 		}
-		response = lnd.CloseChannel(request)
+		if !lnd.IsRequestAllowed(request.NodeId, request) {
+			return lightning_helpers.CloseChannelResponse{}, PermissionDeniedError
+		}
+		response = lnd.CloseChannel(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(request.NodeId) {
 			return lightning_helpers.CloseChannelResponse{}, ServiceInactiveError
 		}
-		response = cln.CloseChannel(request)
+		response = cln.CloseChannel(ctx, request)
 	}
 	if response.Error != "" {
 		return lightning_helpers.CloseChannelResponse{}, errors.New(response.Error)
@@ -391,7 +446,9 @@ func CloseChannel(request lightning_helpers.CloseChannelRequest) (lightning_help
 	return response, nil
 }
 
-func NewInvoice(request lightning_helpers.NewInvoiceRequest) (lightning_helpers.NewInvoiceResponse, error) {
+func NewInvoice(ctx context.Context,
+	request lightning_helpers.NewInvoiceRequest) (lightning_helpers.NewInvoiceResponse, error) {
+
 	response := lightning_helpers.NewInvoiceResponse{
 		Request: request,
 		CommunicationResponse: lightning_helpers.CommunicationResponse{
@@ -405,12 +462,15 @@ func NewInvoice(request lightning_helpers.NewInvoiceRequest) (lightning_helpers.
 		if !cache.IsLndServiceActive(request.NodeId) {
 			return lightning_helpers.NewInvoiceResponse{}, ServiceInactiveError
 		}
-		response = lnd.NewInvoice(request)
+		if !lnd.IsRequestAllowed(request.NodeId, request) {
+			return lightning_helpers.NewInvoiceResponse{}, PermissionDeniedError
+		}
+		response = lnd.NewInvoice(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(request.NodeId) {
 			return lightning_helpers.NewInvoiceResponse{}, ServiceInactiveError
 		}
-		response = cln.NewInvoice(request)
+		response = cln.NewInvoice(ctx, request)
 	}
 	if response.Error != "" {
 		return lightning_helpers.NewInvoiceResponse{}, errors.New(response.Error)
@@ -418,7 +478,7 @@ func NewInvoice(request lightning_helpers.NewInvoiceRequest) (lightning_helpers.
 	return response, nil
 }
 
-func OnChainPayment(request lightning_helpers.OnChainPaymentRequest) (string, error) {
+func OnChainPayment(ctx context.Context, request lightning_helpers.OnChainPaymentRequest) (string, error) {
 	response := lightning_helpers.OnChainPaymentResponse{
 		Request: request,
 		CommunicationResponse: lightning_helpers.CommunicationResponse{
@@ -432,12 +492,15 @@ func OnChainPayment(request lightning_helpers.OnChainPaymentRequest) (string, er
 		if !cache.IsLndServiceActive(request.NodeId) {
 			return "", ServiceInactiveError
 		}
-		response = lnd.OnChainPayment(request)
+		if !lnd.IsRequestAllowed(request.NodeId, request) {
+			return "", PermissionDeniedError
+		}
+		response = lnd.OnChainPayment(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(request.NodeId) {
 			return "", ServiceInactiveError
 		}
-		response = cln.OnChainPayment(request)
+		response = cln.OnChainPayment(ctx, request)
 	}
 	if response.Error != "" {
 		return "", errors.New(response.Error)
@@ -445,7 +508,9 @@ func OnChainPayment(request lightning_helpers.OnChainPaymentRequest) (string, er
 	return response.TxId, nil
 }
 
-func NewPayment(request lightning_helpers.NewPaymentRequest) (lightning_helpers.NewPaymentResponse, error) {
+func NewPayment(ctx context.Context,
+	request lightning_helpers.NewPaymentRequest) (lightning_helpers.NewPaymentResponse, error) {
+
 	response := lightning_helpers.NewPaymentResponse{
 		Request: request,
 		CommunicationResponse: lightning_helpers.CommunicationResponse{
@@ -459,12 +524,15 @@ func NewPayment(request lightning_helpers.NewPaymentRequest) (lightning_helpers.
 		if !cache.IsLndServiceActive(request.NodeId) {
 			return lightning_helpers.NewPaymentResponse{}, ServiceInactiveError
 		}
-		response = lnd.NewPayment(request)
+		if !lnd.IsRequestAllowed(request.NodeId, request) {
+			return lightning_helpers.NewPaymentResponse{}, PermissionDeniedError
+		}
+		response = lnd.NewPayment(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(request.NodeId) {
 			return lightning_helpers.NewPaymentResponse{}, ServiceInactiveError
 		}
-		response = cln.NewPayment(request)
+		response = cln.NewPayment(ctx, request)
 	}
 	if response.Error != "" {
 		return lightning_helpers.NewPaymentResponse{}, errors.New(response.Error)
@@ -472,7 +540,9 @@ func NewPayment(request lightning_helpers.NewPaymentRequest) (lightning_helpers.
 	return response, nil
 }
 
-func DecodeInvoice(request lightning_helpers.DecodeInvoiceRequest) (lightning_helpers.DecodeInvoiceResponse, error) {
+func DecodeInvoice(ctx context.Context,
+	request lightning_helpers.DecodeInvoiceRequest) (lightning_helpers.DecodeInvoiceResponse, error) {
+
 	response := lightning_helpers.DecodeInvoiceResponse{
 		Request: request,
 		CommunicationResponse: lightning_helpers.CommunicationResponse{
@@ -486,7 +556,10 @@ func DecodeInvoice(request lightning_helpers.DecodeInvoiceRequest) (lightning_he
 		if !cache.IsLndServiceActive(request.NodeId) {
 			return lightning_helpers.DecodeInvoiceResponse{}, ServiceInactiveError
 		}
-		response = lnd.DecodeInvoice(request)
+		if !lnd.IsRequestAllowed(request.NodeId, request) {
+			return lightning_helpers.DecodeInvoiceResponse{}, PermissionDeniedError
+		}
+		response = lnd.DecodeInvoice(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(request.NodeId) {
 			return lightning_helpers.DecodeInvoiceResponse{}, ServiceInactiveError
@@ -499,7 +572,7 @@ func DecodeInvoice(request lightning_helpers.DecodeInvoiceRequest) (lightning_he
 	return response, nil
 }
 
-func ChannelStatusUpdate(request lightning_helpers.ChannelStatusUpdateRequest) error {
+func ChannelStatusUpdate(ctx context.Context, request lightning_helpers.ChannelStatusUpdateRequest) error {
 	response := lightning_helpers.ChannelStatusUpdateResponse{
 		Request: request,
 		CommunicationResponse: lightning_helpers.CommunicationResponse{
@@ -513,7 +586,10 @@ func ChannelStatusUpdate(request lightning_helpers.ChannelStatusUpdateRequest) e
 		if !cache.IsLndServiceActive(request.NodeId) {
 			return ServiceInactiveError
 		}
-		response = lnd.ChannelStatusUpdate(request)
+		if !lnd.IsRequestAllowed(request.NodeId, request) {
+			return PermissionDeniedError
+		}
+		response = lnd.ChannelStatusUpdate(ctx, request)
 	case core.CLN:
 		if !cache.IsClnServiceActive(request.NodeId) {
 			return ServiceInactiveError
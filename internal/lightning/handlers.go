@@ -10,23 +10,35 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/lncapital/torq/internal/audit"
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/lnd"
+	"github.com/lncapital/torq/internal/spending"
 	"github.com/lncapital/torq/pkg/server_errors"
 )
 
-func batchOpenHandler(c *gin.Context) {
+func batchOpenHandler(c *gin.Context, db *sqlx.DB) {
 	var batchOpnReq lightning_helpers.BatchOpenChannelRequest
 	if err := c.BindJSON(&batchOpnReq); err != nil {
 		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
 		return
 	}
 
-	response, err := BatchOpenChannel(batchOpnReq)
+	spendingRequest, approved := authorizeSpending(c, db, spending.ActionBatchOpenChannel, batchOpnReq.NodeId,
+		batchOpnReq)
+	if !approved {
+		return
+	}
+	response, err := BatchOpenChannel(c.Request.Context(), batchOpnReq)
+	spending.RecordResult(db, audit.GetRequestActor(c), spendingRequest,
+		strings.Join(response.PendingChannelPoints, ","), err)
 	if err != nil {
 		server_errors.WrapLogAndSendServerError(c, err, "Batch open channels")
 		return
@@ -36,7 +48,7 @@ func batchOpenHandler(c *gin.Context) {
 }
 
 // openChannelHandler opens a channel to a peer
-func openChannelHandler(c *gin.Context) {
+func openChannelHandler(c *gin.Context, db *sqlx.DB) {
 	var openChannelRequest lightning_helpers.OpenChannelRequest
 	err := c.BindJSON(&openChannelRequest)
 	if err != nil {
@@ -44,7 +56,13 @@ func openChannelHandler(c *gin.Context) {
 		return
 	}
 
-	response, err := OpenChannel(openChannelRequest)
+	spendingRequest, approved := authorizeSpending(c, db, spending.ActionOpenChannel, openChannelRequest.NodeId,
+		openChannelRequest)
+	if !approved {
+		return
+	}
+	response, err := OpenChannel(c.Request.Context(), openChannelRequest)
+	spending.RecordResult(db, audit.GetRequestActor(c), spendingRequest, response.ChannelPoint, err)
 	switch {
 	case err != nil && strings.Contains(err.Error(), "connecting to "):
 		serr := server_errors.ServerError{}
@@ -114,13 +132,14 @@ func closeChannelHandler(c *gin.Context, db *sqlx.DB) {
 	}
 
 	closeChannelRequest.Db = db
-	response, err := CloseChannel(closeChannelRequest)
+	response, err := CloseChannel(c.Request.Context(), closeChannelRequest)
 	if err != nil {
 		// Check if the error was because the node could not connect to the peer
 		if strings.Contains(err.Error(), "could not connect to peer.") {
 			serr := server_errors.ServerError{}
 			serr.AddServerError("Could not connect to peer node.")
 			server_errors.SendBadRequestFieldError(c, &serr)
+			return
 		}
 		server_errors.SendBadRequestFromError(c, err)
 		return
@@ -139,7 +158,7 @@ func updateRoutingPolicyHandler(c *gin.Context, db *sqlx.DB) {
 	requestBody.RateLimitCount = 10
 	requestBody.Db = db
 
-	response, err := SetRoutingPolicy(requestBody)
+	response, err := SetRoutingPolicy(c.Request.Context(), requestBody)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, server_errors.SingleServerError(err.Error()))
 		err = errors.Wrap(err, "Problem when setting routing policy")
@@ -154,20 +173,20 @@ func getNodesWalletBalancesHandler(c *gin.Context) {
 	network, err := strconv.Atoi(c.Param("network"))
 	if err != nil {
 		server_errors.SendBadRequest(c, "Can't process network")
+		return
 	}
-
-	activeTorqNodes := cache.GetActiveTorqNodeSettings()
-
+	nodeIds, err := auth.GetNodeIds(c)
 	if err != nil {
-		server_errors.WrapLogAndSendServerError(c, err, "Unable to get nodes")
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", err.Error()))
 		return
 	}
+
 	walletBalances := make([]lightning_helpers.WalletBalanceResponse, 0)
-	for _, activeTorqNode := range activeTorqNodes {
-		if activeTorqNode.Network != core.Network(network) {
+	for _, activeTorqNode := range cache.GetActiveTorqNodeSettings() {
+		if activeTorqNode.Network != core.Network(network) || !slices.Contains(nodeIds, activeTorqNode.NodeId) {
 			continue
 		}
-		resp, err := GetWalletBalance(activeTorqNode.NodeId)
+		resp, err := GetWalletBalance(c.Request.Context(), activeTorqNode.NodeId)
 		if err != nil {
 			errorMsg := fmt.Sprintf("Error retrieving wallet balance for nodeId: %v", activeTorqNode.NodeId)
 			server_errors.WrapLogAndSendServerError(c, err, errorMsg)
@@ -188,7 +207,7 @@ func newInvoiceHandler(c *gin.Context) {
 		return
 	}
 
-	resp, err := NewInvoice(requestBody)
+	resp, err := NewInvoice(c.Request.Context(), requestBody)
 	if err != nil {
 		server_errors.WrapLogAndSendServerError(c, err, "Creating new invoice")
 		return
@@ -206,7 +225,7 @@ func decodeInvoiceHandler(c *gin.Context) {
 		return
 	}
 
-	di, err := DecodeInvoice(lightning_helpers.DecodeInvoiceRequest{
+	di, err := DecodeInvoice(c.Request.Context(), lightning_helpers.DecodeInvoiceRequest{
 		CommunicationRequest: lightning_helpers.CommunicationRequest{NodeId: nodeId},
 		Invoice:              invoice,
 	})
@@ -227,7 +246,7 @@ func decodeInvoiceHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, di)
 }
 
-func sendCoinsHandler(c *gin.Context) {
+func sendCoinsHandler(c *gin.Context, db *sqlx.DB) {
 	var requestBody lightning_helpers.OnChainPaymentRequest
 
 	if err := c.BindJSON(&requestBody); err != nil {
@@ -235,7 +254,12 @@ func sendCoinsHandler(c *gin.Context) {
 		return
 	}
 
-	resp, err := OnChainPayment(requestBody)
+	spendingRequest, approved := authorizeSpending(c, db, spending.ActionSendCoins, requestBody.NodeId, requestBody)
+	if !approved {
+		return
+	}
+	resp, err := OnChainPayment(c.Request.Context(), requestBody)
+	spending.RecordResult(db, audit.GetRequestActor(c), spendingRequest, resp, err)
 	if err != nil {
 		server_errors.WrapLogAndSendServerError(c, err, "Sending on-chain payment")
 		return
@@ -252,7 +276,7 @@ func newAddressHandler(c *gin.Context) {
 		return
 	}
 
-	resp, err := NewAddress(requestBody)
+	resp, err := NewAddress(c.Request.Context(), requestBody)
 	if err != nil {
 		// TODO: Improve error handling. Can't find LND errors in the codebase
 		server_errors.LogAndSendServerError(c, err)
@@ -261,3 +285,17 @@ func newAddressHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+func getPermissionsHandler(c *gin.Context) {
+	nodeId, err := strconv.Atoi(c.Param("nodeId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Can't process nodeId")
+		return
+	}
+	if cache.GetNodeConnectionDetails(nodeId).Implementation != core.LND {
+		server_errors.SendBadRequest(c, "Macaroon permissions are only available for LND nodes")
+		return
+	}
+
+	c.JSON(http.StatusOK, lnd.GetPermissionsReport(nodeId))
+}
@@ -3,16 +3,21 @@ package lightning
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/auth"
 )
 
 func RegisterLightningRoutes(r *gin.RouterGroup, db *sqlx.DB) {
-	r.POST("open", func(c *gin.Context) { openChannelHandler(c) })
-	r.POST("openbatch", func(c *gin.Context) { batchOpenHandler(c) })
-	r.POST("close", func(c *gin.Context) { closeChannelHandler(c, db) })
+	// Moving funds requires a recently verified second factor
+	freshFactor := auth.FreshFactorRequired(db)
+	r.POST("open", freshFactor, func(c *gin.Context) { openChannelHandler(c, db) })
+	r.POST("openbatch", freshFactor, func(c *gin.Context) { batchOpenHandler(c, db) })
+	r.POST("close", freshFactor, func(c *gin.Context) { closeChannelHandler(c, db) })
 	r.PUT("updateRoutingPolicy", func(c *gin.Context) { updateRoutingPolicyHandler(c, db) })
 	r.GET("/:network/walletBalances", func(c *gin.Context) { getNodesWalletBalancesHandler(c) })
 	r.POST("newinvoice", func(c *gin.Context) { newInvoiceHandler(c) })
 	r.GET("decode", func(c *gin.Context) { decodeInvoiceHandler(c) })
-	r.POST("sendcoins", func(c *gin.Context) { sendCoinsHandler(c) })
+	r.POST("sendcoins", freshFactor, func(c *gin.Context) { sendCoinsHandler(c, db) })
 	r.POST("new-address", func(c *gin.Context) { newAddressHandler(c) })
+	r.GET("permissions/:nodeId", func(c *gin.Context) { getPermissionsHandler(c) })
 }
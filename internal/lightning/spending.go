@@ -0,0 +1,163 @@
+package lightning
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/audit"
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/spending"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+// RegisterSpendingApprovalRoutes registers the approval of spending requests, it's part of the lightning package
+// because an approved request is executed straight away.
+func RegisterSpendingApprovalRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.POST("requests/:spendingRequestId/approve", auth.FreshFactorRequired(db),
+		func(c *gin.Context) { approveSpendingRequestHandler(c, db) })
+}
+
+func approveSpendingRequestHandler(c *gin.Context, db *sqlx.DB) {
+	spendingRequestId, err := strconv.Atoi(c.Param("spendingRequestId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse spendingRequestId in the request.")
+		return
+	}
+	spendingRequest, reason, err := ApproveSpendingRequest(c.Request.Context(), db, spendingRequestId,
+		audit.GetRequestActor(c))
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Approving spending request.")
+		return
+	}
+	if reason != "" {
+		server_errors.SendUnprocessableEntity(c, reason)
+		return
+	}
+	c.JSON(http.StatusOK, spendingRequest)
+}
+
+// AuthorizeSpending evaluates the request against the spending limits of the node.
+// When the returned request is not Approved it's waiting for approval and should not be executed.
+func AuthorizeSpending(ctx context.Context, db *sqlx.DB, actor audit.Actor, action spending.Action, nodeId int,
+	request interface{}) (spending.Request, error) {
+
+	amountSat, err := getSpendingAmountSat(ctx, action, request)
+	if err != nil {
+		return spending.Request{}, errors.Wrap(err, "Obtaining amount")
+	}
+	return spending.Authorize(db, actor, nodeId, action, amountSat, request)
+}
+
+// authorizeSpending is AuthorizeSpending for handlers, it sends the response when the request is not approved
+func authorizeSpending(c *gin.Context, db *sqlx.DB, action spending.Action, nodeId int,
+	request interface{}) (spending.Request, bool) {
+
+	spendingRequest, err := AuthorizeSpending(c.Request.Context(), db, audit.GetRequestActor(c), action, nodeId,
+		request)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Evaluating spending limits")
+		return spending.Request{}, false
+	}
+	if spendingRequest.Status != spending.Approved {
+		c.JSON(http.StatusAccepted, gin.H{"spendingRequest": spendingRequest})
+		return spendingRequest, false
+	}
+	return spendingRequest, true
+}
+
+// getSpendingAmountSat returns the maximum amount the request can move (including the fee limit of payments)
+func getSpendingAmountSat(ctx context.Context, action spending.Action, request interface{}) (int64, error) {
+	switch r := request.(type) {
+	case lightning_helpers.OnChainPaymentRequest:
+		if r.SendAll != nil && *r.SendAll {
+			walletBalance, err := GetWalletBalance(ctx, r.NodeId)
+			if err != nil {
+				return 0, errors.Wrap(err, "Obtaining wallet balance to send all")
+			}
+			return walletBalance.TotalBalance, nil
+		}
+		return r.AmountSat, nil
+	case lightning_helpers.NewPaymentRequest:
+		var amountMsat int64
+		if r.AmtMSat != nil {
+			amountMsat = *r.AmtMSat
+		}
+		if amountMsat == 0 && r.Invoice != nil && *r.Invoice != "" {
+			decodedInvoice, err := DecodeInvoice(ctx, lightning_helpers.DecodeInvoiceRequest{
+				CommunicationRequest: r.CommunicationRequest,
+				Invoice:              *r.Invoice,
+			})
+			if err != nil {
+				return 0, errors.Wrap(err, "Decoding invoice")
+			}
+			amountMsat = decodedInvoice.ValueMsat
+		}
+		if r.FeeLimitMsat != nil {
+			amountMsat += *r.FeeLimitMsat
+		}
+		return (amountMsat + 999) / 1000, nil
+	case lightning_helpers.OpenChannelRequest:
+		return r.LocalFundingAmount, nil
+	case lightning_helpers.BatchOpenChannelRequest:
+		var amountSat int64
+		for _, channel := range r.Channels {
+			amountSat += channel.LocalFundingAmount
+		}
+		return amountSat, nil
+	}
+	return 0, errors.Newf("Unsupported request for action %v", action)
+}
+
+// ApproveSpendingRequest approves a pending request and executes it.
+// The second return value is the reason why it can't be approved (when it's not empty).
+func ApproveSpendingRequest(ctx context.Context, db *sqlx.DB, spendingRequestId int,
+	actor audit.Actor) (spending.Request, string, error) {
+
+	spendingRequest, reason, err := spending.Approve(db, spendingRequestId, actor)
+	if err != nil || reason != "" {
+		return spendingRequest, reason, err
+	}
+	result, err := executeSpendingRequest(ctx, spendingRequest)
+	return spending.RecordResult(db, actor, spendingRequest, result, err), "", nil
+}
+
+func executeSpendingRequest(ctx context.Context, spendingRequest spending.Request) (string, error) {
+	switch spendingRequest.Action {
+	case spending.ActionSendCoins:
+		var request lightning_helpers.OnChainPaymentRequest
+		if err := json.Unmarshal(spendingRequest.Request, &request); err != nil {
+			return "", errors.Wrap(err, "Unmarshalling on-chain payment request")
+		}
+		return OnChainPayment(ctx, request)
+	case spending.ActionNewPayment:
+		var request lightning_helpers.NewPaymentRequest
+		if err := json.Unmarshal(spendingRequest.Request, &request); err != nil {
+			return "", errors.Wrap(err, "Unmarshalling payment request")
+		}
+		response, err := NewPayment(ctx, request)
+		return response.Hash, err
+	case spending.ActionOpenChannel:
+		var request lightning_helpers.OpenChannelRequest
+		if err := json.Unmarshal(spendingRequest.Request, &request); err != nil {
+			return "", errors.Wrap(err, "Unmarshalling open channel request")
+		}
+		response, err := OpenChannel(ctx, request)
+		return response.ChannelPoint, err
+	case spending.ActionBatchOpenChannel:
+		var request lightning_helpers.BatchOpenChannelRequest
+		if err := json.Unmarshal(spendingRequest.Request, &request); err != nil {
+			return "", errors.Wrap(err, "Unmarshalling batch open channel request")
+		}
+		response, err := BatchOpenChannel(ctx, request)
+		return strings.Join(response.PendingChannelPoints, ","), err
+	}
+	return "", errors.Newf("Unsupported spending action %v", spendingRequest.Action)
+}
@@ -0,0 +1,210 @@
+package lightning
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/exp/slices"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/audit"
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/spending"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+// spendingApprovalRequired is the (202) response when the request exceeds a spending limit
+type spendingApprovalRequired struct {
+	SpendingRequest spending.Request `json:"spendingRequest"`
+}
+
+func GetV1Endpoints(db *sqlx.DB) []ah.Endpoint {
+	// Moving funds requires a recently verified second factor
+	freshFactor := []gin.HandlerFunc{auth.FreshFactorRequired(db)}
+	return []ah.Endpoint{
+		{
+			Method:           http.MethodPost,
+			Path:             "/lightning/channels",
+			Resource:         "lightning",
+			Summary:          "Open a channel",
+			RequestBody:      lightning_helpers.OpenChannelRequest{},
+			Response:         lightning_helpers.OpenChannelResponse{},
+			AcceptedResponse: spendingApprovalRequired{},
+			Middleware:       freshFactor,
+			Handler:          func(c *gin.Context) { openChannelHandler(c, db) },
+		},
+		{
+			Method:           http.MethodPost,
+			Path:             "/lightning/channels/batch",
+			Resource:         "lightning",
+			Summary:          "Open multiple channels in one transaction",
+			RequestBody:      lightning_helpers.BatchOpenChannelRequest{},
+			Response:         lightning_helpers.BatchOpenChannelResponse{},
+			AcceptedResponse: spendingApprovalRequired{},
+			Middleware:       freshFactor,
+			Handler:          func(c *gin.Context) { batchOpenHandler(c, db) },
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/lightning/channels/close",
+			Resource:    "lightning",
+			Summary:     "Close a channel",
+			RequestBody: lightning_helpers.CloseChannelRequest{},
+			Response:    lightning_helpers.CloseChannelResponse{},
+			Middleware:  freshFactor,
+			Handler:     func(c *gin.Context) { closeChannelHandler(c, db) },
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/lightning/routing-policy",
+			Resource:    "lightning",
+			Summary:     "Update the routing policy of a channel",
+			Description: "Can be used by API tokens with the write:policy scope.",
+			RequestBody: lightning_helpers.RoutingPolicyUpdateRequest{},
+			Response:    lightning_helpers.RoutingPolicyUpdateResponse{},
+			Handler:     func(c *gin.Context) { updateRoutingPolicyHandler(c, db) },
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/lightning/wallet-balances",
+			Resource:   "lightning",
+			Summary:    "Get the on-chain wallet balances of the active nodes",
+			Parameters: []ah.Parameter{ah.NodeIdParameter},
+			Response:   []lightning_helpers.WalletBalanceResponse{},
+			Handler:    getV1WalletBalancesHandler,
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/lightning/invoices",
+			Resource:    "lightning",
+			Summary:     "Create an invoice",
+			RequestBody: lightning_helpers.NewInvoiceRequest{},
+			Response:    lightning_helpers.NewInvoiceResponse{},
+			Handler:     newInvoiceHandler,
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/lightning/invoices/decode",
+			Resource: "lightning",
+			Summary:  "Decode an invoice",
+			Parameters: []ah.Parameter{
+				{Name: "nodeId", Description: "The Torq node that decodes the invoice", Type: "integer", Required: true},
+				{Name: "invoice", Type: "string", Required: true},
+			},
+			Response: lightning_helpers.DecodeInvoiceResponse{},
+			Handler:  decodeV1InvoiceHandler,
+		},
+		{
+			Method:           http.MethodPost,
+			Path:             "/lightning/payments",
+			Resource:         "lightning",
+			Summary:          "Pay an invoice or send a keysend payment",
+			Description:      "The response is sent when the payment succeeded or failed.",
+			RequestBody:      lightning_helpers.NewPaymentRequest{},
+			Response:         lightning_helpers.NewPaymentResponse{},
+			AcceptedResponse: spendingApprovalRequired{},
+			Middleware:       freshFactor,
+			Handler:          func(c *gin.Context) { newV1PaymentHandler(c, db) },
+		},
+		{
+			Method:           http.MethodPost,
+			Path:             "/lightning/on-chain-payments",
+			Resource:         "lightning",
+			Summary:          "Send an on-chain payment, the response is the transaction id",
+			RequestBody:      lightning_helpers.OnChainPaymentRequest{},
+			Response:         "",
+			AcceptedResponse: spendingApprovalRequired{},
+			Middleware:       freshFactor,
+			Handler:          func(c *gin.Context) { sendCoinsHandler(c, db) },
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/lightning/addresses",
+			Resource:    "lightning",
+			Summary:     "Generate a new on-chain address",
+			RequestBody: lightning_helpers.NewAddressRequest{},
+			Response:    "",
+			Handler:     newAddressHandler,
+		},
+	}
+}
+
+func getV1WalletBalancesHandler(c *gin.Context) {
+	nodeIds, err := auth.GetNodeIds(c)
+	if err != nil {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", err.Error()))
+		return
+	}
+	walletBalances := make([]lightning_helpers.WalletBalanceResponse, 0)
+	for _, activeTorqNode := range cache.GetActiveTorqNodeSettings() {
+		if !slices.Contains(nodeIds, activeTorqNode.NodeId) {
+			continue
+		}
+		resp, err := GetWalletBalance(c.Request.Context(), activeTorqNode.NodeId)
+		if err != nil {
+			server_errors.WrapLogAndSendServerError(c, err,
+				fmt.Sprintf("Error retrieving wallet balance for nodeId: %v", activeTorqNode.NodeId))
+			return
+		}
+		walletBalances = append(walletBalances, resp)
+	}
+	c.JSON(http.StatusOK, walletBalances)
+}
+
+func decodeV1InvoiceHandler(c *gin.Context) {
+	nodeId, err := strconv.Atoi(c.Query("nodeId"))
+	if err != nil || !slices.Contains(cache.GetAllTorqNodeIds(), nodeId) {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", "Unknown nodeId"))
+		return
+	}
+	if c.Query("invoice") == "" {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("invoice", "Invoice is required"))
+		return
+	}
+	di, err := DecodeInvoice(c.Request.Context(), lightning_helpers.DecodeInvoiceRequest{
+		CommunicationRequest: lightning_helpers.CommunicationRequest{NodeId: nodeId},
+		Invoice:              c.Query("invoice"),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "checksum failed") {
+			server_errors.SendBadRequestFieldError(c,
+				server_errors.SingleFieldErrorCode("invoice", "CHECKSUM_FAILED", nil))
+			return
+		}
+		server_errors.SendBadRequestFieldError(c,
+			server_errors.SingleFieldErrorCode("invoice", "COULD_NOT_DECODE_INVOICE", nil))
+		return
+	}
+	c.JSON(http.StatusOK, di)
+}
+
+func newV1PaymentHandler(c *gin.Context, db *sqlx.DB) {
+	var request lightning_helpers.NewPaymentRequest
+	if err := c.BindJSON(&request); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if (request.Invoice == nil) == (request.Dest == nil) {
+		server_errors.SendUnprocessableEntity(c, "Either invoice or dest is required.")
+		return
+	}
+
+	spendingRequest, approved := authorizeSpending(c, db, spending.ActionNewPayment, request.NodeId, request)
+	if !approved {
+		return
+	}
+	response, err := NewPayment(c.Request.Context(), request)
+	spending.RecordResult(db, audit.GetRequestActor(c), spendingRequest, response.Hash, err)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Sending payment")
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -130,6 +130,10 @@ type CommunicationRequest struct {
 	NodeId int `json:"nodeId"`
 }
 
+func (r CommunicationRequest) GetNodeId() int {
+	return r.NodeId
+}
+
 type RebalanceRequest struct {
 	Origin RebalanceOrigin `json:"origin"`
 	// Either manually generated number for manual rebalance or
@@ -9,8 +9,8 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc"
 
@@ -27,6 +27,7 @@ func ChannelBalanceCacheMaintenance(ctx context.Context,
 	nodeSettings cache.NodeSettingsCache) {
 
 	serviceType := services_helpers.LndServiceChannelBalanceCacheService
+	log := logging.WithNodeService(streamLog, serviceType.String(), nodeSettings.NodeId)
 
 	bootStrapping := true
 	lndSyncTicker := time.NewTicker(channelbalanceTickerSeconds * time.Second)
@@ -42,7 +43,7 @@ func ChannelBalanceCacheMaintenance(ctx context.Context,
 		if initiateSync {
 			bootStrapping, err = synchronizeDataFromLnd(nodeSettings, bootStrapping, serviceType, lndClient, db, mutex)
 			if err != nil {
-				log.Error().Err(err).Msgf("Channel balance synchronization failed for nodeId: %v", nodeSettings.NodeId)
+				log.Error().Err(err).Msg("Channel balance synchronization failed")
 				cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 				return
 			}
@@ -73,7 +74,7 @@ func ChannelBalanceCacheMaintenance(ctx context.Context,
 		case <-lndSyncTicker.C:
 			bootStrapping, err = synchronizeDataFromLnd(nodeSettings, bootStrapping, serviceType, lndClient, db, mutex)
 			if err != nil {
-				log.Error().Err(err).Msgf("Channel balance synchronization failed for nodeId: %v", nodeSettings.NodeId)
+				log.Error().Err(err).Msg("Channel balance synchronization failed")
 				cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 				return
 			}
@@ -91,7 +92,8 @@ func synchronizeDataFromLnd(nodeSettings cache.NodeSettingsCache,
 	if !cache.IsLndServiceActive(nodeSettings.NodeId) {
 		if !bootStrapping {
 			bootStrapping = true
-			log.Error().Msgf("Channel balance cache got out-of-sync because of a non-active LND stream. (nodeId: %v)", nodeSettings.NodeId)
+			log.Error().Int(logging.NodeIdField, nodeSettings.NodeId).Msg(
+				"Channel balance cache got out-of-sync because of a non-active LND stream.")
 		}
 	}
 	if bootStrapping {
@@ -99,7 +101,8 @@ func synchronizeDataFromLnd(nodeSettings cache.NodeSettingsCache,
 	}
 	err := initializeChannelBalanceFromLnd(lndClient, nodeSettings.NodeId, db, mutex)
 	if err != nil {
-		log.Error().Err(err).Msgf("Failed to initialize channel balance cache. This is a critical issue! (nodeId: %v)", nodeSettings.NodeId)
+		log.Error().Err(err).Int(logging.NodeIdField, nodeSettings.NodeId).Msg(
+			"Failed to initialize channel balance cache. This is a critical issue!")
 		cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 		return bootStrapping, err
 	}
@@ -114,7 +117,8 @@ func synchronizeDataFromLnd(nodeSettings cache.NodeSettingsCache,
 
 func initializeChannelBalanceFromLnd(lndClient lnrpc.LightningClient, nodeId int, db *sqlx.DB, mutex *sync.RWMutex) error {
 	if core.RWMutexWriteLocked(mutex) {
-		log.Error().Msgf("The lock initializeChannelBalanceFromLnd is already locked? This is a critical issue! (nodeId: %v)", nodeId)
+		log.Error().Int(logging.NodeIdField, nodeId).Msg(
+			"The lock initializeChannelBalanceFromLnd is already locked? This is a critical issue!")
 		return errors.New(fmt.Sprintf("The lock initializeChannelBalanceFromLnd is already locked? This is a critical issue! (nodeId: %v)", nodeId))
 	}
 	nodeSettings := cache.GetNodeSettingsByNodeId(nodeId)
@@ -11,6 +11,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
 
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc"
 
@@ -20,7 +21,6 @@ import (
 	"github.com/lncapital/torq/internal/nodes"
 	"github.com/lncapital/torq/internal/vector"
 
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 )
 
@@ -181,9 +181,9 @@ func storeChannelEvent(ctx context.Context,
 		return nil
 	case lnrpc.ChannelEventUpdate_INACTIVE_CHANNEL:
 		// We receive this event in case of a closure. So let's ask LND for a fresh copy of the pending channels.
-		err := importPendingChannels(db, false, nodeSettings)
+		err := importPendingChannels(ctx, db, false, nodeSettings)
 		if err != nil {
-			log.Error().Err(err).Msgf("Failed to import pending channels for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Int(logging.NodeIdField, nodeSettings.NodeId).Msg("Failed to import pending channels")
 		}
 		c := ce.GetInactiveChannel()
 		channelPoint, err := chanPointFromByte(c.GetFundingTxidBytes(), c.GetOutputIndex())
@@ -202,9 +202,9 @@ func storeChannelEvent(ctx context.Context,
 		}
 		return nil
 	case lnrpc.ChannelEventUpdate_FULLY_RESOLVED_CHANNEL:
-		err := importPendingChannels(db, true, nodeSettings)
+		err := importPendingChannels(ctx, db, true, nodeSettings)
 		if err != nil {
-			log.Error().Err(err).Msgf("Failed to import pending channels for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Int(logging.NodeIdField, nodeSettings.NodeId).Msg("Failed to import pending channels")
 		}
 		c := ce.GetFullyResolvedChannel()
 		channelPoint, err := chanPointFromByte(c.GetFundingTxidBytes(), c.GetOutputIndex())
@@ -223,9 +223,9 @@ func storeChannelEvent(ctx context.Context,
 		}
 		return nil
 	case lnrpc.ChannelEventUpdate_PENDING_OPEN_CHANNEL:
-		err := importPendingChannels(db, true, nodeSettings)
+		err := importPendingChannels(ctx, db, true, nodeSettings)
 		if err != nil {
-			log.Error().Err(err).Msgf("Failed to import pending channels for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Int(logging.NodeIdField, nodeSettings.NodeId).Msg("Failed to import pending channels")
 		}
 		c := ce.GetPendingOpenChannel()
 		channelPoint, err := chanPointFromByte(c.GetTxid(), c.GetOutputIndex())
@@ -252,10 +252,10 @@ func storeChannelEvent(ctx context.Context,
 	return nil
 }
 
-func importPendingChannels(db *sqlx.DB, force bool, nodeSettings cache.NodeSettingsCache) error {
-	err := ImportPendingChannels(db, force, nodeSettings.NodeId)
+func importPendingChannels(ctx context.Context, db *sqlx.DB, force bool, nodeSettings cache.NodeSettingsCache) error {
+	err := ImportPendingChannels(ctx, db, force, nodeSettings.NodeId)
 	if err != nil {
-		log.Error().Err(err).Msgf("Failed to obtain pending channels for nodeId: %v", nodeSettings.NodeId)
+		log.Error().Err(err).Int(logging.NodeIdField, nodeSettings.NodeId).Msg("Failed to obtain pending channels")
 		return errors.Wrapf(err, "Obtaining pending channels for nodeId: %v", nodeSettings.NodeId)
 	}
 	return nil
@@ -355,6 +355,7 @@ func SubscribeAndStoreChannelEvents(ctx context.Context,
 	nodeSettings cache.NodeSettingsCache) {
 
 	serviceType := services_helpers.LndServiceChannelEventStream
+	log := logging.WithNodeService(streamLog, serviceType.String(), nodeSettings.NodeId)
 
 	stream, err := client.SubscribeChannelEvents(ctx, &lnrpc.ChannelEventSubscription{})
 	if err != nil {
@@ -363,7 +364,7 @@ func SubscribeAndStoreChannelEvents(ctx context.Context,
 			return
 		}
 		log.Error().Err(err).Msgf(
-			"%v failure to obtain a stream from LND for nodeId: %v", serviceType.String(), nodeSettings.NodeId)
+			"%v failure to obtain a stream from LND", serviceType.String())
 		cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 		return
 	}
@@ -384,8 +385,7 @@ func SubscribeAndStoreChannelEvents(ctx context.Context,
 				cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
 				return
 			}
-			log.Error().Err(err).Msgf(
-				"Receiving channel events from the stream failed for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Receiving channel events from the stream failed")
 			cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 			return
 		}
@@ -393,7 +393,7 @@ func SubscribeAndStoreChannelEvents(ctx context.Context,
 		err = storeChannelEvent(ctx, db, chanEvent, nodeSettings)
 		if err != nil {
 			// TODO FIXME STORE THIS SOMEWHERE??? CHANNELEVENT IS NOW IGNORED???
-			log.Error().Err(err).Msgf("Storing channel event failed for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Storing channel event failed")
 		}
 	}
 }
@@ -815,12 +815,13 @@ func processEmptyChanId(channelPoint string, nodeSettings cache.NodeSettingsCach
 	}
 
 	if cache.GetVectorUrlBase() == vector.VectorUrl && (nodeSettings.Chain != core.Bitcoin || nodeSettings.Network != core.MainNet) {
-		log.Info().Msgf("Skipping obtaining short channel id from vector for nodeId: %v", nodeSettings.NodeId)
+		log.Info().Int(logging.NodeIdField, nodeSettings.NodeId).Msg("Skipping obtaining short channel id from vector")
 		return 0
 	}
 
 	if fundingTransactionHash == nil || *fundingTransactionHash == "" || fundingOutputIndex == nil {
-		log.Info().Msgf("No funding information for short channel id from vector for nodeId: %v", nodeSettings.NodeId)
+		log.Info().Int(logging.NodeIdField, nodeSettings.NodeId).Msg(
+			"No funding information for short channel id from vector")
 		return 0
 	}
 
@@ -854,6 +855,7 @@ func insertChannelEvent(db *sqlx.DB, eventTime time.Time, eventType lnrpc.Channe
 	if !imported {
 		ChannelChanges <- channelEvent
 		ProcessChannelEvent(channelEvent)
+		cache.PublishEvent(channelEvent)
 	}
 
 	return nil
@@ -9,12 +9,12 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc"
 
@@ -39,6 +39,7 @@ func SubscribeAndStoreChannelGraph(ctx context.Context,
 	nodeSettings cache.NodeSettingsCache) {
 
 	serviceType := services_helpers.LndServiceGraphEventStream
+	log := logging.WithNodeService(streamLog, serviceType.String(), nodeSettings.NodeId)
 
 	stream, err := client.SubscribeChannelGraph(ctx, &lnrpc.GraphTopologySubscription{})
 	if err != nil {
@@ -47,7 +48,7 @@ func SubscribeAndStoreChannelGraph(ctx context.Context,
 			return
 		}
 		log.Error().Err(err).Msgf(
-			"%v failure to obtain a stream from LND for nodeId: %v", serviceType.String(), nodeSettings.NodeId)
+			"%v failure to obtain a stream from LND", serviceType.String())
 		cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 		return
 	}
@@ -68,8 +69,7 @@ func SubscribeAndStoreChannelGraph(ctx context.Context,
 				cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
 				return
 			}
-			log.Error().Err(err).Msgf(
-				"Receiving channel events from the stream failed for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Receiving channel events from the stream failed")
 			cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 			return
 		}
@@ -77,13 +77,13 @@ func SubscribeAndStoreChannelGraph(ctx context.Context,
 		err = processNodeUpdates(gpu.NodeUpdates, db, nodeSettings)
 		if err != nil {
 			// TODO FIXME STORE THIS SOMEWHERE??? NODE UPDATES ARE NOW IGNORED???
-			log.Error().Err(err).Msgf("Failed to store node update events for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Failed to store node update events")
 		}
 
 		err = processChannelUpdates(gpu.ChannelUpdates, db, nodeSettings)
 		if err != nil {
 			// TODO FIXME STORE THIS SOMEWHERE??? CHANNEL UPDATES ARE NOW IGNORED???
-			log.Error().Err(err).Msgf("Failed to store channel update events for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Failed to store channel update events")
 		}
 	}
 }
@@ -98,8 +98,8 @@ func ImportNodeInfoFromLnd(ctx context.Context,
 
 	peers, err := client.ListPeers(ctx, &lnrpc.ListPeersRequest{LatestError: true})
 	if err != nil {
-		log.Debug().Err(err).Msgf(
-			"Failed to obtain peers (node info import) for nodeId: %v", nodeSettings.NodeId)
+		log.Debug().Err(err).Int(logging.NodeIdField, nodeSettings.NodeId).Msg(
+			"Failed to obtain peers (node info import)")
 	}
 	if peers != nil {
 		for _, p := range peers.Peers {
@@ -112,8 +112,8 @@ func ImportNodeInfoFromLnd(ctx context.Context,
 						Network:   nodeSettings.Network,
 					}, nil)
 					if err != nil {
-						log.Debug().Err(err).Msgf(
-							"Failed to create connected peer node (node info import) for nodeId: %v", nodeSettings.NodeId)
+						log.Debug().Err(err).Int(logging.NodeIdField, nodeSettings.NodeId).Msg(
+							"Failed to create connected peer node (node info import)")
 						continue
 					}
 				}
@@ -292,9 +292,10 @@ func insertRoutingPolicy(
 			return errors.Wrapf(err, "insertRoutingPolicy")
 		}
 
-		ProcessChannelGraphEvent(
-			constructChannelGraphEvent(
-				eventTime, nodeSettings, announcingNodeId, connectingNodeId, channelId, cu, channelEvent))
+		channelGraphEvent := constructChannelGraphEvent(
+			eventTime, nodeSettings, announcingNodeId, connectingNodeId, channelId, cu, channelEvent)
+		ProcessChannelGraphEvent(channelGraphEvent)
+		cache.PublishEvent(channelGraphEvent)
 	}
 	return nil
 }
@@ -412,5 +413,6 @@ func insertNodeEvent(db *sqlx.DB, eventTime time.Time, eventNodeId int, alias st
 			Features:  nodeEvent.Features,
 		}
 	}
+	cache.PublishEvent(nodeGraphEvent)
 	return nil
 }
@@ -13,13 +13,14 @@ import (
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/lncapital/torq/internal/channels"
 	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc/routerrpc"
 	"github.com/lncapital/torq/proto/lnrpc/walletrpc"
@@ -31,6 +32,7 @@ import (
 	"github.com/lncapital/torq/internal/graph_events"
 	"github.com/lncapital/torq/internal/settings"
 	"github.com/lncapital/torq/pkg/lnd_connect"
+	"github.com/lncapital/torq/pkg/tracing"
 )
 
 const routingPolicyUpdateLimiterSeconds = 5 * 60
@@ -76,7 +78,7 @@ func getConnection(nodeId int) (*grpc.ClientConn, error) {
 
 		conn, err := lnd_connect.Connect(ncd.GRPCAddress, ncd.TLSFileBytes, ncd.MacaroonFileBytes)
 		if err != nil {
-			log.Error().Err(err).Msgf("GRPC connection Failed for node id: %v", nodeId)
+			log.Error().Err(err).Int(logging.NodeIdField, nodeId).Msg("GRPC connection Failed")
 			return nil, errors.Wrapf(err, "Connecting to GRPC.")
 		}
 		connectionWrapper.connections[nodeId] = conn
@@ -86,7 +88,7 @@ func getConnection(nodeId int) (*grpc.ClientConn, error) {
 		if exists && existingConnection != nil {
 			err = existingConnection.Close()
 			if err != nil {
-				log.Error().Err(err).Msgf("GRPC close connection failed for node id: %v", nodeId)
+				log.Error().Err(err).Int(logging.NodeIdField, nodeId).Msg("GRPC close connection failed")
 			}
 		}
 	}
@@ -97,9 +99,11 @@ type lightningService struct {
 	limit chan struct{}
 }
 
-func Information(request lightning_helpers.InformationRequest) lightning_helpers.InformationResponse {
+func Information(ctx context.Context,
+	request lightning_helpers.InformationRequest) lightning_helpers.InformationResponse {
+
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.InformationResponse); ok {
 		return res
@@ -107,9 +111,11 @@ func Information(request lightning_helpers.InformationRequest) lightning_helpers
 	return lightning_helpers.InformationResponse{}
 }
 
-func SignMessage(request lightning_helpers.SignMessageRequest) lightning_helpers.SignMessageResponse {
+func SignMessage(ctx context.Context,
+	request lightning_helpers.SignMessageRequest) lightning_helpers.SignMessageResponse {
+
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.SignMessageResponse); ok {
 		return res
@@ -117,9 +123,11 @@ func SignMessage(request lightning_helpers.SignMessageRequest) lightning_helpers
 	return lightning_helpers.SignMessageResponse{}
 }
 
-func SignatureVerification(request lightning_helpers.SignatureVerificationRequest) lightning_helpers.SignatureVerificationResponse {
+func SignatureVerification(ctx context.Context,
+	request lightning_helpers.SignatureVerificationRequest) lightning_helpers.SignatureVerificationResponse {
+
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.SignatureVerificationResponse); ok {
 		return res
@@ -127,9 +135,11 @@ func SignatureVerification(request lightning_helpers.SignatureVerificationReques
 	return lightning_helpers.SignatureVerificationResponse{}
 }
 
-func RoutingPolicyUpdate(request lightning_helpers.RoutingPolicyUpdateRequest) lightning_helpers.RoutingPolicyUpdateResponse {
+func RoutingPolicyUpdate(ctx context.Context,
+	request lightning_helpers.RoutingPolicyUpdateRequest) lightning_helpers.RoutingPolicyUpdateResponse {
+
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.RoutingPolicyUpdateResponse); ok {
 		return res
@@ -137,9 +147,11 @@ func RoutingPolicyUpdate(request lightning_helpers.RoutingPolicyUpdateRequest) l
 	return lightning_helpers.RoutingPolicyUpdateResponse{}
 }
 
-func ConnectPeer(request lightning_helpers.ConnectPeerRequest) lightning_helpers.ConnectPeerResponse {
+func ConnectPeer(ctx context.Context,
+	request lightning_helpers.ConnectPeerRequest) lightning_helpers.ConnectPeerResponse {
+
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 60, request, responseChan)
+	processConcurrent(ctx, 60, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.ConnectPeerResponse); ok {
 		return res
@@ -147,9 +159,11 @@ func ConnectPeer(request lightning_helpers.ConnectPeerRequest) lightning_helpers
 	return lightning_helpers.ConnectPeerResponse{}
 }
 
-func DisconnectPeer(request lightning_helpers.DisconnectPeerRequest) lightning_helpers.DisconnectPeerResponse {
+func DisconnectPeer(ctx context.Context,
+	request lightning_helpers.DisconnectPeerRequest) lightning_helpers.DisconnectPeerResponse {
+
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), disconnectPeerTimeoutInSeconds, request, responseChan)
+	processConcurrent(ctx, disconnectPeerTimeoutInSeconds, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.DisconnectPeerResponse); ok {
 		return res
@@ -157,9 +171,11 @@ func DisconnectPeer(request lightning_helpers.DisconnectPeerRequest) lightning_h
 	return lightning_helpers.DisconnectPeerResponse{}
 }
 
-func WalletBalance(request lightning_helpers.WalletBalanceRequest) lightning_helpers.WalletBalanceResponse {
+func WalletBalance(ctx context.Context,
+	request lightning_helpers.WalletBalanceRequest) lightning_helpers.WalletBalanceResponse {
+
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.WalletBalanceResponse); ok {
 		return res
@@ -167,9 +183,9 @@ func WalletBalance(request lightning_helpers.WalletBalanceRequest) lightning_hel
 	return lightning_helpers.WalletBalanceResponse{}
 }
 
-func ListPeers(request lightning_helpers.ListPeersRequest) lightning_helpers.ListPeersResponse {
+func ListPeers(ctx context.Context, request lightning_helpers.ListPeersRequest) lightning_helpers.ListPeersResponse {
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 60, request, responseChan)
+	processConcurrent(ctx, 60, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.ListPeersResponse); ok {
 		return res
@@ -177,9 +193,9 @@ func ListPeers(request lightning_helpers.ListPeersRequest) lightning_helpers.Lis
 	return lightning_helpers.ListPeersResponse{}
 }
 
-func NewAddress(request lightning_helpers.NewAddressRequest) lightning_helpers.NewAddressResponse {
+func NewAddress(ctx context.Context, request lightning_helpers.NewAddressRequest) lightning_helpers.NewAddressResponse {
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.NewAddressResponse); ok {
 		return res
@@ -187,9 +203,11 @@ func NewAddress(request lightning_helpers.NewAddressRequest) lightning_helpers.N
 	return lightning_helpers.NewAddressResponse{}
 }
 
-func OpenChannel(request lightning_helpers.OpenChannelRequest) lightning_helpers.OpenChannelResponse {
+func OpenChannel(ctx context.Context,
+	request lightning_helpers.OpenChannelRequest) lightning_helpers.OpenChannelResponse {
+
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 300, request, responseChan)
+	processConcurrent(ctx, 300, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.OpenChannelResponse); ok {
 		return res
@@ -197,9 +215,11 @@ func OpenChannel(request lightning_helpers.OpenChannelRequest) lightning_helpers
 	return lightning_helpers.OpenChannelResponse{}
 }
 
-func BatchOpenChannel(request lightning_helpers.BatchOpenChannelRequest) lightning_helpers.BatchOpenChannelResponse {
+func BatchOpenChannel(ctx context.Context,
+	request lightning_helpers.BatchOpenChannelRequest) lightning_helpers.BatchOpenChannelResponse {
+
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 300, request, responseChan)
+	processConcurrent(ctx, 300, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.BatchOpenChannelResponse); ok {
 		return res
@@ -207,9 +227,11 @@ func BatchOpenChannel(request lightning_helpers.BatchOpenChannelRequest) lightni
 	return lightning_helpers.BatchOpenChannelResponse{}
 }
 
-func CloseChannel(request lightning_helpers.CloseChannelRequest) lightning_helpers.CloseChannelResponse {
+func CloseChannel(ctx context.Context,
+	request lightning_helpers.CloseChannelRequest) lightning_helpers.CloseChannelResponse {
+
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 300, request, responseChan)
+	processConcurrent(ctx, 300, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.CloseChannelResponse); ok {
 		return res
@@ -217,9 +239,9 @@ func CloseChannel(request lightning_helpers.CloseChannelRequest) lightning_helpe
 	return lightning_helpers.CloseChannelResponse{}
 }
 
-func NewInvoice(request lightning_helpers.NewInvoiceRequest) lightning_helpers.NewInvoiceResponse {
+func NewInvoice(ctx context.Context, request lightning_helpers.NewInvoiceRequest) lightning_helpers.NewInvoiceResponse {
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.NewInvoiceResponse); ok {
 		return res
@@ -227,9 +249,11 @@ func NewInvoice(request lightning_helpers.NewInvoiceRequest) lightning_helpers.N
 	return lightning_helpers.NewInvoiceResponse{}
 }
 
-func OnChainPayment(request lightning_helpers.OnChainPaymentRequest) lightning_helpers.OnChainPaymentResponse {
+func OnChainPayment(ctx context.Context,
+	request lightning_helpers.OnChainPaymentRequest) lightning_helpers.OnChainPaymentResponse {
+
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.OnChainPaymentResponse); ok {
 		return res
@@ -244,9 +268,9 @@ func OnChainPayment(request lightning_helpers.OnChainPaymentRequest) lightning_h
 // amt and amt_msat are mutually exclusive
 // payments hash - the hash to use within the payment's HTLC
 // timeout seconds is mandatory
-func NewPayment(request lightning_helpers.NewPaymentRequest) lightning_helpers.NewPaymentResponse {
+func NewPayment(ctx context.Context, request lightning_helpers.NewPaymentRequest) lightning_helpers.NewPaymentResponse {
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 120, request, responseChan)
+	processConcurrent(ctx, 120, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.NewPaymentResponse); ok {
 		return res
@@ -254,9 +278,11 @@ func NewPayment(request lightning_helpers.NewPaymentRequest) lightning_helpers.N
 	return lightning_helpers.NewPaymentResponse{}
 }
 
-func DecodeInvoice(request lightning_helpers.DecodeInvoiceRequest) lightning_helpers.DecodeInvoiceResponse {
+func DecodeInvoice(ctx context.Context,
+	request lightning_helpers.DecodeInvoiceRequest) lightning_helpers.DecodeInvoiceResponse {
+
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.DecodeInvoiceResponse); ok {
 		return res
@@ -264,11 +290,11 @@ func DecodeInvoice(request lightning_helpers.DecodeInvoiceRequest) lightning_hel
 	return lightning_helpers.DecodeInvoiceResponse{}
 }
 
-func ChannelStatusUpdate(
+func ChannelStatusUpdate(ctx context.Context,
 	request lightning_helpers.ChannelStatusUpdateRequest) lightning_helpers.ChannelStatusUpdateResponse {
 
 	responseChan := make(chan any)
-	processSequential(context.Background(), 2, request, responseChan)
+	processSequential(ctx, 2, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(lightning_helpers.ChannelStatusUpdateResponse); ok {
 		return res
@@ -276,9 +302,11 @@ func ChannelStatusUpdate(
 	return lightning_helpers.ChannelStatusUpdateResponse{}
 }
 
-func ImportAllChannelsUnshared(request ImportAllChannelsRequest) ImportAllChannelsResponse {
+func ImportAllChannelsUnshared(ctx context.Context,
+	request ImportAllChannelsRequest) ImportAllChannelsResponse {
+
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 60, request, responseChan)
+	processConcurrent(ctx, 60, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(ImportAllChannelsResponse); ok {
 		return res
@@ -286,9 +314,11 @@ func ImportAllChannelsUnshared(request ImportAllChannelsRequest) ImportAllChanne
 	return ImportAllChannelsResponse{}
 }
 
-func ImportPendingChannelsUnshared(request ImportPendingChannelsRequest) ImportPendingChannelsResponse {
+func ImportPendingChannelsUnshared(ctx context.Context,
+	request ImportPendingChannelsRequest) ImportPendingChannelsResponse {
+
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 60, request, responseChan)
+	processConcurrent(ctx, 60, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(ImportPendingChannelsResponse); ok {
 		return res
@@ -296,9 +326,11 @@ func ImportPendingChannelsUnshared(request ImportPendingChannelsRequest) ImportP
 	return ImportPendingChannelsResponse{}
 }
 
-func ImportChannelRoutingPoliciesUnshared(request ImportChannelRoutingPoliciesRequest) ImportChannelRoutingPoliciesResponse {
+func ImportChannelRoutingPoliciesUnshared(ctx context.Context,
+	request ImportChannelRoutingPoliciesRequest) ImportChannelRoutingPoliciesResponse {
+
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 60, request, responseChan)
+	processConcurrent(ctx, 60, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(ImportChannelRoutingPoliciesResponse); ok {
 		return res
@@ -306,9 +338,11 @@ func ImportChannelRoutingPoliciesUnshared(request ImportChannelRoutingPoliciesRe
 	return ImportChannelRoutingPoliciesResponse{}
 }
 
-func ImportNodeInformationUnshared(request ImportNodeInformationRequest) ImportNodeInformationResponse {
+func ImportNodeInformationUnshared(ctx context.Context,
+	request ImportNodeInformationRequest) ImportNodeInformationResponse {
+
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 60, request, responseChan)
+	processConcurrent(ctx, 60, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(ImportNodeInformationResponse); ok {
 		return res
@@ -316,9 +350,11 @@ func ImportNodeInformationUnshared(request ImportNodeInformationRequest) ImportN
 	return ImportNodeInformationResponse{}
 }
 
-func ImportPeerStatusUnshared(request ImportPeerStatusRequest) ImportPeerStatusResponse {
+func ImportPeerStatusUnshared(ctx context.Context,
+	request ImportPeerStatusRequest) ImportPeerStatusResponse {
+
 	responseChan := make(chan any)
-	processConcurrent(context.Background(), 60, request, responseChan)
+	processConcurrent(ctx, 60, request, responseChan)
 	response := <-responseChan
 	if res, ok := response.(ImportPeerStatusResponse); ok {
 		return res
@@ -332,21 +368,28 @@ var serviceSequential = lightningService{limit: make(chan struct{}, 1)}
 var serviceConcurrent = lightningService{limit: make(chan struct{}, concurrentWorkLimit)} //nolint:gochecknoglobals
 
 func processSequential(ctx context.Context, timeoutInSeconds int, req any, responseChan chan any) {
+	// The span includes waiting for a free slot and ends once the request is processed.
+	// Only the trace of ctx is kept, the request is not cancelled with its caller.
+	ctx, span := tracing.StartNodeRequest(tracing.Detach(ctx), core.LND.String(), req)
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutInSeconds)*time.Second)
 
 	select {
 	case <-ctx.Done():
+		tracing.EndSpan(span, ctx.Err())
 		cancel()
 		return
 	case serviceSequential.limit <- struct{}{}:
 	}
 
-	go processRequestSequential(ctx, cancel, req, responseChan)
+	go processRequestSequential(ctx, cancel, span, req, responseChan)
 }
 
-func processRequestSequential(ctx context.Context, cancel context.CancelFunc, req any, responseChan chan<- any) {
+func processRequestSequential(ctx context.Context, cancel context.CancelFunc, span trace.Span, req any,
+	responseChan chan<- any) {
+
 	defer func() {
+		tracing.EndSpan(span, ctx.Err())
 		cancel()
 		<-serviceSequential.limit
 	}()
@@ -362,21 +405,28 @@ func processRequestSequential(ctx context.Context, cancel context.CancelFunc, re
 }
 
 func processConcurrent(ctx context.Context, timeoutInSeconds int, req any, responseChan chan any) {
+	// The span includes waiting for a free slot and ends once the request is processed.
+	// Only the trace of ctx is kept, the request is not cancelled with its caller.
+	ctx, span := tracing.StartNodeRequest(tracing.Detach(ctx), core.LND.String(), req)
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutInSeconds)*time.Second)
 
 	select {
 	case <-ctx.Done():
+		tracing.EndSpan(span, ctx.Err())
 		cancel()
 		return
 	case serviceConcurrent.limit <- struct{}{}:
 	}
 
-	go processRequestConcurrent(ctx, cancel, req, responseChan)
+	go processRequestConcurrent(ctx, cancel, span, req, responseChan)
 }
 
-func processRequestConcurrent(ctx context.Context, cancel context.CancelFunc, req any, responseChan chan<- any) {
+func processRequestConcurrent(ctx context.Context, cancel context.CancelFunc, span trace.Span, req any,
+	responseChan chan<- any) {
+
 	defer func() {
+		tracing.EndSpan(span, ctx.Err())
 		cancel()
 		<-serviceConcurrent.limit
 	}()
@@ -632,7 +682,7 @@ func processOpenChannelRequest(ctx context.Context,
 
 	//If host provided - check if node is connected to peer and if not, connect peer
 	if request.NodePubKey != "" && request.Host != nil {
-		if err := checkConnectPeer(request.NodeId, request.NodePubKey, *request.Host); err != nil {
+		if err := checkConnectPeer(ctx, request.NodeId, request.NodePubKey, *request.Host); err != nil {
 			response.Error = "could not connect to peer"
 			return response
 		}
@@ -710,8 +760,8 @@ func prepareOpenRequest(request lightning_helpers.OpenChannelRequest) (r *lnrpc.
 	return openChanReq, nil
 }
 
-func checkConnectPeer(nodeId int, remotePublicKey string, host string) error {
-	peerList := ListPeers(lightning_helpers.ListPeersRequest{
+func checkConnectPeer(ctx context.Context, nodeId int, remotePublicKey string, host string) error {
+	peerList := ListPeers(ctx, lightning_helpers.ListPeersRequest{
 		CommunicationRequest: lightning_helpers.CommunicationRequest{NodeId: nodeId},
 		LatestError:          false,
 	})
@@ -733,7 +783,7 @@ func checkConnectPeer(nodeId int, remotePublicKey string, host string) error {
 		Host:                 host,
 	}
 
-	res := ConnectPeer(req)
+	res := ConnectPeer(ctx, req)
 	if res.Error != "" {
 		return errors.Wrap(errors.New(res.Error), "Connect peer")
 	}
@@ -1888,12 +1938,12 @@ func getSuccessTimes(nodeId int,
 	if !force {
 		successTime, exists := cache.GetSuccessTimes(nodeId)[importType]
 		if exists && time.Since(successTime).Seconds() < avoidChannelAndPolicyImportRerunTimeSeconds {
-			log.Info().Msgf("%v were imported very recently for nodeId: %v.", importType.String(), nodeId)
+			log.Info().Int(logging.NodeIdField, nodeId).Msgf("%v were imported very recently", importType.String())
 			return nil, true
 		}
 	}
 	if force {
-		log.Info().Msgf("Forced import of %v for nodeId: %v.", importType.String(), nodeId)
+		log.Info().Int(logging.NodeIdField, nodeId).Msgf("Forced import of %v", importType.String())
 	}
 	return successTimes, false
 }
@@ -1902,7 +1952,7 @@ func setSuccessTime(nodeId int,
 	successTimes map[services_helpers.ImportType]time.Time,
 	importType services_helpers.ImportType) {
 
-	log.Info().Msgf("%v was imported successfully for nodeId: %v.", importType.String(), nodeId)
+	log.Info().Int(logging.NodeIdField, nodeId).Msgf("%v was imported successfully", importType.String())
 	successTimes[importType] = time.Now()
 	cache.SetSuccessTimes(nodeId, successTimes)
 }
@@ -2089,8 +2139,8 @@ func processChannelStatusUpdateRequest(ctx context.Context,
 
 	_, err = routerrpc.NewRouterClient(connection).UpdateChanStatus(ctx, constructUpdateChanStatusRequest(request))
 	if err != nil {
-		log.Error().Err(err).Msgf("Failed to update channel status for channelId: %v on nodeId: %v",
-			request.ChannelId, request.NodeId)
+		log.Error().Err(err).Int(logging.NodeIdField, request.NodeId).Msgf(
+			"Failed to update channel status for channelId: %v", request.ChannelId)
 		return lightning_helpers.ChannelStatusUpdateResponse{
 			CommunicationResponse: lightning_helpers.CommunicationResponse{
 				Status: lightning_helpers.Inactive,
@@ -2214,7 +2264,9 @@ func processRoutingPolicyUpdateRequest(ctx context.Context,
 		return *response
 	}
 
+	_, span := tracing.StartCacheRequest(ctx, "GetChannelState")
 	channelState := cache.GetChannelState(request.NodeId, request.ChannelId, true)
+	span.End()
 	if channelState == nil {
 		return lightning_helpers.RoutingPolicyUpdateResponse{
 			CommunicationResponse: lightning_helpers.CommunicationResponse{
@@ -2254,8 +2306,8 @@ func processRoutingPolicyUpdateResponse(request lightning_helpers.RoutingPolicyU
 	err error) lightning_helpers.RoutingPolicyUpdateResponse {
 
 	if err != nil && resp == nil {
-		log.Error().Err(err).Msgf("Failed to update routing policy for channelId: %v on nodeId: %v",
-			request.ChannelId, request.NodeId)
+		log.Error().Err(err).Int(logging.NodeIdField, request.NodeId).Msgf(
+			"Failed to update routing policy for channelId: %v", request.ChannelId)
 		return lightning_helpers.RoutingPolicyUpdateResponse{
 			CommunicationResponse: lightning_helpers.CommunicationResponse{
 				Status: lightning_helpers.Inactive,
@@ -2265,8 +2317,9 @@ func processRoutingPolicyUpdateResponse(request lightning_helpers.RoutingPolicyU
 	}
 	var failedUpdateArray []lightning_helpers.FailedRequest
 	for _, failedUpdate := range resp.GetFailedUpdates() {
-		log.Error().Msgf("Failed to update routing policy for channelId: %v on nodeId: %v (lnd-grpc error: %v)",
-			request.ChannelId, request.NodeId, failedUpdate.Reason)
+		log.Error().Int(logging.NodeIdField, request.NodeId).Msgf(
+			"Failed to update routing policy for channelId: %v (lnd-grpc error: %v)",
+			request.ChannelId, failedUpdate.Reason)
 		failedRequest := lightning_helpers.FailedRequest{
 			Reason: failedUpdate.UpdateError,
 			Error:  failedUpdate.UpdateError,
@@ -2274,8 +2327,8 @@ func processRoutingPolicyUpdateResponse(request lightning_helpers.RoutingPolicyU
 		failedUpdateArray = append(failedUpdateArray, failedRequest)
 	}
 	if err != nil || len(failedUpdateArray) != 0 {
-		log.Error().Err(err).Msgf("Failed to update routing policy for channelId: %v on nodeId: %v",
-			request.ChannelId, request.NodeId)
+		log.Error().Err(err).Int(logging.NodeIdField, request.NodeId).Msgf(
+			"Failed to update routing policy for channelId: %v", request.ChannelId)
 		return lightning_helpers.RoutingPolicyUpdateResponse{
 			CommunicationResponse: lightning_helpers.CommunicationResponse{
 				Status: lightning_helpers.Inactive,
@@ -1,12 +1,15 @@
 package lnd
 
 import (
+	"context"
+
 	"github.com/jmoiron/sqlx"
 
 	"github.com/lncapital/torq/internal/lightning_helpers"
 )
 
-func ImportAllChannels(db *sqlx.DB,
+func ImportAllChannels(ctx context.Context,
+	db *sqlx.DB,
 	force bool,
 	nodeId int) error {
 
@@ -19,14 +22,15 @@ func ImportAllChannels(db *sqlx.DB,
 			Force: force,
 		},
 	}
-	response := ImportAllChannelsUnshared(request)
+	response := ImportAllChannelsUnshared(ctx, request)
 	if response.Error != nil {
 		return response.Error
 	}
 	return nil
 }
 
-func ImportPendingChannels(db *sqlx.DB,
+func ImportPendingChannels(ctx context.Context,
+	db *sqlx.DB,
 	force bool,
 	nodeId int) error {
 
@@ -39,14 +43,15 @@ func ImportPendingChannels(db *sqlx.DB,
 			Force: force,
 		},
 	}
-	response := ImportPendingChannelsUnshared(request)
+	response := ImportPendingChannelsUnshared(ctx, request)
 	if response.Error != nil {
 		return response.Error
 	}
 	return nil
 }
 
-func ImportChannelRoutingPolicies(db *sqlx.DB,
+func ImportChannelRoutingPolicies(ctx context.Context,
+	db *sqlx.DB,
 	force bool,
 	nodeId int) error {
 
@@ -59,14 +64,15 @@ func ImportChannelRoutingPolicies(db *sqlx.DB,
 			Force: force,
 		},
 	}
-	response := ImportChannelRoutingPoliciesUnshared(request)
+	response := ImportChannelRoutingPoliciesUnshared(ctx, request)
 	if response.Error != nil {
 		return response.Error
 	}
 	return nil
 }
 
-func ImportNodeInformation(db *sqlx.DB,
+func ImportNodeInformation(ctx context.Context,
+	db *sqlx.DB,
 	force bool,
 	nodeId int) error {
 
@@ -79,14 +85,15 @@ func ImportNodeInformation(db *sqlx.DB,
 			Force: force,
 		},
 	}
-	response := ImportNodeInformationUnshared(request)
+	response := ImportNodeInformationUnshared(ctx, request)
 	if response.Error != nil {
 		return response.Error
 	}
 	return nil
 }
 
-func ImportPeerStatus(db *sqlx.DB,
+func ImportPeerStatus(ctx context.Context,
+	db *sqlx.DB,
 	force bool,
 	nodeId int) error {
 
@@ -99,7 +106,7 @@ func ImportPeerStatus(db *sqlx.DB,
 			Force: force,
 		},
 	}
-	response := ImportPeerStatusUnshared(request)
+	response := ImportPeerStatusUnshared(ctx, request)
 	if response.Error != nil {
 		return response.Error
 	}
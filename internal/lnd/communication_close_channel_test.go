@@ -4,8 +4,6 @@ import (
 	"reflect"
 	"testing"
 
-	"github.com/rs/zerolog/log"
-
 	"github.com/lncapital/torq/internal/channels"
 	"github.com/lncapital/torq/internal/lightning_helpers"
 	"github.com/lncapital/torq/proto/lnrpc"
@@ -7,10 +7,11 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"go.uber.org/ratelimit"
 	"google.golang.org/grpc"
 
+	"github.com/lncapital/torq/internal/logging"
+	"github.com/lncapital/torq/internal/metrics"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc"
 
@@ -77,6 +78,8 @@ func storeForwardingHistory(db *sqlx.DB, fwh []*lnrpc.ForwardingEvent, nodeId in
 		if !bootStrapping {
 			for _, forwardEvent := range forwardEvents {
 				ProcessForwardEvent(forwardEvent)
+				metrics.AddForward(forwardEvent)
+				cache.PublishEvent(forwardEvent)
 			}
 		}
 	}
@@ -125,6 +128,7 @@ func SubscribeForwardingEvents(ctx context.Context,
 	opt *FwhOptions) {
 
 	serviceType := services_helpers.LndServiceForwardsService
+	log := logging.WithNodeService(streamLog, serviceType.String(), nodeSettings.NodeId)
 
 	maxEvents := streamLndMaxForwards
 	bootStrapping := true
@@ -148,7 +152,7 @@ func SubscribeForwardingEvents(ctx context.Context,
 	var enforcedReferenceDate *time.Time
 	importHistoricForwards := cache.HasCustomSetting(nodeSettings.NodeId, core.ImportHistoricForwards)
 	if !importHistoricForwards {
-		log.Info().Msgf("Import of historic forwards is disabled for nodeId: %v", nodeSettings.NodeId)
+		log.Info().Msg("Import of historic forwards is disabled")
 		cache.SetActiveNodeServiceState(serviceType, nodeSettings.NodeId)
 		enforcedReferenceDateO := time.Now()
 		enforcedReferenceDate = &enforcedReferenceDateO
@@ -172,7 +176,7 @@ func SubscribeForwardingEvents(ctx context.Context,
 				// Fetch the nanosecond timestamp of the most recent record we have.
 				lastNs, err := fetchLastForwardTime(db, nodeSettings.NodeId)
 				if err != nil {
-					log.Error().Err(err).Msgf("Failed to obtain last know forward for nodeId: %v", nodeSettings.NodeId)
+					log.Error().Err(err).Msg("Failed to obtain last know forward")
 					cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 					return
 				}
@@ -198,7 +202,7 @@ func SubscribeForwardingEvents(ctx context.Context,
 						cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
 						return
 					}
-					log.Error().Err(err).Msgf("Failed to obtain forwards for nodeId: %v", nodeSettings.NodeId)
+					log.Error().Err(err).Msg("Failed to obtain forwards")
 					cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 					return
 				}
@@ -209,7 +213,7 @@ func SubscribeForwardingEvents(ctx context.Context,
 				// Store the forwarding history
 				err = storeForwardingHistory(db, fwh.ForwardingEvents, nodeSettings.NodeId, bootStrapping)
 				if err != nil {
-					log.Error().Err(err).Msgf("Failed to store forward event for nodeId: %v", nodeSettings.NodeId)
+					log.Error().Err(err).Msg("Failed to store forward event")
 				}
 
 				// Stop fetching if there are fewer forwards than max requested
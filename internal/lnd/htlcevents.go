@@ -8,13 +8,12 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
 
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc/routerrpc"
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
-
-	"github.com/rs/zerolog/log"
 )
 
 type HtlcEvent struct {
@@ -95,6 +94,28 @@ func storeFullEvent(db *sqlx.DB, h *routerrpc.HtlcEvent, nodeId int, eventType s
 	if err != nil {
 		return HtlcEvent{}, errors.Wrapf(err, "Storing HTLC Event (%v)", eventType)
 	}
+	cache.PublishEvent(core.HtlcEvent{
+		EventData: core.EventData{
+			EventTime: time.Now().UTC(),
+			NodeId:    nodeId,
+		},
+		Timestamp:         htlcEvent.Time,
+		Data:              htlcEvent.Data,
+		EventOrigin:       htlcEvent.EventOrigin,
+		EventType:         htlcEvent.EventType,
+		OutgoingHtlcId:    htlcEvent.OutgoingHtlcId,
+		IncomingHtlcId:    htlcEvent.IncomingHtlcId,
+		TimestampNs:       htlcEvent.TimestampNs,
+		IncomingAmtMsat:   htlcEvent.IncomingAmtMsat,
+		OutgoingAmtMsat:   htlcEvent.OutgoingAmtMsat,
+		IncomingTimelock:  htlcEvent.IncomingTimelock,
+		OutgoingTimelock:  htlcEvent.OutgoingTimelock,
+		BoltFailureCode:   htlcEvent.BoltFailureCode,
+		BoltFailureString: htlcEvent.BoltFailureString,
+		LndFailureDetail:  htlcEvent.LndFailureDetail,
+		OutgoingChannelId: htlcEvent.OutgoingChannelId,
+		IncomingChannelId: htlcEvent.IncomingChannelId,
+	})
 	return htlcEvent, nil
 }
 
@@ -159,6 +180,7 @@ func SubscribeAndStoreHtlcEvents(ctx context.Context,
 	nodeSettings cache.NodeSettingsCache) {
 
 	serviceType := services_helpers.LndServiceHtlcEventStream
+	log := logging.WithNodeService(streamLog, serviceType.String(), nodeSettings.NodeId)
 
 	stream, err := router.SubscribeHtlcEvents(ctx, &routerrpc.SubscribeHtlcEventsRequest{})
 	if err != nil {
@@ -167,7 +189,7 @@ func SubscribeAndStoreHtlcEvents(ctx context.Context,
 			return
 		}
 		log.Error().Err(err).Msgf(
-			"%v failure to obtain a stream from LND for nodeId: %v", serviceType.String(), nodeSettings.NodeId)
+			"%v failure to obtain a stream from LND", serviceType.String())
 		cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 		return
 	}
@@ -188,8 +210,7 @@ func SubscribeAndStoreHtlcEvents(ctx context.Context,
 				cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
 				return
 			}
-			log.Error().Err(err).Msgf(
-				"Receiving channel events from the stream failed for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Receiving channel events from the stream failed")
 			cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 			return
 		}
@@ -199,33 +220,25 @@ func SubscribeAndStoreHtlcEvents(ctx context.Context,
 			_, err = storeForwardEvent(db, htlcEvent, nodeSettings.NodeId)
 			if err != nil {
 				// TODO FIXME STORE THIS SOMEWHERE??? TRANSACTION IS NOW IGNORED???
-				log.Error().Err(err).Msgf(
-					"Failed to store forward event of type HtlcEvent_ForwardEvent for nodeId: %v",
-					nodeSettings.NodeId)
+				log.Error().Err(err).Msg("Failed to store forward event of type HtlcEvent_ForwardEvent")
 			}
 		case *routerrpc.HtlcEvent_ForwardFailEvent:
 			_, err = storeForwardFailEvent(db, htlcEvent, nodeSettings.NodeId)
 			if err != nil {
 				// TODO FIXME STORE THIS SOMEWHERE??? TRANSACTION IS NOW IGNORED???
-				log.Error().Err(err).Msgf(
-					"Failed to store forward event of type HtlcEvent_ForwardFailEvent for nodeId: %v",
-					nodeSettings.NodeId)
+				log.Error().Err(err).Msg("Failed to store forward event of type HtlcEvent_ForwardFailEvent")
 			}
 		case *routerrpc.HtlcEvent_LinkFailEvent:
 			_, err = storeLinkFailEvent(db, htlcEvent, nodeSettings.NodeId)
 			if err != nil {
 				// TODO FIXME STORE THIS SOMEWHERE??? TRANSACTION IS NOW IGNORED???
-				log.Error().Err(err).Msgf(
-					"Failed to store forward event of type HtlcEvent_LinkFailEvent for nodeId: %v",
-					nodeSettings.NodeId)
+				log.Error().Err(err).Msg("Failed to store forward event of type HtlcEvent_LinkFailEvent")
 			}
 		case *routerrpc.HtlcEvent_SettleEvent:
 			_, err = storeSettleEvent(db, htlcEvent, nodeSettings.NodeId)
 			if err != nil {
 				// TODO FIXME STORE THIS SOMEWHERE??? TRANSACTION IS NOW IGNORED???
-				log.Error().Err(err).Msgf(
-					"Failed to store forward event of type HtlcEvent_SettleEvent for nodeId: %v",
-					nodeSettings.NodeId)
+				log.Error().Err(err).Msg("Failed to store forward event of type HtlcEvent_SettleEvent")
 			}
 		}
 	}
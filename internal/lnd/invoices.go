@@ -11,9 +11,9 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc/zpay32"
 
@@ -207,6 +207,7 @@ func SubscribeAndStoreInvoices(ctx context.Context, client invoicesClient, db *s
 	nodeSettings cache.NodeSettingsCache) {
 
 	serviceType := services_helpers.LndServiceInvoiceStream
+	log := logging.WithNodeService(streamLog, serviceType.String(), nodeSettings.NodeId)
 
 	bootStrapping := true
 	importCounter := 0
@@ -222,7 +223,7 @@ func SubscribeAndStoreInvoices(ctx context.Context, client invoicesClient, db *s
 		// Get the latest settle and add index to prevent duplicate entries.
 		addIndex, _, err := fetchLastInvoiceIndexes(db, nodeSettings.NodeId)
 		if err != nil {
-			log.Error().Err(err).Msgf("Failed to obtain last know invoice for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Failed to obtain last know invoice")
 			cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 			return
 		}
@@ -236,7 +237,7 @@ func SubscribeAndStoreInvoices(ctx context.Context, client invoicesClient, db *s
 				cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
 				return
 			}
-			log.Error().Err(err).Msgf("Failed to obtain list invoice for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Failed to obtain list invoice")
 			cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 			return
 		}
@@ -270,7 +271,7 @@ func SubscribeAndStoreInvoices(ctx context.Context, client invoicesClient, db *s
 		// Get the latest settle and add index to prevent duplicate entries.
 		addIndex, settleIndex, err := fetchLastInvoiceIndexes(db, nodeSettings.NodeId)
 		if err != nil {
-			log.Error().Err(err).Msgf("Failed to obtain last invoice index for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Failed to obtain last invoice index")
 			cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 			return
 		}
@@ -286,7 +287,7 @@ func SubscribeAndStoreInvoices(ctx context.Context, client invoicesClient, db *s
 				cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
 				return
 			}
-			log.Error().Err(err).Msgf("Failed to obtain Invoices stream for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Failed to obtain Invoices stream")
 			cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 			return
 		}
@@ -298,8 +299,7 @@ func SubscribeAndStoreInvoices(ctx context.Context, client invoicesClient, db *s
 				cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
 				return
 			}
-			log.Error().Err(err).Msgf(
-				"Failed to obtain receive Invoices from the stream for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Failed to obtain receive Invoices from the stream")
 			cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 			return
 		}
@@ -349,6 +349,7 @@ func processInvoice(lndInvoice *lnrpc.Invoice, nodeSettings cache.NodeSettingsCa
 	defer func() {
 		if !bootStrapping {
 			ProcessInvoiceEvent(invoiceEvent)
+			cache.PublishEvent(invoiceEvent)
 		}
 	}()
 
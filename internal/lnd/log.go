@@ -0,0 +1,11 @@
+package lnd
+
+import "github.com/lncapital/torq/internal/logging"
+
+// The stream services (i.e. SubscribeAndStoreChannelEvents) log with the streams subsystem
+//
+//nolint:gochecknoglobals
+var (
+	log       = logging.GetLogger(logging.Lnd)
+	streamLog = logging.GetLogger(logging.Streams)
+)
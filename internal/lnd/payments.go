@@ -8,9 +8,9 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc/zpay32"
 
@@ -40,6 +40,7 @@ func SubscribeAndStorePayments(ctx context.Context, client lightningClient_ListP
 	opt *PayOptions) {
 
 	serviceType := services_helpers.LndServicePaymentsService
+	log := logging.WithNodeService(streamLog, serviceType.String(), nodeSettings.NodeId)
 
 	bootStrapping := true
 	includeIncomplete := cache.HasCustomSetting(nodeSettings.NodeId, core.ImportFailedPayments)
@@ -67,7 +68,7 @@ func SubscribeAndStorePayments(ctx context.Context, client lightningClient_ListP
 			lastPaymentIndex, err := fetchLastPaymentIndex(db, nodeSettings.NodeId)
 			if err != nil {
 				cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
-				log.Error().Err(err).Msgf("Failed to obtain last know payment for nodeId: %v", nodeSettings.NodeId)
+				log.Error().Err(err).Msg("Failed to obtain last know payment")
 				return
 			}
 
@@ -82,7 +83,7 @@ func SubscribeAndStorePayments(ctx context.Context, client lightningClient_ListP
 						return
 					}
 					cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
-					log.Error().Err(err).Msgf("Failed to obtain payments for nodeId: %v", nodeSettings.NodeId)
+					log.Error().Err(err).Msg("Failed to obtain payments")
 					return
 				}
 
@@ -247,6 +248,7 @@ func storePayments(db *sqlx.DB, p []*lnrpc.Payment, nodeSettings cache.NodeSetti
 	if !bootStrapping {
 		for _, paymentEvent := range paymentEvents {
 			ProcessPaymentEvent(paymentEvent)
+			cache.PublishEvent(paymentEvent)
 		}
 	}
 
@@ -260,6 +262,7 @@ func UpdateInFlightPayments(ctx context.Context,
 	opt *PayOptions) {
 
 	serviceType := services_helpers.LndServiceInFlightPaymentsService
+	log := logging.WithNodeService(streamLog, serviceType.String(), nodeSettings.NodeId)
 
 	bootStrapping := true
 
@@ -283,7 +286,7 @@ func UpdateInFlightPayments(ctx context.Context,
 		case <-tickerChannel:
 			inFlightIndexes, err := fetchInFlightPaymentIndexes(db, nodeSettings.NodeId)
 			if err != nil {
-				log.Error().Err(err).Msgf("Failed to obtain in-flight payment indexes for nodeId: %v", nodeSettings.NodeId)
+				log.Error().Err(err).Msg("Failed to obtain in-flight payment indexes")
 				cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 				return
 			}
@@ -299,14 +302,13 @@ func UpdateInFlightPayments(ctx context.Context,
 						cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
 						return
 					}
-					log.Error().Err(err).Msgf("Error with subscribe and update payments for nodeId: %v", nodeSettings.NodeId)
+					log.Error().Err(err).Msg("Error with subscribe and update payments")
 					continue
 				}
 				if len(listPaymentsResponse.Payments) == 0 {
 					log.Info().Msgf("We had an inflight payment but nothing from LND: %v", i)
 					if err = setPaymentToFailedDetailsUnavailable(db, i); err != nil {
-						log.Error().Err(err).Msgf(
-							"Error with Setting payment to failed details unavailable for nodeId: %v", nodeSettings.NodeId)
+						log.Error().Err(err).Msg("Error with Setting payment to failed details unavailable")
 					}
 					continue
 				}
@@ -314,8 +316,7 @@ func UpdateInFlightPayments(ctx context.Context,
 				if listPaymentsResponse.Payments[0].PaymentIndex != i {
 					log.Warn().Msgf("Payment data missing from LND for payment index: %v", i)
 					if err = setPaymentToFailedDetailsUnavailable(db, i); err != nil {
-						log.Error().Err(err).Msgf(
-							"Error with Setting payment to failed details unavailable for nodeId: %v", nodeSettings.NodeId)
+						log.Error().Err(err).Msg("Error with Setting payment to failed details unavailable")
 					}
 					continue
 				}
@@ -323,8 +324,7 @@ func UpdateInFlightPayments(ctx context.Context,
 				// Store the payments
 				err = updatePayments(db, listPaymentsResponse.Payments, nodeSettings.NodeId)
 				if err != nil {
-					log.Error().Err(err).Msgf(
-						"Failed to store update payments for nodeId: %v", nodeSettings.NodeId)
+					log.Error().Err(err).Msg("Failed to store update payments")
 				}
 			}
 			if bootStrapping {
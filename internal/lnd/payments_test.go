@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/mixer/clock"
-	"github.com/rs/zerolog/log"
 
 	"github.com/lncapital/torq/proto/lnrpc"
 
@@ -6,9 +6,9 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc"
 
@@ -34,6 +34,7 @@ func SubscribePeerEvents(ctx context.Context,
 	nodeSettings cache.NodeSettingsCache) {
 
 	serviceType := services_helpers.LndServicePeerEventStream
+	log := logging.WithNodeService(streamLog, serviceType.String(), nodeSettings.NodeId)
 
 	stream, err := client.SubscribePeerEvents(ctx, &lnrpc.PeerEventSubscription{})
 	if err != nil {
@@ -42,7 +43,7 @@ func SubscribePeerEvents(ctx context.Context,
 			return
 		}
 		log.Error().Err(err).Msgf(
-			"%v failure to obtain a stream from LND for nodeId: %v", serviceType.String(), nodeSettings.NodeId)
+			"%v failure to obtain a stream from LND", serviceType.String())
 		cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 		return
 	}
@@ -63,8 +64,7 @@ func SubscribePeerEvents(ctx context.Context,
 				cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
 				return
 			}
-			log.Error().Err(err).Msgf(
-				"Receiving channel events from the stream failed for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Receiving channel events from the stream failed")
 			cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 			return
 		}
@@ -74,18 +74,19 @@ func SubscribePeerEvents(ctx context.Context,
 			err = setNodeConnectionHistory(db, peerEvent.Type, eventNodeId, nodeSettings.NodeId)
 			if err != nil {
 				log.Error().Err(err).Msgf(
-					"Adding node connection history entry failed for nodeId: %v (eventNodeId: %v)",
-					nodeSettings.NodeId, eventNodeId)
+					"Adding node connection history entry failed (eventNodeId: %v)", eventNodeId)
 			}
 
-			ProcessPeerEvent(core.PeerEvent{
+			torqPeerEvent := core.PeerEvent{
 				EventData: core.EventData{
 					EventTime: time.Now().UTC(),
 					NodeId:    nodeSettings.NodeId,
 				},
 				Type:        peerEvent.Type,
 				EventNodeId: eventNodeId,
-			})
+			}
+			ProcessPeerEvent(torqPeerEvent)
+			cache.PublishEvent(torqPeerEvent)
 		}
 	}
 }
@@ -0,0 +1,336 @@
+package lnd
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/logging"
+	"github.com/lncapital/torq/internal/services_helpers"
+	"github.com/lncapital/torq/pkg/lnd_connect"
+	"github.com/lncapital/torq/proto/lnrpc"
+	"github.com/lncapital/torq/proto/lnrpc/chainrpc"
+	"github.com/lncapital/torq/proto/lnrpc/routerrpc"
+	"github.com/lncapital/torq/proto/lnrpc/walletrpc"
+)
+
+const permissionsCheckTimeoutSeconds = 60
+
+// PermissionsReport shows which services and actions the macaroon of a node does not allow
+// and which permissions it grants that are not needed.
+type PermissionsReport struct {
+	NodeId int `json:"nodeId"`
+	// False when LND could not check the permissions (the macaroon requires macaroon:read)
+	Checked        bool       `json:"checked"`
+	CheckedOn      *time.Time `json:"checkedOn"`
+	DeniedServices []string   `json:"deniedServices"`
+	DeniedActions  []string   `json:"deniedActions"`
+	// The services that are inactive because of a missing permission, they are activated when the macaroon changes
+	InactiveServices []string `json:"inactiveServices"`
+	// The permissions (entity:action) the macaroon grants that the enabled services and the actions don't need
+	ExcessPermissions []string `json:"excessPermissions"`
+	// Bakes a macaroon that only allows the RPC methods the enabled services and the actions need
+	BakeMacaroonCommand string `json:"bakeMacaroonCommand"`
+}
+
+// The LND RPC methods each service calls
+func getServiceMethods() map[services_helpers.ServiceType][]string {
+	return map[services_helpers.ServiceType][]string{
+		services_helpers.LndServiceVectorService: {
+			lnrpc.Lightning_GetInfo_FullMethodName,
+			lnrpc.Lightning_SignMessage_FullMethodName,
+		},
+		services_helpers.LndServiceAmbossService: {
+			lnrpc.Lightning_SignMessage_FullMethodName,
+		},
+		services_helpers.LndServiceRebalanceService: {
+			lnrpc.Lightning_AddInvoice_FullMethodName,
+			lnrpc.Lightning_QueryRoutes_FullMethodName,
+			routerrpc.Router_SendToRouteV2_FullMethodName,
+		},
+		services_helpers.LndServiceChannelEventStream: {
+			lnrpc.Lightning_ListChannels_FullMethodName,
+			lnrpc.Lightning_PendingChannels_FullMethodName,
+			lnrpc.Lightning_ClosedChannels_FullMethodName,
+			lnrpc.Lightning_GetChanInfo_FullMethodName,
+			lnrpc.Lightning_GetNodeInfo_FullMethodName,
+			lnrpc.Lightning_SubscribeChannelEvents_FullMethodName,
+		},
+		services_helpers.LndServiceGraphEventStream: {
+			lnrpc.Lightning_ListChannels_FullMethodName,
+			lnrpc.Lightning_PendingChannels_FullMethodName,
+			lnrpc.Lightning_ClosedChannels_FullMethodName,
+			lnrpc.Lightning_GetChanInfo_FullMethodName,
+			lnrpc.Lightning_GetNodeInfo_FullMethodName,
+			lnrpc.Lightning_ListPeers_FullMethodName,
+			lnrpc.Lightning_SubscribeChannelGraph_FullMethodName,
+		},
+		services_helpers.LndServiceTransactionStream: {
+			lnrpc.Lightning_GetTransactions_FullMethodName,
+			chainrpc.ChainNotifier_RegisterBlockEpochNtfn_FullMethodName,
+		},
+		services_helpers.LndServiceHtlcEventStream: {
+			routerrpc.Router_SubscribeHtlcEvents_FullMethodName,
+		},
+		services_helpers.LndServiceForwardsService: {
+			lnrpc.Lightning_ForwardingHistory_FullMethodName,
+		},
+		services_helpers.LndServiceInvoiceStream: {
+			lnrpc.Lightning_ListInvoices_FullMethodName,
+			lnrpc.Lightning_SubscribeInvoices_FullMethodName,
+		},
+		services_helpers.LndServicePaymentsService: {
+			lnrpc.Lightning_ListPayments_FullMethodName,
+		},
+		services_helpers.LndServicePeerEventStream: {
+			lnrpc.Lightning_ListPeers_FullMethodName,
+			lnrpc.Lightning_SubscribePeerEvents_FullMethodName,
+		},
+		services_helpers.LndServiceInFlightPaymentsService: {
+			lnrpc.Lightning_ListPayments_FullMethodName,
+		},
+		services_helpers.LndServiceChannelBalanceCacheService: {
+			lnrpc.Lightning_ListChannels_FullMethodName,
+		},
+	}
+}
+
+// The LND RPC methods each action (request) calls
+func getActionMethods() map[string][]string {
+	return map[string][]string{
+		"getInformation":      {lnrpc.Lightning_GetInfo_FullMethodName},
+		"signMessage":         {lnrpc.Lightning_SignMessage_FullMethodName},
+		"verifyMessage":       {lnrpc.Lightning_VerifyMessage_FullMethodName},
+		"updateRoutingPolicy": {lnrpc.Lightning_UpdateChannelPolicy_FullMethodName},
+		"updateChannelStatus": {routerrpc.Router_UpdateChanStatus_FullMethodName},
+		"connectPeer": {
+			lnrpc.Lightning_ConnectPeer_FullMethodName,
+			lnrpc.Lightning_ListPeers_FullMethodName,
+		},
+		"disconnectPeer": {
+			lnrpc.Lightning_DisconnectPeer_FullMethodName,
+			lnrpc.Lightning_ListPeers_FullMethodName,
+		},
+		"listPeers":     {lnrpc.Lightning_ListPeers_FullMethodName},
+		"walletBalance": {lnrpc.Lightning_WalletBalance_FullMethodName},
+		"newAddress":    {walletrpc.WalletKit_NextAddr_FullMethodName},
+		"openChannel": {
+			lnrpc.Lightning_OpenChannel_FullMethodName,
+			lnrpc.Lightning_ConnectPeer_FullMethodName,
+			lnrpc.Lightning_ListPeers_FullMethodName,
+		},
+		"batchOpenChannel": {lnrpc.Lightning_BatchOpenChannel_FullMethodName},
+		"closeChannel":     {lnrpc.Lightning_CloseChannel_FullMethodName},
+		"newInvoice":       {lnrpc.Lightning_AddInvoice_FullMethodName},
+		"onChainPayment":   {lnrpc.Lightning_SendCoins_FullMethodName},
+		"newPayment":       {routerrpc.Router_SendPaymentV2_FullMethodName},
+		"decodeInvoice": {
+			lnrpc.Lightning_DecodePayReq_FullMethodName,
+			lnrpc.Lightning_GetNodeInfo_FullMethodName,
+		},
+	}
+}
+
+// The LND RPC methods that check the permissions
+func getPermissionsMethods() []string {
+	return []string{
+		lnrpc.Lightning_ListPermissions_FullMethodName,
+		lnrpc.Lightning_CheckMacaroonPermissions_FullMethodName,
+	}
+}
+
+func getRequestAction(request any) string {
+	switch request.(type) {
+	case lightning_helpers.InformationRequest:
+		return "getInformation"
+	case lightning_helpers.SignMessageRequest:
+		return "signMessage"
+	case lightning_helpers.SignatureVerificationRequest:
+		return "verifyMessage"
+	case lightning_helpers.RoutingPolicyUpdateRequest:
+		return "updateRoutingPolicy"
+	case lightning_helpers.ChannelStatusUpdateRequest:
+		return "updateChannelStatus"
+	case lightning_helpers.ConnectPeerRequest:
+		return "connectPeer"
+	case lightning_helpers.DisconnectPeerRequest:
+		return "disconnectPeer"
+	case lightning_helpers.ListPeersRequest:
+		return "listPeers"
+	case lightning_helpers.WalletBalanceRequest:
+		return "walletBalance"
+	case lightning_helpers.NewAddressRequest:
+		return "newAddress"
+	case lightning_helpers.OpenChannelRequest:
+		return "openChannel"
+	case lightning_helpers.BatchOpenChannelRequest:
+		return "batchOpenChannel"
+	case lightning_helpers.CloseChannelRequest:
+		return "closeChannel"
+	case lightning_helpers.NewInvoiceRequest:
+		return "newInvoice"
+	case lightning_helpers.OnChainPaymentRequest:
+		return "onChainPayment"
+	case lightning_helpers.NewPaymentRequest:
+		return "newPayment"
+	case lightning_helpers.DecodeInvoiceRequest:
+		return "decodeInvoice"
+	}
+	return ""
+}
+
+// getTorqMethods returns all LND RPC methods used by Torq
+func getTorqMethods() []string {
+	var methods []string
+	for _, serviceMethods := range getServiceMethods() {
+		methods = appendMissing(methods, serviceMethods)
+	}
+	for _, actionMethods := range getActionMethods() {
+		methods = appendMissing(methods, actionMethods)
+	}
+	return appendMissing(methods, getPermissionsMethods())
+}
+
+func appendMissing(values []string, newValues []string) []string {
+	for _, value := range newValues {
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// CheckPermissions checks the permissions of the macaroon of the node unless they were checked for this macaroon
+func CheckPermissions(conn *grpc.ClientConn, nodeId int) cache.NodePermissions {
+	macaroonBytes := cache.GetNodeConnectionDetails(nodeId).MacaroonFileBytes
+	existingPermissions := cache.GetNodePermissions(nodeId)
+	if existingPermissions != nil && bytes.Equal(existingPermissions.MacaroonFileBytes, macaroonBytes) {
+		return *existingPermissions
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), permissionsCheckTimeoutSeconds*time.Second)
+	defer cancel()
+	nodePermissions := cache.NodePermissions{MacaroonFileBytes: macaroonBytes, CheckedOn: time.Now()}
+	macaroonPermissions, err := lnd_connect.CheckMacaroonPermissions(ctx, conn, macaroonBytes, getTorqMethods())
+	if err != nil {
+		if !errors.Is(err, lnd_connect.ErrPermissionsUnavailable) {
+			// Checked again when the next service boots
+			log.Error().Err(err).Int(logging.NodeIdField, nodeId).Msg("Failed to check the macaroon permissions")
+			return nodePermissions
+		}
+		log.Warn().Err(err).Int(logging.NodeIdField, nodeId).Msg("The macaroon permissions are not checked, " +
+			"grant macaroon:read to disable the services and actions the macaroon does not allow")
+		cache.SetNodePermissions(nodeId, nodePermissions)
+		return nodePermissions
+	}
+	nodePermissions.Checked = true
+	nodePermissions.DeniedMethods = macaroonPermissions.DeniedMethods
+	nodePermissions.GrantedPermissions = macaroonPermissions.GrantedPermissions
+	nodePermissions.MethodPermissions = macaroonPermissions.MethodPermissions
+	cache.SetNodePermissions(nodeId, nodePermissions)
+
+	report := getPermissionsReport(nodeId, nodePermissions)
+	if len(report.DeniedServices) != 0 || len(report.DeniedActions) != 0 {
+		log.Warn().Int(logging.NodeIdField, nodeId).Msgf("The macaroon does not allow the services %v and actions %v, "+
+			"they are disabled", report.DeniedServices, report.DeniedActions)
+	}
+	if len(report.ExcessPermissions) != 0 {
+		log.Warn().Int(logging.NodeIdField, nodeId).Msgf("The macaroon grants %v which Torq does not need, "+
+			"bake a macaroon with only the required permissions: %v",
+			report.ExcessPermissions, report.BakeMacaroonCommand)
+	}
+	return nodePermissions
+}
+
+// IsServiceAllowed checks the macaroon of the node allows the RPC methods the service calls
+func IsServiceAllowed(conn *grpc.ClientConn, serviceType services_helpers.ServiceType, nodeId int) bool {
+	return isAllowed(CheckPermissions(conn, nodeId), getServiceMethods()[serviceType])
+}
+
+// IsRequestAllowed checks the macaroon of the node allows the RPC methods the request calls,
+// requests are allowed when the permissions of the current macaroon are not checked yet.
+func IsRequestAllowed(nodeId int, request any) bool {
+	nodePermissions := cache.GetNodePermissions(nodeId)
+	if nodePermissions == nil ||
+		!bytes.Equal(nodePermissions.MacaroonFileBytes, cache.GetNodeConnectionDetails(nodeId).MacaroonFileBytes) {
+		return true
+	}
+	return isAllowed(*nodePermissions, getActionMethods()[getRequestAction(request)])
+}
+
+func isAllowed(nodePermissions cache.NodePermissions, methods []string) bool {
+	for _, method := range methods {
+		if slices.Contains(nodePermissions.DeniedMethods, method) {
+			return false
+		}
+	}
+	return true
+}
+
+func GetPermissionsReport(nodeId int) PermissionsReport {
+	nodePermissions := cache.GetNodePermissions(nodeId)
+	if nodePermissions == nil {
+		return getPermissionsReport(nodeId, cache.NodePermissions{})
+	}
+	return getPermissionsReport(nodeId, *nodePermissions)
+}
+
+func getPermissionsReport(nodeId int, nodePermissions cache.NodePermissions) PermissionsReport {
+	report := PermissionsReport{
+		NodeId:            nodeId,
+		Checked:           nodePermissions.Checked,
+		DeniedServices:    []string{},
+		DeniedActions:     []string{},
+		InactiveServices:  []string{},
+		ExcessPermissions: []string{},
+	}
+	if !nodePermissions.CheckedOn.IsZero() {
+		report.CheckedOn = &nodePermissions.CheckedOn
+	}
+
+	requiredMethods := getPermissionsMethods()
+	for serviceType, methods := range getServiceMethods() {
+		if !isAllowed(nodePermissions, methods) {
+			report.DeniedServices = append(report.DeniedServices, serviceType.String())
+			continue
+		}
+		if cache.GetDesiredNodeServiceState(serviceType, nodeId).Status == services_helpers.Active {
+			requiredMethods = appendMissing(requiredMethods, methods)
+		}
+	}
+	for action, methods := range getActionMethods() {
+		if !isAllowed(nodePermissions, methods) {
+			report.DeniedActions = append(report.DeniedActions, action)
+		}
+		requiredMethods = appendMissing(requiredMethods, methods)
+	}
+	for _, serviceType := range cache.GetDeniedServices(nodeId) {
+		report.InactiveServices = append(report.InactiveServices, serviceType.String())
+	}
+	sort.Strings(report.DeniedServices)
+	sort.Strings(report.DeniedActions)
+	sort.Strings(report.InactiveServices)
+
+	var requiredPermissions []string
+	for _, method := range requiredMethods {
+		requiredPermissions = appendMissing(requiredPermissions, nodePermissions.MethodPermissions[method])
+	}
+	for _, permission := range nodePermissions.GrantedPermissions {
+		if !slices.Contains(requiredPermissions, permission) {
+			report.ExcessPermissions = append(report.ExcessPermissions, permission)
+		}
+	}
+
+	sort.Strings(requiredMethods)
+	report.BakeMacaroonCommand = "lncli bakemacaroon uri:" + strings.Join(requiredMethods, " uri:") +
+		" --save_to=torq.macaroon"
+	return report
+}
@@ -0,0 +1,187 @@
+package lnd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/services_helpers"
+	"github.com/lncapital/torq/proto/lnrpc"
+	"github.com/lncapital/torq/proto/lnrpc/routerrpc"
+)
+
+func TestGetServiceMethods(t *testing.T) {
+	serviceMethods := getServiceMethods()
+	torqMethods := getTorqMethods()
+	for _, serviceType := range services_helpers.GetLndServiceTypes() {
+		methods := serviceMethods[serviceType]
+		if len(methods) == 0 {
+			t.Errorf("%v has no LND RPC methods, it would never be disabled", serviceType.String())
+		}
+		for _, method := range methods {
+			if !isRpcMethod(method) || !slices.Contains(torqMethods, method) {
+				t.Errorf("%v calls %v, expected an LND RPC method that is checked", serviceType.String(), method)
+			}
+		}
+	}
+	if len(serviceMethods) != len(services_helpers.GetLndServiceTypes()) {
+		t.Errorf("Got methods for %v services, expected only the %v LND services",
+			len(serviceMethods), len(services_helpers.GetLndServiceTypes()))
+	}
+}
+
+func TestGetActionMethods(t *testing.T) {
+	torqMethods := getTorqMethods()
+	for action, methods := range getActionMethods() {
+		if len(methods) == 0 {
+			t.Errorf("%v has no LND RPC methods", action)
+		}
+		for _, method := range methods {
+			if !isRpcMethod(method) || !slices.Contains(torqMethods, method) {
+				t.Errorf("%v calls %v, expected an LND RPC method that is checked", action, method)
+			}
+		}
+	}
+	for _, method := range getPermissionsMethods() {
+		if !slices.Contains(torqMethods, method) {
+			t.Errorf("The permissions are checked with %v, expected it to be part of the macaroon", method)
+		}
+	}
+}
+
+func TestGetRequestAction(t *testing.T) {
+	requests := []any{
+		lightning_helpers.InformationRequest{},
+		lightning_helpers.SignMessageRequest{},
+		lightning_helpers.SignatureVerificationRequest{},
+		lightning_helpers.RoutingPolicyUpdateRequest{},
+		lightning_helpers.ChannelStatusUpdateRequest{},
+		lightning_helpers.ConnectPeerRequest{},
+		lightning_helpers.DisconnectPeerRequest{},
+		lightning_helpers.ListPeersRequest{},
+		lightning_helpers.WalletBalanceRequest{},
+		lightning_helpers.NewAddressRequest{},
+		lightning_helpers.OpenChannelRequest{},
+		lightning_helpers.BatchOpenChannelRequest{},
+		lightning_helpers.CloseChannelRequest{},
+		lightning_helpers.NewInvoiceRequest{},
+		lightning_helpers.OnChainPaymentRequest{},
+		lightning_helpers.NewPaymentRequest{},
+		lightning_helpers.DecodeInvoiceRequest{},
+	}
+	actionMethods := getActionMethods()
+	var actions []string
+	for _, request := range requests {
+		action := getRequestAction(request)
+		if _, exists := actionMethods[action]; !exists {
+			t.Errorf("%T has action %q, expected an action with LND RPC methods", request, action)
+		}
+		actions = appendMissing(actions, []string{action})
+	}
+	if len(actions) != len(actionMethods) {
+		t.Errorf("Got %v actions for the requests, expected the %v actions", len(actions), len(actionMethods))
+	}
+	// Unknown requests have no methods so they are not denied
+	allDenied := cache.NodePermissions{Checked: true, DeniedMethods: getTorqMethods()}
+	if !isAllowed(allDenied, getActionMethods()[getRequestAction(struct{}{})]) {
+		t.Errorf("Unknown request is denied, expected it to be allowed")
+	}
+}
+
+func TestIsAllowed(t *testing.T) {
+	nodePermissions := cache.NodePermissions{
+		Checked:       true,
+		DeniedMethods: []string{lnrpc.Lightning_SignMessage_FullMethodName},
+	}
+	tests := []struct {
+		name     string
+		methods  []string
+		expected bool
+	}{
+		{"No methods", nil, true},
+		{"Allowed method", []string{lnrpc.Lightning_GetInfo_FullMethodName}, true},
+		{"Denied method", []string{lnrpc.Lightning_SignMessage_FullMethodName}, false},
+		{"One denied method",
+			[]string{lnrpc.Lightning_GetInfo_FullMethodName, lnrpc.Lightning_SignMessage_FullMethodName}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if allowed := isAllowed(nodePermissions, test.methods); allowed != test.expected {
+				t.Errorf("isAllowed(%v) = %v, expected %v", test.methods, allowed, test.expected)
+			}
+		})
+	}
+	if !isAllowed(cache.NodePermissions{}, getTorqMethods()) {
+		t.Errorf("Unchecked permissions deny methods, expected everything to be allowed")
+	}
+}
+
+func TestGetPermissionsReport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cache.ServiceCacheHandler(cache.ServicesCacheChannel, ctx)
+	go cache.NodePermissionsCacheHandler(cache.NodePermissionsCacheChannel, ctx)
+	cache.InitStates(false)
+	nodeId := 1
+	amboss := services_helpers.LndServiceAmbossService
+	vector := services_helpers.LndServiceVectorService
+	cache.SetNodeConnectionDetails(nodeId, cache.NodeConnectionDetails{Implementation: core.LND})
+	cache.SetDesiredNodeServiceState(services_helpers.LndServiceForwardsService, nodeId, services_helpers.Active)
+
+	report := getPermissionsReport(nodeId, cache.NodePermissions{
+		Checked:       true,
+		DeniedMethods: []string{lnrpc.Lightning_SignMessage_FullMethodName},
+		GrantedPermissions: []string{"info:read", "offchain:read", "offchain:write", "onchain:write",
+			"macaroon:read"},
+		MethodPermissions: map[string][]string{
+			lnrpc.Lightning_ForwardingHistory_FullMethodName:        {"offchain:read"},
+			lnrpc.Lightning_ListPermissions_FullMethodName:          {"info:read"},
+			lnrpc.Lightning_CheckMacaroonPermissions_FullMethodName: {"macaroon:read"},
+			routerrpc.Router_SendPaymentV2_FullMethodName:           {"offchain:write"},
+		},
+	})
+	expectedServices := []string{amboss.String(), vector.String()}
+	if !slices.Equal(report.DeniedServices, expectedServices) {
+		t.Errorf("Denied services = %v, expected %v", report.DeniedServices, expectedServices)
+	}
+	if !slices.Equal(report.DeniedActions, []string{"signMessage"}) {
+		t.Errorf("Denied actions = %v, expected signMessage", report.DeniedActions)
+	}
+	// Only the active services and the actions need permissions
+	if !slices.Equal(report.ExcessPermissions, []string{"onchain:write"}) {
+		t.Errorf("Excess permissions = %v, expected onchain:write", report.ExcessPermissions)
+	}
+	if !strings.Contains(report.BakeMacaroonCommand, "uri:"+lnrpc.Lightning_ForwardingHistory_FullMethodName) ||
+		strings.Contains(report.BakeMacaroonCommand, "uri:"+lnrpc.Lightning_ListInvoices_FullMethodName) {
+		t.Errorf("Bake macaroon command %v, expected only the methods of the active services",
+			report.BakeMacaroonCommand)
+	}
+	if len(report.InactiveServices) != 0 {
+		t.Errorf("Inactive services = %v, expected none", report.InactiveServices)
+	}
+
+	// Denied services are activated again when the macaroon changes
+	cache.SetDeniedService(vector, nodeId)
+	report = getPermissionsReport(nodeId, cache.NodePermissions{})
+	if !slices.Equal(report.InactiveServices, []string{vector.String()}) {
+		t.Errorf("Inactive services = %v, expected the vector service", report.InactiveServices)
+	}
+	cache.RestartNodeServices(nodeId)
+	if deniedServices := cache.GetDeniedServices(nodeId); len(deniedServices) != 0 {
+		t.Errorf("Denied services after a restart = %v, expected none", deniedServices)
+	}
+	desiredState := cache.GetDesiredNodeServiceState(vector, nodeId)
+	if desiredState.Status != services_helpers.Active {
+		t.Errorf("Desired state after a restart = %v, expected active", desiredState.Status)
+	}
+}
+
+func isRpcMethod(method string) bool {
+	parts := strings.Split(method, "/")
+	return len(parts) == 3 && parts[0] == "" && strings.Contains(parts[1], ".") && parts[2] != ""
+}
@@ -7,9 +7,9 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
-	"github.com/rs/zerolog/log"
 
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc/chainrpc"
 
@@ -55,6 +55,7 @@ func SubscribeAndStoreTransactions(ctx context.Context,
 	nodeSettings cache.NodeSettingsCache) {
 
 	serviceType := services_helpers.LndServiceTransactionStream
+	log := logging.WithNodeService(streamLog, serviceType.String(), nodeSettings.NodeId)
 
 	var transactionHeight uint32
 	var err error
@@ -72,7 +73,7 @@ func SubscribeAndStoreTransactions(ctx context.Context,
 			cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
 			return
 		}
-		log.Error().Err(err).Msgf("Failed to obtain last know transaction for nodeId: %v", nodeSettings.NodeId)
+		log.Error().Err(err).Msg("Failed to obtain last know transaction")
 		cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 		return
 	}
@@ -80,7 +81,7 @@ func SubscribeAndStoreTransactions(ctx context.Context,
 	cache.SetBlockHeight(uint32(transactionHeight))
 	stream, err = chain.RegisterBlockEpochNtfn(ctx, &chainrpc.BlockEpoch{Height: uint32(transactionHeight + 1)})
 	if err != nil {
-		log.Error().Err(err).Msgf("Obtaining stream (RegisterBlockEpochNtfn) from LND failed for nodeId: %v", nodeSettings.NodeId)
+		log.Error().Err(err).Msg("Obtaining stream (RegisterBlockEpochNtfn) from LND failed")
 		cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 		return
 	}
@@ -101,19 +102,19 @@ func SubscribeAndStoreTransactions(ctx context.Context,
 				cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
 				return
 			}
-			log.Error().Err(err).Msgf("Receiving block epoch from the stream failed for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Receiving block epoch from the stream failed")
 			cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 			return
 		}
 		cache.SetBlockHeight(blockEpoch.Height)
-		//commons.BlockEvent{
-		//	EventData: commons.EventData{
-		//		EventTime: time.Now().UTC(),
-		//		NodeId:    nodeSettings.NodeId,
-		//	},
-		//	Hash:   blockEpoch.Hash,
-		//	Height: blockEpoch.Height,
-		//}
+		cache.PublishEvent(core.BlockEvent{
+			EventData: core.EventData{
+				EventTime: time.Now().UTC(),
+				NodeId:    nodeSettings.NodeId,
+			},
+			Hash:   blockEpoch.Hash,
+			Height: blockEpoch.Height,
+		})
 		// transactionHeight + 1: otherwise that last transaction will be downloaded over-and-over.
 		transactionDetails, err = client.GetTransactions(ctx, &lnrpc.GetTransactionsRequest{
 			StartHeight: int32(transactionHeight + 1),
@@ -123,7 +124,7 @@ func SubscribeAndStoreTransactions(ctx context.Context,
 				cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
 				return
 			}
-			log.Error().Err(err).Msgf("Failed to obtain last transaction details for nodeId: %v", nodeSettings.NodeId)
+			log.Error().Err(err).Msg("Failed to obtain last transaction details")
 			cache.SetFailedNodeServiceState(serviceType, nodeSettings.NodeId)
 			return
 		}
@@ -134,24 +135,24 @@ func SubscribeAndStoreTransactions(ctx context.Context,
 				// TODO FIXME This transaction is now missing
 				log.Error().Err(err).Msg("Failed to store the transaction (transaction is now missing and can only be recovered by emptying the transactions table)")
 			}
-			//if !bootStrapping {
-			//	commons.TransactionEvent{
-			//		EventData: commons.EventData{
-			//			EventTime: time.Now().UTC(),
-			//			NodeId:    nodeSettings.NodeId,
-			//		},
-			//		Timestamp:             storedTx.Timestamp,
-			//		TransactionHash:       storedTx.TransactionHash,
-			//		Amount:                storedTx.Amount,
-			//		NumberOfConfirmations: storedTx.NumberOfConfirmations,
-			//		BlockHash:             storedTx.BlockHash,
-			//		BlockHeight:           storedTx.BlockHeight,
-			//		TotalFees:             storedTx.TotalFees,
-			//		DestinationAddresses:  storedTx.DestinationAddresses,
-			//		RawTransactionHex:     storedTx.RawTransactionHex,
-			//		Label:                 storedTx.Label,
-			//	}
-			//}
+			if err == nil && !bootStrapping {
+				cache.PublishEvent(core.TransactionEvent{
+					EventData: core.EventData{
+						EventTime: time.Now().UTC(),
+						NodeId:    nodeSettings.NodeId,
+					},
+					Timestamp:             storedTx.Timestamp,
+					TransactionHash:       storedTx.TransactionHash,
+					Amount:                storedTx.Amount,
+					NumberOfConfirmations: storedTx.NumberOfConfirmations,
+					BlockHash:             storedTx.BlockHash,
+					BlockHeight:           storedTx.BlockHeight,
+					TotalFees:             storedTx.TotalFees,
+					DestinationAddresses:  storedTx.DestinationAddresses,
+					RawTransactionHex:     storedTx.RawTransactionHex,
+					Label:                 storedTx.Label,
+				})
+			}
 			if uint32(*storedTx.BlockHeight) > transactionHeight {
 				transactionHeight = *storedTx.BlockHeight
 			}
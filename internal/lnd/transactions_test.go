@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/lib/pq"
-	"github.com/rs/zerolog/log"
 
 	"github.com/lncapital/torq/proto/lnrpc"
 
@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+)
+
+// rotatingFile renames the file to file.1 (and file.1 to file.2, ...) when it exceeds the maximum size
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func newRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	rf := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	err := rf.open()
+	if err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *rotatingFile) open() error {
+	file, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrap(err, "Opening file")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.Wrap(err, "Reading file size")
+	}
+	rf.file = file
+	rf.size = info.Size()
+	return nil
+}
+
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	if rf.maxSize > 0 && rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize {
+		err := rf.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, errors.Wrap(err, "Writing log file")
+}
+
+func (rf *rotatingFile) rotate() error {
+	err := rf.file.Close()
+	if err != nil {
+		return errors.Wrap(err, "Closing log file")
+	}
+	if rf.maxBackups <= 0 {
+		err = os.Remove(rf.path)
+	} else {
+		for i := rf.maxBackups - 1; i >= 1; i-- {
+			err = os.Rename(rf.backupPath(i), rf.backupPath(i+1))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return errors.Wrap(err, "Rotating log file backups")
+			}
+		}
+		err = os.Rename(rf.path, rf.backupPath(1))
+	}
+	if err != nil {
+		return errors.Wrap(err, "Rotating log file")
+	}
+	return rf.open()
+}
+
+func (rf *rotatingFile) backupPath(i int) string {
+	return fmt.Sprintf("%v.%v", rf.path, i)
+}
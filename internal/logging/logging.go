@@ -0,0 +1,208 @@
+package logging
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type Subsystem string
+
+const (
+	// Torq is the subsystem of everything that doesn't have its own subsystem
+	Torq          = Subsystem("torq")
+	Api           = Subsystem("api")
+	Cache         = Subsystem("cache")
+	Lnd           = Subsystem("lnd")
+	Cln           = Subsystem("cln")
+	Streams       = Subsystem("streams")
+	Rebalancer    = Subsystem("rebalancer")
+	Workflows     = Subsystem("workflows")
+	Notifications = Subsystem("notifications")
+	Ping          = Subsystem("ping")
+)
+
+func GetSubsystems() []Subsystem {
+	return []Subsystem{
+		Torq,
+		Api,
+		Cache,
+		Lnd,
+		Cln,
+		Streams,
+		Rebalancer,
+		Workflows,
+		Notifications,
+		Ping,
+	}
+}
+
+// The fields that identify what a log line is about, use these instead of adding the ids to the message
+const (
+	SubsystemField         = "subsystem"
+	NodeIdField            = "node_id"
+	ServiceTypeField       = "service_type"
+	WorkflowVersionIdField = "workflow_version_id"
+	RebalanceIdField       = "rebalance_id"
+	ChannelIdField         = "channel_id"
+)
+
+//nolint:gochecknoglobals
+var levels = struct {
+	sync.RWMutex
+	defaultLevel zerolog.Level
+	// Subsystems without a level use the default level
+	subsystemLevels map[Subsystem]zerolog.Level
+}{
+	defaultLevel:    zerolog.InfoLevel,
+	subsystemLevels: make(map[Subsystem]zerolog.Level),
+}
+
+//nolint:gochecknoinits
+func init() {
+	// The global logger is used by everything that doesn't have its own subsystem
+	log.Logger = GetLogger(Torq)
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+}
+
+// GetLogger returns the logger of a subsystem, the level of the subsystem can be changed at runtime.
+// A package of a subsystem declares it as the package variable log so it replaces the global zerolog logger.
+func GetLogger(subsystem Subsystem) zerolog.Logger {
+	return zerolog.New(output).
+		With().Timestamp().Str(SubsystemField, string(subsystem)).Logger().
+		Hook(levelHook{subsystem: subsystem})
+}
+
+// WithService adds the service type to the log lines of a core service
+func WithService(logger zerolog.Logger, serviceType string) zerolog.Logger {
+	return logger.With().Str(ServiceTypeField, serviceType).Logger()
+}
+
+// WithNodeService adds the service type and the node id to the log lines of a node service
+func WithNodeService(logger zerolog.Logger, serviceType string, nodeId int) zerolog.Logger {
+	return logger.With().Str(ServiceTypeField, serviceType).Int(NodeIdField, nodeId).Logger()
+}
+
+type levelHook struct {
+	subsystem Subsystem
+}
+
+func (h levelHook) Run(e *zerolog.Event, level zerolog.Level, _ string) {
+	if level < GetLevel(h.subsystem) {
+		e.Discard()
+	}
+}
+
+func GetLevel(subsystem Subsystem) zerolog.Level {
+	levels.RLock()
+	defer levels.RUnlock()
+	level, exists := levels.subsystemLevels[subsystem]
+	if !exists {
+		return levels.defaultLevel
+	}
+	return level
+}
+
+func GetDefaultLevel() zerolog.Level {
+	levels.RLock()
+	defer levels.RUnlock()
+	return levels.defaultLevel
+}
+
+func SetDefaultLevel(level zerolog.Level) {
+	levels.Lock()
+	defer levels.Unlock()
+	levels.defaultLevel = level
+	setGlobalLevel()
+}
+
+// SetLevel sets the level of a subsystem, a subsystem without a level (nil) uses the default level
+func SetLevel(subsystem Subsystem, level *zerolog.Level) error {
+	if !isSubsystem(subsystem) {
+		return errors.Newf("Unknown subsystem %v", subsystem)
+	}
+	levels.Lock()
+	defer levels.Unlock()
+	if level == nil {
+		delete(levels.subsystemLevels, subsystem)
+	} else {
+		levels.subsystemLevels[subsystem] = *level
+	}
+	setGlobalLevel()
+	return nil
+}
+
+// SetLevels sets the levels of subsystems from a comma separated list (i.e. rebalancer=debug,lnd=warn)
+func SetLevels(subsystemLevels string) error {
+	for _, subsystemLevel := range strings.Split(subsystemLevels, ",") {
+		if strings.TrimSpace(subsystemLevel) == "" {
+			continue
+		}
+		parts := strings.Split(subsystemLevel, "=")
+		if len(parts) != 2 {
+			return errors.Newf("Invalid subsystem level %q (subsystem=level)", subsystemLevel)
+		}
+		level, err := ParseLevel(parts[1])
+		if err != nil {
+			return err
+		}
+		err = SetLevel(Subsystem(strings.ToLower(strings.TrimSpace(parts[0]))), &level)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ParseLevel(level string) (zerolog.Level, error) {
+	parsedLevel, err := zerolog.ParseLevel(strings.ToLower(strings.TrimSpace(level)))
+	if err != nil || level == "" {
+		return zerolog.NoLevel, errors.Newf("Unknown level %q (panic|fatal|error|warn|info|debug|trace)", level)
+	}
+	return parsedLevel, nil
+}
+
+// setGlobalLevel lets zerolog skip the events that no subsystem logs, the subsystem levels are applied by the hook
+func setGlobalLevel() {
+	globalLevel := levels.defaultLevel
+	for _, level := range levels.subsystemLevels {
+		if level < globalLevel {
+			globalLevel = level
+		}
+	}
+	zerolog.SetGlobalLevel(globalLevel)
+}
+
+type SubsystemLevel struct {
+	Subsystem Subsystem `json:"subsystem"`
+	Level     string    `json:"level"`
+	// When not set the subsystem uses the default level
+	Configured bool `json:"configured"`
+}
+
+func GetSubsystemLevels() []SubsystemLevel {
+	levels.RLock()
+	defer levels.RUnlock()
+	var subsystemLevels []SubsystemLevel
+	for _, subsystem := range GetSubsystems() {
+		level, configured := levels.subsystemLevels[subsystem]
+		if !configured {
+			level = levels.defaultLevel
+		}
+		subsystemLevels = append(subsystemLevels,
+			SubsystemLevel{Subsystem: subsystem, Level: level.String(), Configured: configured})
+	}
+	return subsystemLevels
+}
+
+func isSubsystem(subsystem Subsystem) bool {
+	for _, s := range GetSubsystems() {
+		if s == subsystem {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+func TestSubsystemLevels(t *testing.T) {
+	var buffer bytes.Buffer
+	output.setWriters([]io.Writer{&buffer})
+	defer output.setWriters([]io.Writer{os.Stderr})
+	defer resetLevels()
+
+	rebalancerLog := GetLogger(Rebalancer)
+	lndLog := WithNodeService(GetLogger(Lnd), "LndServiceChannelEventStream", 1)
+
+	err := SetLevels("rebalancer=debug, lnd=warn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zerolog.GlobalLevel() != zerolog.DebugLevel {
+		t.Errorf("Global level = %v, expected the lowest subsystem level", zerolog.GlobalLevel())
+	}
+
+	rebalancerLog.Debug().Msg("rebalancer debug")
+	lndLog.Info().Msg("lnd info")
+	lndLog.Warn().Msg("lnd warn")
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Logged %v, expected the rebalancer debug and the lnd warning", lines)
+	}
+	var fields map[string]interface{}
+	err = json.Unmarshal([]byte(lines[1]), &fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields[SubsystemField] != "lnd" || fields[NodeIdField] != float64(1) ||
+		fields[ServiceTypeField] != "LndServiceChannelEventStream" || fields["message"] != "lnd warn" {
+		t.Errorf("Logged %v, expected the subsystem, node id and service type fields", fields)
+	}
+
+	// Back to the default level at runtime
+	err = SetLevel(Rebalancer, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffer.Reset()
+	rebalancerLog.Debug().Msg("rebalancer debug")
+	if buffer.Len() != 0 {
+		t.Errorf("Logged %v, expected nothing at the default level", buffer.String())
+	}
+}
+
+func TestSetLevelsInvalid(t *testing.T) {
+	defer resetLevels()
+	for _, levels := range []string{"rebalancer", "unknown=debug", "lnd=verbose"} {
+		if err := SetLevels(levels); err == nil {
+			t.Errorf("SetLevels(%q) should fail", levels)
+		}
+	}
+}
+
+func TestSetLevelHandler(t *testing.T) {
+	defer resetLevels()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterLoggingRoutes(r.Group("/logging"))
+
+	tests := []struct {
+		name     string
+		body     string
+		expected int
+	}{
+		{"Subsystem level", `{"subsystem": "streams", "level": "warn"}`, http.StatusOK},
+		{"Default level", `{"level": "debug"}`, http.StatusOK},
+		{"Unknown subsystem", `{"subsystem": "unknown", "level": "warn"}`, http.StatusBadRequest},
+		{"Unknown level", `{"subsystem": "streams", "level": "verbose"}`, http.StatusBadRequest},
+		{"Missing default level", `{}`, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			r.ServeHTTP(response, httptest.NewRequest(http.MethodPut, "/logging", strings.NewReader(test.body)))
+			if response.Code != test.expected {
+				t.Errorf("PUT /logging %v = %v, expected %v", test.body, response.Code, test.expected)
+			}
+		})
+	}
+	if GetLevel(Streams) != zerolog.WarnLevel || GetLevel(Lnd) != zerolog.DebugLevel {
+		t.Errorf("Levels = %v, expected streams at warn and the others at debug", GetSubsystemLevels())
+	}
+}
+
+func resetLevels() {
+	levels.Lock()
+	levels.defaultLevel = zerolog.InfoLevel
+	levels.subsystemLevels = make(map[Subsystem]zerolog.Level)
+	setGlobalLevel()
+	levels.Unlock()
+}
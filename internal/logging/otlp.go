@@ -0,0 +1,148 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lncapital/torq/build"
+	"github.com/lncapital/torq/pkg/otlp"
+)
+
+// The OTLP/HTTP JSON encoding of the logs service (opentelemetry-proto logs/v1)
+type otlpLogs struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlp.Resource   `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlp.Scope      `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string           `json:"timeUnixNano"`
+	SeverityNumber int              `json:"severityNumber"`
+	SeverityText   string           `json:"severityText"`
+	Body           otlp.Value       `json:"body"`
+	Attributes     []otlp.Attribute `json:"attributes"`
+}
+
+type otlpSender struct {
+	client   *otlp.Client
+	resource otlp.Resource
+}
+
+func newOtlpSender(endpoint string) *otlpSender {
+	return &otlpSender{client: otlp.NewClient(endpoint), resource: otlp.GetResource()}
+}
+
+func (s *otlpSender) send(lines []logLine) error {
+	var records []otlpLogRecord
+	for _, line := range lines {
+		records = append(records, getOtlpLogRecord(line))
+	}
+	return s.client.Send(context.Background(), "logs", otlpLogs{ResourceLogs: []otlpResourceLogs{{
+		Resource: s.resource,
+		ScopeLogs: []otlpScopeLogs{{
+			Scope:      otlp.Scope{Name: "torq", Version: build.ExtendedVersion()},
+			LogRecords: records,
+		}},
+	}}})
+}
+
+// getOtlpLogRecord converts a JSON log line, the message is the body and the other fields are attributes
+func getOtlpLogRecord(line logLine) otlpLogRecord {
+	record := otlpLogRecord{
+		TimeUnixNano:   strconv.FormatInt(time.Now().UnixNano(), 10),
+		SeverityNumber: getOtlpSeverityNumber(line.level),
+		SeverityText:   getOtlpSeverityText(line.level),
+		Body:           getOtlpValue(""),
+	}
+	var fields map[string]interface{}
+	err := json.Unmarshal(line.line, &fields)
+	if err != nil {
+		record.Body = getOtlpValue(string(bytes.TrimRight(line.line, "\n")))
+		return record
+	}
+	var keys []string
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch key {
+		case zerolog.MessageFieldName:
+			record.Body = getOtlpValue(fields[key])
+		case zerolog.LevelFieldName:
+		case zerolog.TimestampFieldName:
+			timestamp, ok := fields[key].(string)
+			if !ok {
+				continue
+			}
+			parsedTime, err := time.Parse(zerolog.TimeFieldFormat, timestamp)
+			if err == nil {
+				record.TimeUnixNano = strconv.FormatInt(parsedTime.UnixNano(), 10)
+			}
+		default:
+			record.Attributes = append(record.Attributes, otlp.Attribute{Key: key, Value: getOtlpValue(fields[key])})
+		}
+	}
+	return record
+}
+
+func getOtlpValue(value interface{}) otlp.Value {
+	switch v := value.(type) {
+	case string:
+		return otlp.StringValue(v)
+	case bool:
+		return otlp.BoolValue(v)
+	case float64:
+		if v == float64(int64(v)) {
+			return otlp.IntValue(int64(v))
+		}
+		return otlp.DoubleValue(v)
+	default:
+		stringValue := fmt.Sprintf("%v", v)
+		if encoded, err := json.Marshal(v); err == nil {
+			stringValue = string(encoded)
+		}
+		return otlp.StringValue(stringValue)
+	}
+}
+
+func getOtlpSeverityNumber(level zerolog.Level) int {
+	switch level {
+	case zerolog.TraceLevel:
+		return 1
+	case zerolog.DebugLevel:
+		return 5
+	case zerolog.InfoLevel:
+		return 9
+	case zerolog.WarnLevel:
+		return 13
+	case zerolog.ErrorLevel:
+		return 17
+	case zerolog.FatalLevel, zerolog.PanicLevel:
+		return 21
+	default:
+		return 0
+	}
+}
+
+func getOtlpSeverityText(level zerolog.Level) string {
+	if level == zerolog.NoLevel {
+		return ""
+	}
+	return level.String()
+}
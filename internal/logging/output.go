@@ -0,0 +1,147 @@
+package logging
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog"
+)
+
+// The size of the queue of a shipping output, log lines are dropped when the destination can't keep up
+const shippingQueueSize = 10000
+
+type OutputConfig struct {
+	// JSON log lines are appended to the file, it's rotated when it exceeds the maximum size
+	FilePath       string
+	FileMaxSizeMB  int
+	FileMaxBackups int
+	// udp://host:port or tcp://host:port of a syslog (RFC 5424) server
+	SyslogAddress string
+	// http(s)://host:port/v1/logs of an OpenTelemetry (OTLP/HTTP JSON) collector
+	OtlpEndpoint string
+}
+
+// output is the writer of all loggers, the writers can be replaced after the loggers were created
+//
+//nolint:gochecknoglobals
+var output = &multiWriter{writers: []io.Writer{os.Stderr}}
+
+type multiWriter struct {
+	sync.RWMutex
+	writers []io.Writer
+}
+
+func (mw *multiWriter) Write(p []byte) (int, error) {
+	return mw.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (mw *multiWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	mw.RLock()
+	defer mw.RUnlock()
+	var err error
+	for _, w := range mw.writers {
+		var writeErr error
+		if lw, ok := w.(zerolog.LevelWriter); ok {
+			_, writeErr = lw.WriteLevel(level, p)
+		} else {
+			_, writeErr = w.Write(p)
+		}
+		if writeErr != nil && err == nil {
+			err = writeErr
+		}
+	}
+	return len(p), err
+}
+
+func (mw *multiWriter) setWriters(writers []io.Writer) {
+	mw.Lock()
+	defer mw.Unlock()
+	mw.writers = writers
+}
+
+// ConfigureOutputs sends the log lines to the configured outputs besides stderr
+func ConfigureOutputs(config OutputConfig) error {
+	writers := []io.Writer{os.Stderr}
+	if config.FilePath != "" {
+		file, err := newRotatingFile(config.FilePath, int64(config.FileMaxSizeMB)*1024*1024, config.FileMaxBackups)
+		if err != nil {
+			return errors.Wrapf(err, "Opening log file %v", config.FilePath)
+		}
+		writers = append(writers, file)
+	}
+	if config.SyslogAddress != "" {
+		address, err := url.Parse(config.SyslogAddress)
+		if err != nil || (address.Scheme != "udp" && address.Scheme != "tcp") || address.Host == "" {
+			return errors.Newf("Invalid syslog address %v (udp://host:port or tcp://host:port)", config.SyslogAddress)
+		}
+		writers = append(writers, newShippingWriter(newSyslogSender(address.Scheme, address.Host)))
+	}
+	if config.OtlpEndpoint != "" {
+		endpoint, err := url.Parse(config.OtlpEndpoint)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return errors.Newf("Invalid OTLP endpoint %v (http(s)://host:port/v1/logs)", config.OtlpEndpoint)
+		}
+		writers = append(writers, newShippingWriter(newOtlpSender(config.OtlpEndpoint)))
+	}
+	output.setWriters(writers)
+	return nil
+}
+
+type logLine struct {
+	level zerolog.Level
+	line  []byte
+}
+
+type sender interface {
+	// send ships a batch of log lines, the lines are dropped when it fails
+	send(lines []logLine) error
+}
+
+// shippingWriter queues the log lines so logging never waits on the network
+type shippingWriter struct {
+	queue chan logLine
+}
+
+func newShippingWriter(s sender) *shippingWriter {
+	sw := &shippingWriter{queue: make(chan logLine, shippingQueueSize)}
+	go sw.ship(s)
+	return sw
+}
+
+func (sw *shippingWriter) Write(p []byte) (int, error) {
+	return sw.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (sw *shippingWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	// zerolog reuses the buffer after the write
+	line := make([]byte, len(p))
+	copy(line, p)
+	select {
+	case sw.queue <- logLine{level: level, line: line}:
+	default:
+	}
+	return len(p), nil
+}
+
+func (sw *shippingWriter) ship(s sender) {
+	for line := range sw.queue {
+		lines := []logLine{line}
+	batch:
+		for len(lines) < 100 {
+			select {
+			case line = <-sw.queue:
+				lines = append(lines, line)
+			default:
+				break batch
+			}
+		}
+		err := s.send(lines)
+		if err != nil {
+			// Logging the failure would be shipped again
+			_, _ = os.Stderr.WriteString("Failed to ship log lines: " + err.Error() + "\n")
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lncapital/torq/pkg/otlp"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "torq.log")
+	file, err := newRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err = file.Write([]byte(line))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := map[string]string{path: "fourth\n", path + ".1": "third\n", path + ".2": "second\n"}
+	for p, content := range expected {
+		written, err := os.ReadFile(p)
+		if err != nil || string(written) != content {
+			t.Errorf("%v = %q (%v), expected %q", p, written, err, content)
+		}
+	}
+	if _, err = os.Stat(path + ".3"); err == nil {
+		t.Errorf("Only 2 backups should be kept")
+	}
+}
+
+func TestSyslogSender(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	sender := newSyslogSender("udp", listener.LocalAddr().String())
+	err = sender.send([]logLine{{level: zerolog.WarnLevel, line: []byte(`{"level":"warn","message":"test"}` + "\n")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buffer := make([]byte, 1024)
+	err = listener.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, _, err := listener.ReadFrom(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := string(buffer[:n])
+	// user facility (1) * 8 + warning (4)
+	if !strings.HasPrefix(message, "<12>1 ") ||
+		!strings.HasSuffix(message, fmt.Sprintf(` torq %d - - {"level":"warn","message":"test"}`, os.Getpid())) {
+		t.Errorf("Received %q, expected a RFC 5424 message with the log line", message)
+	}
+}
+
+func TestOtlpSender(t *testing.T) {
+	received := make(chan otlpLogs, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil || r.URL.Path != "/v1/logs" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var logs otlpLogs
+		if json.Unmarshal(body, &logs) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- logs
+	}))
+	defer collector.Close()
+
+	err := ConfigureOutputs(OutputConfig{OtlpEndpoint: collector.URL + "/v1/logs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.setWriters([]io.Writer{os.Stderr})
+
+	logger := GetLogger(Rebalancer)
+	logger.Error().Int(RebalanceIdField, 12).Msg("Rebalance failed")
+
+	select {
+	case logs := <-received:
+		record := logs.ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+		if *record.Body.StringValue != "Rebalance failed" || record.SeverityNumber != 17 {
+			t.Errorf("Received %+v, expected the error", record)
+		}
+		attributes := make(map[string]otlp.Value)
+		for _, attribute := range record.Attributes {
+			attributes[attribute.Key] = attribute.Value
+		}
+		if *attributes[SubsystemField].StringValue != "rebalancer" || *attributes[RebalanceIdField].IntValue != "12" {
+			t.Errorf("Received attributes %+v, expected the subsystem and rebalance id", record.Attributes)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("The collector did not receive the log line")
+	}
+}
+
+func TestConfigureOutputsInvalid(t *testing.T) {
+	for _, config := range []OutputConfig{
+		{SyslogAddress: "localhost:514"},
+		{SyslogAddress: "unix:///dev/log"},
+		{OtlpEndpoint: "localhost:4318"},
+		{FilePath: filepath.Join(t.TempDir(), "missing", "torq.log")},
+	} {
+		if err := ConfigureOutputs(config); err == nil {
+			t.Errorf("ConfigureOutputs(%+v) should fail", config)
+		}
+	}
+}
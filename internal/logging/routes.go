@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+type Levels struct {
+	DefaultLevel string           `json:"defaultLevel"`
+	Subsystems   []SubsystemLevel `json:"subsystems"`
+}
+
+type LevelRequest struct {
+	// Without subsystem the default level is set
+	Subsystem Subsystem `json:"subsystem"`
+	// Without level the subsystem uses the default level again
+	Level string `json:"level"`
+}
+
+// RegisterLoggingRoutes registers the routes to change the levels at runtime, they are reset on restart
+func RegisterLoggingRoutes(r *gin.RouterGroup) {
+	r.GET("", getLevelsHandler)
+	r.PUT("", setLevelHandler)
+}
+
+func getLevelsHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, getLevels())
+}
+
+func setLevelHandler(c *gin.Context) {
+	var request LevelRequest
+	if err := c.BindJSON(&request); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if request.Subsystem != "" && !isSubsystem(request.Subsystem) {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("subsystem", "Unknown subsystem"))
+		return
+	}
+	var level *zerolog.Level
+	if request.Level != "" {
+		parsedLevel, err := ParseLevel(request.Level)
+		if err != nil {
+			server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("level", err.Error()))
+			return
+		}
+		level = &parsedLevel
+	}
+	switch {
+	case request.Subsystem == "" && level == nil:
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("level", "The default level is required"))
+		return
+	case request.Subsystem == "":
+		SetDefaultLevel(*level)
+	default:
+		err := SetLevel(request.Subsystem, level)
+		if err != nil {
+			server_errors.SendBadRequest(c, err.Error())
+			return
+		}
+	}
+	log.Info().Msgf("Log level of %v changed to %v", getSubsystemName(request.Subsystem),
+		getEffectiveLevel(request.Subsystem))
+	c.JSON(http.StatusOK, getLevels())
+}
+
+func getLevels() Levels {
+	return Levels{DefaultLevel: GetDefaultLevel().String(), Subsystems: GetSubsystemLevels()}
+}
+
+func getEffectiveLevel(subsystem Subsystem) zerolog.Level {
+	if subsystem == "" {
+		return GetDefaultLevel()
+	}
+	return GetLevel(subsystem)
+}
+
+func getSubsystemName(subsystem Subsystem) string {
+	if subsystem == "" {
+		return "all subsystems"
+	}
+	return string(subsystem)
+}
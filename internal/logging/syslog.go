@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog"
+)
+
+// The user-level facility (RFC 5424)
+const syslogFacility = 1
+
+const syslogDialTimeout = 5 * time.Second
+
+// syslogSender sends every log line as a RFC 5424 message with the JSON log line as message
+type syslogSender struct {
+	network  string
+	address  string
+	hostname string
+	conn     net.Conn
+}
+
+func newSyslogSender(network string, address string) *syslogSender {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &syslogSender{network: network, address: address, hostname: hostname}
+}
+
+func (s *syslogSender) send(lines []logLine) error {
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.network, s.address, syslogDialTimeout)
+		if err != nil {
+			return errors.Wrapf(err, "Connecting to syslog %v://%v", s.network, s.address)
+		}
+		s.conn = conn
+	}
+	for _, line := range lines {
+		message := s.format(line, time.Now())
+		if s.network == "tcp" {
+			// Octet counting framing (RFC 6587)
+			message = append([]byte(fmt.Sprintf("%d ", len(message))), message...)
+		}
+		_, err := s.conn.Write(message)
+		if err != nil {
+			_ = s.conn.Close()
+			s.conn = nil
+			return errors.Wrapf(err, "Sending to syslog %v://%v", s.network, s.address)
+		}
+	}
+	return nil
+}
+
+func (s *syslogSender) format(line logLine, timestamp time.Time) []byte {
+	return []byte(fmt.Sprintf("<%d>1 %v %v torq %d - - %s",
+		syslogFacility*8+getSyslogSeverity(line.level), timestamp.UTC().Format(time.RFC3339Nano), s.hostname,
+		os.Getpid(), bytes.TrimRight(line.line, "\n")))
+}
+
+func getSyslogSeverity(level zerolog.Level) int {
+	switch level {
+	case zerolog.PanicLevel:
+		return 0
+	case zerolog.FatalLevel:
+		return 2
+	case zerolog.ErrorLevel:
+		return 3
+	case zerolog.WarnLevel:
+		return 4
+	case zerolog.InfoLevel:
+		return 6
+	case zerolog.DebugLevel, zerolog.TraceLevel:
+		return 7
+	default:
+		return 5
+	}
+}
@@ -1,16 +1,18 @@
 package messages
 
 import (
+	"context"
+
 	"github.com/cockroachdb/errors"
 
 	"github.com/lncapital/torq/internal/lightning"
 )
 
-func verifyMessage(req VerifyMessageRequest) (VerifyMessageResponse, error) {
+func verifyMessage(ctx context.Context, req VerifyMessageRequest) (VerifyMessageResponse, error) {
 	if req.NodeId == 0 {
 		return VerifyMessageResponse{}, errors.New("Node Id missing")
 	}
-	publicKey, valid, err := lightning.SignatureVerification(req.NodeId, req.Message, req.Signature)
+	publicKey, valid, err := lightning.SignatureVerification(ctx, req.NodeId, req.Message, req.Signature)
 	if err != nil {
 		return VerifyMessageResponse{}, errors.Wrapf(err, "Signature Verification (nodeId: %v)", req.NodeId)
 	}
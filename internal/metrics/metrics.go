@@ -0,0 +1,213 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/services_helpers"
+)
+
+const namespace = "torq"
+
+var (
+	forwardsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{ //nolint:gochecknoglobals
+		Namespace: namespace,
+		Name:      "forwards_total",
+		Help:      "Number of forwards processed since Torq started.",
+	}, []string{"node_id"})
+	forwardFeesMsatTotal = prometheus.NewCounterVec(prometheus.CounterOpts{ //nolint:gochecknoglobals
+		Namespace: namespace,
+		Name:      "forward_fees_msat_total",
+		Help:      "Fees earned in milli satoshis from forwards processed since Torq started.",
+	}, []string{"node_id"})
+	forwardAmountMsatTotal = prometheus.NewCounterVec(prometheus.CounterOpts{ //nolint:gochecknoglobals
+		Namespace: namespace,
+		Name:      "forward_amount_msat_total",
+		Help:      "Outgoing amount in milli satoshis of forwards processed since Torq started.",
+	}, []string{"node_id"})
+	workflowNodeExecutionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{ //nolint:gochecknoglobals
+		Namespace: namespace,
+		Name:      "workflow_node_executions_total",
+		Help:      "Number of workflow node executions.",
+	}, []string{"workflow_version_id", "node_type"})
+	workflowNodeErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{ //nolint:gochecknoglobals
+		Namespace: namespace,
+		Name:      "workflow_node_errors_total",
+		Help:      "Number of workflow node executions that ended in an error.",
+	}, []string{"workflow_version_id", "node_type"})
+)
+
+var (
+	nodeConnectedDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+		prometheus.BuildFQName(namespace, "node", "connected"),
+		"Whether the main stream of the Torq node is active (1) or not (0).",
+		[]string{"node_id", "name", "public_key", "implementation"}, nil)
+	serviceStatusDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+		prometheus.BuildFQName(namespace, "service", "status"),
+		"Current status of a Torq service (0=Inactive, 1=Active, 2=Pending, 4=Initializing).",
+		[]string{"service", "node_id"}, nil)
+	serviceDesiredStatusDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+		prometheus.BuildFQName(namespace, "service", "desired_status"),
+		"Desired status of a Torq service (0=Inactive, 1=Active).",
+		[]string{"service", "node_id"}, nil)
+	channelLocalBalanceDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+		prometheus.BuildFQName(namespace, "channel", "local_balance_sat"),
+		"Local balance of the channel in satoshis.",
+		[]string{"node_id", "channel_id", "short_channel_id", "remote_node_id"}, nil)
+	channelRemoteBalanceDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+		prometheus.BuildFQName(namespace, "channel", "remote_balance_sat"),
+		"Remote balance of the channel in satoshis.",
+		[]string{"node_id", "channel_id", "short_channel_id", "remote_node_id"}, nil)
+	channelPendingHtlcsDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+		prometheus.BuildFQName(namespace, "channel", "pending_htlcs"),
+		"Number of pending HTLCs on the channel.",
+		[]string{"node_id", "channel_id", "short_channel_id", "direction"}, nil)
+	channelPendingHtlcAmountDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+		prometheus.BuildFQName(namespace, "channel", "pending_htlc_amount_sat"),
+		"Amount in satoshis of the pending HTLCs on the channel.",
+		[]string{"node_id", "channel_id", "short_channel_id", "direction"}, nil)
+	channelDisabledDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+		prometheus.BuildFQName(namespace, "channel", "local_disabled"),
+		"Whether the channel is disabled by the local node (1) or not (0).",
+		[]string{"node_id", "channel_id", "short_channel_id"}, nil)
+)
+
+// registry is declared after the descriptors because stateCollector uses them while being registered
+var registry = newRegistry() //nolint:gochecknoglobals
+
+func newRegistry() *prometheus.Registry {
+	r := prometheus.NewRegistry()
+	r.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		forwardsTotal,
+		forwardFeesMsatTotal,
+		forwardAmountMsatTotal,
+		workflowNodeExecutionsTotal,
+		workflowNodeErrorsTotal,
+		stateCollector{},
+	)
+	return r
+}
+
+// Register adds collectors that live outside of this package (i.e. the rebalancer collector) to the Torq registry.
+func Register(collectors ...prometheus.Collector) {
+	for _, collector := range collectors {
+		if err := registry.Register(collector); err != nil {
+			if _, ok := err.(prometheus.AlreadyRegisteredError); ok {
+				continue
+			}
+			panic(err)
+		}
+	}
+}
+
+func AddForward(forwardEvent core.ForwardEvent) {
+	if forwardEvent.NodeId == 0 {
+		return
+	}
+	nodeId := strconv.Itoa(forwardEvent.NodeId)
+	forwardsTotal.WithLabelValues(nodeId).Inc()
+	forwardFeesMsatTotal.WithLabelValues(nodeId).Add(float64(forwardEvent.FeeMsat))
+	forwardAmountMsatTotal.WithLabelValues(nodeId).Add(float64(forwardEvent.AmountOutMsat))
+}
+
+func AddWorkflowNodeExecution(workflowVersionId int, workflowNodeType int, workflowError error) {
+	labels := []string{strconv.Itoa(workflowVersionId), strconv.Itoa(workflowNodeType)}
+	workflowNodeExecutionsTotal.WithLabelValues(labels...).Inc()
+	if workflowError != nil {
+		workflowNodeErrorsTotal.WithLabelValues(labels...).Inc()
+	}
+}
+
+// stateCollector reads the node, service and channel states from the caches at scrape time.
+type stateCollector struct{}
+
+func (stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- nodeConnectedDesc
+	ch <- serviceStatusDesc
+	ch <- serviceDesiredStatusDesc
+	ch <- channelLocalBalanceDesc
+	ch <- channelRemoteBalanceDesc
+	ch <- channelPendingHtlcsDesc
+	ch <- channelPendingHtlcAmountDesc
+	ch <- channelDisabledDesc
+}
+
+func (stateCollector) Collect(ch chan<- prometheus.Metric) {
+	collectServiceStates(ch, services_helpers.GetCoreServiceTypes(), 0)
+	// Node and channel information is only reliable once the caches are loaded
+	if cache.GetCurrentCoreServiceState(services_helpers.RootService).Status != services_helpers.Active {
+		return
+	}
+	for _, torqNode := range cache.GetActiveTorqNodeSettings() {
+		nodeId := strconv.Itoa(torqNode.NodeId)
+		name := ""
+		if torqNode.Name != nil {
+			name = *torqNode.Name
+		}
+		var connected bool
+		implementation := cache.GetNodeConnectionDetails(torqNode.NodeId).Implementation
+		switch implementation {
+		case core.LND:
+			connected = cache.IsLndServiceActive(torqNode.NodeId)
+			collectServiceStates(ch, services_helpers.GetLndServiceTypes(), torqNode.NodeId)
+		case core.CLN:
+			connected = cache.IsClnServiceActive(torqNode.NodeId)
+			collectServiceStates(ch, services_helpers.GetClnServiceTypes(), torqNode.NodeId)
+		}
+		ch <- prometheus.MustNewConstMetric(nodeConnectedDesc, prometheus.GaugeValue, boolToFloat(connected),
+			nodeId, name, torqNode.PublicKey, implementation.String())
+
+		for _, channelState := range cache.GetChannelStates(torqNode.NodeId, true) {
+			channelId := strconv.Itoa(channelState.ChannelId)
+			shortChannelId := ""
+			channelSettings := cache.GetChannelSettingByChannelId(channelState.ChannelId)
+			if channelSettings.ShortChannelId != nil {
+				shortChannelId = *channelSettings.ShortChannelId
+			}
+			ch <- prometheus.MustNewConstMetric(channelLocalBalanceDesc, prometheus.GaugeValue,
+				float64(channelState.LocalBalance), nodeId, channelId, shortChannelId, strconv.Itoa(channelState.RemoteNodeId))
+			ch <- prometheus.MustNewConstMetric(channelRemoteBalanceDesc, prometheus.GaugeValue,
+				float64(channelState.RemoteBalance), nodeId, channelId, shortChannelId, strconv.Itoa(channelState.RemoteNodeId))
+			ch <- prometheus.MustNewConstMetric(channelPendingHtlcsDesc, prometheus.GaugeValue,
+				float64(channelState.PendingIncomingHtlcCount), nodeId, channelId, shortChannelId, "incoming")
+			ch <- prometheus.MustNewConstMetric(channelPendingHtlcsDesc, prometheus.GaugeValue,
+				float64(channelState.PendingOutgoingHtlcCount), nodeId, channelId, shortChannelId, "outgoing")
+			ch <- prometheus.MustNewConstMetric(channelPendingHtlcAmountDesc, prometheus.GaugeValue,
+				float64(channelState.PendingIncomingHtlcAmount), nodeId, channelId, shortChannelId, "incoming")
+			ch <- prometheus.MustNewConstMetric(channelPendingHtlcAmountDesc, prometheus.GaugeValue,
+				float64(channelState.PendingOutgoingHtlcAmount), nodeId, channelId, shortChannelId, "outgoing")
+			ch <- prometheus.MustNewConstMetric(channelDisabledDesc, prometheus.GaugeValue,
+				boolToFloat(channelState.LocalDisabled), nodeId, channelId, shortChannelId)
+		}
+	}
+}
+
+func collectServiceStates(ch chan<- prometheus.Metric, serviceTypes []services_helpers.ServiceType, nodeId int) {
+	for _, serviceType := range serviceTypes {
+		var currentState cache.ServiceState
+		var desiredState cache.ServiceState
+		if nodeId == 0 {
+			currentState = cache.GetCurrentCoreServiceState(serviceType)
+			desiredState = cache.GetDesiredCoreServiceState(serviceType)
+		} else {
+			currentState = cache.GetCurrentNodeServiceState(serviceType, nodeId)
+			desiredState = cache.GetDesiredNodeServiceState(serviceType, nodeId)
+		}
+		ch <- prometheus.MustNewConstMetric(serviceStatusDesc, prometheus.GaugeValue,
+			float64(currentState.Status), serviceType.String(), strconv.Itoa(nodeId))
+		ch <- prometheus.MustNewConstMetric(serviceDesiredStatusDesc, prometheus.GaugeValue,
+			float64(desiredState.Status), serviceType.String(), strconv.Itoa(nodeId))
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
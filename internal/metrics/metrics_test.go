@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+)
+
+func TestMetricsRoutes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cache.ServiceCacheHandler(cache.ServicesCacheChannel, ctx)
+	cache.InitStates(false)
+
+	AddForward(core.ForwardEvent{EventData: core.EventData{NodeId: 1}, FeeMsat: 1000, AmountOutMsat: 250000})
+	AddWorkflowNodeExecution(2, 3, errors.New("workflow node failed"))
+	// Collectors that are registered already are skipped
+	Register(forwardsTotal)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterMetricsRoutes(r, "torq", "metrics-password")
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		expected int
+	}{
+		{"No credentials", "", "", http.StatusUnauthorized},
+		{"Wrong password", "torq", "password", http.StatusUnauthorized},
+		{"Wrong username", "admin", "metrics-password", http.StatusUnauthorized},
+		{"Credentials", "torq", "metrics-password", http.StatusOK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if test.username != "" {
+				request.SetBasicAuth(test.username, test.password)
+			}
+			response := httptest.NewRecorder()
+			r.ServeHTTP(response, request)
+			if response.Code != test.expected {
+				t.Fatalf("GET /metrics = %v, expected %v", response.Code, test.expected)
+			}
+			if test.expected != http.StatusOK {
+				if strings.Contains(response.Body.String(), "torq_") {
+					t.Errorf("GET /metrics without valid credentials returned the metrics")
+				}
+				return
+			}
+			for _, metric := range []string{
+				`torq_forwards_total{node_id="1"} 1`,
+				`torq_forward_fees_msat_total{node_id="1"} 1000`,
+				`torq_forward_amount_msat_total{node_id="1"} 250000`,
+				`torq_workflow_node_executions_total{node_type="3",workflow_version_id="2"} 1`,
+				`torq_workflow_node_errors_total{node_type="3",workflow_version_id="2"} 1`,
+				`torq_service_status{node_id="0",service="RootService"}`,
+				`torq_service_desired_status{node_id="0",service="RootService"}`,
+				"go_goroutines",
+				"process_start_time_seconds",
+			} {
+				if !strings.Contains(response.Body.String(), metric) {
+					t.Errorf("GET /metrics is missing %v", metric)
+				}
+			}
+		})
+	}
+
+	// Without password the endpoint is public
+	r = gin.New()
+	RegisterMetricsRoutes(r, "", "")
+	response := httptest.NewRecorder()
+	r.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if response.Code != http.StatusOK {
+		t.Errorf("GET /metrics without password = %v, expected %v", response.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// RegisterMetricsRoutes serves the Prometheus exposition format on /metrics.
+// When a password is provided the endpoint is protected with basic authentication.
+func RegisterMetricsRoutes(r *gin.Engine, username string, password string) {
+	handlers := []gin.HandlerFunc{}
+	if password != "" {
+		handlers = append(handlers, gin.BasicAuth(gin.Accounts{username: password}))
+	}
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	handlers = append(handlers, func(c *gin.Context) { handler.ServeHTTP(c.Writer, c.Request) })
+	r.GET("/metrics", handlers...)
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/users"
 	"github.com/lncapital/torq/pkg/server_errors"
 )
 
@@ -33,19 +35,32 @@ type NodeWalletBalance struct {
 
 func RegisterNodeRoutes(r *gin.RouterGroup, db *sqlx.DB) {
 	r.GET("/:network/nodes", func(c *gin.Context) { getNodesByNetworkHandler(c, db) })
-	r.DELETE(":nodeId", func(c *gin.Context) { removeNodeHandler(c, db) })
+	r.GET("/:network/scores", func(c *gin.Context) { getPeerScoresHandler(c) })
+	// Removing a node removes its connection details so like changing them it's for admins only
+	r.DELETE(":nodeId", auth.RoleRequired(users.RoleAdmin), func(c *gin.Context) { removeNodeHandler(c, db) })
 }
 
 func getNodesByNetworkHandler(c *gin.Context, db *sqlx.DB) {
 	network, err := strconv.Atoi(c.Param("network"))
 	if err != nil {
 		server_errors.SendBadRequest(c, "Can't process network")
+		return
 	}
 	nds, err := getAllNodeInformationByNetwork(db, core.Network(network))
 	if err != nil {
 		server_errors.WrapLogAndSendServerError(c, err, "Getting nodes by network.")
 		return
 	}
+	// API tokens restricted to a node only see that node
+	if restrictedNodeId := auth.GetRestrictedNodeId(c); restrictedNodeId != nil {
+		nodeInformations := []NodeInformation{}
+		for _, nodeInformation := range nds {
+			if nodeInformation.NodeId == *restrictedNodeId {
+				nodeInformations = append(nodeInformations, nodeInformation)
+			}
+		}
+		nds = nodeInformations
+	}
 	c.JSON(http.StatusOK, nds)
 }
 
@@ -0,0 +1,62 @@
+package nodes
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/exp/slices"
+
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+type PeerScore struct {
+	NodeId    int                      `json:"nodeId"`
+	PublicKey string                   `json:"publicKey"`
+	Alias     string                   `json:"alias"`
+	Score     float64                  `json:"score"`
+	Breakdown cache.PeerScoreBreakdown `json:"breakdown"`
+	UpdatedOn time.Time                `json:"updatedOn"`
+}
+
+// getPeerScoresHandler returns the score report of all peers on the network ordered from best to worst.
+func getPeerScoresHandler(c *gin.Context) {
+	network, err := strconv.Atoi(c.Param("network"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Can't process network")
+		return
+	}
+	// API tokens restricted to a node only see the peers that have a channel with that node
+	var channelPeerNodeIds []int
+	restricted := auth.GetRestrictedNodeId(c) != nil
+	if restricted {
+		channelPeerNodeIds = cache.GetChannelPeerNodeIdsByTorqNodeIds(auth.RestrictNodeIds(c, cache.GetAllTorqNodeIds()))
+	}
+	peerScores := []PeerScore{}
+	for _, peerScore := range cache.GetPeerScores() {
+		nodeSettings := cache.GetNodeSettingsByNodeId(peerScore.NodeId)
+		if nodeSettings.Network != core.Network(network) {
+			continue
+		}
+		if restricted && !slices.Contains(channelPeerNodeIds, peerScore.NodeId) {
+			continue
+		}
+		peerScores = append(peerScores, PeerScore{
+			NodeId:    peerScore.NodeId,
+			PublicKey: nodeSettings.PublicKey,
+			Alias:     cache.GetNodeAlias(peerScore.NodeId),
+			Score:     peerScore.Score,
+			Breakdown: peerScore.Breakdown,
+			UpdatedOn: peerScore.UpdatedOn,
+		})
+	}
+	sort.Slice(peerScores, func(i, j int) bool {
+		return peerScores[i].Score > peerScores[j].Score
+	})
+	c.JSON(http.StatusOK, peerScores)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/jmoiron/sqlx"
 
 	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	qp "github.com/lncapital/torq/internal/query_parser"
@@ -96,7 +97,7 @@ func getOnChainTxsHandler(c *gin.Context, db *sqlx.DB) {
 
 	chain := core.Bitcoin
 
-	r, total, err := getOnChainTxs(db, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network)), filter, sort, limit, offset)
+	r, total, err := getOnChainTxs(db, auth.RestrictNodeIds(c, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network))), filter, sort, limit, offset)
 	if err != nil {
 		server_errors.LogAndSendServerError(c, err)
 		return
@@ -9,6 +9,7 @@ import (
 	"github.com/jmoiron/sqlx"
 
 	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	qp "github.com/lncapital/torq/internal/query_parser"
@@ -107,7 +108,7 @@ func getPaymentsHandler(c *gin.Context, db *sqlx.DB) {
 
 	chain := core.Bitcoin
 
-	r, total, err := getPayments(db, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network)), filter, sort, limit, offset)
+	r, total, err := GetPayments(db, auth.RestrictNodeIds(c, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network))), filter, sort, limit, offset)
 	if err != nil {
 		server_errors.LogAndSendServerError(c, err)
 		return
@@ -130,7 +131,7 @@ func getPaymentHandler(c *gin.Context, db *sqlx.DB) {
 
 	chain := core.Bitcoin
 
-	r, err := getPaymentDetails(db, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network)), c.Param("identifier"))
+	r, err := getPaymentDetails(db, auth.RestrictNodeIds(c, cache.GetAllTorqNodeIdsByNetwork(chain, core.Network(network))), c.Param("identifier"))
 	switch err.(type) {
 	case nil:
 		break
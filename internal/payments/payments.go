@@ -63,7 +63,7 @@ type PaymentDetails struct {
 	FailedRoutes     []*Route `json:"failedRoutes" db:"failed_routes"`
 }
 
-func getPayments(db *sqlx.DB, nodeIds []int, filter sq.Sqlizer, order []string,
+func GetPayments(db *sqlx.DB, nodeIds []int, filter sq.Sqlizer, order []string,
 	limit uint64, offset uint64) (r []*Payment, total uint64, err error) {
 
 	var publicKeys []string
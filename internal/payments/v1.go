@@ -0,0 +1,103 @@
+package payments
+
+import (
+	"net/http"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+func GetV1Endpoints(db *sqlx.DB) []ah.Endpoint {
+	return []ah.Endpoint{
+		{
+			Method:   http.MethodGet,
+			Path:     "/payments",
+			Resource: "payments",
+			Summary:  "List payments, the most recent first",
+			Parameters: []ah.Parameter{
+				{Name: "from", Description: "Created on or after", Type: "string", Format: "date-time"},
+				{Name: "to", Description: "Created before", Type: "string", Format: "date-time"},
+				{Name: "status", Description: "i.e. SUCCEEDED, FAILED or IN_FLIGHT", Type: "string"},
+				ah.NodeIdParameter,
+			},
+			Response:  Payment{},
+			Paginated: true,
+			Handler:   func(c *gin.Context) { getV1PaymentsHandler(c, db) },
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/payments/:identifier",
+			Resource:   "payments",
+			Summary:    "Get a payment with its routes by payment hash, preimage or request",
+			Parameters: []ah.Parameter{ah.NodeIdParameter},
+			Response:   PaymentDetails{},
+			Handler:    func(c *gin.Context) { getV1PaymentHandler(c, db) },
+		},
+	}
+}
+
+func getV1PaymentsHandler(c *gin.Context, db *sqlx.DB) {
+	pagination, err := ah.GetPagination(c)
+	if err != nil {
+		server_errors.SendBadRequestFromError(c, err)
+		return
+	}
+	nodeIds, err := auth.GetNodeIds(c)
+	if err != nil {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", err.Error()))
+		return
+	}
+	filter := sq.And{}
+	if c.Query("from") != "" {
+		from, err := time.Parse(time.RFC3339, c.Query("from"))
+		if err != nil {
+			server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("from", "Invalid date-time"))
+			return
+		}
+		filter = append(filter, sq.GtOrEq{"date": from})
+	}
+	if c.Query("to") != "" {
+		to, err := time.Parse(time.RFC3339, c.Query("to"))
+		if err != nil {
+			server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("to", "Invalid date-time"))
+			return
+		}
+		filter = append(filter, sq.Lt{"date": to})
+	}
+	if c.Query("status") != "" {
+		filter = append(filter, sq.Eq{"status": c.Query("status")})
+	}
+	r, total, err := GetPayments(db, nodeIds, filter, []string{"date DESC"}, pagination.Limit, pagination.Offset)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting payments.")
+		return
+	}
+	pagination.Total = total
+	c.JSON(http.StatusOK, ah.ApiResponse{Data: r, Pagination: pagination})
+}
+
+func getV1PaymentHandler(c *gin.Context, db *sqlx.DB) {
+	nodeIds, err := auth.GetNodeIds(c)
+	if err != nil {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", err.Error()))
+		return
+	}
+	r, err := getPaymentDetails(db, nodeIds, c.Param("identifier"))
+	switch err.(type) {
+	case nil:
+		break
+	case ErrPaymentNotFound:
+		server_errors.SendNotFound(c, err.Error())
+		return
+	default:
+		server_errors.WrapLogAndSendServerError(c, err, "Getting payment.")
+		return
+	}
+	c.JSON(http.StatusOK, r)
+}
@@ -0,0 +1,195 @@
+package peer_scores
+
+import (
+	"math"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/database"
+)
+
+// Peers are scored based on the last 30 days (except for force closes which are counted since the start)
+const peerScoreLookback = 30 * 24 * time.Hour
+
+// Weights of the component scores in the composite score
+const (
+	uptimeWeight         = 0.25
+	forwardSuccessWeight = 0.25
+	revenueWeight        = 0.20
+	forceCloseWeight     = 0.15
+	feeStabilityWeight   = 0.15
+)
+
+// Every force close halves the force close score
+const forceClosePenaltyFactor = 0.5
+
+// The fee stability score halves when the peer changes its fee policy this many times per channel
+const feePolicyChangesHalfScore = 4
+
+type peerMetrics struct {
+	NodeId                     int      `db:"node_id"`
+	Uptime                     *float64 `db:"uptime"`
+	ForwardSuccessCount        int64    `db:"forward_success_count"`
+	ForwardFailureCount        int64    `db:"forward_failure_count"`
+	Revenue                    int64    `db:"revenue"`
+	ForceCloseCount            int      `db:"force_close_count"`
+	FeePolicyChangesPerChannel float64  `db:"fee_policy_changes_per_channel"`
+}
+
+// RefreshPeerScoreCache calculates the scores of all peers of the torq nodes and stores them in the cache.
+func RefreshPeerScoreCache(db *sqlx.DB) error {
+	torqNodeIds := cache.GetAllTorqNodeIds()
+	if len(torqNodeIds) == 0 {
+		cache.SetPeerScores(nil)
+		return nil
+	}
+	metrics, err := getPeerMetrics(db, torqNodeIds, time.Now().Add(-peerScoreLookback))
+	if err != nil {
+		return errors.Wrap(err, "Getting peer metrics")
+	}
+	cache.SetPeerScores(calculatePeerScores(metrics, time.Now()))
+	return nil
+}
+
+func getPeerMetrics(db *sqlx.DB, torqNodeIds []int, from time.Time) ([]peerMetrics, error) {
+	var metrics []peerMetrics
+	err := db.Select(&metrics, `
+		WITH peer_channel AS (
+			SELECT channel_id,
+				CASE WHEN first_node_id = ANY($2) THEN second_node_id ELSE first_node_id END AS node_id,
+				status_id
+			FROM channel
+			WHERE first_node_id = ANY($2) OR second_node_id = ANY($2)
+		),
+		uptime AS (
+			SELECT node_id,
+				sum(CASE WHEN connection_status = $3
+					THEN EXTRACT(EPOCH FROM (coalesce(next_created_on, now()) - greatest(created_on, $1)))
+					ELSE 0 END)
+				/ nullif(sum(EXTRACT(EPOCH FROM (coalesce(next_created_on, now()) - greatest(created_on, $1)))), 0)
+					AS uptime
+			FROM (
+				SELECT node_id, created_on, connection_status,
+					lead(created_on) OVER (PARTITION BY torq_node_id, node_id ORDER BY created_on) AS next_created_on
+				FROM node_connection_history
+				WHERE torq_node_id = ANY($2)
+			) AS h
+			WHERE coalesce(next_created_on, now()) > $1
+			GROUP BY node_id
+		),
+		htlc AS (
+			SELECT pc.node_id,
+				count(*) FILTER (WHERE he.event_type = 'SettleEvent') AS forward_success_count,
+				count(*) FILTER (WHERE he.event_type IN ('ForwardFailEvent', 'LinkFailEvent')) AS forward_failure_count
+			FROM htlc_event he
+			JOIN peer_channel pc ON pc.channel_id = he.outgoing_channel_id
+			WHERE he.time >= $1 AND he.event_origin = 'FORWARD' AND he.node_id = ANY($2)
+			GROUP BY pc.node_id
+		),
+		revenue AS (
+			SELECT pc.node_id, floor(sum(f.fee_msat)/1000)::bigint AS revenue
+			FROM forward f
+			JOIN peer_channel pc ON pc.channel_id = f.outgoing_channel_id
+			WHERE f.time >= $1 AND f.node_id = ANY($2)
+			GROUP BY pc.node_id
+		),
+		force_close AS (
+			SELECT node_id, count(*) AS force_close_count
+			FROM peer_channel
+			WHERE status_id IN ($4, $5, $6)
+			GROUP BY node_id
+		),
+		fee_policy AS (
+			SELECT announcing_node_id AS node_id,
+				count(*)::numeric / count(DISTINCT channel_id) AS fee_policy_changes_per_channel
+			FROM routing_policy
+			WHERE ts >= $1 AND node_id = ANY($2) AND NOT (announcing_node_id = ANY($2))
+			GROUP BY announcing_node_id
+		)
+		SELECT p.node_id,
+			u.uptime,
+			coalesce(h.forward_success_count, 0) AS forward_success_count,
+			coalesce(h.forward_failure_count, 0) AS forward_failure_count,
+			coalesce(r.revenue, 0) AS revenue,
+			coalesce(fc.force_close_count, 0) AS force_close_count,
+			coalesce(fp.fee_policy_changes_per_channel, 0) AS fee_policy_changes_per_channel
+		FROM (SELECT DISTINCT node_id FROM peer_channel WHERE NOT (node_id = ANY($2))) AS p
+		LEFT JOIN uptime u ON u.node_id = p.node_id
+		LEFT JOIN htlc h ON h.node_id = p.node_id
+		LEFT JOIN revenue r ON r.node_id = p.node_id
+		LEFT JOIN force_close fc ON fc.node_id = p.node_id
+		LEFT JOIN fee_policy fp ON fp.node_id = p.node_id;`,
+		from, pq.Array(torqNodeIds), core.NodeConnectionStatusConnected,
+		core.LocalForceClosed, core.RemoteForceClosed, core.BreachClosed)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return metrics, nil
+}
+
+// calculatePeerScores turns the raw metrics into component scores and a weighted composite score.
+// Components without data are left out of the composite score.
+// The revenue score is relative to the peer with the highest revenue.
+func calculatePeerScores(metrics []peerMetrics, updatedOn time.Time) []cache.PeerScoreSettingsCache {
+	var maxRevenue int64
+	for _, metric := range metrics {
+		if metric.Revenue > maxRevenue {
+			maxRevenue = metric.Revenue
+		}
+	}
+
+	peerScores := make([]cache.PeerScoreSettingsCache, 0, len(metrics))
+	for _, metric := range metrics {
+		breakdown := cache.PeerScoreBreakdown{
+			Uptime:                     metric.Uptime,
+			ForwardSuccessCount:        metric.ForwardSuccessCount,
+			ForwardFailureCount:        metric.ForwardFailureCount,
+			Revenue:                    metric.Revenue,
+			ForceCloseCount:            metric.ForceCloseCount,
+			FeePolicyChangesPerChannel: metric.FeePolicyChangesPerChannel,
+			ForceCloseScore:            100 * math.Pow(forceClosePenaltyFactor, float64(metric.ForceCloseCount)),
+			FeeStabilityScore:          100 / (1 + metric.FeePolicyChangesPerChannel/feePolicyChangesHalfScore),
+		}
+		if metric.Uptime != nil {
+			uptimeScore := 100 * *metric.Uptime
+			breakdown.UptimeScore = &uptimeScore
+		}
+		if metric.ForwardSuccessCount+metric.ForwardFailureCount != 0 {
+			forwardSuccessScore := 100 * float64(metric.ForwardSuccessCount) /
+				float64(metric.ForwardSuccessCount+metric.ForwardFailureCount)
+			breakdown.ForwardSuccessScore = &forwardSuccessScore
+		}
+		if maxRevenue != 0 {
+			revenueScore := 100 * float64(metric.Revenue) / float64(maxRevenue)
+			breakdown.RevenueScore = &revenueScore
+		}
+
+		weightedScore := forceCloseWeight*breakdown.ForceCloseScore + feeStabilityWeight*breakdown.FeeStabilityScore
+		totalWeight := forceCloseWeight + feeStabilityWeight
+		if breakdown.UptimeScore != nil {
+			weightedScore += uptimeWeight * *breakdown.UptimeScore
+			totalWeight += uptimeWeight
+		}
+		if breakdown.ForwardSuccessScore != nil {
+			weightedScore += forwardSuccessWeight * *breakdown.ForwardSuccessScore
+			totalWeight += forwardSuccessWeight
+		}
+		if breakdown.RevenueScore != nil {
+			weightedScore += revenueWeight * *breakdown.RevenueScore
+			totalWeight += revenueWeight
+		}
+
+		peerScores = append(peerScores, cache.PeerScoreSettingsCache{
+			NodeId:    metric.NodeId,
+			Score:     weightedScore / totalWeight,
+			Breakdown: breakdown,
+			UpdatedOn: updatedOn,
+		})
+	}
+	return peerScores
+}
@@ -0,0 +1,62 @@
+package peer_scores
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCalculatePeerScores(t *testing.T) {
+	fullUptime := 1.0
+	halfUptime := 0.5
+	metrics := []peerMetrics{
+		{
+			NodeId:              1,
+			Uptime:              &fullUptime,
+			ForwardSuccessCount: 10,
+			Revenue:             1000,
+		},
+		{
+			NodeId:                     2,
+			Uptime:                     &halfUptime,
+			ForwardSuccessCount:        5,
+			ForwardFailureCount:        5,
+			Revenue:                    500,
+			ForceCloseCount:            1,
+			FeePolicyChangesPerChannel: 4,
+		},
+		{
+			NodeId: 3,
+		},
+	}
+
+	peerScores := calculatePeerScores(metrics, time.Now())
+	if len(peerScores) != 3 {
+		t.Fatalf("Expected 3 peer scores, got %v", len(peerScores))
+	}
+
+	testCases := []struct {
+		name  string
+		index int
+		want  float64
+	}{
+		{"perfect peer", 0, 100},
+		// (0.25*50 + 0.25*50 + 0.20*50 + 0.15*50 + 0.15*50) / 1
+		{"average peer", 1, 50},
+		// Only the revenue (relative to the best peer), force close and fee stability components have data
+		// (0.20*0 + 0.15*100 + 0.15*100) / 0.5
+		{"peer without data", 2, 60},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := peerScores[tc.index].Score
+			if math.Abs(got-tc.want) > 0.0001 {
+				t.Errorf("Score got %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if peerScores[2].Breakdown.UptimeScore != nil || peerScores[2].Breakdown.ForwardSuccessScore != nil {
+		t.Errorf("Expected no uptime nor forward success score for a peer without data")
+	}
+}
@@ -30,6 +30,8 @@ type PeerNode struct {
 	DateLastDisconnected *time.Time                  `json:"dateLastDisconnected" db:"date_last_disconnected"`
 	DateLastConnected    *time.Time                  `json:"dateLastConnected" db:"date_last_connected"`
 	Tags                 []tags.Tag                  `json:"tags"`
+	Score                *float64                    `json:"score"`
+	ScoreBreakdown       *cache.PeerScoreBreakdown   `json:"scoreBreakdown"`
 }
 
 func (p PeerNode) MarshalJSON() ([]byte, error) {
@@ -1,6 +1,7 @@
 package peers
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/lightning"
@@ -64,16 +66,42 @@ func getAllPeersHandler(c *gin.Context, db *sqlx.DB) {
 		server_errors.SendBadRequest(c, "Can't process network")
 		return
 	}
-	peerNodes, err := GetPeerNodes(db, core.Network(network))
+	var peerNodes []PeerNode
+	if auth.GetRestrictedNodeId(c) == nil {
+		peerNodes, err = GetPeerNodes(db, core.Network(network))
+	} else {
+		// API tokens restricted to a node only see the peers of that node
+		torqNodeIds := getNetworkNodeIds(auth.RestrictNodeIds(c, cache.GetAllTorqNodeIds()), core.Network(network))
+		peerNodes, err = getPeerNodesOfTorqNodes(db, torqNodeIds)
+	}
 	if err != nil {
 		server_errors.WrapLogAndSendServerError(c, err, "Getting all Peer nodes.")
 		return
 	}
+	setPeerTagsAndScores(peerNodes)
+
+	c.JSON(http.StatusOK, peerNodes)
+}
+
+func getNetworkNodeIds(torqNodeIds []int, network core.Network) []int {
+	var networkNodeIds []int
+	for _, torqNodeId := range torqNodeIds {
+		if cache.GetNodeSettingsByNodeId(torqNodeId).Network == network {
+			networkNodeIds = append(networkNodeIds, torqNodeId)
+		}
+	}
+	return networkNodeIds
+}
+
+func setPeerTagsAndScores(peerNodes []PeerNode) {
 	for peerIndex, peer := range peerNodes {
 		peerNodes[peerIndex].Tags = tags.GetTagsByTagIds(cache.GetTagIdsByNodeId(peer.NodeId))
+		peerScore := cache.GetPeerScore(peer.NodeId)
+		if peerScore != nil {
+			peerNodes[peerIndex].Score = &peerScore.Score
+			peerNodes[peerIndex].ScoreBreakdown = &peerScore.Breakdown
+		}
 	}
-
-	c.JSON(http.StatusOK, peerNodes)
 }
 
 func connectNewPeerHandler(c *gin.Context, db *sqlx.DB) {
@@ -94,7 +122,7 @@ func connectNewPeerHandler(c *gin.Context, db *sqlx.DB) {
 	pubKey := s[0]
 	host := s[1]
 
-	_, err := lightning.ConnectPeer(req.NodeId, pubKey, host)
+	_, err := lightning.ConnectPeer(c.Request.Context(), req.NodeId, pubKey, host)
 	if err != nil {
 		server_errors.WrapLogAndSendServerError(c, err, "Error connecting to peer.")
 		return
@@ -130,7 +158,7 @@ func disconnectPeerHandler(c *gin.Context, db *sqlx.DB) {
 	}
 
 	if address == nil || *address == "" {
-		host, err := getHostFromPeer(req.TorqNodeId, req.NodeId)
+		host, err := getHostFromPeer(c.Request.Context(), req.TorqNodeId, req.NodeId)
 		if err != nil {
 			server_errors.WrapLogAndSendServerError(c, err, "Getting host from peer.")
 			return
@@ -139,7 +167,7 @@ func disconnectPeerHandler(c *gin.Context, db *sqlx.DB) {
 	}
 
 	disconnected := core.NodeConnectionStatusDisconnected
-	requestFailedCurrentlyDisconnected, err := lightning.DisconnectPeer(req.TorqNodeId, req.NodeId)
+	requestFailedCurrentlyDisconnected, err := lightning.DisconnectPeer(c.Request.Context(), req.TorqNodeId, req.NodeId)
 	if err != nil {
 		if requestFailedCurrentlyDisconnected {
 			err = settings.AddNodeConnectionHistory(db, req.TorqNodeId, req.NodeId, address, setting, &disconnected)
@@ -175,7 +203,7 @@ func reconnectPeerHandler(c *gin.Context, db *sqlx.DB) {
 	}
 
 	if address == nil || *address == "" {
-		host, err := getHostFromPeer(req.TorqNodeId, req.NodeId)
+		host, err := getHostFromPeer(c.Request.Context(), req.TorqNodeId, req.NodeId)
 		if err != nil {
 			server_errors.WrapLogAndSendServerError(c, err, "Getting host from peer.")
 			return
@@ -185,7 +213,7 @@ func reconnectPeerHandler(c *gin.Context, db *sqlx.DB) {
 
 	connected := core.NodeConnectionStatusConnected
 	publicKey := cache.GetNodeSettingsByNodeId(req.NodeId).PublicKey
-	requestFailCurrentlyConnected, err := lightning.ConnectPeer(req.TorqNodeId, publicKey, *address)
+	requestFailCurrentlyConnected, err := lightning.ConnectPeer(c.Request.Context(), req.TorqNodeId, publicKey, *address)
 	if err != nil {
 		if requestFailCurrentlyConnected {
 			err = settings.AddNodeConnectionHistory(db, req.TorqNodeId, req.NodeId, address, setting, &connected)
@@ -236,7 +264,7 @@ func updatePeer(c *gin.Context, db *sqlx.DB) {
 	}
 
 	if address == nil || *address == "" {
-		host, err := getHostFromPeer(req.TorqNodeId, req.NodeId)
+		host, err := getHostFromPeer(c.Request.Context(), req.TorqNodeId, req.NodeId)
 		if err != nil {
 			server_errors.WrapLogAndSendServerError(c, err, "Getting host from peer.")
 			return
@@ -252,9 +280,9 @@ func updatePeer(c *gin.Context, db *sqlx.DB) {
 
 }
 
-func getHostFromPeer(connectionDetailsNodeId int, nodeId int) (string, error) {
+func getHostFromPeer(ctx context.Context, connectionDetailsNodeId int, nodeId int) (string, error) {
 	nodeSettings := cache.GetNodeSettingsByNodeId(nodeId)
-	peers, err := lightning.ListPeers(connectionDetailsNodeId, true)
+	peers, err := lightning.ListPeers(ctx, connectionDetailsNodeId, true)
 	if err != nil {
 		return "", errors.Wrap(err, "Getting list of peers.")
 	}
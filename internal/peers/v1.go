@@ -0,0 +1,85 @@
+package peers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/exp/slices"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+func GetV1Endpoints(db *sqlx.DB) []ah.Endpoint {
+	return []ah.Endpoint{
+		{
+			Method:      http.MethodGet,
+			Path:        "/peers",
+			Resource:    "peers",
+			Summary:     "List the peers of the nodes",
+			Description: "Peers that are connected to or have a channel with one of the nodes.",
+			Parameters:  []ah.Parameter{ah.NodeIdParameter},
+			Response:    PeerNode{},
+			Paginated:   true,
+			Handler:     func(c *gin.Context) { getV1PeersHandler(c, db) },
+		},
+	}
+}
+
+func getV1PeersHandler(c *gin.Context, db *sqlx.DB) {
+	pagination, err := ah.GetPagination(c)
+	if err != nil {
+		server_errors.SendBadRequestFromError(c, err)
+		return
+	}
+	nodeIds, err := auth.GetNodeIds(c)
+	if err != nil {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", err.Error()))
+		return
+	}
+	peerNodes, err := getPeerNodesOfTorqNodes(db, nodeIds)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting peer nodes.")
+		return
+	}
+	start, end := pagination.GetPage(len(peerNodes))
+	peerNodes = peerNodes[start:end]
+	setPeerTagsAndScores(peerNodes)
+	c.JSON(http.StatusOK, ah.ApiResponse{Data: peerNodes, Pagination: pagination})
+}
+
+// getPeerNodesOfTorqNodes returns the peers (of all networks) that are connected to or have a channel with
+// one of the Torq nodes
+func getPeerNodesOfTorqNodes(db *sqlx.DB, torqNodeIds []int) ([]PeerNode, error) {
+	channelPeerNodeIds := make(map[int]bool)
+	var networks []core.Network
+	for _, torqNodeId := range torqNodeIds {
+		network := cache.GetNodeSettingsByNodeId(torqNodeId).Network
+		if !slices.Contains(networks, network) {
+			networks = append(networks, network)
+		}
+		for _, channelId := range cache.GetChannelStateChannelIds(torqNodeId, true) {
+			channelSettings := cache.GetChannelSettingByChannelId(channelId)
+			channelPeerNodeIds[channelSettings.FirstNodeId] = true
+			channelPeerNodeIds[channelSettings.SecondNodeId] = true
+		}
+	}
+	var peerNodes []PeerNode
+	for _, network := range networks {
+		networkPeerNodes, err := GetPeerNodes(db, network)
+		if err != nil {
+			return nil, err
+		}
+		for _, peerNode := range networkPeerNodes {
+			if channelPeerNodeIds[peerNode.NodeId] ||
+				(peerNode.TorqNodeId != nil && slices.Contains(torqNodeIds, *peerNode.TorqNodeId)) {
+				peerNodes = append(peerNodes, peerNode)
+			}
+		}
+	}
+	return peerNodes, nil
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/build"
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/internal/leader_election"
+	"github.com/lncapital/torq/internal/services_helpers"
+)
+
+// The services cache and the database should answer well within the timeout of the probes
+const healthCheckTimeout = 2 * time.Second
+
+type HealthCheck struct {
+	Ok      bool   `json:"ok"`
+	Message string `json:"message,omitempty"`
+}
+
+type Health struct {
+	Status string                 `json:"status"`
+	Checks map[string]HealthCheck `json:"checks"`
+}
+
+type ServiceStatus struct {
+	Type            services_helpers.ServiceType   `json:"type"`
+	TypeString      string                         `json:"typeString"`
+	Status          services_helpers.ServiceStatus `json:"status"`
+	StatusString    string                         `json:"statusString"`
+	DesiredStatus   string                         `json:"desiredStatus"`
+	ActiveTime      *time.Time                     `json:"activeTime,omitempty"`
+	LastFailureTime *time.Time                     `json:"lastFailureTime,omitempty"`
+	FailureCount    int                            `json:"failureCount"`
+}
+
+type NodeStatus struct {
+	NodeId         int                  `json:"nodeId"`
+	Implementation string               `json:"implementation"`
+	Network        string               `json:"network"`
+	Services       []ServiceStatus      `json:"services"`
+	SuccessTimes   map[string]time.Time `json:"successTimes"`
+}
+
+type Status struct {
+	Version      string          `json:"version"`
+	Status       string          `json:"status"`
+	BootTime     *time.Time      `json:"bootTime,omitempty"`
+	CoreServices []ServiceStatus `json:"coreServices"`
+	Nodes        []NodeStatus    `json:"nodes"`
+	// Only the leader runs the core and node services when high availability is enabled
+	HighAvailability leader_election.State `json:"highAvailability"`
+}
+
+// RegisterHealthRoutes registers the unauthenticated probes for orchestration (i.e. Kubernetes)
+func RegisterHealthRoutes(r gin.IRoutes, db *sqlx.DB) {
+	r.GET("/healthz", getHealthHandler)
+	r.GET("/readyz", func(c *gin.Context) { getReadinessHandler(c, db) })
+	r.GET("/status", getStatusHandler)
+}
+
+// getHealthHandler answers when the process is alive, the services cache must respond as everything depends on it
+func getHealthHandler(c *gin.Context) {
+	check := checkServicesCache()
+	sendHealth(c, map[string]HealthCheck{"servicesCache": check})
+}
+
+// getReadinessHandler answers when Torq can serve requests
+func getReadinessHandler(c *gin.Context, db *sqlx.DB) {
+	checks := map[string]HealthCheck{"servicesCache": checkServicesCache()}
+	if checks["servicesCache"].Ok {
+		checks["rootService"] = checkRootService()
+	}
+	checks["database"] = checkDatabase(c.Request.Context(), db)
+	if checks["database"].Ok {
+		checks["migrations"] = checkMigrations(db)
+	}
+	sendHealth(c, checks)
+}
+
+func sendHealth(c *gin.Context, checks map[string]HealthCheck) {
+	for _, check := range checks {
+		if !check.Ok {
+			c.JSON(http.StatusServiceUnavailable, Health{Status: "unavailable", Checks: checks})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, Health{Status: "ok", Checks: checks})
+}
+
+func checkServicesCache() HealthCheck {
+	responded := make(chan struct{})
+	go func() {
+		cache.GetCurrentCoreServiceState(services_helpers.RootService)
+		close(responded)
+	}()
+	select {
+	case <-responded:
+		return HealthCheck{Ok: true}
+	case <-time.After(healthCheckTimeout):
+		return HealthCheck{Message: "The services cache is not responding"}
+	}
+}
+
+func checkRootService() HealthCheck {
+	rootService := cache.GetCurrentCoreServiceState(services_helpers.RootService)
+	if rootService.Status != services_helpers.Active {
+		return HealthCheck{Message: "The root service is " + rootService.Status.String()}
+	}
+	return HealthCheck{Ok: true}
+}
+
+func checkDatabase(ctx context.Context, db *sqlx.DB) HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	err := db.PingContext(ctx)
+	if err != nil {
+		return HealthCheck{Message: "The database is not reachable: " + err.Error()}
+	}
+	return HealthCheck{Ok: true}
+}
+
+func checkMigrations(db *sqlx.DB) HealthCheck {
+	version, err := database.GetMigrationVersion(db)
+	if err != nil {
+		return HealthCheck{Message: err.Error()}
+	}
+	latestVersion, err := database.GetLatestMigrationVersion()
+	if err != nil {
+		return HealthCheck{Message: err.Error()}
+	}
+	if version != latestVersion {
+		return HealthCheck{Message: fmt.Sprintf("The database is at migration %v instead of %v", version, latestVersion)}
+	}
+	return HealthCheck{Ok: true}
+}
+
+// getStatusHandler returns the state of every service, it responds with 503 until the root service is active
+func getStatusHandler(c *gin.Context) {
+	check := checkServicesCache()
+	if !check.Ok {
+		c.JSON(http.StatusServiceUnavailable, Health{Status: "unavailable",
+			Checks: map[string]HealthCheck{"servicesCache": check}})
+		return
+	}
+	rootService := cache.GetCurrentCoreServiceState(services_helpers.RootService)
+	result := Status{
+		Version:  build.ExtendedVersion(),
+		Status:   rootService.Status.String(),
+		BootTime: rootService.ActiveTime,
+		Nodes:    []NodeStatus{},
+
+		HighAvailability: leader_election.GetState(),
+	}
+	for _, serviceType := range services_helpers.GetCoreServiceTypes() {
+		result.CoreServices = append(result.CoreServices, getServiceStatus(serviceType,
+			cache.GetCurrentCoreServiceState(serviceType), cache.GetDesiredCoreServiceState(serviceType)))
+	}
+	result.Nodes = append(result.Nodes,
+		getNodeStatuses(core.LND, cache.GetLndNodeIds(), services_helpers.GetLndServiceTypes())...)
+	result.Nodes = append(result.Nodes,
+		getNodeStatuses(core.CLN, cache.GetClnNodeIds(), services_helpers.GetClnServiceTypes())...)
+	sort.Slice(result.Nodes, func(i, j int) bool { return result.Nodes[i].NodeId < result.Nodes[j].NodeId })
+
+	if rootService.Status != services_helpers.Active {
+		c.JSON(http.StatusServiceUnavailable, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func getNodeStatuses(implementation core.Implementation, nodeIds []int,
+	serviceTypes []services_helpers.ServiceType) []NodeStatus {
+
+	var nodes []NodeStatus
+	for _, nodeId := range nodeIds {
+		node := NodeStatus{
+			NodeId:         nodeId,
+			Implementation: implementation.String(),
+			Network:        cache.GetNodeSettingsByNodeId(nodeId).Network.String(),
+			SuccessTimes:   make(map[string]time.Time),
+		}
+		for _, serviceType := range serviceTypes {
+			node.Services = append(node.Services, getServiceStatus(serviceType,
+				cache.GetCurrentNodeServiceState(serviceType, nodeId),
+				cache.GetDesiredNodeServiceState(serviceType, nodeId)))
+		}
+		for importType, successTime := range cache.GetSuccessTimes(nodeId) {
+			node.SuccessTimes[importType.String()] = successTime
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func getServiceStatus(serviceType services_helpers.ServiceType,
+	state cache.ServiceState, desiredState cache.ServiceState) ServiceStatus {
+
+	return ServiceStatus{
+		Type:            serviceType,
+		TypeString:      serviceType.String(),
+		Status:          state.Status,
+		StatusString:    state.Status.String(),
+		DesiredStatus:   desiredState.Status.String(),
+		ActiveTime:      state.ActiveTime,
+		LastFailureTime: state.LastFailureTime,
+		FailureCount:    state.FailureCount,
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/services_helpers"
+)
+
+func TestHealthAndStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/healthz", getHealthHandler)
+	r.GET("/status", getStatusHandler)
+
+	// Without the services cache nothing answers
+	response := get(t, r, "/healthz")
+	if response.Code != http.StatusServiceUnavailable {
+		t.Fatalf("/healthz without services cache = %v, expected %v", response.Code, http.StatusServiceUnavailable)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cache.ServiceCacheHandler(cache.ServicesCacheChannel, ctx)
+	cache.InitStates(false)
+
+	response = get(t, r, "/healthz")
+	if response.Code != http.StatusOK {
+		t.Errorf("/healthz = %v, expected %v", response.Code, http.StatusOK)
+	}
+
+	response = get(t, r, "/status")
+	if response.Code != http.StatusServiceUnavailable {
+		t.Errorf("/status while initializing = %v, expected %v", response.Code, http.StatusServiceUnavailable)
+	}
+
+	cache.InitRootService(cancel)
+	cache.SetActiveCoreServiceState(services_helpers.RootService)
+	cache.SetFailedCoreServiceState(services_helpers.MaintenanceService)
+	cache.SetFailedCoreServiceState(services_helpers.MaintenanceService)
+	cache.SetActiveCoreServiceState(services_helpers.MaintenanceService)
+
+	response = get(t, r, "/status")
+	if response.Code != http.StatusOK {
+		t.Fatalf("/status = %v, expected %v", response.Code, http.StatusOK)
+	}
+	var status Status
+	err := json.Unmarshal(response.Body.Bytes(), &status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	active := services_helpers.Active
+	if status.Status != active.String() || status.BootTime == nil {
+		t.Errorf("/status root service = %v (%v), expected active", status.Status, status.BootTime)
+	}
+	for _, service := range status.CoreServices {
+		if service.Type != services_helpers.MaintenanceService {
+			continue
+		}
+		if service.Status != services_helpers.Active || service.FailureCount != 2 || service.LastFailureTime == nil {
+			t.Errorf("/status maintenance service = %+v, expected active after 2 failures", service)
+		}
+	}
+}
+
+func get(t *testing.T, r http.Handler, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	response := httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	return response
+}
@@ -0,0 +1,152 @@
+package settings
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+)
+
+// Rotated files are usually replaced (i.e. a Kubernetes secret swaps a symlink) so the content is compared
+const credentialsWatcherInterval = 10 * time.Second
+
+// NodeCredentialPaths are the credential files on disk of a node configured in the config
+type NodeCredentialPaths struct {
+	Implementation core.Implementation
+	GRPCAddress    string
+	// LND tls certificate or CLN client certificate
+	CertificatePath string
+	// LND macaroon or CLN client key
+	AuthenticationPath string
+	// CLN only
+	CaCertificatePath string
+}
+
+func (paths NodeCredentialPaths) getPaths() []string {
+	if paths.Implementation == core.CLN {
+		return []string{paths.CertificatePath, paths.AuthenticationPath, paths.CaCertificatePath}
+	}
+	return []string{paths.CertificatePath, paths.AuthenticationPath}
+}
+
+type watchedCredentials struct {
+	paths NodeCredentialPaths
+	// The content of the files when they were last applied
+	files [][]byte
+}
+
+// getChangedFiles returns the content of all files when one of them changed, a file that can't be read (i.e. in the
+// middle of a rotation) is an error and the previous content is kept so the change is picked up on the next check.
+func (wc *watchedCredentials) getChangedFiles() ([][]byte, error) {
+	var files [][]byte
+	changed := false
+	for i, path := range wc.paths.getPaths() {
+		file, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Reading %v", path)
+		}
+		if len(file) == 0 {
+			return nil, errors.Newf("%v is empty", path)
+		}
+		if i >= len(wc.files) || !bytes.Equal(wc.files[i], file) {
+			changed = true
+		}
+		files = append(files, file)
+	}
+	if !changed {
+		return nil, nil
+	}
+	return files, nil
+}
+
+// StartCredentialsWatcher checks the credential files of the configured nodes until the context is cancelled. When
+// the files of a node change the node connection details are updated and the services of the node are restarted.
+// Only the leader (isLeader) updates the node connection details, a follower picks up the changes when it's elected.
+func StartCredentialsWatcher(ctx context.Context, db *sqlx.DB, nodes []NodeCredentialPaths, isLeader func() bool) {
+	if len(nodes) == 0 {
+		return
+	}
+	var watched []*watchedCredentials
+	for _, paths := range nodes {
+		wc := &watchedCredentials{paths: paths}
+		// The files were just read to add or update the node
+		wc.files, _ = wc.getChangedFiles()
+		watched = append(watched, wc)
+	}
+	log.Info().Msgf("Watching the credential files of %v configured node(s)", len(watched))
+
+	ticker := time.NewTicker(credentialsWatcherInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if isLeader() {
+				checkWatchedCredentials(db, watched)
+			}
+		}
+	}
+}
+
+func checkWatchedCredentials(db *sqlx.DB, watched []*watchedCredentials) {
+	for _, wc := range watched {
+		files, err := wc.getChangedFiles()
+		if err != nil {
+			log.Warn().Err(err).Msgf("Checking the credential files of node %v (will retry in %v)",
+				wc.paths.GRPCAddress, credentialsWatcherInterval)
+			continue
+		}
+		if files == nil {
+			continue
+		}
+		err = updateNodeCredentials(db, wc.paths, files)
+		if err != nil {
+			log.Error().Err(err).Msgf("Updating the rotated credentials of node %v (will retry in %v)",
+				wc.paths.GRPCAddress, credentialsWatcherInterval)
+			continue
+		}
+		wc.files = files
+	}
+}
+
+func updateNodeCredentials(db *sqlx.DB, paths NodeCredentialPaths, files [][]byte) error {
+	nodeId, err := GetNodeIdByGRPC(db, paths.GRPCAddress)
+	if err != nil {
+		return errors.Wrap(err, "Obtaining node id")
+	}
+	if nodeId == 0 {
+		// The node was not added yet (i.e. it was unreachable at startup), nothing to update
+		return nil
+	}
+	ncd, err := getNodeConnectionDetails(db, nodeId)
+	if err != nil {
+		return errors.Wrap(err, "Obtaining existing node connection details")
+	}
+	switch paths.Implementation {
+	case core.LND:
+		ncd.TLSDataBytes = files[0]
+		ncd.MacaroonDataBytes = files[1]
+	case core.CLN:
+		ncd.CertificateDataBytes = files[0]
+		ncd.KeyDataBytes = files[1]
+		ncd.CaCertificateDataBytes = files[2]
+	}
+	ncd, err = SetNodeConnectionDetails(db, ncd)
+	if err != nil {
+		return errors.Wrap(err, "Updating node connection details")
+	}
+	log.Info().Msgf("Credential files of node %v (%v) changed, node connection details updated",
+		nodeId, paths.GRPCAddress)
+	if ncd.Status == core.Active {
+		cache.RestartNodeServices(nodeId)
+	}
+	return nil
+}
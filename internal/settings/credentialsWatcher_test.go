@@ -0,0 +1,83 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lncapital/torq/internal/core"
+)
+
+func TestGetChangedFiles(t *testing.T) {
+	directory := t.TempDir()
+	tlsPath := filepath.Join(directory, "tls.cert")
+	macaroonPath := filepath.Join(directory, "torq.macaroon")
+	writeFile(t, tlsPath, "certificate")
+	writeFile(t, macaroonPath, "macaroon")
+
+	wc := &watchedCredentials{paths: NodeCredentialPaths{
+		Implementation:     core.LND,
+		GRPCAddress:        "10.0.0.1:10009",
+		CertificatePath:    tlsPath,
+		AuthenticationPath: macaroonPath,
+	}}
+	files, err := wc.getChangedFiles()
+	if err != nil || len(files) != 2 {
+		t.Fatalf("getChangedFiles() initially = %v, %v, expected both files", files, err)
+	}
+	wc.files = files
+
+	files, err = wc.getChangedFiles()
+	if err != nil || files != nil {
+		t.Errorf("getChangedFiles() without changes = %v, %v, expected nothing", files, err)
+	}
+
+	// A file in the middle of a rotation
+	err = os.Remove(tlsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = wc.getChangedFiles()
+	if err == nil {
+		t.Error("getChangedFiles() with a missing file should fail")
+	}
+	writeFile(t, tlsPath, "")
+	_, err = wc.getChangedFiles()
+	if err == nil {
+		t.Error("getChangedFiles() with an empty file should fail")
+	}
+
+	writeFile(t, tlsPath, "rotated certificate")
+	files, err = wc.getChangedFiles()
+	if err != nil || len(files) != 2 || string(files[0]) != "rotated certificate" || string(files[1]) != "macaroon" {
+		t.Errorf("getChangedFiles() after rotation = %q, %v, expected the rotated certificate", files, err)
+	}
+}
+
+func TestGetDeclaredNodeCredentialPaths(t *testing.T) {
+	paths := GetDeclaredNodeCredentialPaths([]DeclaredNode{{
+		Implementation:    "cln",
+		GRPCAddress:       "10.0.0.2:9736",
+		CertificatePath:   "/cln/client.pem",
+		KeyPath:           "/cln/client-key.pem",
+		CaCertificatePath: "/cln/ca.pem",
+	}})
+	expected := NodeCredentialPaths{
+		Implementation:     core.CLN,
+		GRPCAddress:        "10.0.0.2:9736",
+		CertificatePath:    "/cln/client.pem",
+		AuthenticationPath: "/cln/client-key.pem",
+		CaCertificatePath:  "/cln/ca.pem",
+	}
+	if len(paths) != 1 || paths[0] != expected {
+		t.Errorf("GetDeclaredNodeCredentialPaths() = %+v, expected %+v", paths, expected)
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
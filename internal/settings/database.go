@@ -52,6 +52,10 @@ func getTimeZones(db *sqlx.DB) (timeZones []timeZone, err error) {
 }
 
 func updateSettings(db *sqlx.DB, settings settings) (err error) {
+	err = encryptSettingsCredentials(&settings)
+	if err != nil {
+		return err
+	}
 	_, err = db.Exec(`
 		UPDATE settings SET
 		  default_date_range = $1,
@@ -409,6 +413,106 @@ func InitializeChannelsCache(db *sqlx.DB) error {
 	return nil
 }
 
+type channelStateRecord struct {
+	ChannelId              int    `db:"channel_id"`
+	RemoteNodeId           int    `db:"remote_node_id"`
+	LocalBalance           int64  `db:"local_balance"`
+	LocalDisabled          bool   `db:"local_disabled"`
+	LocalFeeBaseMsat       int64  `db:"local_fee_base_msat"`
+	LocalFeeRateMilliMsat  int64  `db:"local_fee_rate_mill_msat"`
+	LocalMinHtlcMsat       uint64 `db:"local_min_htlc"`
+	LocalMaxHtlcMsat       uint64 `db:"local_max_htlc_msat"`
+	LocalTimeLockDelta     uint32 `db:"local_time_lock_delta"`
+	RemoteBalance          int64  `db:"remote_balance"`
+	RemoteDisabled         bool   `db:"remote_disabled"`
+	RemoteFeeBaseMsat      int64  `db:"remote_fee_base_msat"`
+	RemoteFeeRateMilliMsat int64  `db:"remote_fee_rate_mill_msat"`
+	RemoteMinHtlcMsat      uint64 `db:"remote_min_htlc"`
+	RemoteMaxHtlcMsat      uint64 `db:"remote_max_htlc_msat"`
+	RemoteTimeLockDelta    uint32 `db:"remote_time_lock_delta"`
+}
+
+// InitializeChannelStatesCache pushes the open channels of the active torq nodes with their last stored balance
+// and routing policies to the ChannelStatesCache. This is only for followers (high availability), they don't run the
+// node services that obtain the channel states from the nodes.
+func InitializeChannelStatesCache(db *sqlx.DB) error {
+	log.Debug().Msg("Pushing stored channel states to ChannelStatesCache.")
+	for _, torqNode := range cache.GetActiveTorqNodeSettings() {
+		var channelStateRecords []channelStateRecord
+		err := db.Select(&channelStateRecords, `
+			SELECT c.channel_id,
+			       CASE WHEN c.first_node_id=$1 THEN c.second_node_id ELSE c.first_node_id END AS remote_node_id,
+			       coalesce(b.local_balance, 0) AS local_balance,
+			       coalesce(lrp.disabled, false) AS local_disabled,
+			       coalesce(lrp.fee_base_msat, 0) AS local_fee_base_msat,
+			       coalesce(lrp.fee_rate_mill_msat, 0) AS local_fee_rate_mill_msat,
+			       coalesce(lrp.min_htlc, 0) AS local_min_htlc,
+			       coalesce(lrp.max_htlc_msat, 0) AS local_max_htlc_msat,
+			       coalesce(lrp.time_lock_delta, 0) AS local_time_lock_delta,
+			       coalesce(b.remote_balance, 0) AS remote_balance,
+			       coalesce(rrp.disabled, false) AS remote_disabled,
+			       coalesce(rrp.fee_base_msat, 0) AS remote_fee_base_msat,
+			       coalesce(rrp.fee_rate_mill_msat, 0) AS remote_fee_rate_mill_msat,
+			       coalesce(rrp.min_htlc, 0) AS remote_min_htlc,
+			       coalesce(rrp.max_htlc_msat, 0) AS remote_max_htlc_msat,
+			       coalesce(rrp.time_lock_delta, 0) AS remote_time_lock_delta
+			FROM channel c
+			LEFT JOIN LATERAL (
+				SELECT local_balance, remote_balance
+				FROM channel_balance_history
+				WHERE channel_id=c.channel_id AND node_id=$1
+				ORDER BY time DESC
+				LIMIT 1
+			) b ON true
+			LEFT JOIN LATERAL (
+				SELECT disabled, fee_base_msat, fee_rate_mill_msat, min_htlc, max_htlc_msat, time_lock_delta
+				FROM routing_policy
+				WHERE channel_id=c.channel_id AND announcing_node_id=$1
+				ORDER BY ts DESC
+				LIMIT 1
+			) lrp ON true
+			LEFT JOIN LATERAL (
+				SELECT disabled, fee_base_msat, fee_rate_mill_msat, min_htlc, max_htlc_msat, time_lock_delta
+				FROM routing_policy
+				WHERE channel_id=c.channel_id AND connecting_node_id=$1
+				ORDER BY ts DESC
+				LIMIT 1
+			) rrp ON true
+			WHERE (c.first_node_id=$1 OR c.second_node_id=$1) AND c.status_id=$2;`,
+			torqNode.NodeId, core.Open)
+		if err != nil {
+			return errors.Wrapf(err, "Obtaining stored channel states for nodeId: %v", torqNode.NodeId)
+		}
+		var channelStateSettingsList []cache.ChannelStateSettingsCache
+		for _, record := range channelStateRecords {
+			channelStateSettingsList = append(channelStateSettingsList, cache.ChannelStateSettingsCache{
+				NodeId:                 torqNode.NodeId,
+				RemoteNodeId:           record.RemoteNodeId,
+				ChannelId:              record.ChannelId,
+				LocalBalance:           record.LocalBalance,
+				LocalDisabled:          record.LocalDisabled,
+				LocalFeeBaseMsat:       record.LocalFeeBaseMsat,
+				LocalFeeRateMilliMsat:  record.LocalFeeRateMilliMsat,
+				LocalMinHtlcMsat:       record.LocalMinHtlcMsat,
+				LocalMaxHtlcMsat:       record.LocalMaxHtlcMsat,
+				LocalTimeLockDelta:     record.LocalTimeLockDelta,
+				RemoteBalance:          record.RemoteBalance,
+				RemoteDisabled:         record.RemoteDisabled,
+				RemoteFeeBaseMsat:      record.RemoteFeeBaseMsat,
+				RemoteFeeRateMilliMsat: record.RemoteFeeRateMilliMsat,
+				RemoteMinHtlcMsat:      record.RemoteMinHtlcMsat,
+				RemoteMaxHtlcMsat:      record.RemoteMaxHtlcMsat,
+				RemoteTimeLockDelta:    record.RemoteTimeLockDelta,
+			})
+		}
+		// Replacing existing channel states would publish balance changes, they are only processed by the leader
+		cache.RemoveChannelStatesFromCache(torqNode.NodeId)
+		cache.SetChannelStates(torqNode.NodeId, channelStateSettingsList)
+		cache.SetChannelStateNodeStatus(torqNode.NodeId, core.Active)
+	}
+	return nil
+}
+
 func getNodeAlias(db *sqlx.DB, nodeId int) string {
 	var alias string
 	err := db.Get(&alias, `SELECT alias FROM node_event WHERE event_node_id = $1 ORDER BY timestamp DESC LIMIT 1;`,
@@ -518,9 +622,13 @@ func setCustomSettings(db *sqlx.DB,
 }
 
 func SetNodeConnectionDetails(db *sqlx.DB, ncd NodeConnectionDetails) (NodeConnectionDetails, error) {
+	err := encryptNodeConnectionDetails(&ncd)
+	if err != nil {
+		return ncd, err
+	}
 	updatedOn := time.Now().UTC()
 	ncd.UpdatedOn = &updatedOn
-	_, err := db.Exec(`
+	_, err = db.Exec(`
 		UPDATE node_connection_details
 		SET implementation = $1, name = $2, grpc_address = $3,
 		    tls_file_name = $4, tls_data = $5, macaroon_file_name = $6, macaroon_data = $7,
@@ -594,9 +702,13 @@ func SetNodeConnectionDetailsByConnectionDetails(
 }
 
 func addNodeConnectionDetails(db *sqlx.DB, ncd NodeConnectionDetails) (NodeConnectionDetails, error) {
+	err := encryptNodeConnectionDetails(&ncd)
+	if err != nil {
+		return ncd, err
+	}
 	updatedOn := time.Now().UTC()
 	ncd.UpdatedOn = &updatedOn
-	_, err := db.Exec(`
+	_, err = db.Exec(`
 		INSERT INTO node_connection_details
 		    (node_id, name, implementation, grpc_address,
 		     tls_file_name, tls_data, macaroon_file_name, macaroon_data,
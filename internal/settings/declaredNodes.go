@@ -0,0 +1,327 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/core"
+)
+
+// DeclaredNode is a node of the [[nodes]] array in the configuration file
+type DeclaredNode struct {
+	Name string `toml:"name"`
+	// lnd or cln
+	Implementation string `toml:"implementation"`
+	GRPCAddress    string `toml:"grpc-address"`
+	// LND credentials
+	TLSPath      string `toml:"tls-path"`
+	MacaroonPath string `toml:"macaroon-path"`
+	// CLN credentials
+	CertificatePath   string `toml:"certificate-path"`
+	KeyPath           string `toml:"key-path"`
+	CaCertificatePath string `toml:"ca-certificate-path"`
+	// amboss and/or vector
+	PingSystems []string `toml:"ping-systems"`
+	// The data to import, all data except failed payments when not set
+	Imports       []string `toml:"imports"`
+	NodeStartDate string   `toml:"node-start-date"`
+	Disabled      bool     `toml:"disabled"`
+}
+
+func getPingSystemNames() map[string]core.PingSystem {
+	return map[string]core.PingSystem{
+		"amboss": core.Amboss,
+		"vector": core.Vector,
+	}
+}
+
+func getImportNames() map[string]core.NodeConnectionDetailCustomSettings {
+	return map[string]core.NodeConnectionDetailCustomSettings{
+		"failed-payments":   core.ImportFailedPayments,
+		"htlc-events":       core.ImportHtlcEvents,
+		"transactions":      core.ImportTransactions,
+		"payments":          core.ImportPayments,
+		"invoices":          core.ImportInvoices,
+		"forwards":          core.ImportForwards,
+		"historic-forwards": core.ImportHistoricForwards,
+	}
+}
+
+// LoadDeclaredNodes reads the [[nodes]] array from the configuration file, there are no nodes when the file does not exist
+func LoadDeclaredNodes(configPath string) ([]DeclaredNode, error) {
+	if _, err := os.Stat(configPath); err != nil {
+		return nil, nil
+	}
+	var config struct {
+		Nodes []DeclaredNode `toml:"nodes"`
+	}
+	_, err := toml.DecodeFile(configPath, &config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Reading nodes from %v", configPath)
+	}
+	grpcAddresses := make(map[string]bool)
+	for i, node := range config.Nodes {
+		err = validateDeclaredNode(node)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Node %v (%v) in %v", i+1, node.GRPCAddress, configPath)
+		}
+		if grpcAddresses[node.GRPCAddress] {
+			return nil, errors.Newf("Node %v in %v: grpc-address %v is declared twice", i+1, configPath,
+				node.GRPCAddress)
+		}
+		grpcAddresses[node.GRPCAddress] = true
+	}
+	return config.Nodes, nil
+}
+
+func validateDeclaredNode(node DeclaredNode) error {
+	if node.GRPCAddress == "" {
+		return errors.New("grpc-address is required")
+	}
+	implementation, err := getDeclaredImplementation(node)
+	if err != nil {
+		return err
+	}
+	switch implementation {
+	case core.LND:
+		if node.TLSPath == "" || node.MacaroonPath == "" {
+			return errors.New("tls-path and macaroon-path are required for LND")
+		}
+	case core.CLN:
+		if node.CertificatePath == "" || node.KeyPath == "" || node.CaCertificatePath == "" {
+			return errors.New("certificate-path, key-path and ca-certificate-path are required for CLN")
+		}
+	}
+	_, err = getDeclaredPingSystem(node)
+	if err != nil {
+		return err
+	}
+	_, err = getDeclaredCustomSettings(node)
+	if err != nil {
+		return err
+	}
+	_, err = getDeclaredNodeStartDate(node)
+	return err
+}
+
+func getDeclaredImplementation(node DeclaredNode) (core.Implementation, error) {
+	switch strings.ToLower(node.Implementation) {
+	case "lnd":
+		return core.LND, nil
+	case "cln":
+		return core.CLN, nil
+	default:
+		return 0, errors.Newf("Unknown implementation %q (lnd|cln)", node.Implementation)
+	}
+}
+
+func getDeclaredPingSystem(node DeclaredNode) (core.PingSystem, error) {
+	var pingSystem core.PingSystem
+	for _, name := range node.PingSystems {
+		value, exists := getPingSystemNames()[strings.ToLower(name)]
+		if !exists {
+			return 0, errors.Newf("Unknown ping system %q (amboss|vector)", name)
+		}
+		pingSystem = pingSystem.AddPingSystem(value)
+	}
+	return pingSystem, nil
+}
+
+func getDeclaredCustomSettings(node DeclaredNode) (core.NodeConnectionDetailCustomSettings, error) {
+	var customSettings core.NodeConnectionDetailCustomSettings
+	if node.Imports == nil {
+		for name, value := range getImportNames() {
+			if name != "failed-payments" {
+				customSettings = customSettings.AddNodeConnectionDetailCustomSettings(value)
+			}
+		}
+		return customSettings, nil
+	}
+	for _, name := range node.Imports {
+		value, exists := getImportNames()[strings.ToLower(name)]
+		if !exists {
+			return 0, errors.Newf("Unknown import %q", name)
+		}
+		customSettings = customSettings.AddNodeConnectionDetailCustomSettings(value)
+	}
+	return customSettings, nil
+}
+
+func getDeclaredNodeStartDate(node DeclaredNode) (*time.Time, error) {
+	if node.NodeStartDate == "" {
+		return nil, nil
+	}
+	nodeStartDate, err := time.Parse("2006-01-02", node.NodeStartDate)
+	if err != nil {
+		return nil, errors.Newf("node-start-date %q is not a date (2006-01-02)", node.NodeStartDate)
+	}
+	return &nodeStartDate, nil
+}
+
+// ReconcileDeclaredNodes adds or updates the declared nodes. In strict mode the active nodes that are not declared are
+// disabled, the grpc addresses of the nodes configured with the lnd and cln flags count as declared.
+// A declared node that can't be added (i.e. the credentials can't be read or the node is unreachable) is skipped.
+func ReconcileDeclaredNodes(db *sqlx.DB, nodes []DeclaredNode, strict bool, otherGrpcAddresses ...string) error {
+	declared := make(map[string]bool)
+	for _, grpcAddress := range otherGrpcAddresses {
+		if grpcAddress != "" {
+			declared[grpcAddress] = true
+		}
+	}
+	for _, node := range nodes {
+		declared[node.GRPCAddress] = true
+		err := reconcileDeclaredNode(db, node)
+		if err != nil {
+			log.Error().Err(err).Msgf("Skipping node %v declared in the config", node.GRPCAddress)
+		}
+	}
+	if !strict {
+		return nil
+	}
+	allNodeConnectionDetails, err := GetAllNodeConnectionDetails(db, false)
+	if err != nil {
+		return errors.Wrap(err, "Getting node connection details")
+	}
+	for _, ncd := range allNodeConnectionDetails {
+		if ncd.Status != core.Active || (ncd.GRPCAddress != nil && declared[*ncd.GRPCAddress]) {
+			continue
+		}
+		_, err = setNodeConnectionDetailsStatus(db, ncd.NodeId, core.Inactive)
+		if err != nil {
+			return errors.Wrapf(err, "Disabling node %v", ncd.NodeId)
+		}
+		log.Info().Msgf("Disabled node %v (%v) as it's not declared in the config (strict mode)",
+			ncd.NodeId, ncd.Name)
+	}
+	return nil
+}
+
+// GetDeclaredNodeCredentialPaths returns the credential files on disk of the declared nodes
+func GetDeclaredNodeCredentialPaths(nodes []DeclaredNode) []NodeCredentialPaths {
+	var credentialPaths []NodeCredentialPaths
+	for _, node := range nodes {
+		paths, err := getDeclaredCredentialPaths(node)
+		if err != nil {
+			continue
+		}
+		credentialPaths = append(credentialPaths, paths)
+	}
+	return credentialPaths
+}
+
+func getDeclaredCredentialPaths(node DeclaredNode) (NodeCredentialPaths, error) {
+	implementation, err := getDeclaredImplementation(node)
+	if err != nil {
+		return NodeCredentialPaths{}, err
+	}
+	paths := NodeCredentialPaths{Implementation: implementation, GRPCAddress: node.GRPCAddress}
+	switch implementation {
+	case core.LND:
+		paths.CertificatePath = node.TLSPath
+		paths.AuthenticationPath = node.MacaroonPath
+	case core.CLN:
+		paths.CertificatePath = node.CertificatePath
+		paths.AuthenticationPath = node.KeyPath
+		paths.CaCertificatePath = node.CaCertificatePath
+	}
+	return paths, nil
+}
+
+func reconcileDeclaredNode(db *sqlx.DB, node DeclaredNode) error {
+	paths, err := getDeclaredCredentialPaths(node)
+	if err != nil {
+		return err
+	}
+	implementation := paths.Implementation
+	certificate, err := os.ReadFile(paths.CertificatePath)
+	if err != nil {
+		return errors.Wrap(err, "Reading certificate")
+	}
+	authentication, err := os.ReadFile(paths.AuthenticationPath)
+	if err != nil {
+		return errors.Wrap(err, "Reading authentication")
+	}
+	var caCertificate []byte
+	if paths.CaCertificatePath != "" {
+		caCertificate, err = os.ReadFile(paths.CaCertificatePath)
+		if err != nil {
+			return errors.Wrap(err, "Reading ca certificate")
+		}
+	}
+
+	nodeId, err := GetNodeIdByGRPC(db, node.GRPCAddress)
+	if err != nil {
+		return errors.Wrap(err, "Checking if the node exists")
+	}
+	var ncd NodeConnectionDetails
+	if nodeId == 0 {
+		log.Info().Msgf("Node %v declared in the config is not in DB, obtaining public key from GRPC",
+			node.GRPCAddress)
+		ncd, err = AddNodeToDB(db, implementation, node.GRPCAddress, certificate, authentication, caCertificate)
+		if err != nil {
+			return errors.Wrap(err, "Adding node")
+		}
+	} else {
+		ncd, err = getNodeConnectionDetails(db, nodeId)
+		if err != nil {
+			return errors.Wrap(err, "Getting node connection details")
+		}
+	}
+
+	ncd.Implementation = implementation
+	ncd.GRPCAddress = &node.GRPCAddress
+	switch implementation {
+	case core.LND:
+		ncd.TLSFileName = getFileName(node.TLSPath)
+		ncd.TLSDataBytes = certificate
+		ncd.MacaroonFileName = getFileName(node.MacaroonPath)
+		ncd.MacaroonDataBytes = authentication
+	case core.CLN:
+		ncd.CertificateFileName = getFileName(node.CertificatePath)
+		ncd.CertificateDataBytes = certificate
+		ncd.KeyFileName = getFileName(node.KeyPath)
+		ncd.KeyDataBytes = authentication
+		ncd.CaCertificateFileName = getFileName(node.CaCertificatePath)
+		ncd.CaCertificateDataBytes = caCertificate
+	}
+	if node.Name != "" {
+		ncd.Name = node.Name
+	}
+	ncd.PingSystem, err = getDeclaredPingSystem(node)
+	if err != nil {
+		return err
+	}
+	ncd.CustomSettings, err = getDeclaredCustomSettings(node)
+	if err != nil {
+		return err
+	}
+	nodeStartDate, err := getDeclaredNodeStartDate(node)
+	if err != nil {
+		return err
+	}
+	if nodeStartDate != nil {
+		ncd.NodeStartDate = nodeStartDate
+	}
+	ncd.Status = core.Active
+	if node.Disabled {
+		ncd.Status = core.Inactive
+	}
+	_, err = SetNodeConnectionDetails(db, ncd)
+	if err != nil {
+		return errors.Wrap(err, "Updating node connection details")
+	}
+	log.Info().Msgf("Node %v (%v) declared in the config is up to date", ncd.NodeId, node.GRPCAddress)
+	return nil
+}
+
+func getFileName(path string) *string {
+	fileName := filepath.Base(path)
+	return &fileName
+}
@@ -0,0 +1,115 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lncapital/torq/internal/core"
+)
+
+func TestLoadDeclaredNodes(t *testing.T) {
+	nodes, err := LoadDeclaredNodes(filepath.Join(t.TempDir(), "missing.conf"))
+	if err != nil || len(nodes) != 0 {
+		t.Fatalf("LoadDeclaredNodes() without config = %v, %v, expected no nodes", nodes, err)
+	}
+
+	configPath := writeConfig(t, `
+[db]
+password = "runningtorq"
+
+[torq]
+nodes-strict = true
+
+[[nodes]]
+name = "Routing node"
+implementation = "lnd"
+grpc-address = "10.0.0.1:10009"
+tls-path = "/lnd/tls.cert"
+macaroon-path = "/lnd/torq.macaroon"
+ping-systems = ["amboss", "vector"]
+
+[[nodes]]
+implementation = "cln"
+grpc-address = "10.0.0.2:9736"
+certificate-path = "/cln/client.pem"
+key-path = "/cln/client-key.pem"
+ca-certificate-path = "/cln/ca.pem"
+imports = ["forwards", "invoices"]
+node-start-date = "2023-01-01"
+`)
+	nodes, err = LoadDeclaredNodes(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("LoadDeclaredNodes() = %v nodes, expected 2", len(nodes))
+	}
+	pingSystem, _ := getDeclaredPingSystem(nodes[0])
+	if pingSystem != core.Amboss|core.Vector {
+		t.Errorf("ping system = %v, expected amboss and vector", pingSystem)
+	}
+	customSettings, _ := getDeclaredCustomSettings(nodes[0])
+	if customSettings.HasNodeConnectionDetailCustomSettings(core.ImportFailedPayments) ||
+		!customSettings.HasNodeConnectionDetailCustomSettings(core.ImportHistoricForwards) {
+		t.Errorf("default imports = %v, expected everything but failed payments", customSettings)
+	}
+	customSettings, _ = getDeclaredCustomSettings(nodes[1])
+	if customSettings != core.ImportForwards|core.ImportInvoices {
+		t.Errorf("imports = %v, expected forwards and invoices", customSettings)
+	}
+}
+
+func TestLoadDeclaredNodesInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{"Unknown implementation", `
+[[nodes]]
+implementation = "eclair"
+grpc-address = "10.0.0.1:10009"`},
+		{"Missing macaroon", `
+[[nodes]]
+implementation = "lnd"
+grpc-address = "10.0.0.1:10009"
+tls-path = "/lnd/tls.cert"`},
+		{"Unknown import", `
+[[nodes]]
+implementation = "lnd"
+grpc-address = "10.0.0.1:10009"
+tls-path = "/lnd/tls.cert"
+macaroon-path = "/lnd/torq.macaroon"
+imports = ["everything"]`},
+		{"Duplicate address", `
+[[nodes]]
+implementation = "lnd"
+grpc-address = "10.0.0.1:10009"
+tls-path = "/lnd/tls.cert"
+macaroon-path = "/lnd/torq.macaroon"
+
+[[nodes]]
+implementation = "lnd"
+grpc-address = "10.0.0.1:10009"
+tls-path = "/lnd/tls.cert"
+macaroon-path = "/lnd/torq.macaroon"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := LoadDeclaredNodes(writeConfig(t, test.config))
+			if err == nil {
+				t.Error("LoadDeclaredNodes() should fail")
+			}
+		})
+	}
+}
+
+func writeConfig(t *testing.T, config string) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "torq.conf")
+	err := os.WriteFile(configPath, []byte(config), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return configPath
+}
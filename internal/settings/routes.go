@@ -19,8 +19,10 @@ import (
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc"
 
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/users"
 	"github.com/lncapital/torq/pkg/cln_connect"
 	"github.com/lncapital/torq/pkg/lnd_connect"
 	"github.com/lncapital/torq/pkg/server_errors"
@@ -39,6 +41,7 @@ type settings struct {
 	SlackBotAppToken                *string    `json:"slackBotAppToken" db:"slack_bot_app_token"`
 	TelegramHighPriorityCredentials *string    `json:"telegramHighPriorityCredentials" db:"telegram_high_priority_credentials"`
 	TelegramLowPriorityCredentials  *string    `json:"telegramLowPriorityCredentials" db:"telegram_low_priority_credentials"`
+	SecretsKeyCheck                 *string    `json:"-" db:"secrets_key_check"`
 	CreatedOn                       time.Time  `json:"createdOn" db:"created_on"`
 	UpdateOn                        *time.Time `json:"updatedOn" db:"updated_on"`
 }
@@ -85,10 +88,15 @@ func setService(serviceType services_helpers.ServiceType, nodeId int, active boo
 func RegisterSettingRoutes(r *gin.RouterGroup, db *sqlx.DB) {
 	r.GET("", func(c *gin.Context) { getSettingsHandler(c, db) })
 	r.PUT("", func(c *gin.Context) { updateSettingsHandler(c, db) })
-	r.GET("nodeConnectionDetails", func(c *gin.Context) { getAllNodeConnectionDetailsHandler(c, db) })
-	r.GET("nodeConnectionDetails/:nodeId", func(c *gin.Context) { getNodeConnectionDetailsHandler(c, db) })
-	r.POST("nodeConnectionDetails", func(c *gin.Context) { addNodeConnectionDetailsHandler(c, db) })
-	r.PUT("nodeConnectionDetails", func(c *gin.Context) { setNodeConnectionDetailsHandler(c, db) })
+	// Node connection details contain credentials so only admins can read or replace them (API tokens can't)
+	r.GET("nodeConnectionDetails", auth.SessionAdminRequired,
+		func(c *gin.Context) { getAllNodeConnectionDetailsHandler(c, db) })
+	r.GET("nodeConnectionDetails/:nodeId", auth.SessionAdminRequired,
+		func(c *gin.Context) { getNodeConnectionDetailsHandler(c, db) })
+	r.POST("nodeConnectionDetails", auth.SessionAdminRequired,
+		func(c *gin.Context) { addNodeConnectionDetailsHandler(c, db) })
+	r.PUT("nodeConnectionDetails", auth.SessionAdminRequired,
+		func(c *gin.Context) { setNodeConnectionDetailsHandler(c, db) })
 	r.PUT("nodeConnectionDetails/:nodeId/:statusId", func(c *gin.Context) {
 		setNodeConnectionDetailsStatusHandler(c, db)
 	})
@@ -120,6 +128,13 @@ func getSettingsHandler(c *gin.Context, db *sqlx.DB) {
 		server_errors.LogAndSendServerError(c, err)
 		return
 	}
+	// The Slack and Telegram tokens are credentials like the node connection details so only admins can read them
+	if auth.GetApiToken(c) != nil || auth.GetRole(c) < users.RoleAdmin {
+		setts.SlackOAuthToken = nil
+		setts.SlackBotAppToken = nil
+		setts.TelegramHighPriorityCredentials = nil
+		setts.TelegramLowPriorityCredentials = nil
+	}
 	c.JSON(http.StatusOK, setts)
 }
 
@@ -419,6 +434,11 @@ func setNodeConnectionDetailsHandler(c *gin.Context, db *sqlx.DB) {
 	cache.SetTorqNode(ncd.NodeId, ncd.Name, ncd.Status,
 		nodeSettings.PublicKey, nodeSettings.Chain, nodeSettings.Network)
 
+	// The running services still use the previous connection details
+	if lndDetailsUpdate && ncd.Status == core.Active {
+		cache.RestartNodeServices(ncd.NodeId)
+	}
+
 	success := setAllLndServices(ncd.NodeId, ncd.Status == core.Active, ncd.CustomSettings, ncd.PingSystem)
 	if !success {
 		server_errors.LogAndSendServerError(c, errors.New("Some services did not start correctly"))
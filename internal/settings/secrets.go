@@ -0,0 +1,228 @@
+package settings
+
+import (
+	"database/sql"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/pkg/secrets"
+)
+
+// secretsKeyCheckValue is stored encrypted (settings.secrets_key_check) to verify the configured secrets key
+const secretsKeyCheckValue = "torq-secrets-key-check"
+
+type nodeConnectionSecrets struct {
+	NodeId                 int    `db:"node_id"`
+	TLSDataBytes           []byte `db:"tls_data"`
+	MacaroonDataBytes      []byte `db:"macaroon_data"`
+	CertificateDataBytes   []byte `db:"certificate_data"`
+	KeyDataBytes           []byte `db:"key_data"`
+	CaCertificateDataBytes []byte `db:"ca_certificate_data"`
+}
+
+type settingsSecrets struct {
+	SettingsId                      int     `db:"settings_id"`
+	SlackOAuthToken                 *string `db:"slack_oauth_token"`
+	SlackBotAppToken                *string `db:"slack_bot_app_token"`
+	TelegramHighPriorityCredentials *string `db:"telegram_high_priority_credentials"`
+	TelegramLowPriorityCredentials  *string `db:"telegram_low_priority_credentials"`
+}
+
+func encryptNodeConnectionDetails(ncd *NodeConnectionDetails) error {
+	for _, value := range []*[]byte{&ncd.TLSDataBytes, &ncd.MacaroonDataBytes,
+		&ncd.CertificateDataBytes, &ncd.KeyDataBytes, &ncd.CaCertificateDataBytes} {
+		encrypted, err := secrets.Encrypt(*value)
+		if err != nil {
+			return errors.Wrap(err, "Encrypting node connection details")
+		}
+		*value = encrypted
+	}
+	return nil
+}
+
+func encryptSettingsCredentials(setts *settings) error {
+	for _, value := range []*string{setts.SlackOAuthToken, setts.SlackBotAppToken,
+		setts.TelegramHighPriorityCredentials, setts.TelegramLowPriorityCredentials} {
+		if value == nil {
+			continue
+		}
+		encrypted, err := secrets.EncryptString(*value)
+		if err != nil {
+			return errors.Wrap(err, "Encrypting notification credentials")
+		}
+		*value = encrypted
+	}
+	return nil
+}
+
+// InitializeSecrets verifies the configured secrets key and encrypts the credentials that are still stored in plaintext
+// (i.e. stored before the secrets key was configured).
+func InitializeSecrets(db *sqlx.DB) error {
+	secretsKeyCheck, err := getSecretsKeyCheck(db)
+	if err != nil {
+		return err
+	}
+	if !secrets.HasKey() {
+		if secretsKeyCheck != nil {
+			return errors.Newf("The credentials are encrypted but no secrets key is configured "+
+				"(use --torq.secrets-key-file or %v)", secrets.KeyEnvironmentVariable)
+		}
+		log.Warn().Msgf("No secrets key is configured (use --torq.secrets-key-file or %v), "+
+			"node credentials and notification tokens are stored unencrypted", secrets.KeyEnvironmentVariable)
+		return nil
+	}
+	if secretsKeyCheck != nil {
+		err = verifySecretsKey(*secretsKeyCheck, secrets.Decrypt)
+		if err != nil {
+			return err
+		}
+	}
+	return convertSecrets(db, secrets.Encrypt)
+}
+
+// RekeySecrets re-encrypts all credentials with the new key, oldKey is nil when the credentials are not encrypted yet.
+// Torq must not be running as it keeps the credentials encrypted with the old key in its cache.
+func RekeySecrets(db *sqlx.DB, oldKey []byte, newKey []byte) error {
+	if newKey == nil {
+		return errors.New("The new secrets key is required")
+	}
+	secretsKeyCheck, err := getSecretsKeyCheck(db)
+	if err != nil {
+		return err
+	}
+	if secretsKeyCheck != nil {
+		if oldKey == nil {
+			return errors.Newf("The credentials are encrypted, the current secrets key is required "+
+				"(use --torq.secrets-key-file or %v)", secrets.KeyEnvironmentVariable)
+		}
+		err = verifySecretsKey(*secretsKeyCheck, func(value []byte) ([]byte, error) {
+			return secrets.DecryptWithKey(oldKey, value)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return convertSecrets(db, func(value []byte) ([]byte, error) {
+		if oldKey != nil {
+			decrypted, err := secrets.DecryptWithKey(oldKey, value)
+			if err != nil {
+				return nil, err
+			}
+			value = decrypted
+		}
+		return secrets.EncryptWithKey(newKey, value)
+	})
+}
+
+func getSecretsKeyCheck(db *sqlx.DB) (*string, error) {
+	var secretsKeyCheck *string
+	err := db.Get(&secretsKeyCheck, `SELECT secrets_key_check FROM settings LIMIT 1;`)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return secretsKeyCheck, nil
+}
+
+func verifySecretsKey(secretsKeyCheck string, decrypt func(value []byte) ([]byte, error)) error {
+	value, err := decrypt([]byte(secretsKeyCheck))
+	if err != nil || string(value) != secretsKeyCheckValue {
+		return errors.New("The secrets key does not match the key the credentials are encrypted with")
+	}
+	return nil
+}
+
+// convertSecrets converts (encrypts or re-encrypts) all credentials and the secrets key check in one transaction
+func convertSecrets(db *sqlx.DB, convert func(value []byte) ([]byte, error)) error {
+	tx, err := db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, database.SqlBeginTransactionError)
+	}
+	err = convertSecretsInTransaction(tx, convert)
+	if err != nil {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Error().Err(rollbackErr).Msgf("Failed to rollback converting the secrets.")
+		}
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, database.SqlCommitTransactionError)
+	}
+	return nil
+}
+
+func convertSecretsInTransaction(tx *sqlx.Tx, convert func(value []byte) ([]byte, error)) error {
+	var nodeConnectionDetails []nodeConnectionSecrets
+	err := tx.Select(&nodeConnectionDetails, `
+		SELECT node_id, tls_data, macaroon_data, certificate_data, key_data, ca_certificate_data
+		FROM node_connection_details
+		FOR UPDATE;`)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	for _, ncd := range nodeConnectionDetails {
+		for _, value := range []*[]byte{&ncd.TLSDataBytes, &ncd.MacaroonDataBytes,
+			&ncd.CertificateDataBytes, &ncd.KeyDataBytes, &ncd.CaCertificateDataBytes} {
+			if len(*value) == 0 {
+				continue
+			}
+			*value, err = convert(*value)
+			if err != nil {
+				return errors.Wrapf(err, "Converting connection details for nodeId: %v", ncd.NodeId)
+			}
+		}
+		_, err = tx.Exec(`
+			UPDATE node_connection_details
+			SET tls_data = $1, macaroon_data = $2, certificate_data = $3, key_data = $4, ca_certificate_data = $5
+			WHERE node_id = $6;`,
+			ncd.TLSDataBytes, ncd.MacaroonDataBytes, ncd.CertificateDataBytes, ncd.KeyDataBytes,
+			ncd.CaCertificateDataBytes, ncd.NodeId)
+		if err != nil {
+			return errors.Wrap(err, database.SqlExecutionError)
+		}
+	}
+
+	var settingsData []settingsSecrets
+	err = tx.Select(&settingsData, `
+		SELECT settings_id, slack_oauth_token, slack_bot_app_token,
+		       telegram_high_priority_credentials, telegram_low_priority_credentials
+		FROM settings
+		FOR UPDATE;`)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	for _, setts := range settingsData {
+		for _, value := range []*string{setts.SlackOAuthToken, setts.SlackBotAppToken,
+			setts.TelegramHighPriorityCredentials, setts.TelegramLowPriorityCredentials} {
+			if value == nil || *value == "" {
+				continue
+			}
+			converted, err := convert([]byte(*value))
+			if err != nil {
+				return errors.Wrap(err, "Converting notification credentials")
+			}
+			*value = string(converted)
+		}
+		secretsKeyCheck, err := convert([]byte(secretsKeyCheckValue))
+		if err != nil {
+			return errors.Wrap(err, "Converting the secrets key check")
+		}
+		_, err = tx.Exec(`
+			UPDATE settings
+			SET slack_oauth_token = $1, slack_bot_app_token = $2,
+			    telegram_high_priority_credentials = $3, telegram_low_priority_credentials = $4,
+			    secrets_key_check = $5
+			WHERE settings_id = $6;`,
+			setts.SlackOAuthToken, setts.SlackBotAppToken,
+			setts.TelegramHighPriorityCredentials, setts.TelegramLowPriorityCredentials,
+			string(secretsKeyCheck), setts.SettingsId)
+		if err != nil {
+			return errors.Wrap(err, database.SqlExecutionError)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package spending
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/audit"
+	"github.com/lncapital/torq/internal/database"
+)
+
+func GetLimits(db *sqlx.DB) ([]Limit, error) {
+	var limits []Limit
+	err := db.Select(&limits, `SELECT * FROM spending_limit ORDER BY node_id, operation;`)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []Limit{}, nil
+		}
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return limits, nil
+}
+
+// getLimit returns nil when there is no limit for the node and operation
+func getLimit(db *sqlx.DB, nodeId int, operation Operation) (*Limit, error) {
+	var limit Limit
+	err := db.Get(&limit, `SELECT * FROM spending_limit WHERE node_id=$1 AND operation=$2;`, nodeId, operation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return &limit, nil
+}
+
+// setLimit adds or updates the limit of the node and operation
+func setLimit(db *sqlx.DB, limit Limit) (Limit, error) {
+	now := time.Now().UTC()
+	err := db.Get(&limit, `
+		INSERT INTO spending_limit (node_id, operation, per_transaction_sat, daily_sat, created_on, updated_on)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (node_id, operation) DO UPDATE
+		SET per_transaction_sat=EXCLUDED.per_transaction_sat, daily_sat=EXCLUDED.daily_sat, updated_on=EXCLUDED.updated_on
+		RETURNING *;`,
+		limit.NodeId, limit.Operation, limit.PerTransactionSat, limit.DailySat, now)
+	if err != nil {
+		return Limit{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return limit, nil
+}
+
+func removeLimit(db *sqlx.DB, spendingLimitId int) (int64, error) {
+	res, err := db.Exec(`DELETE FROM spending_limit WHERE spending_limit_id=$1;`, spendingLimitId)
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlAffectedRowsCheckError)
+	}
+	return rowsAffected, nil
+}
+
+// getSpentSat returns the amount of the requests that are executed or being executed since the given time
+func getSpentSat(db *sqlx.DB, nodeId int, operation Operation, since time.Time) (int64, error) {
+	var spentSat int64
+	err := db.Get(&spentSat, `
+		SELECT coalesce(sum(amount_sat), 0)
+		FROM spending_request
+		WHERE node_id=$1 AND operation=$2 AND status IN ($3, $4) AND coalesce(decided_on, created_on)>=$5;`,
+		nodeId, operation, Approved, Executed, since)
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return spentSat, nil
+}
+
+func addRequest(db *sqlx.DB, request Request) (Request, error) {
+	err := db.Get(&request, `
+		INSERT INTO spending_request (node_id, operation, action, amount_sat, request, status, reason,
+			requested_by_type, requested_by_id, requested_by_name, decided_on, created_on, updated_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+		RETURNING *;`,
+		request.NodeId, request.Operation, request.Action, request.AmountSat, request.Request, request.Status,
+		request.Reason, request.RequestedByType, request.RequestedById, request.RequestedByName, request.DecidedOn,
+		request.CreatedOn)
+	if err != nil {
+		return Request{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return request, nil
+}
+
+// GetRequest returns nil when the request does not exist
+func GetRequest(db *sqlx.DB, spendingRequestId int) (*Request, error) {
+	var request Request
+	err := db.Get(&request, `SELECT * FROM spending_request WHERE spending_request_id=$1;`, spendingRequestId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return &request, nil
+}
+
+// getRequests returns the requests (newest first) and the total number of matching requests
+func getRequests(db *sqlx.DB, status *Status, limit uint64, offset uint64) ([]Request, uint64, error) {
+	var total uint64
+	err := db.Get(&total, `SELECT count(*) FROM spending_request WHERE ($1::integer IS NULL OR status=$1);`, status)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	var requests []Request
+	err = db.Select(&requests, `
+		SELECT *
+		FROM spending_request
+		WHERE ($1::integer IS NULL OR status=$1)
+		ORDER BY created_on DESC, spending_request_id DESC
+		LIMIT $2 OFFSET $3;`, status, limit, offset)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []Request{}, total, nil
+		}
+		return nil, 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return requests, total, nil
+}
+
+// setDecision changes the status of a pending request, it returns false when the request was no longer pending
+func setDecision(db *sqlx.DB, spendingRequestId int, status Status, actor *audit.Actor, now time.Time) (bool, error) {
+	var decidedByType *audit.ActorType
+	var decidedById *string
+	var decidedByName *string
+	if actor != nil {
+		decidedByType = &actor.Type
+		decidedById = &actor.Id
+		decidedByName = &actor.Name
+	}
+	res, err := db.Exec(`
+		UPDATE spending_request
+		SET status=$1, decided_by_type=$2, decided_by_id=$3, decided_by_name=$4, decided_on=$5, updated_on=$5
+		WHERE spending_request_id=$6 AND status=$7;`,
+		status, decidedByType, decidedById, decidedByName, now, spendingRequestId, Pending)
+	if err != nil {
+		return false, errors.Wrap(err, database.SqlExecutionError)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, database.SqlAffectedRowsCheckError)
+	}
+	return rowsAffected == 1, nil
+}
+
+func setResult(db *sqlx.DB, spendingRequestId int, status Status, result *string, resultError *string) error {
+	_, err := db.Exec(`
+		UPDATE spending_request SET status=$1, result=$2, error=$3, updated_on=$4
+		WHERE spending_request_id=$5;`,
+		status, result, resultError, time.Now().UTC(), spendingRequestId)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
+// GetRequestsToNotify returns the pending requests that were not sent to the chats yet
+func GetRequestsToNotify(db *sqlx.DB) ([]Request, error) {
+	var requests []Request
+	err := db.Select(&requests, `
+		SELECT * FROM spending_request WHERE status=$1 AND notified_on IS NULL ORDER BY created_on;`, Pending)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []Request{}, nil
+		}
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return requests, nil
+}
+
+func SetRequestNotifiedOn(db *sqlx.DB, spendingRequestId int, notifiedOn time.Time) error {
+	_, err := db.Exec(`UPDATE spending_request SET notified_on=$1 WHERE spending_request_id=$2;`,
+		notifiedOn, spendingRequestId)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package spending
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/audit"
+)
+
+// authorizeLock makes sure concurrent requests can't exceed the daily limit together
+//
+//nolint:gochecknoglobals
+var authorizeLock sync.Mutex
+
+// Authorize stores the request and evaluates it against the limits of the node.
+// The returned request is Approved when it's within the limits and can be executed straight away,
+// otherwise it's Pending until a second user or a chat approves it.
+func Authorize(db *sqlx.DB, actor audit.Actor, nodeId int, action Action, amountSat int64,
+	request interface{}) (Request, error) {
+
+	requestJson, err := json.Marshal(request)
+	if err != nil {
+		return Request{}, errors.Wrap(err, "Marshalling spending request")
+	}
+	operation := action.GetOperation()
+
+	authorizeLock.Lock()
+	defer authorizeLock.Unlock()
+
+	now := time.Now().UTC()
+	limit, err := getLimit(db, nodeId, operation)
+	if err != nil {
+		return Request{}, errors.Wrapf(err, "Getting spending limit for nodeId: %v", nodeId)
+	}
+	spentSat, err := getSpentSat(db, nodeId, operation, now.Add(-dailyWindow))
+	if err != nil {
+		return Request{}, errors.Wrapf(err, "Getting spent amount for nodeId: %v", nodeId)
+	}
+	spendingRequest := Request{
+		NodeId:          nodeId,
+		Operation:       operation,
+		Action:          action,
+		AmountSat:       amountSat,
+		Request:         requestJson,
+		Status:          Approved,
+		RequestedByType: actor.Type,
+		RequestedById:   &actor.Id,
+		RequestedByName: &actor.Name,
+		DecidedOn:       &now,
+		CreatedOn:       now,
+	}
+	auditAction := "spending autoApproved"
+	if reason := evaluateLimit(limit, amountSat, spentSat); reason != "" {
+		spendingRequest.Status = Pending
+		spendingRequest.Reason = &reason
+		spendingRequest.DecidedOn = nil
+		auditAction = "spending pendingApproval"
+	}
+	spendingRequest, err = addRequest(db, spendingRequest)
+	if err != nil {
+		return Request{}, errors.Wrap(err, "Storing spending request")
+	}
+	recordDecision(db, actor, auditAction, spendingRequest, nil)
+	return spendingRequest, nil
+}
+
+// Approve releases a pending request so it can be executed.
+// The second return value is the reason why it can't be approved (when it's not empty).
+func Approve(db *sqlx.DB, spendingRequestId int, actor audit.Actor) (Request, string, error) {
+	return decide(db, spendingRequestId, actor, Approved)
+}
+
+// Reject declines a pending request.
+// The second return value is the reason why it can't be rejected (when it's not empty).
+func Reject(db *sqlx.DB, spendingRequestId int, actor audit.Actor) (Request, string, error) {
+	return decide(db, spendingRequestId, actor, Rejected)
+}
+
+func decide(db *sqlx.DB, spendingRequestId int, actor audit.Actor, status Status) (Request, string, error) {
+	spendingRequest, err := GetRequest(db, spendingRequestId)
+	if err != nil {
+		return Request{}, "", errors.Wrapf(err, "Getting spending request for spendingRequestId: %v", spendingRequestId)
+	}
+	if spendingRequest == nil {
+		return Request{}, fmt.Sprintf("Spending request %v does not exist", spendingRequestId), nil
+	}
+	now := time.Now().UTC()
+	if spendingRequest.IsExpired(now) {
+		ok, err := setDecision(db, spendingRequestId, Expired, nil, now)
+		if err != nil {
+			return Request{}, "", errors.Wrapf(err, "Expiring spending request %v", spendingRequestId)
+		}
+		if ok {
+			spendingRequest.Status = Expired
+			recordDecision(db, actor, "spending expired", *spendingRequest, nil)
+		}
+		return *spendingRequest, fmt.Sprintf("Spending request %v expired", spendingRequestId), nil
+	}
+	if spendingRequest.Status != Pending {
+		return *spendingRequest, fmt.Sprintf("Spending request %v is %v", spendingRequestId,
+			spendingRequest.Status), nil
+	}
+	requester := spendingRequest.GetRequester()
+	if status == Approved && requester.Type == actor.Type && requester.Id == actor.Id {
+		return *spendingRequest, "A spending request must be approved by someone else than the requester", nil
+	}
+	ok, err := setDecision(db, spendingRequestId, status, &actor, now)
+	if err != nil {
+		return Request{}, "", errors.Wrapf(err, "Storing decision for spending request %v", spendingRequestId)
+	}
+	if !ok {
+		return *spendingRequest, fmt.Sprintf("Spending request %v was already decided", spendingRequestId), nil
+	}
+	spendingRequest.Status = status
+	spendingRequest.DecidedByType = &actor.Type
+	spendingRequest.DecidedById = &actor.Id
+	spendingRequest.DecidedByName = &actor.Name
+	spendingRequest.DecidedOn = &now
+	recordDecision(db, actor, "spending "+status.String(), *spendingRequest, nil)
+	return *spendingRequest, "", nil
+}
+
+// RecordResult stores the outcome of the execution of an approved request.
+// The funds already moved (or not) so failing to store the result is only logged.
+func RecordResult(db *sqlx.DB, actor audit.Actor, spendingRequest Request, result string, resultErr error) Request {
+	spendingRequest.Status = Executed
+	spendingRequest.Result = &result
+	if resultErr != nil {
+		spendingRequest.Status = Failed
+		spendingRequest.Result = nil
+		errorMessage := resultErr.Error()
+		spendingRequest.Error = &errorMessage
+	}
+	err := setResult(db, spendingRequest.SpendingRequestId, spendingRequest.Status,
+		spendingRequest.Result, spendingRequest.Error)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to store the result of spending request %v",
+			spendingRequest.SpendingRequestId)
+	}
+	recordDecision(db, actor, "spending "+spendingRequest.Status.String(), spendingRequest, resultErr)
+	return spendingRequest
+}
+
+func recordDecision(db *sqlx.DB, actor audit.Actor, action string, spendingRequest Request, actionErr error) {
+	parameters := map[string]interface{}{
+		"nodeId":    spendingRequest.NodeId,
+		"operation": spendingRequest.Operation.String(),
+		"action":    spendingRequest.Action,
+		"amountSat": spendingRequest.AmountSat,
+		"status":    spendingRequest.Status.String(),
+	}
+	if spendingRequest.Reason != nil {
+		parameters["reason"] = *spendingRequest.Reason
+	}
+	if spendingRequest.Result != nil {
+		parameters["result"] = *spendingRequest.Result
+	}
+	audit.Record(db, actor, action, "spendingRequest", strconv.Itoa(spendingRequest.SpendingRequestId),
+		parameters, actionErr)
+}
@@ -0,0 +1,142 @@
+package spending
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/exp/slices"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/audit"
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+// The maximum number of spending requests in one page
+const maximumRequestLimit = 1000
+
+func RegisterSpendingRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("limits", func(c *gin.Context) { getLimitsHandler(c, db) })
+	r.PUT("limits", func(c *gin.Context) { setLimitHandler(c, db) })
+	r.DELETE("limits/:spendingLimitId", func(c *gin.Context) { removeLimitHandler(c, db) })
+	r.GET("requests", func(c *gin.Context) { getRequestsHandler(c, db) })
+	r.POST("requests/:spendingRequestId/reject", func(c *gin.Context) { rejectRequestHandler(c, db) })
+}
+
+func getLimitsHandler(c *gin.Context, db *sqlx.DB) {
+	limits, err := GetLimits(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting spending limits.")
+		return
+	}
+	c.JSON(http.StatusOK, limits)
+}
+
+func setLimitHandler(c *gin.Context, db *sqlx.DB) {
+	var limit Limit
+	if err := c.BindJSON(&limit); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if !slices.Contains(cache.GetAllTorqNodeIds(), limit.NodeId) {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("nodeId", "Unknown node"))
+		return
+	}
+	if !limit.Operation.IsValid() {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("operation", "Unknown operation"))
+		return
+	}
+	if limit.PerTransactionSat != nil && *limit.PerTransactionSat < 0 {
+		server_errors.SendBadRequestFieldError(c,
+			server_errors.SingleFieldError("perTransactionSat", "The limit can't be negative"))
+		return
+	}
+	if limit.DailySat != nil && *limit.DailySat < 0 {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("dailySat", "The limit can't be negative"))
+		return
+	}
+	limit, err := setLimit(db, limit)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Setting spending limit.")
+		return
+	}
+	c.JSON(http.StatusOK, limit)
+}
+
+func removeLimitHandler(c *gin.Context, db *sqlx.DB) {
+	spendingLimitId, err := strconv.Atoi(c.Param("spendingLimitId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse spendingLimitId in the request.")
+		return
+	}
+	count, err := removeLimit(db, spendingLimitId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err,
+			fmt.Sprintf("Removing spending limit for spendingLimitId: %v.", spendingLimitId))
+		return
+	}
+	c.JSON(http.StatusOK, map[string]interface{}{"message": fmt.Sprintf("Successfully deleted %v limit(s).", count)})
+}
+
+func getRequestsHandler(c *gin.Context, db *sqlx.DB) {
+	var status *Status
+	if c.Query("status") != "" {
+		statusInt, err := strconv.Atoi(c.Query("status"))
+		if err != nil {
+			server_errors.SendBadRequest(c, "Can't process status")
+			return
+		}
+		status = (*Status)(&statusInt)
+	}
+	limit := uint64(maximumRequestLimit)
+	var offset uint64
+	var err error
+	if c.Query("limit") != "" {
+		if limit, err = strconv.ParseUint(c.Query("limit"), 10, 64); err != nil {
+			server_errors.SendBadRequest(c, "Limit must be a positive number")
+			return
+		}
+		if limit == 0 || limit > maximumRequestLimit {
+			limit = maximumRequestLimit
+		}
+	}
+	if c.Query("offset") != "" {
+		if offset, err = strconv.ParseUint(c.Query("offset"), 10, 64); err != nil {
+			server_errors.SendBadRequest(c, "Offset must be a positive number")
+			return
+		}
+	}
+	requests, total, err := getRequests(db, status, limit, offset)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting spending requests.")
+		return
+	}
+	c.JSON(http.StatusOK, ah.ApiResponse{
+		Data: requests, Pagination: ah.Pagination{
+			Total:  total,
+			Limit:  limit,
+			Offset: offset,
+		}})
+}
+
+func rejectRequestHandler(c *gin.Context, db *sqlx.DB) {
+	spendingRequestId, err := strconv.Atoi(c.Param("spendingRequestId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse spendingRequestId in the request.")
+		return
+	}
+	spendingRequest, reason, err := Reject(db, spendingRequestId, audit.GetRequestActor(c))
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Rejecting spending request.")
+		return
+	}
+	if reason != "" {
+		server_errors.SendUnprocessableEntity(c, reason)
+		return
+	}
+	c.JSON(http.StatusOK, spendingRequest)
+}
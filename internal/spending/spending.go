@@ -0,0 +1,160 @@
+package spending
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+
+	"github.com/lncapital/torq/internal/audit"
+)
+
+// Pending requests that are not approved within this duration can no longer be approved
+const pendingExpiry = 24 * time.Hour
+
+// The daily limit is evaluated over a rolling window
+const dailyWindow = 24 * time.Hour
+
+type Operation int
+
+const (
+	OnChainSend Operation = iota + 1
+	LightningPayment
+	ChannelFunding
+)
+
+func (o Operation) String() string {
+	switch o {
+	case OnChainSend:
+		return "on-chain send"
+	case LightningPayment:
+		return "lightning payment"
+	case ChannelFunding:
+		return "channel funding"
+	}
+	return "unknown"
+}
+
+func (o Operation) IsValid() bool {
+	return o >= OnChainSend && o <= ChannelFunding
+}
+
+// Action is the request type that is stored so it can be executed when it's approved
+type Action string
+
+const (
+	ActionSendCoins        Action = "sendCoins"
+	ActionNewPayment       Action = "newPayment"
+	ActionOpenChannel      Action = "openChannel"
+	ActionBatchOpenChannel Action = "batchOpenChannel"
+)
+
+func (a Action) GetOperation() Operation {
+	switch a {
+	case ActionSendCoins:
+		return OnChainSend
+	case ActionNewPayment:
+		return LightningPayment
+	case ActionOpenChannel, ActionBatchOpenChannel:
+		return ChannelFunding
+	}
+	return 0
+}
+
+type Status int
+
+const (
+	// Pending is waiting for the approval of a second user or a chat
+	Pending Status = iota + 1
+	// Approved is being executed
+	Approved
+	Executed
+	Failed
+	Rejected
+	Expired
+)
+
+func (s Status) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Approved:
+		return "approved"
+	case Executed:
+		return "executed"
+	case Failed:
+		return "failed"
+	case Rejected:
+		return "rejected"
+	case Expired:
+		return "expired"
+	}
+	return "unknown"
+}
+
+type Limit struct {
+	SpendingLimitId int       `json:"spendingLimitId" db:"spending_limit_id"`
+	NodeId          int       `json:"nodeId" db:"node_id"`
+	Operation       Operation `json:"operation" db:"operation"`
+	// PerTransactionSat and DailySat are not limited when nil
+	PerTransactionSat *int64    `json:"perTransactionSat" db:"per_transaction_sat"`
+	DailySat          *int64    `json:"dailySat" db:"daily_sat"`
+	CreatedOn         time.Time `json:"createdOn" db:"created_on"`
+	UpdatedOn         time.Time `json:"updatedOn" db:"updated_on"`
+}
+
+type Request struct {
+	SpendingRequestId int            `json:"spendingRequestId" db:"spending_request_id"`
+	NodeId            int            `json:"nodeId" db:"node_id"`
+	Operation         Operation      `json:"operation" db:"operation"`
+	Action            Action         `json:"action" db:"action"`
+	AmountSat         int64          `json:"amountSat" db:"amount_sat"`
+	Request           types.JSONText `json:"request" db:"request"`
+	Status            Status         `json:"status" db:"status"`
+	// Reason explains why the request requires approval
+	Reason          *string          `json:"reason" db:"reason"`
+	RequestedByType audit.ActorType  `json:"requestedByType" db:"requested_by_type"`
+	RequestedById   *string          `json:"requestedById" db:"requested_by_id"`
+	RequestedByName *string          `json:"requestedByName" db:"requested_by_name"`
+	DecidedByType   *audit.ActorType `json:"decidedByType" db:"decided_by_type"`
+	DecidedById     *string          `json:"decidedById" db:"decided_by_id"`
+	DecidedByName   *string          `json:"decidedByName" db:"decided_by_name"`
+	DecidedOn       *time.Time       `json:"decidedOn" db:"decided_on"`
+	Result          *string          `json:"result" db:"result"`
+	Error           *string          `json:"error" db:"error"`
+	NotifiedOn      *time.Time       `json:"notifiedOn" db:"notified_on"`
+	CreatedOn       time.Time        `json:"createdOn" db:"created_on"`
+	UpdatedOn       time.Time        `json:"updatedOn" db:"updated_on"`
+}
+
+func (r Request) GetRequester() audit.Actor {
+	actor := audit.Actor{Type: r.RequestedByType}
+	if r.RequestedById != nil {
+		actor.Id = *r.RequestedById
+	}
+	if r.RequestedByName != nil {
+		actor.Name = *r.RequestedByName
+	}
+	return actor
+}
+
+func (r Request) IsExpired(now time.Time) bool {
+	return r.Status == Pending && now.Sub(r.CreatedOn) > pendingExpiry
+}
+
+// evaluateLimit returns the reason why the amount requires approval or an empty string when it's within the limit.
+// spentSat is the amount that was already spent within the daily window.
+func evaluateLimit(limit *Limit, amountSat int64, spentSat int64) string {
+	if limit == nil {
+		return ""
+	}
+	if limit.PerTransactionSat != nil && amountSat > *limit.PerTransactionSat {
+		return fmt.Sprintf("%v sats exceeds the per transaction limit of %v sats for %v",
+			amountSat, *limit.PerTransactionSat, limit.Operation)
+	}
+	if limit.DailySat != nil && spentSat+amountSat > *limit.DailySat {
+		return fmt.Sprintf("%v sats exceeds the daily limit of %v sats for %v (%v sats spent in the last 24 hours)",
+			amountSat, *limit.DailySat, limit.Operation, spentSat)
+	}
+	return ""
+}
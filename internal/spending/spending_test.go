@@ -0,0 +1,62 @@
+package spending
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluateLimit(t *testing.T) {
+	perTransactionSat := int64(100_000)
+	dailySat := int64(250_000)
+	limit := &Limit{Operation: OnChainSend, PerTransactionSat: &perTransactionSat, DailySat: &dailySat}
+	tests := []struct {
+		name        string
+		limit       *Limit
+		amountSat   int64
+		spentSat    int64
+		needsReview bool
+	}{
+		{"No limit", nil, 10_000_000, 10_000_000, false},
+		{"Within limits", limit, 100_000, 150_000, false},
+		{"Over the per transaction limit", limit, 100_001, 0, true},
+		{"Over the daily limit", limit, 50_000, 200_001, true},
+		{"Only a daily limit", &Limit{Operation: ChannelFunding, DailySat: &dailySat}, 250_000, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reason := evaluateLimit(test.limit, test.amountSat, test.spentSat)
+			if (reason != "") != test.needsReview {
+				t.Errorf("evaluateLimit() = %q, needs review %v", reason, test.needsReview)
+			}
+		})
+	}
+}
+
+func TestRequestIsExpired(t *testing.T) {
+	now := time.Now()
+	request := Request{Status: Pending, CreatedOn: now.Add(-pendingExpiry - time.Minute)}
+	if !request.IsExpired(now) {
+		t.Error("IsExpired() = false for an old pending request")
+	}
+	request.Status = Executed
+	if request.IsExpired(now) {
+		t.Error("IsExpired() = true for an executed request")
+	}
+	request = Request{Status: Pending, CreatedOn: now.Add(-time.Hour)}
+	if request.IsExpired(now) {
+		t.Error("IsExpired() = true for a recent pending request")
+	}
+}
+
+func TestActionGetOperation(t *testing.T) {
+	for action, operation := range map[Action]Operation{
+		ActionSendCoins:        OnChainSend,
+		ActionNewPayment:       LightningPayment,
+		ActionOpenChannel:      ChannelFunding,
+		ActionBatchOpenChannel: ChannelFunding,
+	} {
+		if action.GetOperation() != operation {
+			t.Errorf("%v.GetOperation() = %v, want %v", action, action.GetOperation(), operation)
+		}
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
+	"golang.org/x/exp/slices"
 
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/pkg/server_errors"
 )
@@ -43,7 +45,7 @@ func getTagHandler(c *gin.Context, db *sqlx.DB) {
 		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Getting tag for tagId: %v", tagId))
 		return
 	}
-	c.JSON(http.StatusOK, tag)
+	c.JSON(http.StatusOK, restrictTags(c, []TagResponse{tag})[0])
 }
 
 func deleteTagHandler(c *gin.Context, db *sqlx.DB) {
@@ -67,7 +69,7 @@ func getTagsHandler(c *gin.Context, db *sqlx.DB) {
 		server_errors.WrapLogAndSendServerError(c, err, "Getting tags.")
 		return
 	}
-	c.JSON(http.StatusOK, tags)
+	c.JSON(http.StatusOK, restrictTags(c, tags))
 }
 
 func getTagsByCategoryHandler(c *gin.Context, db *sqlx.DB) {
@@ -81,7 +83,7 @@ func getTagsByCategoryHandler(c *gin.Context, db *sqlx.DB) {
 		server_errors.WrapLogAndSendServerError(c, err, "Getting tags.")
 		return
 	}
-	c.JSON(http.StatusOK, tags)
+	c.JSON(http.StatusOK, restrictTags(c, tags))
 }
 
 func createTagHandler(c *gin.Context, db *sqlx.DB) {
@@ -123,6 +125,9 @@ func tagEntityHandler(c *gin.Context, db *sqlx.DB) {
 		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
 		return
 	}
+	if !canTagChannel(c, t.ChannelId) {
+		return
+	}
 	err := TagEntity(db, t)
 	if err != nil {
 		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Setting tag for tagId: %v", t.TagId))
@@ -138,6 +143,9 @@ func untagEntityHandler(c *gin.Context, db *sqlx.DB) {
 		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
 		return
 	}
+	if !canTagChannel(c, t.ChannelId) {
+		return
+	}
 	err := UntagEntity(db, t)
 	if err != nil {
 		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Setting tag for tagId: %v", t.TagId))
@@ -200,3 +208,44 @@ func getNodeTagsHandler(c *gin.Context, db *sqlx.DB) {
 	}
 	c.JSON(http.StatusOK, tags)
 }
+
+// restrictTags removes the channels and peers of other Torq nodes when the API token is restricted to a node
+func restrictTags(c *gin.Context, tags []TagResponse) []TagResponse {
+	restrictedNodeId := auth.GetRestrictedNodeId(c)
+	if restrictedNodeId == nil {
+		return tags
+	}
+	peerNodeIds := cache.GetChannelPeerNodeIdsByTorqNodeIds([]int{*restrictedNodeId})
+	for tagIndex, tag := range tags {
+		channels := []TaggedChannels{}
+		for _, channel := range tag.Channels {
+			if isChannelOfNode(channel.ChannelId, *restrictedNodeId) {
+				channels = append(channels, channel)
+			}
+		}
+		nodes := []TaggedNodes{}
+		for _, node := range tag.Nodes {
+			if slices.Contains(peerNodeIds, node.NodeId) {
+				nodes = append(nodes, node)
+			}
+		}
+		tags[tagIndex].Channels = channels
+		tags[tagIndex].Nodes = nodes
+	}
+	return tags
+}
+
+// canTagChannel checks that an API token restricted to a node only (un)tags the channels of that node
+func canTagChannel(c *gin.Context, channelId *int) bool {
+	restrictedNodeId := auth.GetRestrictedNodeId(c)
+	if restrictedNodeId == nil || channelId == nil || isChannelOfNode(*channelId, *restrictedNodeId) {
+		return true
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden: token is restricted to a node"})
+	return false
+}
+
+func isChannelOfNode(channelId int, nodeId int) bool {
+	channelSettings := cache.GetChannelSettingByChannelId(channelId)
+	return channelSettings.FirstNodeId == nodeId || channelSettings.SecondNodeId == nodeId
+}
@@ -0,0 +1,223 @@
+package tags
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+// taggedEntity is either a channel or a (peer) node
+type taggedEntity struct {
+	ChannelId  *int `json:"channelId"`
+	PeerNodeId *int `json:"peerNodeId"`
+}
+
+func GetV1Endpoints(db *sqlx.DB) []ah.Endpoint {
+	return []ah.Endpoint{
+		{
+			Method:    http.MethodGet,
+			Path:      "/tags",
+			Resource:  "tags",
+			Summary:   "List tags with the tagged channels and nodes",
+			Response:  TagResponse{},
+			Paginated: true,
+			Handler:   func(c *gin.Context) { getV1TagsHandler(c, db) },
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/tags/:tagId",
+			Resource: "tags",
+			Summary:  "Get a tag with the tagged channels and nodes",
+			Response: TagResponse{},
+			Handler:  func(c *gin.Context) { getV1TagHandler(c, db) },
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/tags",
+			Resource:    "tags",
+			Summary:     "Create a tag",
+			RequestBody: Tag{},
+			Response:    Tag{},
+			Handler:     func(c *gin.Context) { createV1TagHandler(c, db) },
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/tags/:tagId",
+			Resource:    "tags",
+			Summary:     "Update the name, style or category of a tag",
+			RequestBody: Tag{},
+			Response:    Tag{},
+			Handler:     func(c *gin.Context) { updateV1TagHandler(c, db) },
+		},
+		{
+			Method:   http.MethodDelete,
+			Path:     "/tags/:tagId",
+			Resource: "tags",
+			Summary:  "Delete a tag",
+			Handler:  func(c *gin.Context) { deleteV1TagHandler(c, db) },
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/tags/:tagId/tag",
+			Resource:    "tags",
+			Summary:     "Add the tag to a channel or a node",
+			RequestBody: taggedEntity{},
+			Response:    taggedEntity{},
+			Handler:     func(c *gin.Context) { tagV1EntityHandler(c, db, true) },
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/tags/:tagId/untag",
+			Resource:    "tags",
+			Summary:     "Remove the tag from a channel or a node",
+			RequestBody: taggedEntity{},
+			Response:    taggedEntity{},
+			Handler:     func(c *gin.Context) { tagV1EntityHandler(c, db, false) },
+		},
+	}
+}
+
+func getV1TagsHandler(c *gin.Context, db *sqlx.DB) {
+	pagination, err := ah.GetPagination(c)
+	if err != nil {
+		server_errors.SendBadRequestFromError(c, err)
+		return
+	}
+	tags, err := GetTags(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting tags.")
+		return
+	}
+	tags = restrictTags(c, tags)
+	start, end := pagination.GetPage(len(tags))
+	c.JSON(http.StatusOK, ah.ApiResponse{Data: tags[start:end], Pagination: pagination})
+}
+
+// getV1Tag returns the tag of the tagId path parameter, the response is sent when it's not found
+func getV1Tag(c *gin.Context, db *sqlx.DB) (TagResponse, bool) {
+	tagId, err := strconv.Atoi(c.Param("tagId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse tagId in the request.")
+		return TagResponse{}, false
+	}
+	tag, err := GetTag(db, tagId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Getting tag for tagId: %v", tagId))
+		return TagResponse{}, false
+	}
+	if tag.TagId == 0 {
+		server_errors.SendNotFound(c, "Tag not found")
+		return TagResponse{}, false
+	}
+	return tag, true
+}
+
+func getV1TagHandler(c *gin.Context, db *sqlx.DB) {
+	tag, found := getV1Tag(c, db)
+	if !found {
+		return
+	}
+	c.JSON(http.StatusOK, restrictTags(c, []TagResponse{tag})[0])
+}
+
+func createV1TagHandler(c *gin.Context, db *sqlx.DB) {
+	var t Tag
+	if err := c.BindJSON(&t); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if t.Name == "" {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("name", "Name is required"))
+		return
+	}
+	storedTag, err := createTag(db, t)
+	if errors.Is(err, database.SqlUniqueConstraintError) {
+		server_errors.SendUnprocessableEntity(c, "A tag with this name already exists.")
+		return
+	}
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Adding tag.")
+		return
+	}
+	c.JSON(http.StatusOK, storedTag)
+}
+
+func updateV1TagHandler(c *gin.Context, db *sqlx.DB) {
+	tag, found := getV1Tag(c, db)
+	if !found {
+		return
+	}
+	var t Tag
+	if err := c.BindJSON(&t); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if t.Name == "" {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("name", "Name is required"))
+		return
+	}
+	t.TagId = tag.TagId
+	t.CreatedOn = tag.CreatedOn
+	storedTag, err := updateTag(db, t)
+	if errors.Is(err, database.SqlUniqueConstraintError) {
+		server_errors.SendUnprocessableEntity(c, "A tag with this name already exists.")
+		return
+	}
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Setting tag for tagId: %v", t.TagId))
+		return
+	}
+	c.JSON(http.StatusOK, storedTag)
+}
+
+func deleteV1TagHandler(c *gin.Context, db *sqlx.DB) {
+	tag, found := getV1Tag(c, db)
+	if !found {
+		return
+	}
+	err := deleteTag(db, tag.TagId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Deleting tag for tagId: %v", tag.TagId))
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+func tagV1EntityHandler(c *gin.Context, db *sqlx.DB, tag bool) {
+	storedTag, found := getV1Tag(c, db)
+	if !found {
+		return
+	}
+	var entity taggedEntity
+	if err := c.BindJSON(&entity); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if (entity.ChannelId == nil) == (entity.PeerNodeId == nil) {
+		server_errors.SendUnprocessableEntity(c, "Either channelId or peerNodeId is required.")
+		return
+	}
+	if !canTagChannel(c, entity.ChannelId) {
+		return
+	}
+	request := TagEntityRequest{TagId: storedTag.TagId, ChannelId: entity.ChannelId, NodeId: entity.PeerNodeId}
+	var err error
+	if tag {
+		err = TagEntity(db, request)
+	} else {
+		err = UntagEntity(db, request)
+	}
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Setting tag for tagId: %v", storedTag.TagId))
+		return
+	}
+	c.JSON(http.StatusOK, entity)
+}
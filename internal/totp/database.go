@@ -0,0 +1,175 @@
+package totp
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/lncapital/torq/internal/database"
+)
+
+// The built-in admin (userId 0) is stored with a NULL user_id
+func getUserIdParameter(userId int) *int {
+	if userId == 0 {
+		return nil
+	}
+	return &userId
+}
+
+func GetTotp(db *sqlx.DB, userId int) (*Totp, error) {
+	var totp Totp
+	err := db.Get(&totp, `
+		SELECT coalesce(user_id, 0) AS user_id, secret, recovery_code_hashes, last_used_step,
+			enabled_on, created_on, updated_on
+		FROM user_totp
+		WHERE coalesce(user_id, 0)=$1;`, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return &totp, nil
+}
+
+func IsEnabled(db *sqlx.DB, userId int) (bool, error) {
+	totp, err := GetTotp(db, userId)
+	if err != nil {
+		return false, err
+	}
+	return totp != nil && totp.IsEnabled(), nil
+}
+
+func GetStatus(db *sqlx.DB, userId int) (Status, error) {
+	totp, err := GetTotp(db, userId)
+	if err != nil {
+		return Status{}, err
+	}
+	if totp == nil || !totp.IsEnabled() {
+		return Status{}, nil
+	}
+	return Status{Enabled: true, RecoveryCodesLeft: len(totp.RecoveryCodeHashes)}, nil
+}
+
+// Enroll generates a new secret that needs to be confirmed with Enable.
+// A previous enrollment that was not confirmed is replaced, an enabled one is never replaced.
+func Enroll(db *sqlx.DB, userId int, accountName string) (Enrollment, bool, error) {
+	secret, err := generateSecret()
+	if err != nil {
+		return Enrollment{}, false, err
+	}
+	now := time.Now().UTC()
+	_, err = db.Exec(`DELETE FROM user_totp WHERE coalesce(user_id, 0)=$1 AND enabled_on IS NULL;`, userId)
+	if err != nil {
+		return Enrollment{}, false, errors.Wrap(err, database.SqlExecutionError)
+	}
+	// The unique index on the user_id makes sure an enabled TOTP is not replaced
+	res, err := db.Exec(`
+		INSERT INTO user_totp (user_id, secret, created_on, updated_on) VALUES ($1, $2, $3, $3)
+		ON CONFLICT DO NOTHING;`, getUserIdParameter(userId), secret, now)
+	if err != nil {
+		return Enrollment{}, false, errors.Wrap(err, database.SqlExecutionError)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return Enrollment{}, false, errors.Wrap(err, database.SqlAffectedRowsCheckError)
+	}
+	if rowsAffected == 0 {
+		return Enrollment{}, false, nil
+	}
+	return Enrollment{Secret: secret, Uri: getUri(secret, accountName)}, true, nil
+}
+
+// Enable confirms the enrollment with a code from the authenticator app and returns the recovery codes.
+// The recovery codes are only stored hashed so they can't be shown again.
+func Enable(db *sqlx.DB, userId int, code string, now time.Time) ([]string, bool, error) {
+	totp, err := GetTotp(db, userId)
+	if err != nil {
+		return nil, false, err
+	}
+	if totp == nil || totp.IsEnabled() {
+		return nil, false, nil
+	}
+	step, ok := validateCode(totp.Secret, code, now, totp.LastUsedStep)
+	if !ok {
+		return nil, false, nil
+	}
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, false, err
+	}
+	_, err = db.Exec(`
+		UPDATE user_totp
+		SET enabled_on=$1, last_used_step=$2, recovery_code_hashes=$3, updated_on=$1
+		WHERE coalesce(user_id, 0)=$4 AND enabled_on IS NULL;`,
+		now, step, pq.Array(hashRecoveryCodes(recoveryCodes)), userId)
+	if err != nil {
+		return nil, false, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return recoveryCodes, true, nil
+}
+
+// Verify checks a code from the authenticator app or a recovery code.
+// Codes can only be used once: the time step of a code is stored and a recovery code is removed.
+// Returns false when the code is invalid or TOTP is not enabled.
+func Verify(db *sqlx.DB, userId int, code string, now time.Time) (bool, error) {
+	totp, err := GetTotp(db, userId)
+	if err != nil {
+		return false, err
+	}
+	if totp == nil || !totp.IsEnabled() {
+		return false, nil
+	}
+	var res sql.Result
+	if step, ok := validateCode(totp.Secret, code, now, totp.LastUsedStep); ok {
+		// The condition on last_used_step makes sure concurrent requests can't use the same code
+		res, err = db.Exec(`
+			UPDATE user_totp SET last_used_step=$1, updated_on=$2
+			WHERE coalesce(user_id, 0)=$3 AND last_used_step<$1;`, step, now, userId)
+	} else {
+		res, err = db.Exec(`
+			UPDATE user_totp
+			SET recovery_code_hashes=array_remove(recovery_code_hashes, $1), updated_on=$2
+			WHERE coalesce(user_id, 0)=$3 AND $1=ANY(recovery_code_hashes);`,
+			hashRecoveryCode(code), now, userId)
+	}
+	if err != nil {
+		return false, errors.Wrap(err, database.SqlExecutionError)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, database.SqlAffectedRowsCheckError)
+	}
+	return rowsAffected == 1, nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes (used or not) with new ones
+func RegenerateRecoveryCodes(db *sqlx.DB, userId int) ([]string, error) {
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	_, err = db.Exec(`
+		UPDATE user_totp SET recovery_code_hashes=$1, updated_on=$2
+		WHERE coalesce(user_id, 0)=$3 AND enabled_on IS NOT NULL;`,
+		pq.Array(hashRecoveryCodes(recoveryCodes)), time.Now().UTC(), userId)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return recoveryCodes, nil
+}
+
+func RemoveTotp(db *sqlx.DB, userId int) (int64, error) {
+	res, err := db.Exec(`DELETE FROM user_totp WHERE coalesce(user_id, 0)=$1;`, userId)
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlAffectedRowsCheckError)
+	}
+	return rowsAffected, nil
+}
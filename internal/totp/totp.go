@@ -0,0 +1,153 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // RFC 6238 uses HMAC-SHA1 and it's what authenticator apps support
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq"
+)
+
+const issuer = "Torq"
+
+// Codes are valid for a period of 30 seconds (the time step)
+const period = 30
+
+const digits = 6
+
+// Number of time steps before and after the current one that are accepted to allow for clock drift
+const allowedSkew = 1
+
+const secretLength = 20
+
+const recoveryCodeCount = 10
+
+const recoveryCodeLength = 5
+
+//nolint:gochecknoglobals
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type Totp struct {
+	UserId             int            `json:"userId" db:"user_id"`
+	Secret             string         `json:"-" db:"secret"`
+	RecoveryCodeHashes pq.StringArray `json:"-" db:"recovery_code_hashes"`
+	LastUsedStep       int64          `json:"-" db:"last_used_step"`
+	EnabledOn          *time.Time     `json:"enabledOn" db:"enabled_on"`
+	CreatedOn          time.Time      `json:"createdOn" db:"created_on"`
+	UpdatedOn          time.Time      `json:"updatedOn" db:"updated_on"`
+}
+
+func (totp Totp) IsEnabled() bool {
+	return totp.EnabledOn != nil
+}
+
+type Status struct {
+	Enabled           bool `json:"enabled"`
+	RecoveryCodesLeft int  `json:"recoveryCodesLeft"`
+}
+
+type Enrollment struct {
+	Secret string `json:"secret"`
+	// The otpauth:// URI to show as QR code to the authenticator app
+	Uri string `json:"uri"`
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, secretLength)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", errors.Wrap(err, "Generating random secret")
+	}
+	return secretEncoding.EncodeToString(secret), nil
+}
+
+func getUri(secret string, accountName string) string {
+	values := url.Values{}
+	values.Set("secret", secret)
+	values.Set("issuer", issuer)
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", fmt.Sprintf("%d", digits))
+	values.Set("period", fmt.Sprintf("%d", period))
+	return fmt.Sprintf("otpauth://totp/%s:%s?%s",
+		url.PathEscape(issuer), url.PathEscape(accountName), values.Encode())
+}
+
+func getStep(now time.Time) int64 {
+	return now.Unix() / period
+}
+
+// generateCode calculates the HOTP value (RFC 4226) for the time step
+func generateCode(secret []byte, step int64) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulo := uint32(1)
+	for i := 0; i < digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%modulo)
+}
+
+// validateCode returns the time step the code belongs to when it's valid and not older than the last used step
+func validateCode(secret string, code string, now time.Time, lastUsedStep int64) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != digits {
+		return 0, false
+	}
+	key, err := secretEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+	currentStep := getStep(now)
+	for step := currentStep - allowedSkew; step <= currentStep+allowedSkew; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(generateCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func generateRecoveryCodes() ([]string, error) {
+	recoveryCodes := make([]string, recoveryCodeCount)
+	for i := range recoveryCodes {
+		recoveryCode := make([]byte, recoveryCodeLength*2)
+		_, err := rand.Read(recoveryCode)
+		if err != nil {
+			return nil, errors.Wrap(err, "Generating random recovery code")
+		}
+		encoded := hex.EncodeToString(recoveryCode)
+		recoveryCodes[i] = encoded[:recoveryCodeLength*2] + "-" + encoded[recoveryCodeLength*2:]
+	}
+	return recoveryCodes, nil
+}
+
+func hashRecoveryCode(recoveryCode string) string {
+	recoveryCode = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(recoveryCode), "-", ""))
+	hash := sha256.Sum256([]byte(recoveryCode))
+	return hex.EncodeToString(hash[:])
+}
+
+func hashRecoveryCodes(recoveryCodes []string) []string {
+	hashes := make([]string, len(recoveryCodes))
+	for i, recoveryCode := range recoveryCodes {
+		hashes[i] = hashRecoveryCode(recoveryCode)
+	}
+	return hashes
+}
@@ -0,0 +1,69 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerateCode(t *testing.T) {
+	// Test vectors from RFC 6238 (SHA1) truncated to 6 digits
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, test := range tests {
+		code := generateCode(secret, getStep(time.Unix(test.unix, 0)))
+		if code != test.code {
+			t.Errorf("generateCode() at %v = %v, want %v", test.unix, code, test.code)
+		}
+	}
+}
+
+func TestValidateCode(t *testing.T) {
+	secret := secretEncoding.EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1111111111, 0)
+	step := getStep(now)
+
+	if _, ok := validateCode(secret, "050471", now, 0); !ok {
+		t.Error("validateCode() rejected the current code")
+	}
+	if _, ok := validateCode(secret, "050 471", now, 0); !ok {
+		t.Error("validateCode() rejected the current code with a space")
+	}
+	if _, ok := validateCode(secret, "050471", now.Add(period*time.Second), 0); !ok {
+		t.Error("validateCode() rejected the previous code")
+	}
+	if _, ok := validateCode(secret, "050471", now.Add(3*period*time.Second), 0); ok {
+		t.Error("validateCode() accepted an expired code")
+	}
+	if _, ok := validateCode(secret, "050471", now, step); ok {
+		t.Error("validateCode() accepted a code that was already used")
+	}
+	if _, ok := validateCode(secret, "123456", now, 0); ok {
+		t.Error("validateCode() accepted an invalid code")
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recoveryCodes) != recoveryCodeCount {
+		t.Fatalf("generateRecoveryCodes() returned %v codes, want %v", len(recoveryCodes), recoveryCodeCount)
+	}
+	withoutDash := recoveryCodes[0][:recoveryCodeLength*2] + recoveryCodes[0][recoveryCodeLength*2+1:]
+	if hashRecoveryCode(recoveryCodes[0]) != hashRecoveryCode(withoutDash) {
+		t.Error("hashRecoveryCode() depends on the dash")
+	}
+	if hashRecoveryCode(recoveryCodes[0]) == hashRecoveryCode(recoveryCodes[1]) {
+		t.Error("hashRecoveryCode() returned the same hash for different codes")
+	}
+}
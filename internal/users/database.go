@@ -0,0 +1,139 @@
+package users
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/lncapital/torq/internal/database"
+)
+
+func GetUsers(db *sqlx.DB) ([]User, error) {
+	var users []User
+	err := db.Select(&users, `SELECT * FROM torq_user ORDER BY username;`)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []User{}, nil
+		}
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return users, nil
+}
+
+// GetUserById returns nil when the user does not exist
+func GetUserById(db *sqlx.DB, userId int) (*User, error) {
+	var user User
+	err := db.Get(&user, `SELECT * FROM torq_user WHERE user_id=$1;`, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return &user, nil
+}
+
+// GetUserByUsername returns nil when the user does not exist
+func GetUserByUsername(db *sqlx.DB, username string) (*User, error) {
+	var user User
+	err := db.Get(&user, `SELECT * FROM torq_user WHERE username=$1;`, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return &user, nil
+}
+
+// CheckPassword returns the user when the username and password match and nil otherwise
+func CheckPassword(db *sqlx.DB, username string, password string) (*User, error) {
+	user, err := GetUserByUsername(db, username)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Getting user %v", username)
+	}
+	if user == nil {
+		return nil, nil
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Comparing password hash")
+	}
+	return user, nil
+}
+
+func addUser(db *sqlx.DB, userRequest UserRequest) (User, error) {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(userRequest.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return User{}, errors.Wrap(err, "Hashing password")
+	}
+	user := User{
+		Username:     userRequest.Username,
+		PasswordHash: string(passwordHash),
+		Role:         userRequest.Role,
+		CreatedOn:    time.Now().UTC(),
+	}
+	user.UpdatedOn = user.CreatedOn
+	err = db.QueryRowx(`INSERT INTO torq_user (username, password_hash, role, created_on, updated_on)
+		VALUES ($1, $2, $3, $4, $5) RETURNING user_id;`,
+		user.Username, user.PasswordHash, user.Role, user.CreatedOn, user.UpdatedOn).Scan(&user.UserId)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == "23505" {
+				return User{}, database.SqlUniqueConstraintError
+			}
+		}
+		return User{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return user, nil
+}
+
+func setUser(db *sqlx.DB, userRequest UserRequest) (User, error) {
+	user, err := GetUserById(db, userRequest.UserId)
+	if err != nil {
+		return User{}, errors.Wrapf(err, "Getting user for userId: %v", userRequest.UserId)
+	}
+	if user == nil {
+		return User{}, errors.Newf("User not found for userId: %v", userRequest.UserId)
+	}
+	user.Username = userRequest.Username
+	user.Role = userRequest.Role
+	if userRequest.Password != "" {
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(userRequest.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return User{}, errors.Wrap(err, "Hashing password")
+		}
+		user.PasswordHash = string(passwordHash)
+	}
+	user.UpdatedOn = time.Now().UTC()
+	_, err = db.Exec(`UPDATE torq_user SET username=$1, password_hash=$2, role=$3, updated_on=$4 WHERE user_id=$5;`,
+		user.Username, user.PasswordHash, user.Role, user.UpdatedOn, user.UserId)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == "23505" {
+				return User{}, database.SqlUniqueConstraintError
+			}
+		}
+		return User{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return *user, nil
+}
+
+func removeUser(db *sqlx.DB, userId int) (int64, error) {
+	res, err := db.Exec(`DELETE FROM torq_user WHERE user_id=$1;`, userId)
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlAffectedRowsCheckError)
+	}
+	return rowsAffected, nil
+}
@@ -0,0 +1,135 @@
+package users
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/internal/totp"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+// The username of the built-in admin that logs in with the torq password
+const BuiltInAdminUsername = "admin"
+
+func RegisterUserRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("", func(c *gin.Context) { getUsersHandler(c, db) })
+	r.POST("", func(c *gin.Context) { addUserHandler(c, db) })
+	r.PUT("", func(c *gin.Context) { setUserHandler(c, db) })
+	r.DELETE(":userId", func(c *gin.Context) { removeUserHandler(c, db) })
+	r.DELETE(":userId/totp", func(c *gin.Context) { removeUserTotpHandler(c, db) })
+}
+
+func getUsersHandler(c *gin.Context, db *sqlx.DB) {
+	users, err := GetUsers(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting users.")
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+func addUserHandler(c *gin.Context, db *sqlx.DB) {
+	var userRequest UserRequest
+	if err := c.BindJSON(&userRequest); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if strings.TrimSpace(userRequest.Password) == "" {
+		server_errors.SendUnprocessableEntity(c, "Failed to find password in the request.")
+		return
+	}
+	if !validateUserRequest(c, userRequest) {
+		return
+	}
+	user, err := addUser(db, userRequest)
+	if err != nil {
+		sendUserError(c, err, fmt.Sprintf("Adding user %v.", userRequest.Username))
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func setUserHandler(c *gin.Context, db *sqlx.DB) {
+	var userRequest UserRequest
+	if err := c.BindJSON(&userRequest); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if userRequest.UserId == 0 {
+		server_errors.SendUnprocessableEntity(c, "Failed to find userId in the request.")
+		return
+	}
+	if !validateUserRequest(c, userRequest) {
+		return
+	}
+	user, err := setUser(db, userRequest)
+	if err != nil {
+		sendUserError(c, err, fmt.Sprintf("Setting user for userId: %v.", userRequest.UserId))
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func removeUserHandler(c *gin.Context, db *sqlx.DB) {
+	userId, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse userId in the request.")
+		return
+	}
+	count, err := removeUser(db, userId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Removing user for userId: %v.", userId))
+		return
+	}
+	c.JSON(http.StatusOK, map[string]interface{}{"message": fmt.Sprintf("Successfully deleted %v user(s).", count)})
+}
+
+func validateUserRequest(c *gin.Context, userRequest UserRequest) bool {
+	if strings.TrimSpace(userRequest.Username) == "" {
+		server_errors.SendUnprocessableEntity(c, "Failed to find username in the request.")
+		return false
+	}
+	if userRequest.Username == BuiltInAdminUsername {
+		server_errors.SendBadRequestFieldError(c,
+			server_errors.SingleFieldError("username", "Username is reserved for the built-in admin."))
+		return false
+	}
+	if !userRequest.Role.IsValid() {
+		server_errors.SendBadRequestFieldError(c, server_errors.SingleFieldError("role", "Unknown role."))
+		return false
+	}
+	return true
+}
+
+func sendUserError(c *gin.Context, err error, message string) {
+	if errors.Is(err, database.SqlUniqueConstraintError) {
+		se := server_errors.SingleFieldError("username", "Username already exists.")
+		se.AddServerError(err.Error())
+		server_errors.SendBadRequestFieldError(c, se)
+		return
+	}
+	server_errors.WrapLogAndSendServerError(c, err, message)
+}
+
+// removeUserTotpHandler removes the TOTP of a user that lost their authenticator app and recovery codes.
+// userId 0 is the built-in admin.
+func removeUserTotpHandler(c *gin.Context, db *sqlx.DB) {
+	userId, err := strconv.Atoi(c.Param("userId"))
+	if err != nil || userId < 0 {
+		server_errors.SendBadRequest(c, "Failed to find/parse userId in the request.")
+		return
+	}
+	count, err := totp.RemoveTotp(db, userId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Removing TOTP for userId: %v.", userId))
+		return
+	}
+	c.JSON(http.StatusOK, map[string]interface{}{"message": fmt.Sprintf("Successfully removed %v TOTP(s).", count)})
+}
@@ -0,0 +1,47 @@
+package users
+
+import (
+	"time"
+)
+
+type Role int
+
+// Roles are ordered: every role has the permissions of the roles before it
+const (
+	RoleViewer Role = iota + 1
+	RoleOperator
+	RoleAdmin
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleOperator:
+		return "operator"
+	case RoleAdmin:
+		return "admin"
+	}
+	return "unknown"
+}
+
+func (r Role) IsValid() bool {
+	return r >= RoleViewer && r <= RoleAdmin
+}
+
+type User struct {
+	UserId       int       `json:"userId" db:"user_id"`
+	Username     string    `json:"username" db:"username"`
+	PasswordHash string    `json:"-" db:"password_hash"`
+	Role         Role      `json:"role" db:"role"`
+	CreatedOn    time.Time `json:"createdOn" db:"created_on"`
+	UpdatedOn    time.Time `json:"updatedOn" db:"updated_on"`
+}
+
+type UserRequest struct {
+	UserId   int    `json:"userId"`
+	Username string `json:"username"`
+	// Password is only updated when it's not empty
+	Password string `json:"password"`
+	Role     Role   `json:"role"`
+}
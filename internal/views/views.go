@@ -925,6 +925,51 @@ func getTableViewColumnDefinitions() []tableViewColumnDefinition {
 				PageChannels: 54,
 			},
 		},
+		{
+			key:        "netFlowPerDay",
+			sortable:   true,
+			filterable: true,
+			heading:    "Net Flow per Day",
+			visualType: "NumericCell",
+			valueType:  "number",
+			suffix:     "sat",
+			pages: map[TableViewPage]int{
+				PageChannels: 56,
+			},
+		},
+		{
+			key:        "daysUntilDepleted",
+			sortable:   true,
+			filterable: true,
+			heading:    "Days Until Depleted",
+			visualType: "NumericCell",
+			valueType:  "number",
+			pages: map[TableViewPage]int{
+				PageChannels: 57,
+			},
+		},
+		{
+			key:        "daysUntilSaturated",
+			sortable:   true,
+			filterable: true,
+			heading:    "Days Until Saturated",
+			visualType: "NumericCell",
+			valueType:  "number",
+			pages: map[TableViewPage]int{
+				PageChannels: 58,
+			},
+		},
+		{
+			key:        "peerScore",
+			sortable:   true,
+			filterable: true,
+			heading:    "Peer Score",
+			visualType: "NumericCell",
+			valueType:  "number",
+			pages: map[TableViewPage]int{
+				PageChannels: 59,
+			},
+		},
 		{
 			key:        "date",
 			sortable:   true,
@@ -1672,5 +1717,16 @@ func getTableViewColumnDefinitions() []tableViewColumnDefinition {
 				PagePeers: 10,
 			},
 		},
+		{
+			key:        "score",
+			sortable:   true,
+			filterable: true,
+			heading:    "Peer Score",
+			visualType: "NumericCell",
+			valueType:  "number",
+			pages: map[TableViewPage]int{
+				PagePeers: 11,
+			},
+		},
 	}
 }
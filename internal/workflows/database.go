@@ -1298,7 +1298,7 @@ func addWorkflowVersionNodeLog(db *sqlx.DB, workflowVersionNodeLog WorkflowVersi
 
 func GetNodeLogs(db *sqlx.DB, workflowVersionNodeId int, maximumResultCount int) ([]WorkflowVersionNodeLog, error) {
 	var wfvnls []WorkflowVersionNodeLog
-	err := db.Get(&wfvnls,
+	err := db.Select(&wfvnls,
 		"SELECT * FROM workflow_version_node_log WHERE workflow_version_node_id=$1 ORDER BY created_on DESC LIMIT $2;",
 		workflowVersionNodeId, maximumResultCount)
 	if err != nil {
@@ -1312,7 +1312,7 @@ func GetNodeLogs(db *sqlx.DB, workflowVersionNodeId int, maximumResultCount int)
 
 func GetWorkflowLogs(db *sqlx.DB, workflowId int, maximumResultCount int) ([]WorkflowVersionNodeLog, error) {
 	var wfvnls []WorkflowVersionNodeLog
-	err := db.Get(&wfvnls, `SELECT *
+	err := db.Select(&wfvnls, `SELECT wfvnls.*
 				FROM workflow_version_node_log wfvnls
 				JOIN workflow_version_node wfvn ON wfvn.workflow_version_node_id=wfvnls.workflow_version_node_id
 				JOIN workflow_version wfv ON wfv.workflow_version_id=wfvn.workflow_version_id
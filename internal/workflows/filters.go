@@ -2,7 +2,6 @@ package workflows
 
 import (
 	"github.com/lncapital/torq/internal/tags"
-	"github.com/rs/zerolog/log"
 	"strings"
 	"time"
 )
@@ -0,0 +1,9 @@
+package workflows
+
+import "github.com/lncapital/torq/internal/logging"
+
+//nolint:gochecknoglobals
+var (
+	log           = logging.GetLogger(logging.Workflows)
+	rebalancerLog = logging.GetLogger(logging.Rebalancer)
+)
@@ -0,0 +1,49 @@
+package workflows
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/metrics"
+)
+
+var rebalancersDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+	"torq_rebalancers",
+	"Number of rebalancers in the rebalance cache by status (active or pending).",
+	[]string{"node_id", "status"}, nil)
+
+// RebalancerCollector exposes the content of the rebalance cache as Prometheus metrics.
+type RebalancerCollector struct{}
+
+func (RebalancerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- rebalancersDesc
+}
+
+func (RebalancerCollector) Collect(ch chan<- prometheus.Metric) {
+	counts := make(map[int]map[core.Status]int)
+	for _, rebalancer := range getRebalancers(nil) {
+		if rebalancer.Status != core.Active && rebalancer.Status != core.Pending {
+			continue
+		}
+		if counts[rebalancer.NodeId] == nil {
+			counts[rebalancer.NodeId] = make(map[core.Status]int)
+		}
+		counts[rebalancer.NodeId][rebalancer.Status]++
+	}
+	for nodeId, countsByStatus := range counts {
+		for _, status := range []core.Status{core.Active, core.Pending} {
+			ch <- prometheus.MustNewConstMetric(rebalancersDesc, prometheus.GaugeValue,
+				float64(countsByStatus[status]), strconv.Itoa(nodeId), status.String())
+		}
+	}
+}
+
+// addWorkflowNodeMetrics only counts nodes that actually got processed (so not pending or skipped nodes)
+func addWorkflowNodeMetrics(workflowNode WorkflowNode, processStatus core.Status, err error) {
+	if err == nil && processStatus != core.Active {
+		return
+	}
+	metrics.AddWorkflowNodeExecution(workflowNode.WorkflowVersionId, int(workflowNode.Type), err)
+}
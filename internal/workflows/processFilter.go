@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
-	"github.com/rs/zerolog/log"
 )
 
 type FilterCategoryType string
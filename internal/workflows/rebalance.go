@@ -11,12 +11,14 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
 
 	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/workflow_helpers"
+	"github.com/lncapital/torq/pkg/tracing"
 	"github.com/lncapital/torq/proto/lnrpc/routerrpc"
 
 	"github.com/lncapital/torq/proto/lnrpc"
@@ -102,14 +104,14 @@ func RebalanceServiceStart(ctx context.Context, conn *grpc.ClientConn, db *sqlx.
 			return
 		case <-ticker.C:
 			activeRebalancers := getRebalancers(&active)
-			log.Trace().Msgf("Active rebalancers: %v/%v", len(activeRebalancers), rebalanceMaximumConcurrency)
+			rebalancerLog.Trace().Msgf("Active rebalancers: %v/%v", len(activeRebalancers), rebalanceMaximumConcurrency)
 			if len(activeRebalancers) >= rebalanceMaximumConcurrency {
-				log.Debug().Msgf("Active rebalancers: %v/%v", len(activeRebalancers), rebalanceMaximumConcurrency)
+				rebalancerLog.Debug().Msgf("Active rebalancers: %v/%v", len(activeRebalancers), rebalanceMaximumConcurrency)
 				continue
 			}
 
 			pendingRebalancers := getRebalancers(&pending)
-			log.Trace().Msgf("Queued (or on hold) rebalancers: %v", len(pendingRebalancers))
+			rebalancerLog.Trace().Msgf("Queued (or on hold) rebalancers: %v", len(pendingRebalancers))
 			if len(pendingRebalancers) > 0 {
 				sort.Slice(pendingRebalancers, func(i, j int) bool {
 					return pendingRebalancers[i].ScheduleTarget.Before(pendingRebalancers[j].ScheduleTarget)
@@ -130,13 +132,13 @@ func RebalanceServiceStart(ctx context.Context, conn *grpc.ClientConn, db *sqlx.
 					removeRebalancer(pendingRebalancer)
 					runningFor := time.Since(pendingRebalancer.CreatedOn).Round(1 * time.Second)
 					if pendingRebalancer.Request.IncomingChannelId != 0 {
-						log.Debug().Msgf(
+						rebalancerLog.Debug().Msgf(
 							"Rebalancer timed out after %s for Origin: %v, OriginId: %v, Incoming Channel: %v",
 							runningFor, pendingRebalancer.Request.Origin, pendingRebalancer.Request.OriginId,
 							pendingRebalancer.Request.IncomingChannelId)
 					}
 					if pendingRebalancer.Request.OutgoingChannelId != 0 {
-						log.Debug().Msgf(
+						rebalancerLog.Debug().Msgf(
 							"Rebalancer timed out after %s for Origin: %v, OriginId: %v, Outgoing Channel: %v",
 							runningFor, pendingRebalancer.Request.Origin, pendingRebalancer.Request.OriginId,
 							pendingRebalancer.Request.OutgoingChannelId)
@@ -145,7 +147,7 @@ func RebalanceServiceStart(ctx context.Context, conn *grpc.ClientConn, db *sqlx.
 				}
 
 				if pendingRebalancer != nil && pendingRebalancer.ScheduleTarget.Before(time.Now()) {
-					log.Debug().Msgf("Rebalancers: %v/%v active and %v queued or on hold",
+					rebalancerLog.Debug().Msgf("Rebalancers: %v/%v active and %v queued or on hold",
 						len(activeRebalancers), rebalanceMaximumConcurrency, len(pendingRebalancers)-i)
 					go pendingRebalancer.start(db, client, router,
 						rebalanceRunnerTimeoutSeconds,
@@ -175,7 +177,7 @@ func RebalanceRequests(ctx context.Context,
 		err := errors.New(fmt.Sprintf(
 			"Rebalance request's ignored because focus was both incoming and outgoing, "+
 				"which is impossible for nodeId: %v", nodeId))
-		log.Error().Err(err).Msg("RebalanceRequests failed")
+		rebalancerLog.Error().Err(err).Msg("RebalanceRequests failed")
 		return []lightning_helpers.RebalanceResponse{{
 			Request:               lightning_helpers.RebalanceRequest{},
 			CommunicationResponse: lightning_helpers.CommunicationResponse{},
@@ -185,7 +187,7 @@ func RebalanceRequests(ctx context.Context,
 	for _, request := range requests.Requests {
 		response := validateRebalanceRequest(request)
 		if response != nil {
-			log.Debug().Msgf("Rebalance request ignored due to validation issues: %v", response)
+			rebalancerLog.Debug().Msgf("Rebalance request ignored due to validation issues: %v", response)
 			if incoming {
 				responses[request.IncomingChannelId] = *response
 			} else {
@@ -380,16 +382,17 @@ func (rebalancer *Rebalancer) start(
 	routesTimeout int,
 	payTimeout int) {
 
-	log.Debug().Msgf("Rebalance initiated for origin: %v, originReference: %v, "+
+	rebalancerLog.Debug().Msgf("Rebalance initiated for origin: %v, originReference: %v, "+
 		"incomingChannelId: %v, outgoingChannelId: %v",
 		rebalancer.Request.Origin, rebalancer.Request.OriginReference,
 		rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId)
 	if rebalancer.Request.IncomingChannelId != 0 {
 		incomingChannel := cache.GetChannelSettingByChannelId(rebalancer.Request.IncomingChannelId)
 		if incomingChannel.Capacity == 0 || incomingChannel.Status != core.Open {
-			log.Error().Msgf("IncomingChannelId is invalid "+
-				"for origin: %v, originReference: %v and incomingChannelId: %v",
-				rebalancer.Request.Origin, rebalancer.Request.OriginReference, rebalancer.Request.IncomingChannelId)
+			rebalancerLog.Error().Int(logging.ChannelIdField, rebalancer.Request.IncomingChannelId).
+				Msgf("IncomingChannelId is invalid "+
+					"for origin: %v, originReference: %v and incomingChannelId: %v",
+					rebalancer.Request.Origin, rebalancer.Request.OriginReference, rebalancer.Request.IncomingChannelId)
 			removeRebalancer(rebalancer)
 			rebalancer.RebalanceCancel()
 			return
@@ -398,9 +401,10 @@ func (rebalancer *Rebalancer) start(
 	if rebalancer.Request.OutgoingChannelId != 0 {
 		outgoingChannel := cache.GetChannelSettingByChannelId(rebalancer.Request.OutgoingChannelId)
 		if outgoingChannel.Capacity == 0 || outgoingChannel.Status != core.Open {
-			log.Error().Msgf("OutgoingChannelId is invalid "+
-				"for origin: %v, originReference: %v and outgoingChannelId: %v",
-				rebalancer.Request.Origin, rebalancer.Request.OriginReference, rebalancer.Request.OutgoingChannelId)
+			rebalancerLog.Error().Int(logging.ChannelIdField, rebalancer.Request.OutgoingChannelId).
+				Msgf("OutgoingChannelId is invalid "+
+					"for origin: %v, originReference: %v and outgoingChannelId: %v",
+					rebalancer.Request.Origin, rebalancer.Request.OriginReference, rebalancer.Request.OutgoingChannelId)
 			removeRebalancer(rebalancer)
 			rebalancer.RebalanceCancel()
 			return
@@ -412,7 +416,7 @@ func (rebalancer *Rebalancer) start(
 		rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId, core.Active,
 		rebalancePreviousSuccessResultTimeoutMinutes)
 	if err != nil {
-		log.Error().Err(err).Msgf("Obtaining latest result "+
+		rebalancerLog.Error().Err(err).Msgf("Obtaining latest result "+
 			"for origin: %v, originReference: %v, incomingChannelId: %v, outgoingChannelId: %v",
 			rebalancer.Request.Origin, rebalancer.Request.OriginReference,
 			rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId)
@@ -424,7 +428,7 @@ func (rebalancer *Rebalancer) start(
 
 	err = AddRebalance(db, rebalancer)
 	if err != nil {
-		log.Error().Err(err).Msgf("Storing rebalance "+
+		rebalancerLog.Error().Err(err).Msgf("Storing rebalance "+
 			"for origin: %v, originReference: %v, incomingChannelId: %v, outgoingChannelId: %v",
 			rebalancer.Request.Origin, rebalancer.Request.OriginReference,
 			rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId)
@@ -432,7 +436,7 @@ func (rebalancer *Rebalancer) start(
 	}
 
 	if previousSuccess.Hops != "" {
-		log.Debug().Msgf("Previous success found "+
+		rebalancerLog.Debug().Msgf("Previous success found "+
 			"for origin: %v, originReference: %v, incomingChannelId: %v, outgoingChannelId: %v",
 			rebalancer.Request.Origin, rebalancer.Request.OriginReference,
 			rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId)
@@ -463,7 +467,7 @@ func (rebalancer *Rebalancer) start(
 		}
 		result = rebalancer.startRunner(db, client, router, previousSuccessRunner, routesTimeout, payTimeout, result)
 		if result.Status == core.Active {
-			log.Debug().Msgf("Previous success successfully reused "+
+			rebalancerLog.Debug().Msgf("Previous success successfully reused "+
 				"for origin: %v, originReference: %v, incomingChannelId: %v, outgoingChannelId: %v",
 				rebalancer.Request.Origin, rebalancer.Request.OriginReference,
 				rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId)
@@ -474,13 +478,13 @@ func (rebalancer *Rebalancer) start(
 		if result.Status == core.Active {
 			return
 		}
-		log.Debug().Msgf("Previous success reuse failed "+
+		rebalancerLog.Debug().Msgf("Previous success reuse failed "+
 			"for origin: %v, originReference: %v, incomingChannelId: %v, outgoingChannelId: %v",
 			rebalancer.Request.Origin, rebalancer.Request.OriginReference,
 			rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId)
 	}
 	for i := 0; i < rebalancer.Request.MaximumConcurrency; i++ {
-		log.Debug().Err(err).Msgf("Bootstrapping runner %v "+
+		rebalancerLog.Debug().Err(err).Msgf("Bootstrapping runner %v "+
 			"for origin: %v, originReference: %v, incomingChannelId: %v, outgoingChannelId: %v",
 			i, rebalancer.Request.Origin, rebalancer.Request.OriginReference,
 			rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId)
@@ -492,7 +496,7 @@ func (rebalancer *Rebalancer) convertPreviousSuccess(previousSuccess rebalances.
 	if rebalancer.Request.OutgoingChannelId != 0 &&
 		!slices.Contains(rebalancer.Request.ChannelIds, previousSuccess.IncomingChannelId) {
 
-		log.Debug().Msgf("Previous success ignored as it's not available anymore "+
+		rebalancerLog.Debug().Msgf("Previous success ignored as it's not available anymore "+
 			"for origin: %v, originReference: %v, incomingChannelId: %v, outgoingChannelId: %v",
 			rebalancer.Request.Origin, rebalancer.Request.OriginReference,
 			rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId)
@@ -501,7 +505,7 @@ func (rebalancer *Rebalancer) convertPreviousSuccess(previousSuccess rebalances.
 	if rebalancer.Request.IncomingChannelId != 0 &&
 		!slices.Contains(rebalancer.Request.ChannelIds, previousSuccess.OutgoingChannelId) {
 
-		log.Debug().Msgf("Previous success ignored as it's not available anymore "+
+		rebalancerLog.Debug().Msgf("Previous success ignored as it's not available anymore "+
 			"for origin: %v, originReference: %v, incomingChannelId: %v, outgoingChannelId: %v",
 			rebalancer.Request.Origin, rebalancer.Request.OriginReference,
 			rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId)
@@ -539,12 +543,12 @@ func (rebalancer *Rebalancer) createRunner(
 		removeRebalancer(rebalancer)
 		runningFor := time.Since(rebalancer.ScheduleTarget).Round(1 * time.Second)
 		if rebalancer.Request.IncomingChannelId != 0 {
-			log.Debug().Msgf("Pending Outgoing ChannelIds got exhausted "+
+			rebalancerLog.Debug().Msgf("Pending Outgoing ChannelIds got exhausted "+
 				"for Origin: %v, OriginId: %v, IncomingChannelId: %v (%s)",
 				rebalancer.Request.Origin, rebalancer.Request.OriginId, rebalancer.Request.IncomingChannelId, runningFor)
 		}
 		if rebalancer.Request.OutgoingChannelId != 0 {
-			log.Debug().Msgf("Pending Incoming ChannelIds got exhausted "+
+			rebalancerLog.Debug().Msgf("Pending Incoming ChannelIds got exhausted "+
 				"for Origin: %v, OriginId: %v, OutgoingChannelId: %v (%s)",
 				rebalancer.Request.Origin, rebalancer.Request.OriginId, rebalancer.Request.OutgoingChannelId, runningFor)
 		}
@@ -556,11 +560,11 @@ func (rebalancer *Rebalancer) createRunner(
 		rebalancer.Status = core.Pending
 		if !addRebalancer(rebalancer) {
 			if rebalancer.Request.IncomingChannelId != 0 {
-				log.Error().Msgf("Failed to reschedule the incoming rebalancer for Origin: %v, OriginId: %v (%v)",
+				rebalancerLog.Error().Msgf("Failed to reschedule the incoming rebalancer for Origin: %v, OriginId: %v (%v)",
 					rebalancer.Request.Origin, rebalancer.Request.OriginId, rebalancer.Request.IncomingChannelId)
 			}
 			if rebalancer.Request.OutgoingChannelId != 0 {
-				log.Error().Msgf("Failed to reschedule the outgoing rebalancer for Origin: %v, OriginId: %v (%v)",
+				rebalancerLog.Error().Msgf("Failed to reschedule the outgoing rebalancer for Origin: %v, OriginId: %v (%v)",
 					rebalancer.Request.Origin, rebalancer.Request.OriginId, rebalancer.Request.OutgoingChannelId)
 			}
 		}
@@ -583,7 +587,7 @@ func (rebalancer *Rebalancer) createRunner(
 			runningFor, result.TotalAmountMsat, result.TotalFeeMsat,
 			((result.TotalFeeMsat*1_000_000)/result.TotalAmountMsat)+1, // + 1 for rounding error
 			result.IncomingChannelId, result.OutgoingChannelId)
-		log.Debug().Msgf("%v for Origin: %v, OriginId: %v (Hops: %v)",
+		rebalancerLog.Debug().Msgf("%v for Origin: %v, OriginId: %v (Hops: %v)",
 			msg, rebalancer.Request.Origin, rebalancer.Request.OriginId, result.Hops)
 		rebalancer.Status = core.Inactive
 		return
@@ -603,12 +607,16 @@ func (rebalancer *Rebalancer) startRunner(
 	payTimeout int,
 	result rebalances.RebalanceResult) rebalances.RebalanceResult {
 
-	routesCtx, routesCancel := context.WithTimeout(runner.Ctx, time.Second*time.Duration(routesTimeout))
+	ctx, span := tracing.StartSpan(runner.Ctx, "rebalance runner attempt",
+		tracing.RebalanceIdKey.Int(rebalancer.RebalanceId),
+		tracing.IncomingChannelIdKey.Int(runner.IncomingChannelId),
+		tracing.OutgoingChannelIdKey.Int(runner.OutgoingChannelId))
+	routesCtx, routesCancel := context.WithTimeout(ctx, time.Second*time.Duration(routesTimeout))
 	defer routesCancel()
 	routes, err := runner.getRoutes(routesCtx, client, router, rebalancer.NodeId,
 		rebalancer.Request.AmountMsat, rebalancer.Request.MaximumCostMsat)
 	if err != nil {
-		log.Debug().Err(err).Msgf(
+		rebalancerLog.Debug().Err(err).Msgf(
 			"Failed to obtain routes from LND for incomingChannelId: %v, outgoingChannelId: %v",
 			runner.IncomingChannelId, runner.OutgoingChannelId)
 		result.Status = core.Inactive
@@ -621,7 +629,7 @@ func (rebalancer *Rebalancer) startRunner(
 	routesCancel()
 
 	for _, route := range routes {
-		payCtx, payCancel := context.WithTimeout(runner.Ctx, time.Second*time.Duration(payTimeout))
+		payCtx, payCancel := context.WithTimeout(ctx, time.Second*time.Duration(payTimeout))
 		result = runner.pay(payCtx, client, router, rebalancer.Request.AmountMsat, route)
 		payCancel()
 		if payCtx.Err() == context.DeadlineExceeded {
@@ -629,9 +637,11 @@ func (rebalancer *Rebalancer) startRunner(
 		}
 		rebalancer.processResult(db, result)
 		if result.Status == core.Active {
+			endRunnerAttemptSpan(span, result)
 			return result
 		}
 	}
+	endRunnerAttemptSpan(span, result)
 
 	if result.Status == core.Pending {
 		result = rebalancer.startRunner(db, client, router, runner, routesTimeout, payTimeout, result)
@@ -639,6 +649,15 @@ func (rebalancer *Rebalancer) startRunner(
 	return result
 }
 
+func endRunnerAttemptSpan(span trace.Span, result rebalances.RebalanceResult) {
+	span.SetAttributes(tracing.StatusKey.Int(int(result.Status)))
+	var err error
+	if result.Error != "" {
+		err = errors.New(result.Error)
+	}
+	tracing.EndSpan(span, err)
+}
+
 // TODO FIXME make channel selection smarter instead of at random...
 func (rebalancer *Rebalancer) getPendingChannelId() int {
 	if rebalancer.Request.WorkflowUnfocusedPath == "" {
@@ -649,7 +668,7 @@ func (rebalancer *Rebalancer) getPendingChannelId() int {
 	err := json.Unmarshal([]byte(rebalancer.Request.WorkflowUnfocusedPath.(string)), &unfocusedPath)
 	if err != nil {
 		msg := fmt.Sprintf("Failed to unmarshal the workflow unfocused path originId: %v", rebalancer.Request.OriginId)
-		log.Error().Err(errors.New(msg)).Msg(msg)
+		rebalancerLog.Error().Err(errors.New(msg)).Msg(msg)
 		return 0
 	}
 
@@ -663,7 +682,7 @@ func (rebalancer *Rebalancer) getPendingChannelId() int {
 			if err != nil {
 				msg := fmt.Sprintf("Failed to unmarshal the workflow unfocused path parameters originId: %v",
 					rebalancer.Request.OriginId)
-				log.Error().Err(errors.New(msg)).Msg(msg)
+				rebalancerLog.Error().Err(errors.New(msg)).Msg(msg)
 				return 0
 			}
 
@@ -693,7 +712,7 @@ func (rebalancer *Rebalancer) getPendingChannelId() int {
 				msg := fmt.Sprintf(
 					"Incorrect setup? Event base data source inside the workflow unfocused path originId: %v",
 					rebalancer.Request.OriginId)
-				log.Error().Err(errors.New(msg)).Msg(msg)
+				rebalancerLog.Error().Err(errors.New(msg)).Msg(msg)
 				return 0
 			}
 		case workflow_helpers.WorkflowNodeChannelFilter:
@@ -706,7 +725,7 @@ func (rebalancer *Rebalancer) getPendingChannelId() int {
 			if err != nil {
 				msg := fmt.Sprintf("Failed to unmarshal the workflow unfocused path parameters originId: %v",
 					rebalancer.Request.OriginId)
-				log.Error().Err(errors.New(msg)).Msg(msg)
+				rebalancerLog.Error().Err(errors.New(msg)).Msg(msg)
 				return 0
 			}
 
@@ -715,7 +734,7 @@ func (rebalancer *Rebalancer) getPendingChannelId() int {
 				if err != nil {
 					msg := fmt.Sprintf("Failed to obtain channels for originId: %v",
 						rebalancer.Request.OriginId)
-					log.Error().Err(errors.New(msg)).Msg(msg)
+					rebalancerLog.Error().Err(errors.New(msg)).Msg(msg)
 					return 0
 				}
 				channelIds = FilterChannelBodyChannelIds(params, linkedChannels)
@@ -752,11 +771,11 @@ outer:
 		}
 
 		if rebalancer.Request.IncomingChannelId != 0 {
-			log.Debug().Msgf("New outgoingChannelId (%v) was chosen for incomingChannelId (%v) and originId: %v",
+			rebalancerLog.Debug().Msgf("New outgoingChannelId (%v) was chosen for incomingChannelId (%v) and originId: %v",
 				channelId, rebalancer.Request.IncomingChannelId, rebalancer.Request.OriginId)
 		}
 		if rebalancer.Request.OutgoingChannelId != 0 {
-			log.Debug().Msgf("New incomingChannelId (%v) was chosen for outgoingChannelId (%v) and originId: %v",
+			rebalancerLog.Debug().Msgf("New incomingChannelId (%v) was chosen for outgoingChannelId (%v) and originId: %v",
 				channelId, rebalancer.Request.OutgoingChannelId, rebalancer.Request.OriginId)
 		}
 		return channelId
@@ -794,8 +813,9 @@ func (rebalancer *Rebalancer) processResult(db *sqlx.DB, result rebalances.Rebal
 	result.UpdateOn = time.Now().UTC()
 	err := rebalances.AddRebalanceResult(db, result)
 	if err != nil {
-		log.Error().Err(err).Msgf("Failed to add rebalance log entry for rebalanceId: %v (ref: %v)",
-			rebalancer.RebalanceId, rebalancer.Request.OriginReference)
+		rebalancerLog.Error().Err(err).Int(logging.RebalanceIdField, rebalancer.RebalanceId).
+			Msgf("Failed to add rebalance log entry for rebalanceId: %v (ref: %v)",
+				rebalancer.RebalanceId, rebalancer.Request.OriginReference)
 	}
 }
 
@@ -867,7 +887,7 @@ func (runner *RebalanceRunner) pay(
 
 	invoice, err := runner.createInvoice(ctx, client, amountMsat)
 	if err != nil {
-		log.Debug().Err(err).Msgf("Failed to create an invoice for %v msats", amountMsat)
+		rebalancerLog.Debug().Err(err).Msgf("Failed to create an invoice for %v msats", amountMsat)
 		rebalanceResult.Error = err.Error()
 		return rebalanceResult
 	}
@@ -888,7 +908,7 @@ func (runner *RebalanceRunner) pay(
 		rebalanceResult.TotalAmountMsat = uint64(result.Route.TotalAmtMsat)
 	}
 	if err != nil {
-		log.Debug().Err(err).Msgf("Failed to call SendToRouteV2 for route: %v", route)
+		rebalancerLog.Debug().Err(err).Msgf("Failed to call SendToRouteV2 for route: %v", route)
 		rebalanceResult.Error = err.Error()
 		return rebalanceResult
 	}
@@ -919,7 +939,8 @@ func (runner *RebalanceRunner) pay(
 	if result != nil && result.Route != nil {
 		hopsJsonByteArray, err := json.Marshal(result.Route.Hops)
 		if err != nil {
-			log.Error().Err(err).Msgf("Marshalling the route hops for rebalancerId: %v", runner.RebalanceId)
+			rebalancerLog.Error().Err(err).Int(logging.RebalanceIdField, runner.RebalanceId).
+				Msgf("Marshalling the route hops for rebalancerId: %v", runner.RebalanceId)
 			return rebalanceResult
 		}
 		rebalanceResult.Hops = string(hopsJsonByteArray)
@@ -1139,7 +1160,8 @@ func setRebalancer(db *sqlx.DB, request lightning_helpers.RebalanceRequest, reba
 			rebalancer.Request.MaximumConcurrency, rebalancer.Request.MaximumCostMsat, rebalancer.UpdateOn,
 			rebalancer.RebalanceId)
 		if err != nil {
-			log.Error().Err(err).Msgf("Failed to add rebalance log entry for rebalanceId: %v", rebalancer.RebalanceId)
+			rebalancerLog.Error().Err(err).Int(logging.RebalanceIdField, rebalancer.RebalanceId).
+				Msgf("Failed to add rebalance log entry for rebalanceId: %v", rebalancer.RebalanceId)
 			return errors.Wrapf(err,
 				"Updating the database with the new rebalance settings for origin: %v with originId: %v (ref: %v)",
 				rebalancer.Request.Origin, rebalancer.Request.OriginId, rebalancer.Request.OriginReference)
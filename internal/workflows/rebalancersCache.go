@@ -3,7 +3,6 @@ package workflows
 import (
 	"context"
 
-	"github.com/rs/zerolog/log"
 	"golang.org/x/exp/slices"
 
 	"github.com/lncapital/torq/internal/core"
@@ -108,7 +107,7 @@ func RebalanceCacheHandler(ch <-chan RebalanceCache, ctx context.Context) {
 				rebalanceCache.IncomingChannelId = rebalanceCache.ChannelIds[0]
 				rebalancer := getRebalancerCache(rebalanceCache, rebalancers)
 				if rebalancer != nil {
-					log.Debug().Msgf("Cancelling rebalancer for channelId: %v, origin: %v, originId: %v",
+					rebalancerLog.Debug().Msgf("Cancelling rebalancer for channelId: %v, origin: %v, originId: %v",
 						rebalanceCache.ChannelIds[0], rebalanceCache.Origin, rebalanceCache.OriginId)
 					rebalancer.RebalanceCancel()
 					delete(rebalancers[rebalanceCache.Origin][originIdType(rebalanceCache.OriginId)], channelIdType(rebalanceCache.ChannelIds[0]))
@@ -122,7 +121,7 @@ func RebalanceCacheHandler(ch <-chan RebalanceCache, ctx context.Context) {
 					if slices.Contains(rebalanceCache.ChannelIds, int(channelId)) {
 						continue
 					}
-					log.Debug().Msgf("Cancelling rebalancer for channelId: %v, origin: %v, originId: %v",
+					rebalancerLog.Debug().Msgf("Cancelling rebalancer for channelId: %v, origin: %v, originId: %v",
 						channelId, rebalanceCache.Origin, rebalanceCache.OriginId)
 					rebalancer.RebalanceCancel()
 					delete(rebalancers[rebalanceCache.Origin][originIdType(rebalanceCache.OriginId)], channelId)
@@ -141,7 +140,7 @@ func RebalanceCacheHandler(ch <-chan RebalanceCache, ctx context.Context) {
 					continue
 				}
 				for channelId, rebalancer := range rebalancersForOriginId {
-					log.Debug().Msgf("Cancelling rebalancer for channelId: %v, origin: %v, originId: %v",
+					rebalancerLog.Debug().Msgf("Cancelling rebalancer for channelId: %v, origin: %v, originId: %v",
 						channelId, rebalanceCache.Origin, rebalanceCache.OriginId)
 					rebalancer.RebalanceCancel()
 					delete(rebalancers[rebalanceCache.Origin][originIdType(rebalanceCache.OriginId)], channelId)
@@ -249,7 +248,7 @@ func initializeRebalancersCache(rebalanceCache RebalanceCache,
 
 func isValidRequest(rebalanceCache RebalanceCache) bool {
 	if rebalanceCache.Type != readRebalancersOperation && rebalanceCache.IncomingChannelId == 0 && rebalanceCache.OutgoingChannelId == 0 {
-		log.Error().Msgf("IncomingChannelId (%v) and OutgoingChannelId (%v) cannot both be 0",
+		rebalancerLog.Error().Msgf("IncomingChannelId (%v) and OutgoingChannelId (%v) cannot both be 0",
 			rebalanceCache.IncomingChannelId, rebalanceCache.OutgoingChannelId)
 		return false
 	}
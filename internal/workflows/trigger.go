@@ -5,22 +5,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
-	"github.com/rs/zerolog/log"
 	"golang.org/x/exp/slices"
 
+	"github.com/lncapital/torq/internal/audit"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/channels"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/lightning"
 	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/logging"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/internal/tags"
 	"github.com/lncapital/torq/internal/workflow_helpers"
+	"github.com/lncapital/torq/pkg/tracing"
 )
 
 type workflowVersionNodeIdType int
@@ -134,6 +137,7 @@ func ProcessWorkflow(ctx context.Context, db *sqlx.DB,
 				workflowNodeStatus, reference, workflowNodeInputCache, workflowNodeInputByReferenceIdCache,
 				workflowNodeOutputCache, workflowNodeOutputByReferenceIdCache,
 				workflowStageOutputCache, workflowStageOutputByReferenceIdCache)
+			addWorkflowNodeMetrics(workflowVersionNode, processStatus, err)
 			if err != nil {
 				return errors.Wrapf(err, "Failed to process workflow nodes for WorkflowVersionId: %v (stage: %v)",
 					workflowTriggerNode.WorkflowVersionId, workflowTriggerNode.Stage)
@@ -180,6 +184,7 @@ func ProcessWorkflow(ctx context.Context, db *sqlx.DB,
 					workflowNodeStatus, reference, workflowNodeInputCache, workflowNodeInputByReferenceIdCache,
 					workflowNodeOutputCache, workflowNodeOutputByReferenceIdCache,
 					workflowStageOutputCache, workflowStageOutputByReferenceIdCache)
+				addWorkflowNodeMetrics(workflowVersionNode, processStatus, err)
 				if err != nil {
 					return errors.Wrapf(err, "Failed to process workflow nodes for WorkflowVersionId: %v (stage: %v)",
 						workflowTriggerNode.WorkflowVersionId, workflowStageTriggerNode.Stage)
@@ -265,7 +270,16 @@ func processWorkflowNode(ctx context.Context, db *sqlx.DB,
 	workflowNodeOutputCache map[workflowVersionNodeIdType]map[workflow_helpers.WorkflowParameterLabel]string,
 	workflowNodeOutputByReferenceIdCache map[workflowVersionNodeIdType]map[channelIdType]map[workflow_helpers.WorkflowParameterLabel]string,
 	workflowStageOutputCache map[stageType]map[workflow_helpers.WorkflowParameterLabel]string,
-	workflowStageOutputByReferenceIdCache map[stageType]map[channelIdType]map[workflow_helpers.WorkflowParameterLabel]string) (core.Status, error) {
+	workflowStageOutputByReferenceIdCache map[stageType]map[channelIdType]map[workflow_helpers.WorkflowParameterLabel]string) (processStatus core.Status, err error) {
+
+	ctx, span := tracing.StartSpan(ctx, "workflow node",
+		tracing.WorkflowVersionIdKey.Int(workflowNode.WorkflowVersionId),
+		tracing.WorkflowVersionNodeIdKey.Int(workflowNode.WorkflowVersionNodeId),
+		tracing.WorkflowNodeTypeKey.Int(int(workflowNode.Type)))
+	defer func() {
+		span.SetAttributes(tracing.StatusKey.Int(int(processStatus)))
+		tracing.EndSpan(span, err)
+	}()
 
 	select {
 	case <-ctx.Done():
@@ -476,7 +490,7 @@ linkedInputLoop:
 			return core.Inactive, errors.Wrapf(err, "No ChannelIds found in the inputs for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 		}
 
-		err = addOrRemoveTags(db, linkedChannelIds, workflowNode)
+		err = addOrRemoveTags(db, linkedChannelIds, workflowNode, reference)
 		if err != nil {
 			return core.Inactive, errors.Wrapf(err, "Adding or removing tags with ChannelIds: %v for WorkflowVersionNodeId: %v", linkedChannelIds, workflowNode.WorkflowVersionNodeId)
 		}
@@ -536,7 +550,7 @@ linkedInputLoop:
 				return core.Inactive, errors.Wrapf(err, "Processing Routing Policy Configurator with ChannelIds: %v for WorkflowVersionNodeId: %v", linkedChannelIds, workflowNode.WorkflowVersionNodeId)
 			}
 
-			err = processRoutingPolicyRun(db, routingPolicySettings, workflowNode, reference, workflowTriggerNode.Type)
+			err = processRoutingPolicyRun(ctx, db, routingPolicySettings, workflowNode, reference, workflowTriggerNode.Type)
 			if err != nil {
 				return core.Inactive, errors.Wrapf(err, "Processing Routing Policy Configurator with ChannelIds: %v for WorkflowVersionNodeId: %v", linkedChannelIds, workflowNode.WorkflowVersionNodeId)
 			}
@@ -575,7 +589,7 @@ linkedInputLoop:
 			}
 
 			if routingPolicySettings.ChannelId != 0 {
-				err = processRoutingPolicyRun(db, routingPolicySettings, workflowNode, reference, workflowTriggerNode.Type)
+				err = processRoutingPolicyRun(ctx, db, routingPolicySettings, workflowNode, reference, workflowTriggerNode.Type)
 				if err != nil {
 					return core.Inactive, errors.Wrapf(err, "Processing Routing Policy Configurator for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 				}
@@ -729,7 +743,7 @@ linkedInputLoop:
 		}
 
 		var responses []lightning_helpers.RebalanceResponse
-		responses, err = processRebalanceRun(db, eventChannelIds, rebalanceConfigurations, workflowNode, reference)
+		responses, err = processRebalanceRun(ctx, db, eventChannelIds, rebalanceConfigurations, workflowNode, reference)
 		if err != nil {
 			return core.Inactive, errors.Wrapf(err, "Processing Rebalance for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 		}
@@ -788,7 +802,7 @@ linkedInputLoop:
 			return core.Inactive, errors.Wrapf(err, "Obtaining eventChannelIds for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 		}
 
-		responses, err := processRebalanceRun(db, eventChannelIds, rebalanceConfigurations, workflowNode, reference)
+		responses, err := processRebalanceRun(ctx, db, eventChannelIds, rebalanceConfigurations, workflowNode, reference)
 		if err != nil {
 			return core.Inactive, errors.Wrapf(err, "Processing Rebalance for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 		}
@@ -827,11 +841,13 @@ linkedInputLoop:
 
 	marshalledInputs, err := json.Marshal([]any{inputs, inputsByReferenceId})
 	if err != nil {
-		log.Error().Err(err).Msgf("Marshalling inputs for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		log.Error().Err(err).Int(logging.WorkflowVersionIdField, workflowNode.WorkflowVersionId).
+			Msgf("Marshalling inputs for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 	}
 	marshalledOutputs, err := json.Marshal([]any{outputs, outputsByReferenceId})
 	if err != nil {
-		log.Error().Err(err).Msgf("Marshalling outputs for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		log.Error().Err(err).Int(logging.WorkflowVersionIdField, workflowNode.WorkflowVersionId).
+			Msgf("Marshalling outputs for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 	}
 	_, err = addWorkflowVersionNodeLog(db, WorkflowVersionNodeLog{
 		TriggerReference:                reference,
@@ -844,7 +860,8 @@ linkedInputLoop:
 		CreatedOn:                       time.Now().UTC(),
 	})
 	if err != nil {
-		log.Error().Err(err).Msgf("Storing log for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		log.Error().Err(err).Int(logging.WorkflowVersionIdField, workflowNode.WorkflowVersionId).
+			Msgf("Storing log for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 	}
 	return core.Active, nil
 }
@@ -1019,6 +1036,7 @@ func processRebalanceConfigurator(
 }
 
 func processRebalanceRun(
+	ctx context.Context,
 	db *sqlx.DB,
 	eventChannelIds []int,
 	rebalanceSettings []RebalanceConfiguration,
@@ -1134,8 +1152,11 @@ func processRebalanceRun(
 				activeChannelIds = append(activeChannelIds, req.OutgoingChannelId)
 			}
 		}
-		resp := RebalanceRequests(context.Background(), db, reqs, nodeId)
+		// The rebalancers outlive the workflow node, they only continue its trace
+		resp := RebalanceRequests(tracing.Detach(ctx), db, reqs, nodeId)
 		responses = append(responses, resp...)
+		audit.Record(db, getWorkflowActor(workflowNode, reference), "workflow rebalance", "node", strconv.Itoa(nodeId),
+			map[string]interface{}{"requests": reqs.Requests}, nil)
 	}
 	if len(eventChannelIds) == 0 {
 		CancelRebalancersExcept(lightning_helpers.RebalanceWorkflowNode, workflowNode.WorkflowVersionNodeId,
@@ -1189,14 +1210,18 @@ func processRoutingPolicyConfigurator(
 	return channelPolicyInputConfiguration, nil
 }
 
-func processRoutingPolicyRun(db *sqlx.DB,
+func processRoutingPolicyRun(ctx context.Context, db *sqlx.DB,
 	routingPolicySettings ChannelPolicyConfiguration,
 	workflowNode WorkflowNode,
 	reference string,
 	triggerType workflow_helpers.WorkflowNodeType) error {
 
+	_, span := tracing.StartCacheRequest(ctx, "GetAllTorqNodeIds")
 	torqNodeIds := cache.GetAllTorqNodeIds()
+	span.End()
+	_, span = tracing.StartCacheRequest(ctx, "GetChannelSettingByChannelId")
 	channelSettings := cache.GetChannelSettingByChannelId(routingPolicySettings.ChannelId)
+	span.End()
 	nodeId := channelSettings.FirstNodeId
 	if !slices.Contains(torqNodeIds, nodeId) {
 		nodeId = channelSettings.SecondNodeId
@@ -1227,14 +1252,33 @@ func processRoutingPolicyRun(db *sqlx.DB,
 		TimeLockDelta:    routingPolicySettings.TimeLockDelta,
 	}
 
-	_, err := lightning.SetRoutingPolicy(request)
+	_, err := lightning.SetRoutingPolicy(ctx, request)
 	if err != nil {
 		log.Error().Err(err).Msgf("Workflow Trigger Fired for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 	}
+	audit.Record(db, getWorkflowActor(workflowNode, reference), "workflow updateRoutingPolicy",
+		"channel", strconv.Itoa(routingPolicySettings.ChannelId), map[string]interface{}{
+			"nodeId":           nodeId,
+			"channelId":        routingPolicySettings.ChannelId,
+			"feeRateMilliMsat": routingPolicySettings.FeeRateMilliMsat,
+			"feeBaseMsat":      routingPolicySettings.FeeBaseMsat,
+			"maxHtlcMsat":      routingPolicySettings.MaxHtlcMsat,
+			"minHtlcMsat":      routingPolicySettings.MinHtlcMsat,
+			"timeLockDelta":    routingPolicySettings.TimeLockDelta,
+		}, err)
 	return nil
 }
 
-func addOrRemoveTags(db *sqlx.DB, linkedChannelIds []int, workflowNode WorkflowNode) error {
+// getWorkflowActor identifies the workflow run (the workflow node and the trigger reference) in the audit log
+func getWorkflowActor(workflowNode WorkflowNode, reference string) audit.Actor {
+	return audit.Actor{
+		Type: audit.ActorWorkflow,
+		Id:   strconv.Itoa(workflowNode.WorkflowVersionNodeId),
+		Name: reference,
+	}
+}
+
+func addOrRemoveTags(db *sqlx.DB, linkedChannelIds []int, workflowNode WorkflowNode, reference string) error {
 	var params TagParameters
 	err := json.Unmarshal([]byte(workflowNode.Parameters), &params)
 	if err != nil {
@@ -1251,6 +1295,7 @@ func addOrRemoveTags(db *sqlx.DB, linkedChannelIds []int, workflowNode WorkflowN
 				continue
 			}
 			err = tags.UntagEntity(db, tag)
+			recordWorkflowTagChange(db, workflowNode, reference, "workflow untag", tag, err)
 			if err != nil {
 				return errors.Wrapf(err, "Failed to remove the tags for WorkflowVersionNodeId: %v tagIDd", workflowNode.WorkflowVersionNodeId, tagToDelete.Value)
 			}
@@ -1267,6 +1312,7 @@ func addOrRemoveTags(db *sqlx.DB, linkedChannelIds []int, workflowNode WorkflowN
 			}
 			tag.CreatedByWorkflowVersionNodeId = &workflowNode.WorkflowVersionNodeId
 			err = tags.TagEntity(db, tag)
+			recordWorkflowTagChange(db, workflowNode, reference, "workflow tag", tag, err)
 			if err != nil {
 				return errors.Wrapf(err, "Failed to add the tags for WorkflowVersionNodeId: %v tagIDd", workflowNode.WorkflowVersionNodeId, tagtoAdd.Value)
 			}
@@ -1275,6 +1321,23 @@ func addOrRemoveTags(db *sqlx.DB, linkedChannelIds []int, workflowNode WorkflowN
 	return nil
 }
 
+func recordWorkflowTagChange(db *sqlx.DB, workflowNode WorkflowNode, reference string, action string,
+	tag tags.TagEntityRequest, err error) {
+
+	entityType := "channel"
+	entityId := tag.ChannelId
+	if tag.NodeId != nil {
+		entityType = "node"
+		entityId = tag.NodeId
+	}
+	var entityIdString string
+	if entityId != nil {
+		entityIdString = strconv.Itoa(*entityId)
+	}
+	audit.Record(db, getWorkflowActor(workflowNode, reference), action, entityType, entityIdString,
+		map[string]interface{}{"tagId": tag.TagId, "channelId": tag.ChannelId, "nodeId": tag.NodeId}, err)
+}
+
 func getTagEntityRequest(channelId int, tagId int, params TagParameters, torqNodeIds []int, processedNodeIds []int) ([]int, tags.TagEntityRequest) {
 	if params.ApplyTo == "nodes" {
 		channelSettings := cache.GetChannelSettingByChannelId(channelId)
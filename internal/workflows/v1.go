@@ -0,0 +1,175 @@
+package workflows
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	ah "github.com/lncapital/torq/internal/api_helpers"
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/workflow_helpers"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+type workflowTriggered struct {
+	Reference string `json:"reference"`
+}
+
+func GetV1Endpoints(db *sqlx.DB) []ah.Endpoint {
+	// Workflows act on all Torq nodes so API tokens restricted to a node can't use them
+	allNodes := []gin.HandlerFunc{auth.AllNodesRequired}
+	return []ah.Endpoint{
+		{
+			Method:     http.MethodGet,
+			Path:       "/workflows",
+			Resource:   "workflows",
+			Summary:    "List workflows with their latest and active version",
+			Response:   WorkflowTableRow{},
+			Paginated:  true,
+			Middleware: allNodes,
+			Handler:    func(c *gin.Context) { getV1WorkflowsHandler(c, db) },
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/workflows/:workflowId",
+			Resource:   "workflows",
+			Summary:    "Get a workflow",
+			Response:   Workflow{},
+			Middleware: allNodes,
+			Handler:    func(c *gin.Context) { getV1WorkflowHandler(c, db) },
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/workflows/:workflowId/logs",
+			Resource:   "workflows",
+			Summary:    fmt.Sprintf("List the latest %v logs of a workflow", workflowLogCount),
+			Response:   WorkflowVersionNodeLog{},
+			Paginated:  true,
+			Middleware: allNodes,
+			Handler:    func(c *gin.Context) { getV1WorkflowLogsHandler(c, db) },
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/workflows/trigger",
+			Resource:    "workflows",
+			Summary:     "Manually trigger a workflow version from one of its trigger nodes",
+			Description: "Can be used by API tokens with the workflows:trigger scope.",
+			RequestBody: WorkflowToTrigger{},
+			Response:    workflowTriggered{},
+			Middleware:  allNodes,
+			Handler:     func(c *gin.Context) { triggerV1WorkflowHandler(c, db) },
+		},
+	}
+}
+
+func getV1WorkflowsHandler(c *gin.Context, db *sqlx.DB) {
+	pagination, err := ah.GetPagination(c)
+	if err != nil {
+		server_errors.SendBadRequestFromError(c, err)
+		return
+	}
+	workflows, err := GetWorkflows(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting workflows.")
+		return
+	}
+	start, end := pagination.GetPage(len(workflows))
+	c.JSON(http.StatusOK, ah.ApiResponse{Data: workflows[start:end], Pagination: pagination})
+}
+
+// getV1Workflow returns the workflow of the workflowId path parameter, the response is sent when it's not found
+func getV1Workflow(c *gin.Context, db *sqlx.DB) (Workflow, bool) {
+	workflowId, err := strconv.Atoi(c.Param("workflowId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse workflowId in the request.")
+		return Workflow{}, false
+	}
+	workflow, err := GetWorkflow(db, workflowId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Getting workflow for workflowId: %v", workflowId))
+		return Workflow{}, false
+	}
+	if workflow.WorkflowId == 0 {
+		server_errors.SendNotFound(c, "Workflow not found")
+		return Workflow{}, false
+	}
+	return workflow, true
+}
+
+func getV1WorkflowHandler(c *gin.Context, db *sqlx.DB) {
+	workflow, found := getV1Workflow(c, db)
+	if !found {
+		return
+	}
+	c.JSON(http.StatusOK, workflow)
+}
+
+func getV1WorkflowLogsHandler(c *gin.Context, db *sqlx.DB) {
+	pagination, err := ah.GetPagination(c)
+	if err != nil {
+		server_errors.SendBadRequestFromError(c, err)
+		return
+	}
+	workflow, found := getV1Workflow(c, db)
+	if !found {
+		return
+	}
+	workflowLogs, err := GetWorkflowLogs(db, workflow.WorkflowId, workflowLogCount)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err,
+			fmt.Sprintf("Getting workflow logs for workflowId: %v", workflow.WorkflowId))
+		return
+	}
+	start, end := pagination.GetPage(len(workflowLogs))
+	c.JSON(http.StatusOK, ah.ApiResponse{Data: workflowLogs[start:end], Pagination: pagination})
+}
+
+func triggerV1WorkflowHandler(c *gin.Context, db *sqlx.DB) {
+	var workflow WorkflowToTrigger
+	if err := c.BindJSON(&workflow); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	reference, err := TriggerWorkflow(db, workflow)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err,
+			fmt.Sprintf("Triggering workflow version node for workflowVersionNodeId: %v", workflow.WorkflowVersionNodeId))
+		return
+	}
+	if reference == "" {
+		server_errors.SendUnprocessableEntity(c, "The workflow version node is not part of the workflow version.")
+		return
+	}
+	c.JSON(http.StatusOK, workflowTriggered{Reference: reference})
+}
+
+// TriggerWorkflow schedules a manual trigger of the workflow version from one of its trigger nodes.
+// The returned reference is empty when the workflow version node is not part of the workflow version.
+func TriggerWorkflow(db *sqlx.DB, workflow WorkflowToTrigger) (string, error) {
+	workflowVersionNode, err := GetWorkflowVersionNode(db, workflow.WorkflowVersionNodeId)
+	if err != nil {
+		return "", errors.Wrapf(err, "Getting workflow version node for workflowVersionNodeId: %v",
+			workflow.WorkflowVersionNodeId)
+	}
+	if workflowVersionNode.WorkflowVersionNodeId == 0 ||
+		workflowVersionNode.WorkflowVersionId != workflow.WorkflowVersionId {
+		return "", nil
+	}
+	manualTriggerEvent := ManualTriggerEvent{
+		EventData: core.EventData{
+			EventTime: time.Now(),
+		},
+		WorkflowVersionNodeId: workflow.WorkflowVersionNodeId,
+	}
+	reference := fmt.Sprintf("%v_%v", workflow.WorkflowVersionId, time.Now().UTC().Format("20060102.150405.000000"))
+	cache.ScheduleTrigger(reference, workflow.WorkflowVersionId, workflow_helpers.WorkflowNodeManualTrigger,
+		workflow.WorkflowVersionNodeId, manualTriggerEvent)
+	return reference, nil
+}
@@ -13,12 +13,21 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/grpclog"
+
+	"github.com/lncapital/torq/pkg/secrets"
+	"github.com/lncapital/torq/pkg/tracing"
 )
 
 // Connect connects to CLN using gRPC.
 func Connect(host string, certificate []byte, key []byte, caCertificate []byte) (*grpc.ClientConn, error) {
 	grpclog.SetLoggerV2(grpclog.NewLoggerV2(io.Discard, os.Stderr, os.Stderr))
 
+	// The credentials are stored encrypted when a secrets key is configured and only decrypted here
+	certificate, key, caCertificate, err := decryptCredentials(certificate, key, caCertificate)
+	if err != nil {
+		return nil, err
+	}
+
 	clientCrt, err := tls.X509KeyPair(certificate, key)
 	if err != nil {
 		return nil, errors.New("CLN credentials: failed to create X509 KeyPair")
@@ -47,6 +56,7 @@ func Connect(host string, certificate []byte, key []byte, caCertificate []byte)
 		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
 		// max size to 25mb
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(25 << (10 * 2))),
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor("CLN")),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
@@ -59,3 +69,17 @@ func Connect(host string, certificate []byte, key []byte, caCertificate []byte)
 
 	return conn, nil
 }
+
+func decryptCredentials(certificate []byte, key []byte, caCertificate []byte) ([]byte, []byte, []byte, error) {
+	var err error
+	if certificate, err = secrets.Decrypt(certificate); err != nil {
+		return nil, nil, nil, errors.New("CLN credentials: failed to decrypt certificate: " + err.Error())
+	}
+	if key, err = secrets.Decrypt(key); err != nil {
+		return nil, nil, nil, errors.New("CLN credentials: failed to decrypt key: " + err.Error())
+	}
+	if caCertificate, err = secrets.Decrypt(caCertificate); err != nil {
+		return nil, nil, nil, errors.New("CLN credentials: failed to decrypt CA certificate: " + err.Error())
+	}
+	return certificate, key, caCertificate, nil
+}
@@ -12,6 +12,9 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/grpclog"
 	"gopkg.in/macaroon.v2"
+
+	"github.com/lncapital/torq/pkg/secrets"
+	"github.com/lncapital/torq/pkg/tracing"
 )
 
 // Connect connects to LND using gRPC. DO NOT USE THIS UNLESS THE GRPC SETTINGS ARE NOT VALIDATED NOR ACTIVATED IN TORQ.
@@ -19,6 +22,16 @@ func Connect(host string, tlsCert []byte, macaroonBytes []byte) (*grpc.ClientCon
 
 	grpclog.SetLoggerV2(grpclog.NewLoggerV2(io.Discard, os.Stderr, os.Stderr))
 
+	// The credentials are stored encrypted when a secrets key is configured and only decrypted here
+	tlsCert, err := secrets.Decrypt(tlsCert)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt tls certificate: %v", err)
+	}
+	macaroonBytes, err = secrets.Decrypt(macaroonBytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt macaroon: %v", err)
+	}
+
 	cp := x509.NewCertPool()
 	if !cp.AppendCertsFromPEM(tlsCert) {
 		return nil, fmt.Errorf("credentials: failed to append certificates")
@@ -43,6 +56,7 @@ func Connect(host string, tlsCert []byte, macaroonBytes []byte) (*grpc.ClientCon
 		grpc.WithPerRPCCredentials(macCred),
 		// max size to 25mb
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(25 << (10 * 2))),
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor("LND")),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
@@ -0,0 +1,99 @@
+package lnd_connect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lncapital/torq/pkg/secrets"
+	"github.com/lncapital/torq/proto/lnrpc"
+)
+
+// ErrPermissionsUnavailable is returned when LND can't check the macaroon permissions,
+// checking requires info:read and macaroon:read (or the uri permissions of ListPermissions and CheckMacaroonPermissions).
+var ErrPermissionsUnavailable = errors.New("macaroon permissions can't be checked") //nolint:gochecknoglobals
+
+type MacaroonPermissions struct {
+	// The permissions (entity:action) LND requires for each RPC method
+	MethodPermissions map[string][]string
+	// The requested RPC methods (i.e. /lnrpc.Lightning/GetInfo) the macaroon is not allowed to call
+	DeniedMethods []string
+	// The permissions (entity:action) the macaroon grants
+	GrantedPermissions []string
+}
+
+// CheckMacaroonPermissions checks which of the RPC methods the macaroon is allowed to call and
+// which permissions it grants. Methods unknown to LND (i.e. older versions) are not denied.
+func CheckMacaroonPermissions(ctx context.Context, conn *grpc.ClientConn, macaroonBytes []byte,
+	methods []string) (MacaroonPermissions, error) {
+
+	macaroonBytes, err := secrets.Decrypt(macaroonBytes)
+	if err != nil {
+		return MacaroonPermissions{}, fmt.Errorf("cannot decrypt macaroon: %v", err)
+	}
+
+	client := lnrpc.NewLightningClient(conn)
+	listPermissionsResponse, err := client.ListPermissions(ctx, &lnrpc.ListPermissionsRequest{})
+	if err != nil {
+		return MacaroonPermissions{}, fmt.Errorf("%w: %v", ErrPermissionsUnavailable, err)
+	}
+
+	permissions := MacaroonPermissions{MethodPermissions: make(map[string][]string)}
+	allPermissions := make(map[string]*lnrpc.MacaroonPermission)
+	for method, methodPermissions := range listPermissionsResponse.MethodPermissions {
+		for _, permission := range methodPermissions.Permissions {
+			key := permission.Entity + ":" + permission.Action
+			permissions.MethodPermissions[method] = append(permissions.MethodPermissions[method], key)
+			allPermissions[key] = permission
+		}
+	}
+
+	for _, method := range methods {
+		methodPermissions, exists := listPermissionsResponse.MethodPermissions[method]
+		if !exists {
+			continue
+		}
+		allowed, err := checkPermissions(ctx, client, macaroonBytes, methodPermissions.Permissions, method)
+		if err != nil {
+			return MacaroonPermissions{}, err
+		}
+		if !allowed {
+			permissions.DeniedMethods = append(permissions.DeniedMethods, method)
+		}
+	}
+
+	for key, permission := range allPermissions {
+		allowed, err := checkPermissions(ctx, client, macaroonBytes, []*lnrpc.MacaroonPermission{permission}, "")
+		if err != nil {
+			return MacaroonPermissions{}, err
+		}
+		if allowed {
+			permissions.GrantedPermissions = append(permissions.GrantedPermissions, key)
+		}
+	}
+	sort.Strings(permissions.GrantedPermissions)
+	return permissions, nil
+}
+
+func checkPermissions(ctx context.Context, client lnrpc.LightningClient, macaroonBytes []byte,
+	permissions []*lnrpc.MacaroonPermission, method string) (bool, error) {
+
+	response, err := client.CheckMacaroonPermissions(ctx, &lnrpc.CheckMacPermRequest{
+		Macaroon:    macaroonBytes,
+		Permissions: permissions,
+		FullMethod:  method,
+	})
+	if err != nil {
+		// LND reports the permissions the macaroon lacks as an invalid argument
+		if status.Code(err) == codes.InvalidArgument {
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: %v", ErrPermissionsUnavailable, err)
+	}
+	return response.Valid, nil
+}
@@ -0,0 +1,123 @@
+// Package otlp sends logs and traces to an OpenTelemetry collector with the OTLP/HTTP JSON encoding
+// (opentelemetry-proto) so no gRPC or protobuf dependencies of the collector are needed.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/lncapital/torq/build"
+)
+
+const timeout = 10 * time.Second
+
+type Resource struct {
+	Attributes []Attribute `json:"attributes"`
+}
+
+type Scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type Attribute struct {
+	Key   string `json:"key"`
+	Value Value  `json:"value"`
+}
+
+// Value is the AnyValue of OTLP, only one of the fields is set
+type Value struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func StringValue(value string) Value {
+	return Value{StringValue: &value}
+}
+
+func BoolValue(value bool) Value {
+	return Value{BoolValue: &value}
+}
+
+// IntValue is encoded as a string because JSON numbers can't hold every int64
+func IntValue(value int64) Value {
+	intValue := strconv.FormatInt(value, 10)
+	return Value{IntValue: &intValue}
+}
+
+func DoubleValue(value float64) Value {
+	return Value{DoubleValue: &value}
+}
+
+// GetResourceAttributes returns the attributes that identify this Torq instance in the logs and traces
+func GetResourceAttributes() map[string]string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return map[string]string{
+		"service.name":    "torq",
+		"service.version": build.ExtendedVersion(),
+		"host.name":       hostname,
+	}
+}
+
+func GetResource() Resource {
+	resourceAttributes := GetResourceAttributes()
+	var keys []string
+	for key := range resourceAttributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var resource Resource
+	for _, key := range keys {
+		resource.Attributes = append(resource.Attributes, Attribute{Key: key, Value: StringValue(resourceAttributes[key])})
+	}
+	return resource
+}
+
+// Client posts the logs or traces to the endpoint of the signal (i.e. http://localhost:4318/v1/traces)
+type Client struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewClient(endpoint string) *Client {
+	return &Client{endpoint: endpoint, client: &http.Client{Timeout: timeout}}
+}
+
+// Send posts the JSON encoding of the request, signal (logs|traces) is only used in errors
+func (c *Client) Send(ctx context.Context, signal string, request interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return errors.Wrapf(err, "Marshalling OTLP %v", signal)
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "Creating OTLP %v request", signal)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	response, err := c.client.Do(httpRequest)
+	if err != nil {
+		return errors.Wrapf(err, "Sending OTLP %v to %v", signal, c.endpoint)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return errors.Newf("Sending OTLP %v to %v: %v", signal, c.endpoint, response.Status)
+	}
+	return nil
+}
+
+func (c *Client) Close() {
+	c.client.CloseIdleConnections()
+}
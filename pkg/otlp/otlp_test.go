@@ -0,0 +1,59 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientSend(t *testing.T) {
+	status := http.StatusOK
+	var received struct {
+		Resource Resource `json:"resource"`
+	}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil || r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" ||
+			json.Unmarshal(body, &received) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(status)
+	}))
+	defer collector.Close()
+
+	client := NewClient(collector.URL + "/v1/logs")
+	defer client.Close()
+	err := client.Send(context.Background(), "logs", map[string]Resource{"resource": GetResource()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes := make(map[string]string)
+	for _, attribute := range received.Resource.Attributes {
+		attributes[attribute.Key] = *attribute.Value.StringValue
+	}
+	for key, value := range GetResourceAttributes() {
+		if attributes[key] != value {
+			t.Errorf("Resource attribute %v = %q, expected %q", key, attributes[key], value)
+		}
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := client.Send(context.Background(), "logs", struct{}{}); err == nil {
+		t.Errorf("Send should fail when the collector is unavailable")
+	}
+}
+
+func TestValues(t *testing.T) {
+	encoded, err := json.Marshal([]Value{StringValue("torq"), BoolValue(true), IntValue(1 << 62), DoubleValue(0.5)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"stringValue":"torq"},{"boolValue":true},{"intValue":"4611686018427387904"},{"doubleValue":0.5}]`
+	if string(encoded) != expected {
+		t.Errorf("Values = %v, expected %v", string(encoded), expected)
+	}
+}
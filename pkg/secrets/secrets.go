@@ -0,0 +1,176 @@
+// Package secrets encrypts the credentials Torq stores (node connection details and notification tokens)
+// with AES-256-GCM. Encrypted values are prefixed so plaintext values (stored before a key was configured)
+// are still readable and can be encrypted in place.
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+)
+
+// KeyEnvironmentVariable holds the base64 encoded key when no key file is configured
+const KeyEnvironmentVariable = "TORQ_SECRETS_KEY"
+
+const keySize = 32
+
+var encryptedPrefix = []byte("torq-secret:v1:") //nolint:gochecknoglobals
+
+var (
+	secretsKey      []byte       //nolint:gochecknoglobals
+	secretsKeyMutex sync.RWMutex //nolint:gochecknoglobals
+)
+
+// GenerateKey returns a new random base64 encoded key
+func GenerateKey() (string, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", errors.Wrap(err, "Generating secrets key")
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// ParseKey decodes a base64 encoded key
+func ParseKey(encodedKey string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "Decoding secrets key (base64)")
+	}
+	if len(key) != keySize {
+		return nil, errors.Newf("The secrets key must be %v bytes, got %v", keySize, len(key))
+	}
+	return key, nil
+}
+
+// LoadKey reads the key from the key file or otherwise from the TORQ_SECRETS_KEY environment variable,
+// nil when neither is configured.
+func LoadKey(keyFile string) ([]byte, error) {
+	if keyFile != "" {
+		encodedKey, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Reading secrets key file %v", keyFile)
+		}
+		return ParseKey(string(encodedKey))
+	}
+	if encodedKey := os.Getenv(KeyEnvironmentVariable); encodedKey != "" {
+		return ParseKey(encodedKey)
+	}
+	return nil, nil
+}
+
+// SetKey sets the key used by Encrypt and Decrypt, nil disables encryption
+func SetKey(key []byte) {
+	secretsKeyMutex.Lock()
+	defer secretsKeyMutex.Unlock()
+	secretsKey = key
+}
+
+func HasKey() bool {
+	return getKey() != nil
+}
+
+func getKey() []byte {
+	secretsKeyMutex.RLock()
+	defer secretsKeyMutex.RUnlock()
+	return secretsKey
+}
+
+func IsEncrypted(value []byte) bool {
+	return bytes.HasPrefix(value, encryptedPrefix)
+}
+
+// Encrypt encrypts the value with the configured key.
+// Empty and already encrypted values and all values when no key is configured are returned unchanged.
+func Encrypt(value []byte) ([]byte, error) {
+	key := getKey()
+	if key == nil {
+		return value, nil
+	}
+	return EncryptWithKey(key, value)
+}
+
+// Decrypt decrypts the value with the configured key, values that are not encrypted are returned unchanged.
+func Decrypt(value []byte) ([]byte, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	key := getKey()
+	if key == nil {
+		return nil, errors.Newf("The value is encrypted but no secrets key is configured (use --torq.secrets-key-file or %v)",
+			KeyEnvironmentVariable)
+	}
+	return DecryptWithKey(key, value)
+}
+
+func EncryptString(value string) (string, error) {
+	encrypted, err := Encrypt([]byte(value))
+	return string(encrypted), err
+}
+
+func DecryptString(value string) (string, error) {
+	decrypted, err := Decrypt([]byte(value))
+	return string(decrypted), err
+}
+
+// EncryptWithKey encrypts the value with the given key (i.e. while re-keying),
+// empty and already encrypted values are returned unchanged.
+func EncryptWithKey(key []byte, value []byte) ([]byte, error) {
+	if len(value) == 0 || IsEncrypted(value) {
+		return value, nil
+	}
+	gcm, err := newGcm(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "Generating nonce")
+	}
+	sealed := gcm.Seal(nonce, nonce, value, nil)
+	encrypted := make([]byte, len(encryptedPrefix)+base64.StdEncoding.EncodedLen(len(sealed)))
+	copy(encrypted, encryptedPrefix)
+	base64.StdEncoding.Encode(encrypted[len(encryptedPrefix):], sealed)
+	return encrypted, nil
+}
+
+// DecryptWithKey decrypts the value with the given key, values that are not encrypted are returned unchanged.
+func DecryptWithKey(key []byte, value []byte) ([]byte, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	gcm, err := newGcm(key)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(string(value[len(encryptedPrefix):]))
+	if err != nil {
+		return nil, errors.Wrap(err, "Decoding encrypted value (base64)")
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("The encrypted value is too short")
+	}
+	decrypted, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Decrypting value (wrong secrets key?)")
+	}
+	return decrypted, nil
+}
+
+func newGcm(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "Creating secrets cipher")
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "Creating secrets GCM")
+	}
+	return gcm, nil
+}
@@ -0,0 +1,74 @@
+package secrets
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	encodedKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	key, err := ParseKey(encodedKey)
+	if err != nil {
+		t.Fatalf("ParseKey: %v", err)
+	}
+	otherKey, _ := GenerateKey()
+	wrongKey, _ := ParseKey(otherKey)
+	defer SetKey(nil)
+
+	plaintext := []byte("macaroon")
+	SetKey(nil)
+	unencrypted, _ := Encrypt(plaintext)
+	if !bytes.Equal(unencrypted, plaintext) {
+		t.Errorf("got %s without a key, want the value unchanged", unencrypted)
+	}
+
+	SetKey(key)
+	encrypted, err := Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if !IsEncrypted(encrypted) || bytes.Contains(encrypted, plaintext) {
+		t.Fatalf("got %s, want an encrypted value", encrypted)
+	}
+	if again, _ := Encrypt(encrypted); !bytes.Equal(again, encrypted) {
+		t.Errorf("got %s, want an encrypted value to be returned unchanged", again)
+	}
+
+	testCases := []struct {
+		name    string
+		key     []byte
+		value   []byte
+		want    []byte
+		wantErr bool
+	}{
+		{"encrypted", key, encrypted, plaintext, false},
+		{"plaintext", key, plaintext, plaintext, false},
+		{"empty", key, nil, nil, false},
+		{"wrong key", wrongKey, encrypted, nil, true},
+		{"missing key", nil, encrypted, nil, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetKey(tc.key)
+			decrypted, err := Decrypt(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tc.wantErr)
+			}
+			if !bytes.Equal(decrypted, tc.want) {
+				t.Errorf("got %s, want %s", decrypted, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	if _, err := ParseKey("c2hvcnQ="); err == nil {
+		t.Error("got no error for a short key")
+	}
+	if _, err := ParseKey("not base64"); err == nil {
+		t.Error("got no error for an invalid key")
+	}
+}
@@ -105,6 +105,10 @@ func SendBadRequestFieldError(c *gin.Context, se *ServerError) {
 	c.JSON(http.StatusBadRequest, se)
 }
 
+func SendNotFound(c *gin.Context, message string) {
+	c.JSON(http.StatusNotFound, SingleServerError(message))
+}
+
 func SendUnprocessableEntity(c *gin.Context, message string) {
 	c.JSON(http.StatusUnprocessableEntity, SingleServerError(message))
 }
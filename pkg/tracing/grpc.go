@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor starts a span for every unary call to a node (lnd|cln).
+// Streams are not traced as the subscriptions live as long as the node services.
+func UnaryClientInterceptor(implementation string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+		service, rpcMethod := splitMethod(method)
+		ctx, span := otel.Tracer(tracerName).Start(ctx, strings.TrimPrefix(method, "/"),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				ImplementationKey.String(implementation),
+				attribute.String("rpc.system", "grpc"),
+				attribute.String("rpc.service", service),
+				attribute.String("rpc.method", rpcMethod),
+				attribute.String("net.peer.name", cc.Target()),
+			))
+		defer span.End()
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		rpcStatus, _ := status.FromError(err)
+		span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(rpcStatus.Code())))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, rpcStatus.Message())
+		}
+		return err
+	}
+}
+
+// splitMethod splits /lnrpc.Lightning/GetInfo into lnrpc.Lightning and GetInfo
+func splitMethod(method string) (string, string) {
+	method = strings.TrimPrefix(method, "/")
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		return method[:i], method[i+1:]
+	}
+	return "", method
+}
@@ -0,0 +1,159 @@
+package tracing
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/lncapital/torq/pkg/otlp"
+)
+
+// The OTLP/HTTP JSON encoding of the trace service (opentelemetry-proto trace/v1)
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlp.Resource    `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlp.Scope `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpSpan struct {
+	TraceId           string           `json:"traceId"`
+	SpanId            string           `json:"spanId"`
+	ParentSpanId      string           `json:"parentSpanId,omitempty"`
+	Name              string           `json:"name"`
+	Kind              int              `json:"kind"`
+	StartTimeUnixNano string           `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string           `json:"endTimeUnixNano"`
+	Attributes        []otlp.Attribute `json:"attributes"`
+	Events            []otlpEvent      `json:"events,omitempty"`
+	Status            otlpStatus       `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string           `json:"timeUnixNano"`
+	Name         string           `json:"name"`
+	Attributes   []otlp.Attribute `json:"attributes"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpExporter struct {
+	client *otlp.Client
+}
+
+func newOtlpExporter(endpoint string) (*otlpExporter, error) {
+	endpointUrl, err := url.Parse(endpoint)
+	if err != nil || (endpointUrl.Scheme != "http" && endpointUrl.Scheme != "https") || endpointUrl.Host == "" {
+		return nil, errors.Newf("Tracing OTLP endpoint %v is not a http(s) url", endpoint)
+	}
+	return &otlpExporter{client: otlp.NewClient(endpoint)}, nil
+}
+
+func (e *otlpExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	return e.client.Send(ctx, "traces", getOtlpTraces(spans))
+}
+
+func (e *otlpExporter) Shutdown(ctx context.Context) error {
+	e.client.Close()
+	return nil
+}
+
+// getOtlpTraces groups the spans by scope, all spans share the resource of the tracer provider
+func getOtlpTraces(spans []sdktrace.ReadOnlySpan) otlpTraces {
+	resourceSpans := otlpResourceSpans{
+		Resource: otlp.Resource{Attributes: getOtlpAttributes(spans[0].Resource().Attributes())},
+	}
+	scopeIndexes := make(map[otlp.Scope]int)
+	for _, span := range spans {
+		scope := otlp.Scope{Name: span.InstrumentationScope().Name, Version: span.InstrumentationScope().Version}
+		index, exists := scopeIndexes[scope]
+		if !exists {
+			index = len(resourceSpans.ScopeSpans)
+			scopeIndexes[scope] = index
+			resourceSpans.ScopeSpans = append(resourceSpans.ScopeSpans, otlpScopeSpans{Scope: scope})
+		}
+		resourceSpans.ScopeSpans[index].Spans = append(resourceSpans.ScopeSpans[index].Spans, getOtlpSpan(span))
+	}
+	return otlpTraces{ResourceSpans: []otlpResourceSpans{resourceSpans}}
+}
+
+func getOtlpSpan(span sdktrace.ReadOnlySpan) otlpSpan {
+	result := otlpSpan{
+		TraceId:           span.SpanContext().TraceID().String(),
+		SpanId:            span.SpanContext().SpanID().String(),
+		Name:              span.Name(),
+		Kind:              int(span.SpanKind()),
+		StartTimeUnixNano: getOtlpTime(span.StartTime()),
+		EndTimeUnixNano:   getOtlpTime(span.EndTime()),
+		Attributes:        getOtlpAttributes(span.Attributes()),
+		Status:            otlpStatus{Code: getOtlpStatusCode(span.Status().Code), Message: span.Status().Description},
+	}
+	if span.Parent().HasSpanID() {
+		result.ParentSpanId = span.Parent().SpanID().String()
+	}
+	for _, event := range span.Events() {
+		result.Events = append(result.Events, otlpEvent{
+			TimeUnixNano: getOtlpTime(event.Time),
+			Name:         event.Name,
+			Attributes:   getOtlpAttributes(event.Attributes),
+		})
+	}
+	return result
+}
+
+func getOtlpAttributes(attributes []attribute.KeyValue) []otlp.Attribute {
+	result := make([]otlp.Attribute, 0, len(attributes))
+	for _, keyValue := range attributes {
+		result = append(result, otlp.Attribute{Key: string(keyValue.Key), Value: getOtlpValue(keyValue.Value)})
+	}
+	return result
+}
+
+func getOtlpValue(value attribute.Value) otlp.Value {
+	switch value.Type() {
+	case attribute.BOOL:
+		return otlp.BoolValue(value.AsBool())
+	case attribute.INT64:
+		return otlp.IntValue(value.AsInt64())
+	case attribute.FLOAT64:
+		return otlp.DoubleValue(value.AsFloat64())
+	default:
+		// Slices are sent as their JSON representation
+		return otlp.StringValue(value.Emit())
+	}
+}
+
+// getOtlpStatusCode maps the API status codes (unset, error, ok) on the OTLP ones (unset, ok, error)
+func getOtlpStatusCode(code codes.Code) int {
+	switch code {
+	case codes.Ok:
+		return 1
+	case codes.Error:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func getOtlpTime(timestamp time.Time) string {
+	return strconv.FormatInt(timestamp.UnixNano(), 10)
+}
@@ -0,0 +1,142 @@
+// Package tracing exports OpenTelemetry spans for API requests, workflows, the rebalancer and node requests.
+// Without exporter the global no-op tracer provider is kept so spans cost next to nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/lncapital/torq/pkg/otlp"
+)
+
+const tracerName = "github.com/lncapital/torq"
+
+type Exporter string
+
+const (
+	NoExporter     = Exporter("")
+	StdoutExporter = Exporter("stdout")
+	OtlpExporter   = Exporter("otlp")
+)
+
+const (
+	NodeIdKey                = attribute.Key("torq.node_id")
+	ImplementationKey        = attribute.Key("torq.implementation")
+	RequestKey               = attribute.Key("torq.request")
+	CacheRequestKey          = attribute.Key("torq.cache_request")
+	WorkflowVersionIdKey     = attribute.Key("torq.workflow_version_id")
+	WorkflowVersionNodeIdKey = attribute.Key("torq.workflow_version_node_id")
+	WorkflowNodeTypeKey      = attribute.Key("torq.workflow_node_type")
+	RebalanceIdKey           = attribute.Key("torq.rebalance_id")
+	IncomingChannelIdKey     = attribute.Key("torq.incoming_channel_id")
+	OutgoingChannelIdKey     = attribute.Key("torq.outgoing_channel_id")
+	StatusKey                = attribute.Key("torq.status")
+)
+
+type Config struct {
+	Exporter Exporter
+	// OtlpEndpoint is the OTLP/HTTP traces endpoint i.e. http://localhost:4318/v1/traces
+	OtlpEndpoint string
+	// SampleRatio is the fraction of the traces started by Torq that are exported (incoming sampled traces always are)
+	SampleRatio float64
+	// Writer of the stdout exporter, os.Stdout when not set
+	Writer io.Writer
+}
+
+// Start installs the global tracer provider, the returned function flushes and stops the exporter
+func Start(config Config) (func(ctx context.Context) error, error) {
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, errors.Newf("Tracing sample ratio %v is not between 0 and 1", config.SampleRatio)
+	}
+	var spanProcessor sdktrace.SpanProcessor
+	switch config.Exporter {
+	case NoExporter:
+		return func(ctx context.Context) error { return nil }, nil
+	case StdoutExporter:
+		writer := config.Writer
+		if writer == nil {
+			writer = os.Stdout
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(writer))
+		if err != nil {
+			return nil, errors.Wrap(err, "Creating stdout trace exporter")
+		}
+		// Synchronous so the spans are written when they end
+		spanProcessor = sdktrace.NewSimpleSpanProcessor(exporter)
+	case OtlpExporter:
+		exporter, err := newOtlpExporter(config.OtlpEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		spanProcessor = sdktrace.NewBatchSpanProcessor(exporter)
+	default:
+		return nil, errors.Newf("Unknown tracing exporter %v (stdout|otlp)", config.Exporter)
+	}
+
+	// The same resource as the logs
+	var resourceAttributes []attribute.KeyValue
+	for key, value := range otlp.GetResourceAttributes() {
+		resourceAttributes = append(resourceAttributes, attribute.String(key, value))
+	}
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(spanProcessor),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(resourceAttributes...)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return tracerProvider.Shutdown, nil
+}
+
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+type nodeRequest interface {
+	GetNodeId() int
+}
+
+// StartNodeRequest starts the span of a request to a node, named after the implementation and request type
+// i.e. LND RoutingPolicyUpdateRequest (pointers are named after the type they point to, nil requests <nil>)
+func StartNodeRequest(ctx context.Context, implementation string, request interface{}) (context.Context, trace.Span) {
+	requestName := fmt.Sprintf("%T", request)
+	requestName = requestName[strings.LastIndex(requestName, ".")+1:]
+	attributes := []attribute.KeyValue{ImplementationKey.String(implementation), RequestKey.String(requestName)}
+	if r, ok := request.(nodeRequest); ok {
+		attributes = append(attributes, NodeIdKey.Int(r.GetNodeId()))
+	}
+	return StartSpan(ctx, implementation+" "+requestName, attributes...)
+}
+
+// StartCacheRequest starts the span of a round trip to a cache handler i.e. cache GetChannelState.
+// The handlers serve one request at a time so the span includes waiting for the handler.
+func StartCacheRequest(ctx context.Context, request string) (context.Context, trace.Span) {
+	return StartSpan(ctx, "cache "+request, CacheRequestKey.String(request))
+}
+
+// Detach returns a context that continues the trace of ctx without being cancelled with it
+func Detach(ctx context.Context) context.Context {
+	return trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx))
+}
+
+// EndSpan ends the span and marks it as failed when there is an error
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
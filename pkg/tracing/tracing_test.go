@@ -0,0 +1,305 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// The span as written by the stdout exporter
+type stdoutSpan struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Parent struct {
+		TraceID string
+		SpanID  string
+	}
+	Attributes []struct {
+		Key   string
+		Value struct {
+			Value interface{}
+		}
+	}
+	Status struct {
+		Code        string
+		Description string
+	}
+}
+
+func (s stdoutSpan) attribute(key string) interface{} {
+	for _, attribute := range s.Attributes {
+		if attribute.Key == key {
+			return attribute.Value.Value
+		}
+	}
+	return nil
+}
+
+func startStdout(t *testing.T) func() []stdoutSpan {
+	var buffer bytes.Buffer
+	shutdown, err := Start(Config{Exporter: StdoutExporter, SampleRatio: 1, Writer: &buffer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = shutdown(context.Background())
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+	return func() []stdoutSpan {
+		var spans []stdoutSpan
+		decoder := json.NewDecoder(&buffer)
+		for decoder.More() {
+			var span stdoutSpan
+			if err := decoder.Decode(&span); err != nil {
+				t.Fatal(err)
+			}
+			spans = append(spans, span)
+		}
+		return spans
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	getSpans := startStdout(t)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	api := r.Group("/api", Middleware())
+	api.GET("/channels/:channelId", func(c *gin.Context) {
+		_, span := StartSpan(c.Request.Context(), "child")
+		span.End()
+		c.Status(http.StatusOK)
+	})
+	api.GET("/failure", func(c *gin.Context) {
+		c.Status(http.StatusInternalServerError)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/api/channels/12", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), request)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/failure", nil))
+
+	spans := getSpans()
+	if len(spans) != 3 {
+		t.Fatalf("Exported %v spans, expected the child and 2 request spans", len(spans))
+	}
+	child, server, failure := spans[0], spans[1], spans[2]
+	if server.Name != "GET /api/channels/:channelId" || server.attribute("http.status_code") != float64(200) {
+		t.Errorf("Request span %+v, expected the route and status code", server)
+	}
+	if server.SpanContext.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || server.Parent.SpanID != "00f067aa0ba902b7" {
+		t.Errorf("Request span %+v, expected to continue the incoming trace", server)
+	}
+	if child.Parent.SpanID != server.SpanContext.SpanID {
+		t.Errorf("Child span parent %v, expected the request span %v", child.Parent.SpanID, server.SpanContext.SpanID)
+	}
+	if failure.Status.Code != "Error" {
+		t.Errorf("Request span status %+v, expected an error for a 500", failure.Status)
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	getSpans := startStdout(t)
+	conn, err := grpc.Dial("localhost:10009", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, parent := StartSpan(context.Background(), "parent")
+	interceptor := UnaryClientInterceptor("LND")
+	err = interceptor(ctx, "/lnrpc.Lightning/GetInfo", nil, nil, conn,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return status.Error(grpccodes.Unavailable, "connection refused")
+		})
+	parent.End()
+	if status.Code(err) != grpccodes.Unavailable {
+		t.Errorf("Interceptor returned %v, expected the error of the call", err)
+	}
+
+	spans := getSpans()
+	if len(spans) != 2 {
+		t.Fatalf("Exported %v spans, expected the call and its parent", len(spans))
+	}
+	call := spans[0]
+	if call.Name != "lnrpc.Lightning/GetInfo" || call.attribute("rpc.service") != "lnrpc.Lightning" ||
+		call.attribute("rpc.method") != "GetInfo" || call.attribute(string(ImplementationKey)) != "LND" {
+		t.Errorf("Call span %+v, expected the method and implementation", call)
+	}
+	if call.Parent.SpanID != spans[1].SpanContext.SpanID {
+		t.Errorf("Call span parent %v, expected %v", call.Parent.SpanID, spans[1].SpanContext.SpanID)
+	}
+	if call.Status.Code != "Error" || call.Status.Description != "connection refused" ||
+		call.attribute("rpc.grpc.status_code") != float64(grpccodes.Unavailable) {
+		t.Errorf("Call span %+v, expected the gRPC error", call)
+	}
+}
+
+type testCommunicationRequest struct {
+	NodeId int
+}
+
+func (r testCommunicationRequest) GetNodeId() int {
+	return r.NodeId
+}
+
+type RoutingPolicyUpdateRequest struct {
+	testCommunicationRequest
+}
+
+func TestStartNodeRequestDetached(t *testing.T) {
+	getSpans := startStdout(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx, parent := StartSpan(ctx, "workflow node")
+	detached := Detach(ctx)
+	cancel()
+	if detached.Err() != nil {
+		t.Errorf("The detached context should not be cancelled with its parent")
+	}
+	_, span := StartNodeRequest(detached, "CLN", RoutingPolicyUpdateRequest{testCommunicationRequest{NodeId: 3}})
+	EndSpan(span, errors.New("policy update failed"))
+	parent.End()
+
+	spans := getSpans()
+	if len(spans) != 2 {
+		t.Fatalf("Exported %v spans, expected the request and its parent", len(spans))
+	}
+	request := spans[0]
+	if request.Name != "CLN RoutingPolicyUpdateRequest" || request.attribute(string(NodeIdKey)) != float64(3) {
+		t.Errorf("Request span %+v, expected the implementation, request and node id", request)
+	}
+	if request.Parent.SpanID != spans[1].SpanContext.SpanID {
+		t.Errorf("Request span parent %v, expected %v", request.Parent.SpanID, spans[1].SpanContext.SpanID)
+	}
+	if request.Status.Code != "Error" || request.Status.Description != "policy update failed" {
+		t.Errorf("Request span status %+v, expected the error", request.Status)
+	}
+}
+
+func TestStartNodeRequestName(t *testing.T) {
+	getSpans := startStdout(t)
+	requests := map[string]interface{}{
+		"LND RoutingPolicyUpdateRequest": &RoutingPolicyUpdateRequest{},
+		"LND <nil>":                      nil,
+	}
+	for name, request := range requests {
+		_, span := StartNodeRequest(context.Background(), "LND", request)
+		span.End()
+		spans := getSpans()
+		if len(spans) != 1 || spans[0].Name != name {
+			t.Errorf("Request spans %+v, expected %v", spans, name)
+		}
+	}
+}
+
+func TestStartCacheRequest(t *testing.T) {
+	getSpans := startStdout(t)
+	ctx, parent := StartSpan(context.Background(), "workflow node")
+	_, span := StartCacheRequest(ctx, "GetChannelState")
+	span.End()
+	parent.End()
+
+	spans := getSpans()
+	if len(spans) != 2 {
+		t.Fatalf("Exported %v spans, expected the cache request and its parent", len(spans))
+	}
+	request := spans[0]
+	if request.Name != "cache GetChannelState" || request.attribute(string(CacheRequestKey)) != "GetChannelState" ||
+		request.Parent.SpanID != spans[1].SpanContext.SpanID {
+		t.Errorf("Cache request span %+v, expected the request as child of the workflow node", request)
+	}
+}
+
+func TestOtlpExporter(t *testing.T) {
+	received := make(chan otlpTraces, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil || r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var traces otlpTraces
+		if json.Unmarshal(body, &traces) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- traces
+	}))
+	defer collector.Close()
+
+	shutdown, err := Start(Config{Exporter: OtlpExporter, OtlpEndpoint: collector.URL + "/v1/traces", SampleRatio: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	ctx, parent := StartSpan(context.Background(), "rebalance runner attempt", RebalanceIdKey.Int(7))
+	_, child := StartSpan(ctx, "routerrpc.Router/SendToRouteV2")
+	EndSpan(child, errors.New("TEMPORARY_CHANNEL_FAILURE"))
+	parent.End()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = shutdown(shutdownCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case traces := <-received:
+		resourceSpans := traces.ResourceSpans[0]
+		serviceName := ""
+		for _, attribute := range resourceSpans.Resource.Attributes {
+			if attribute.Key == "service.name" {
+				serviceName = *attribute.Value.StringValue
+			}
+		}
+		if serviceName != "torq" {
+			t.Errorf("Resource %+v, expected the torq service", resourceSpans.Resource)
+		}
+		spans := resourceSpans.ScopeSpans[0].Spans
+		if len(spans) != 2 {
+			t.Fatalf("Received %v spans, expected the attempt and the payment", len(spans))
+		}
+		payment, attempt := spans[0], spans[1]
+		if payment.ParentSpanId != attempt.SpanId || payment.TraceId != attempt.TraceId || attempt.ParentSpanId != "" {
+			t.Errorf("Received %+v and %+v, expected the payment in the attempt", payment, attempt)
+		}
+		if payment.Status.Code != 2 || len(payment.Events) != 1 || payment.Events[0].Name != "exception" {
+			t.Errorf("Received payment %+v, expected the error", payment)
+		}
+		if attempt.Attributes[0].Key != string(RebalanceIdKey) || *attempt.Attributes[0].Value.IntValue != "7" {
+			t.Errorf("Received attempt attributes %+v, expected the rebalance id", attempt.Attributes)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("The collector did not receive the spans")
+	}
+}
+
+func TestStartInvalid(t *testing.T) {
+	for _, config := range []Config{
+		{Exporter: "jaeger", SampleRatio: 1},
+		{Exporter: StdoutExporter, SampleRatio: 2},
+		{Exporter: OtlpExporter, OtlpEndpoint: "localhost:4318", SampleRatio: 1},
+	} {
+		if _, err := Start(config); err == nil {
+			t.Errorf("Start(%+v) should fail", config)
+		}
+	}
+}
@@ -222,9 +222,13 @@ func (srv *Server) NewTestDatabase(migrate bool) (*sqlx.DB, context.CancelFunc,
 	go cache.SettingsCacheHandle(cache.SettingsCacheChannel, ctx)
 	go cache.NodesCacheHandler(cache.NodesCacheChannel, ctx)
 	go cache.NodeAliasesCacheHandler(cache.NodeAliasesCacheChannel, ctx)
+	go cache.ChannelFlowsCacheHandler(cache.ChannelFlowsCacheChannel, ctx)
+	go cache.PeerScoresCacheHandler(cache.PeerScoresCacheChannel, ctx)
 	go cache.ChannelsCacheHandler(cache.ChannelsCacheChannel, ctx)
 	go cache.TaggedCacheHandler(cache.TaggedCacheChannel, ctx)
 	go cache.TriggersCacheHandler(cache.TriggersCacheChannel, ctx)
+	go cache.EventsCacheHandler(cache.EventsCacheChannel, ctx)
+	go cache.NodePermissionsCacheHandler(cache.NodePermissionsCacheChannel, ctx)
 	go tags.TagsCacheHandler(tags.TagsCacheChannel, ctx)
 	// TODO FIXME cyclic dependency so if you need this in tests then initialise it in the test
 	//go automation.RebalanceCache(automation.ManagedRebalanceChannel, ctx)
@@ -344,6 +344,30 @@ export const AllChannelsColumns: ColumnMetaData<channel>[] = [
 		key: "private",
 		valueType: "boolean",
 	},
+	{
+		heading: "Net Flow per Day",
+		type: "NumericCell",
+		key: "netFlowPerDay",
+		valueType: "number",
+	},
+	{
+		heading: "Days Until Depleted",
+		type: "NumericCell",
+		key: "daysUntilDepleted",
+		valueType: "number",
+	},
+	{
+		heading: "Days Until Saturated",
+		type: "NumericCell",
+		key: "daysUntilSaturated",
+		valueType: "number",
+	},
+	{
+		heading: "Peer Score",
+		type: "NumericCell",
+		key: "peerScore",
+		valueType: "number",
+	},
 ];
 
 
@@ -402,6 +426,10 @@ export const ChannelsSortableColumns: Array<keyof channel> = [
 	"remotePubkey",
 	"peerGauge",
 	"private",
+	"netFlowPerDay",
+	"daysUntilDepleted",
+	"daysUntilSaturated",
+	"peerScore",
 ];
 
 
@@ -461,4 +489,8 @@ export const ChannelsFilterableColumns: Array<keyof channel> = [
 	"remotePubkey",
 	"peerGauge",
 	"private",
+	"netFlowPerDay",
+	"daysUntilDepleted",
+	"daysUntilSaturated",
+	"peerScore",
 ];
\ No newline at end of file
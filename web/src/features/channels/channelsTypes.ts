@@ -90,6 +90,10 @@ export type channel = {
   peerLocalBalance: number;
   peerGauge: number;
   private: boolean;
+  netFlowPerDay?: number;
+  daysUntilDepleted?: number;
+  daysUntilSaturated?: number;
+  peerScore?: number;
 };
 
 export type PolicyInterface = {
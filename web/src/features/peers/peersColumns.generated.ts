@@ -76,6 +76,12 @@ export const AllPeersColumns: ColumnMetaData<Peer>[] = [
 		key: "dateLastDisconnected",
 		valueType: "date",
 	},
+	{
+		heading: "Peer Score",
+		type: "NumericCell",
+		key: "score",
+		valueType: "number",
+	},
 ];
 
 
@@ -92,6 +98,7 @@ export const PeersSortableColumns: Array<keyof Peer> = [
 	"secondsDisconnected",
 	"dateLastConnected",
 	"dateLastDisconnected",
+	"score",
 ];
 
 
@@ -109,4 +116,5 @@ export const PeersFilterableColumns: Array<keyof Peer> = [
 	"secondsDisconnected",
 	"dateLastConnected",
 	"dateLastDisconnected",
+	"score",
 ];
\ No newline at end of file
@@ -14,6 +14,22 @@ export type Peer = {
   dateLastConnected?: Date;
   secondsDisconnected: number;
   dateLastDisconnected?: Date;
+  score?: number;
+  scoreBreakdown?: PeerScoreBreakdown;
+};
+
+export type PeerScoreBreakdown = {
+  uptime?: number;
+  forwardSuccessCount: number;
+  forwardFailureCount: number;
+  revenue: number;
+  forceCloseCount: number;
+  feePolicyChangesPerChannel: number;
+  uptimeScore?: number;
+  forwardSuccessScore?: number;
+  revenueScore?: number;
+  forceCloseScore: number;
+  feeStabilityScore: number;
 };
 
 export type ConnectPeerRequest = {